@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -226,6 +227,73 @@ func (abi *ABI) EventByID(topic common.Hash) (*Event, error) {
 	return nil, fmt.Errorf("no event with id: %#x", topic.Hex())
 }
 
+// DecodeLog looks up the event emitted by a log, given its topics and data, and
+// decodes its arguments into their Go types, keyed by name. Anonymous events
+// have no signature topic, the log must match exactly one of them. The indexed
+// arguments of dynamic types are returned as the hash of their value.
+func (abi *ABI) DecodeLog(topics []common.Hash, data []byte) (string, map[string]interface{}, error) {
+	if len(topics) > 0 {
+		if event, err := abi.EventByID(topics[0]); err == nil && !event.Anonymous {
+			args, err := decodeEventLog(event, topics[1:], data)
+			if err != nil {
+				return "", nil, err
+			}
+			return event.Name, args, nil
+		}
+	}
+	var (
+		names []string
+		args  map[string]interface{}
+	)
+	for _, event := range abi.Events {
+		if !event.Anonymous {
+			continue
+		}
+		decoded, err := decodeEventLog(&event, topics, data)
+		if err != nil {
+			continue
+		}
+		// The data of anonymous events must match exactly to tell them apart
+		if values, err := event.Inputs.NonIndexed().UnpackValues(data); err != nil {
+			continue
+		} else if packed, err := event.Inputs.NonIndexed().Pack(values...); err != nil || !bytes.Equal(packed, data) {
+			continue
+		}
+		names, args = append(names, event.Name), decoded
+	}
+	switch len(names) {
+	case 0:
+		if len(topics) == 0 {
+			return "", nil, errors.New("abi: no anonymous event matching the log")
+		}
+		return "", nil, fmt.Errorf("abi: no event matching topic %#x", topics[0])
+	case 1:
+		return names[0], args, nil
+	default:
+		slices.Sort(names)
+		return "", nil, fmt.Errorf("abi: log matches several anonymous events: %v", names)
+	}
+}
+
+// decodeEventLog decodes the arguments of the event from the indexed topics
+// and the data of a log.
+func decodeEventLog(event *Event, topics []common.Hash, data []byte) (map[string]interface{}, error) {
+	var indexed Arguments
+	for _, arg := range event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	args := make(map[string]interface{})
+	if err := ParseTopicsIntoMap(args, indexed, topics); err != nil {
+		return nil, err
+	}
+	if err := event.Inputs.UnpackIntoMap(args, data); err != nil {
+		return nil, err
+	}
+	return args, nil
+}
+
 // ErrorByID looks up an error by the 4-byte id,
 // returns nil if none found.
 func (abi *ABI) ErrorByID(sigdata [4]byte) (*Error, error) {
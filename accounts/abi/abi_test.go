@@ -28,6 +28,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/internal/testrand"
 )
@@ -873,6 +874,62 @@ func TestUnpackEventIntoMap(t *testing.T) {
 	}
 }
 
+func TestDecodeLog(t *testing.T) {
+	t.Parallel()
+	const abiJSON = `[
+		{"type":"event","name":"Transfer","inputs":[{"indexed":true,"name":"from","type":"address"},{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"value","type":"uint256"}]},
+		{"type":"event","name":"Tagged","anonymous":true,"inputs":[{"indexed":true,"name":"tag","type":"bytes32"},{"indexed":false,"name":"value","type":"uint256"}]},
+		{"type":"event","name":"Flagged","anonymous":true,"inputs":[{"indexed":true,"name":"tag","type":"bytes32"},{"indexed":false,"name":"flag","type":"bool"},{"indexed":false,"name":"value","type":"uint256"}]}
+	]`
+	abi, err := JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		from  = common.HexToAddress("0x376c47978271565f56DEB45495afa69E59c16Ab2")
+		to    = common.HexToAddress("0x0000000000000000000000000000000000001337")
+		tag   = common.HexToHash("0xdeadbeef")
+		value = big.NewInt(1000)
+	)
+	transfer := &types.Log{
+		Topics: []common.Hash{abi.Events["Transfer"].ID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:   common.BigToHash(value).Bytes(),
+	}
+	name, args, err := abi.DecodeLog(transfer.Topics, transfer.Data)
+	if err != nil {
+		t.Fatalf("failed to decode log: %v", err)
+	}
+	if name != "Transfer" {
+		t.Errorf("event name mismatch: have %s, want %s", name, "Transfer")
+	}
+	want := map[string]interface{}{"from": from, "to": to, "value": value}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("arguments mismatch: have %v, want %v", args, want)
+	}
+	// Anonymous events are told apart by their topics and data
+	tagged := &types.Log{Topics: []common.Hash{tag}, Data: common.BigToHash(value).Bytes()}
+	name, args, err = abi.DecodeLog(tagged.Topics, tagged.Data)
+	if err != nil {
+		t.Fatalf("failed to decode anonymous log: %v", err)
+	}
+	if name != "Tagged" {
+		t.Errorf("event name mismatch: have %s, want %s", name, "Tagged")
+	}
+	want = map[string]interface{}{"tag": [32]byte(tag), "value": value}
+	if !reflect.DeepEqual(args, want) {
+		t.Errorf("arguments mismatch: have %v, want %v", args, want)
+	}
+	// Logs matching no event are rejected
+	unknown := &types.Log{Topics: []common.Hash{tag, tag}, Data: common.BigToHash(value).Bytes()}
+	if _, _, err := abi.DecodeLog(unknown.Topics, unknown.Data); err == nil {
+		t.Error("decoded log of unknown event")
+	}
+	mismatch := &types.Log{Topics: transfer.Topics[:2], Data: transfer.Data}
+	if _, _, err := abi.DecodeLog(mismatch.Topics, mismatch.Data); err == nil {
+		t.Error("decoded log with missing topics")
+	}
+}
+
 func TestUnpackMethodIntoMap(t *testing.T) {
 	t.Parallel()
 	const abiJSON = `[{"constant":false,"inputs":[{"name":"memo","type":"bytes"}],"name":"receive","outputs":[],"payable":true,"stateMutability":"payable","type":"function"},{"constant":false,"inputs":[],"name":"send","outputs":[{"name":"amount","type":"uint256"}],"payable":true,"stateMutability":"payable","type":"function"},{"constant":false,"inputs":[{"name":"addr","type":"address"}],"name":"get","outputs":[{"name":"hash","type":"bytes"}],"payable":true,"stateMutability":"payable","type":"function"}]`
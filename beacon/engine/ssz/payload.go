@@ -0,0 +1,161 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package ssz implements the SSZ encoding of the engine API execution payloads,
+// as embedded in beacon blocks.
+package ssz
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+	zrntcommon "github.com/protolambda/zrnt/eth2/beacon/common"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	"github.com/protolambda/zrnt/eth2/configs"
+	"github.com/protolambda/ztyp/codec"
+	"github.com/protolambda/ztyp/tree"
+	"github.com/protolambda/ztyp/view"
+)
+
+var errMissingBlobFields = errors.New("missing blob gas fields")
+
+// EncodeExecutionPayloadV3 returns the SSZ encoding of a Deneb execution payload.
+func EncodeExecutionPayloadV3(p *engine.ExecutableData) ([]byte, error) {
+	payload, err := toDeneb(p)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := payload.Serialize(configs.Mainnet, codec.NewEncodingWriter(&buf)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeExecutionPayloadV3 decodes an SSZ encoded Deneb execution payload.
+func DecodeExecutionPayloadV3(data []byte) (*engine.ExecutableData, error) {
+	var payload deneb.ExecutionPayload
+	if err := payload.Deserialize(configs.Mainnet, codec.NewDecodingReader(bytes.NewReader(data), uint64(len(data)))); err != nil {
+		return nil, err
+	}
+	return fromDeneb(&payload), nil
+}
+
+// HTR returns the SSZ hash tree root of a Deneb execution payload, which the
+// beacon block body commits to.
+func HTR(p *engine.ExecutableData) ([32]byte, error) {
+	payload, err := toDeneb(p)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return payload.HashTreeRoot(configs.Mainnet, tree.GetHashFn()), nil
+}
+
+// toDeneb converts an engine API payload into its beacon chain representation.
+func toDeneb(p *engine.ExecutableData) (*deneb.ExecutionPayload, error) {
+	if p.BlobGasUsed == nil || p.ExcessBlobGas == nil {
+		return nil, errMissingBlobFields
+	}
+	if len(p.LogsBloom) != types.BloomByteLength {
+		return nil, fmt.Errorf("invalid logs bloom length: %d", len(p.LogsBloom))
+	}
+	if len(p.ExtraData) > zrntcommon.MAX_EXTRA_DATA_BYTES {
+		return nil, fmt.Errorf("extra data too large: %d", len(p.ExtraData))
+	}
+	if p.BaseFeePerGas == nil {
+		return nil, errors.New("missing base fee")
+	}
+	baseFee, overflow := uint256.FromBig(p.BaseFeePerGas)
+	if overflow {
+		return nil, fmt.Errorf("base fee too large: %v", p.BaseFeePerGas)
+	}
+	payload := &deneb.ExecutionPayload{
+		ParentHash:    zrntcommon.Hash32(p.ParentHash),
+		FeeRecipient:  zrntcommon.Eth1Address(p.FeeRecipient),
+		StateRoot:     zrntcommon.Bytes32(p.StateRoot),
+		ReceiptsRoot:  zrntcommon.Bytes32(p.ReceiptsRoot),
+		PrevRandao:    zrntcommon.Bytes32(p.Random),
+		BlockNumber:   view.Uint64View(p.Number),
+		GasLimit:      view.Uint64View(p.GasLimit),
+		GasUsed:       view.Uint64View(p.GasUsed),
+		Timestamp:     zrntcommon.Timestamp(p.Timestamp),
+		ExtraData:     zrntcommon.ExtraData(p.ExtraData),
+		BaseFeePerGas: view.Uint256View(*baseFee),
+		BlockHash:     zrntcommon.Hash32(p.BlockHash),
+		BlobGasUsed:   view.Uint64View(*p.BlobGasUsed),
+		ExcessBlobGas: view.Uint64View(*p.ExcessBlobGas),
+	}
+	copy(payload.LogsBloom[:], p.LogsBloom)
+	payload.Transactions = make(zrntcommon.PayloadTransactions, len(p.Transactions))
+	for i, tx := range p.Transactions {
+		payload.Transactions[i] = zrntcommon.Transaction(tx)
+	}
+	payload.Withdrawals = make(zrntcommon.Withdrawals, len(p.Withdrawals))
+	for i, w := range p.Withdrawals {
+		payload.Withdrawals[i] = zrntcommon.Withdrawal{
+			Index:          zrntcommon.WithdrawalIndex(w.Index),
+			ValidatorIndex: zrntcommon.ValidatorIndex(w.Validator),
+			Address:        zrntcommon.Eth1Address(w.Address),
+			Amount:         zrntcommon.Gwei(w.Amount),
+		}
+	}
+	return payload, nil
+}
+
+// fromDeneb converts a beacon chain execution payload into its engine API
+// representation.
+func fromDeneb(payload *deneb.ExecutionPayload) *engine.ExecutableData {
+	var (
+		blobGasUsed   = uint64(payload.BlobGasUsed)
+		excessBlobGas = uint64(payload.ExcessBlobGas)
+	)
+	p := &engine.ExecutableData{
+		ParentHash:    common.Hash(payload.ParentHash),
+		FeeRecipient:  common.Address(payload.FeeRecipient),
+		StateRoot:     common.Hash(payload.StateRoot),
+		ReceiptsRoot:  common.Hash(payload.ReceiptsRoot),
+		LogsBloom:     common.CopyBytes(payload.LogsBloom[:]),
+		Random:        common.Hash(payload.PrevRandao),
+		Number:        uint64(payload.BlockNumber),
+		GasLimit:      uint64(payload.GasLimit),
+		GasUsed:       uint64(payload.GasUsed),
+		Timestamp:     uint64(payload.Timestamp),
+		ExtraData:     common.CopyBytes(payload.ExtraData),
+		BaseFeePerGas: (*uint256.Int)(&payload.BaseFeePerGas).ToBig(),
+		BlockHash:     common.Hash(payload.BlockHash),
+		Transactions:  make([][]byte, len(payload.Transactions)),
+		Withdrawals:   make([]*types.Withdrawal, len(payload.Withdrawals)),
+		BlobGasUsed:   &blobGasUsed,
+		ExcessBlobGas: &excessBlobGas,
+	}
+	for i, tx := range payload.Transactions {
+		p.Transactions[i] = common.CopyBytes(tx)
+	}
+	for i, w := range payload.Withdrawals {
+		p.Withdrawals[i] = &types.Withdrawal{
+			Index:     uint64(w.Index),
+			Validator: uint64(w.ValidatorIndex),
+			Address:   common.Address(w.Address),
+			Amount:    uint64(w.Amount),
+		}
+	}
+	return p
+}
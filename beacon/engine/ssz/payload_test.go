@@ -0,0 +1,98 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ssz
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/protolambda/zrnt/eth2/beacon/deneb"
+	"github.com/protolambda/zrnt/eth2/configs"
+	"github.com/protolambda/ztyp/codec"
+	"github.com/protolambda/ztyp/tree"
+)
+
+// TestExecutionPayloadV3Fixture cross-checks the codec against the execution
+// payload of a mainnet Deneb beacon block.
+func TestExecutionPayloadV3Fixture(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "types", "testdata", "block_deneb.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var block deneb.BeaconBlock
+	if err := json.Unmarshal(data, &block); err != nil {
+		t.Fatalf("failed to decode fixture: %v", err)
+	}
+	var (
+		fixture = &block.Body.ExecutionPayload
+		buf     bytes.Buffer
+	)
+	if err := fixture.Serialize(configs.Mainnet, codec.NewEncodingWriter(&buf)); err != nil {
+		t.Fatalf("failed to encode fixture: %v", err)
+	}
+	payload, err := DecodeExecutionPayloadV3(buf.Bytes())
+	if err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if want := common.HexToHash("0x4cf7d9108fc01b50023ab7cab9b372a96068fddcadec551630393b65acb1f34c"); payload.BlockHash != want || payload.Number != 19431837 {
+		t.Fatalf("payload mismatch: have block %d (%x), want 19431837 (%x)", payload.Number, payload.BlockHash, want)
+	}
+	// The decoded payload must hash to the block hash it claims
+	var hashes []common.Hash
+	for i, enc := range payload.Transactions {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(enc); err != nil {
+			t.Fatalf("failed to decode transaction %d: %v", i, err)
+		}
+		hashes = append(hashes, tx.BlobHashes()...)
+	}
+	root := common.Hash(block.ParentRoot)
+	if _, err := engine.ExecutableDataToBlock(*payload, hashes, &root, nil); err != nil {
+		t.Fatalf("decoded payload does not match its block hash: %v", err)
+	}
+	// Encoding must round trip to the fixture, with the same hash tree root
+	enc, err := EncodeExecutionPayloadV3(payload)
+	if err != nil {
+		t.Fatalf("failed to encode payload: %v", err)
+	}
+	if !bytes.Equal(enc, buf.Bytes()) {
+		t.Fatal("encoding mismatch")
+	}
+	htr, err := HTR(payload)
+	if err != nil {
+		t.Fatalf("failed to compute hash tree root: %v", err)
+	}
+	if want := fixture.HashTreeRoot(configs.Mainnet, tree.GetHashFn()); htr != want {
+		t.Fatalf("hash tree root mismatch: have %x, want %x", htr, want)
+	}
+}
+
+func TestExecutionPayloadV3Invalid(t *testing.T) {
+	payload := &engine.ExecutableData{LogsBloom: make([]byte, types.BloomByteLength)}
+	if _, err := EncodeExecutionPayloadV3(payload); err == nil {
+		t.Fatal("expected payload without blob gas fields to be rejected")
+	}
+	if _, err := DecodeExecutionPayloadV3([]byte{0x01, 0x02}); err == nil {
+		t.Fatal("expected truncated encoding to be rejected")
+	}
+}
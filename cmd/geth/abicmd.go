@@ -0,0 +1,283 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/internal/abiutil"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	abiEventFlag = &cli.BoolFlag{
+		Name:  "event",
+		Usage: "Print the 32-byte topic of an event instead of a 4-byte selector",
+	}
+	abiCommand = &cli.Command{
+		Name:  "abi",
+		Usage: "ABI encoding utilities",
+		Subcommands: []*cli.Command{
+			{
+				Action:    abiSelector,
+				Name:      "selector",
+				Usage:     "Compute the selector of a function, error or event signature",
+				ArgsUsage: "<signature>",
+				Flags:     []cli.Flag{abiEventFlag},
+				Description: `
+    geth abi selector "transfer(address,uint256)"
+
+prints the 4-byte selector of the function or error with the given signature.
+With --event, the 32-byte topic of the event with the given signature is printed.`,
+			},
+			{
+				Action:    abiEncode,
+				Name:      "encode",
+				Usage:     "ABI-encode the calldata of a function or error",
+				ArgsUsage: "<signature> <comma separated values>",
+				Description: `
+    geth abi encode "transfer(address,uint256)" "0x000000000000000000000000000000000000dEaD,100"
+
+prints the selector followed by the encoded values. Arrays are given in square
+brackets and tuples in parentheses, e.g. "[1,2],(0x01,true)".`,
+			},
+			{
+				Action:    abiDecode,
+				Name:      "decode",
+				Usage:     "Decode the ABI-encoded calldata of a function or error",
+				ArgsUsage: "<signature> <hex data>",
+				Description: `
+    geth abi decode "transfer(address,uint256)" <hex data>
+
+prints the decoded values, one per line. The data may start with the selector.`,
+			},
+		},
+	}
+)
+
+func abiSelector(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return errors.New("need the signature as the only argument")
+	}
+	selector, err := signatureSelector(ctx.Args().First(), ctx.Bool(abiEventFlag.Name))
+	if err != nil {
+		return err
+	}
+	fmt.Println(hexutil.Encode(selector))
+	return nil
+}
+
+func abiEncode(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return errors.New("need the signature and the values as arguments")
+	}
+	data, err := encodeCall(ctx.Args().Get(0), ctx.Args().Get(1))
+	if err != nil {
+		return err
+	}
+	fmt.Println(hexutil.Encode(data))
+	return nil
+}
+
+func abiDecode(ctx *cli.Context) error {
+	if ctx.NArg() != 2 {
+		return errors.New("need the signature and the hex data as arguments")
+	}
+	data, err := hexutil.Decode(ctx.Args().Get(1))
+	if err != nil {
+		return fmt.Errorf("invalid hex data: %v", err)
+	}
+	values, err := decodeCall(ctx.Args().Get(0), data)
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		if s, ok := value.(string); ok {
+			fmt.Println(s)
+			continue
+		}
+		enc, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(enc))
+	}
+	return nil
+}
+
+// parseSignature parses a function, error or event signature.
+func parseSignature(signature string) (abi.Method, error) {
+	selector, err := abi.ParseSelector(signature)
+	if err != nil {
+		return abi.Method{}, err
+	}
+	spec, err := json.Marshal([]abi.SelectorMarshaling{selector})
+	if err != nil {
+		return abi.Method{}, err
+	}
+	parsed, err := abi.JSON(bytes.NewReader(spec))
+	if err != nil {
+		return abi.Method{}, err
+	}
+	return parsed.Methods[selector.Name], nil
+}
+
+// signatureSelector returns the 4-byte selector of a function or error, or the
+// 32-byte topic of an event.
+func signatureSelector(signature string, event bool) ([]byte, error) {
+	method, err := parseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	if event {
+		return crypto.Keccak256([]byte(method.Sig)), nil
+	}
+	return method.ID, nil
+}
+
+// encodeCall encodes the calldata of a call with the comma separated values.
+func encodeCall(signature string, values string) ([]byte, error) {
+	method, err := parseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	var fields []string
+	if strings.TrimSpace(values) != "" {
+		if fields, err = splitValues(values); err != nil {
+			return nil, err
+		}
+	}
+	if len(fields) != len(method.Inputs) {
+		return nil, fmt.Errorf("value count mismatch: have %d, want %d", len(fields), len(method.Inputs))
+	}
+	args := make([]interface{}, len(fields))
+	for i, input := range method.Inputs {
+		value, err := parseValue(input.Type, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("value %d: %v", i, err)
+		}
+		if args[i], err = abiutil.ToGoValue(input.Type, value); err != nil {
+			return nil, fmt.Errorf("value %d: %v", i, err)
+		}
+	}
+	data, err := method.Inputs.Pack(args...)
+	if err != nil {
+		return nil, err
+	}
+	return append(method.ID, data...), nil
+}
+
+// decodeCall decodes the values of calldata, with or without the selector.
+func decodeCall(signature string, data []byte) ([]interface{}, error) {
+	method, err := parseSignature(signature)
+	if err != nil {
+		return nil, err
+	}
+	if len(data)%32 == 4 {
+		if !bytes.Equal(data[:4], method.ID) {
+			return nil, fmt.Errorf("selector mismatch: have %x, want %x", data[:4], method.ID)
+		}
+		data = data[4:]
+	}
+	values, err := method.Inputs.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	for i, input := range method.Inputs {
+		values[i] = abiutil.FromGoValue(input.Type, values[i])
+	}
+	return values, nil
+}
+
+// parseValue converts a value given on the command line into the values
+// accepted by abiutil.ToGoValue.
+func parseValue(typ abi.Type, value string) (interface{}, error) {
+	value = strings.TrimSpace(value)
+	switch typ.T {
+	case abi.BoolTy:
+		return strconv.ParseBool(value)
+	case abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		open, close := "[", "]"
+		if typ.T == abi.TupleTy {
+			open, close = "(", ")"
+		}
+		if !strings.HasPrefix(value, open) || !strings.HasSuffix(value, close) {
+			return nil, fmt.Errorf("%s must be enclosed in %s%s", typ, open, close)
+		}
+		var fields []string
+		if inner := value[1 : len(value)-1]; strings.TrimSpace(inner) != "" {
+			var err error
+			if fields, err = splitValues(inner); err != nil {
+				return nil, err
+			}
+		}
+		if typ.T == abi.TupleTy && len(fields) != len(typ.TupleElems) {
+			return nil, fmt.Errorf("field count mismatch for %s: have %d, want %d", typ, len(fields), len(typ.TupleElems))
+		}
+		list := make([]interface{}, len(fields))
+		for i, field := range fields {
+			elem := typ.Elem
+			if typ.T == abi.TupleTy {
+				elem = typ.TupleElems[i]
+			}
+			var err error
+			if list[i], err = parseValue(*elem, field); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	default:
+		return value, nil
+	}
+}
+
+// splitValues splits comma separated values, keeping the commas of nested
+// arrays and tuples.
+func splitValues(values string) ([]string, error) {
+	var (
+		fields []string
+		depth  int
+		start  int
+	)
+	for i, c := range values {
+		switch c {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			if depth--; depth < 0 {
+				return nil, fmt.Errorf("unbalanced brackets in %q", values)
+			}
+		case ',':
+			if depth == 0 {
+				fields = append(fields, values[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced brackets in %q", values)
+	}
+	return append(fields, values[start:]), nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestABISelector(t *testing.T) {
+	tests := []struct {
+		signature string
+		event     bool
+		want      string
+	}{
+		{"transfer(address,uint256)", false, "0xa9059cbb"},
+		{"Error(string)", false, "0x08c379a0"},
+		{"Transfer(address,address,uint256)", true, "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"},
+	}
+	for _, tt := range tests {
+		selector, err := signatureSelector(tt.signature, tt.event)
+		if err != nil {
+			t.Fatalf("%s: failed to compute selector: %v", tt.signature, err)
+		}
+		if have := hexutil.Encode(selector); have != tt.want {
+			t.Errorf("%s: selector mismatch: have %s, want %s", tt.signature, have, tt.want)
+		}
+	}
+}
+
+func TestABIEncodeDecode(t *testing.T) {
+	tests := []struct {
+		signature string
+		values    string
+		want      string // JSON encoding of the decoded values
+	}{
+		{
+			signature: "transfer(address,uint256)",
+			values:    "0x000000000000000000000000000000000000dEaD, 100",
+			want:      `["0x000000000000000000000000000000000000dEaD","100"]`,
+		},
+		{
+			signature: "f(uint8[],(bool,string),bytes4)",
+			values:    "[1,2],(true,hello),0xdeadbeef",
+			want:      `[["1","2"],{"name0":true,"name1":"hello"},"0xdeadbeef"]`,
+		},
+		{
+			signature: "f()",
+			values:    "",
+			want:      `[]`,
+		},
+	}
+	for _, tt := range tests {
+		data, err := encodeCall(tt.signature, tt.values)
+		if err != nil {
+			t.Fatalf("%s: failed to encode: %v", tt.signature, err)
+		}
+		values, err := decodeCall(tt.signature, data)
+		if err != nil {
+			t.Fatalf("%s: failed to decode: %v", tt.signature, err)
+		}
+		have, _ := json.Marshal(values)
+		if string(have) != tt.want {
+			t.Errorf("%s: decoded values mismatch: have %s, want %s", tt.signature, have, tt.want)
+		}
+	}
+}
@@ -17,9 +17,14 @@
 package main
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -47,6 +52,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rollup"
 	"github.com/urfave/cli/v2"
 )
 
@@ -55,8 +61,9 @@ var (
 		Action:    initGenesis,
 		Name:      "init",
 		Usage:     "Bootstrap and initialize a new genesis block",
-		ArgsUsage: "<genesisPath>",
+		ArgsUsage: "<genesisPath or https URL>",
 		Flags: slices.Concat([]cli.Flag{
+			genesisChecksumFlag,
 			utils.CachePreimagesFlag,
 			utils.OverridePrague,
 			utils.OverrideVerkle,
@@ -66,7 +73,8 @@ The init command initializes a new genesis block and definition for the network.
 This is a destructive action and changes the network in which you will be
 participating.
 
-It expects the genesis file as argument.`,
+It expects the genesis file as argument, which can also be downloaded over HTTPS.
+The --genesis.checksum flag verifies the SHA256 checksum of the file.`,
 	}
 	dumpGenesisCommand = &cli.Command{
 		Action:    dumpGenesis,
@@ -116,6 +124,10 @@ if one is set.  Otherwise it prints the genesis from the datadir.`,
 			utils.LogNoHistoryFlag,
 			utils.LogExportCheckpointsFlag,
 			utils.StateHistoryFlag,
+			&cli.BoolFlag{
+				Name:  "verify",
+				Usage: "recompute the state of every imported block from its leaves (slow)",
+			},
 		}, utils.DatabaseFlags, debug.Flags),
 		Before: func(ctx *cli.Context) error {
 			flags.MigrateGlobalFlags(ctx)
@@ -127,7 +139,10 @@ containing multiple RLP-encoded blocks, or multiple files can be given.
 
 If only one file is used, an import error will result in the entire import process failing. If
 multiple files are processed, the import process will continue even if an individual RLP file fails
-to import successfully.`,
+to import successfully.
+
+With --verify, the state of every imported block is recomputed from its leaves and compared with
+the state root of the block, stopping the import at the first mismatch.`,
 	}
 	exportCommand = &cli.Command{
 		Action:    exportChain,
@@ -226,6 +241,10 @@ helps reduce storage requirements for nodes that don't need full historical data
 )
 
 var (
+	genesisChecksumFlag = &cli.StringFlag{
+		Name:  "genesis.checksum",
+		Usage: "SHA256 checksum (hex) the genesis file must match",
+	}
 	eraBlockFlag = &cli.StringFlag{
 		Name:  "block",
 		Usage: "Block number to fetch. (can also be a range <start>-<end>)",
@@ -254,16 +273,20 @@ func initGenesis(ctx *cli.Context) error {
 	if len(genesisPath) == 0 {
 		utils.Fatalf("invalid path to genesis file")
 	}
-	file, err := os.Open(genesisPath)
+	client := &http.Client{Timeout: genesisDownloadTimeout, CheckRedirect: checkGenesisRedirect}
+	blob, err := readGenesisFile(genesisPath, ctx.String(genesisChecksumFlag.Name), client)
 	if err != nil {
 		utils.Fatalf("Failed to read genesis file: %v", err)
 	}
-	defer file.Close()
-
 	genesis := new(core.Genesis)
-	if err := json.NewDecoder(file).Decode(genesis); err != nil {
+	if err := json.Unmarshal(blob, genesis); err != nil {
 		utils.Fatalf("invalid genesis file: %v", err)
 	}
+	if genesis.Config != nil && genesis.Config.Optimism != nil {
+		if err := rollup.ValidateOPGenesis(genesis); err != nil {
+			utils.Fatalf("invalid OP-Stack genesis: %v", err)
+		}
+	}
 	// Open and initialise both full and light databases
 	stack, _ := makeConfigNode(ctx)
 	defer stack.Close()
@@ -296,6 +319,66 @@ func initGenesis(ctx *cli.Context) error {
 	return nil
 }
 
+// genesisDownloadTimeout is the time allowed to download a remote genesis file.
+const genesisDownloadTimeout = 30 * time.Second
+
+// readGenesisFile reads the genesis file from the given path, or downloads it
+// if the path is an HTTPS URL. The content is checked against the SHA256 hex
+// checksum, if one is given.
+func readGenesisFile(path string, checksum string, client *http.Client) ([]byte, error) {
+	var (
+		blob []byte
+		err  error
+	)
+	switch {
+	case strings.HasPrefix(path, "https://"):
+		blob, err = downloadGenesis(path, client)
+	case strings.HasPrefix(path, "http://"):
+		return nil, errors.New("genesis file must be downloaded over https")
+	default:
+		blob, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if checksum != "" {
+		want, err := hex.DecodeString(strings.TrimPrefix(checksum, "0x"))
+		if err != nil || len(want) != sha256.Size {
+			return nil, fmt.Errorf("invalid genesis checksum %q", checksum)
+		}
+		if have := sha256.Sum256(blob); !bytes.Equal(have[:], want) {
+			return nil, fmt.Errorf("genesis checksum mismatch: have %x, want %x", have, want)
+		}
+	}
+	return blob, nil
+}
+
+// checkGenesisRedirect refuses the redirects of a genesis download that leave
+// HTTPS, which would otherwise bypass the check on the initial URL.
+func checkGenesisRedirect(req *http.Request, via []*http.Request) error {
+	if req.URL.Scheme != "https" {
+		return fmt.Errorf("genesis file must be downloaded over https, redirected to %s", req.URL.Redacted())
+	}
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	return nil
+}
+
+// downloadGenesis fetches a genesis file over HTTPS.
+func downloadGenesis(url string, client *http.Client) ([]byte, error) {
+	res, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download genesis file: %s", res.Status)
+	}
+	return io.ReadAll(res.Body)
+}
+
 func dumpGenesis(ctx *cli.Context) error {
 	// check if there is a testnet preset enabled
 	var genesis *core.Genesis
@@ -367,13 +450,13 @@ func importChain(ctx *cli.Context) error {
 	var importErr error
 
 	if ctx.Args().Len() == 1 {
-		if err := utils.ImportChain(chain, ctx.Args().First()); err != nil {
+		if err := utils.ImportChain(chain, ctx.Args().First(), ctx.Bool("verify")); err != nil {
 			importErr = err
 			log.Error("Import error", "err", err)
 		}
 	} else {
 		for _, arg := range ctx.Args().Slice() {
-			if err := utils.ImportChain(chain, arg); err != nil {
+			if err := utils.ImportChain(chain, arg, ctx.Bool("verify")); err != nil {
 				importErr = err
 				log.Error("Import error", "file", arg, "err", err)
 				if err == utils.ErrImportInterrupted {
@@ -398,7 +481,7 @@ func importChain(ctx *cli.Context) error {
 	fmt.Printf("GC pause:      %v\n\n", time.Duration(mem.PauseTotalNs))
 
 	if ctx.Bool(utils.NoCompactionFlag.Name) {
-		return nil
+		return importErr
 	}
 
 	// Compact the entire database to more accurately measure disk io and print the stats
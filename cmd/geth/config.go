@@ -43,6 +43,7 @@ import (
 	"github.com/ethereum/go-ethereum/internal/version"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/metrics/exp"
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/naoina/toml"
@@ -249,6 +250,8 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 			"version":   cfg.Node.Version,
 			"protocols": strings.Join(protos, ","),
 		})
+		// Serve the health of the transaction pool next to the metrics
+		exp.Handle("/txpool/health", eth.TxPoolHealthHandler())
 	}
 
 	// Configure log filter RPC API.
@@ -258,6 +261,10 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 	if ctx.IsSet(utils.GraphQLEnabledFlag.Name) {
 		utils.RegisterGraphQLService(stack, backend, filterSystem, &cfg.Node)
 	}
+	// Configure the ERC-4337 bundler API if requested.
+	if ctx.IsSet(utils.BundlerEntryPointsFlag.Name) {
+		utils.RegisterBundlerService(stack, backend, ctx.String(utils.BundlerEntryPointsFlag.Name))
+	}
 	// Add the Ethereum Stats daemon if requested.
 	if cfg.Ethstats.URL != "" {
 		utils.RegisterEthStatsService(stack, backend, cfg.Ethstats.URL)
@@ -18,6 +18,7 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
@@ -32,7 +33,10 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/console/prompt"
+	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/state/pruner"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -74,6 +78,7 @@ Remove blockchain and state databases`,
 			dbStatCmd,
 			dbCompactCmd,
 			dbGetCmd,
+			dbDumpPrefixCmd,
 			dbDeleteCmd,
 			dbPutCmd,
 			dbGetSlotsCmd,
@@ -83,6 +88,11 @@ Remove blockchain and state databases`,
 			dbMetadataCmd,
 			dbCheckStateContentCmd,
 			dbInspectHistoryCmd,
+			dbExportStateCmd,
+			dbPruneHistoryCmd,
+			dbVerifyChainCmd,
+			dbVerifyStateCmd,
+			dbGCTrieCmd,
 		},
 	}
 	dbInspectCmd = &cli.Command{
@@ -129,6 +139,31 @@ corruption if it is aborted during execution'!`,
 		Flags:       slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
 		Description: "This command looks up the specified database key from the database.",
 	}
+	dbDumpPrefixCmd = &cli.Command{
+		Action: dbDumpPrefix,
+		Name:   "dump-prefix",
+		Usage:  "Show the database keys and values with a given prefix",
+		Flags: slices.Concat([]cli.Flag{
+			&cli.StringFlag{
+				Name:  "prefix",
+				Usage: "hex-encoded (0x) or plain prefix of the keys to dump (default = all keys)",
+			},
+			&cli.Uint64Flag{
+				Name:  "limit",
+				Usage: "maximum number of keys to dump (0 = no limit)",
+			},
+			&cli.BoolFlag{
+				Name:  "count",
+				Usage: "only print the number of keys with the prefix",
+			},
+			&cli.BoolFlag{
+				Name:  "decode-key",
+				Usage: "print the meaning of the keys of the known database schemas",
+			},
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command iterates the database keys starting with the given prefix, and
+prints them along with their values as "<key> = <value>" in hex.`,
+	}
 	dbDeleteCmd = &cli.Command{
 		Action:    dbDelete,
 		Name:      "delete",
@@ -207,6 +242,111 @@ WARNING: This is a low-level operation which may cause database corruption!`,
 		}, utils.NetworkFlags, utils.DatabaseFlags),
 		Description: "This command queries the history of the account or storage slot within the specified block range",
 	}
+	dbExportStateCmd = &cli.Command{
+		Action: exportState,
+		Name:   "export-state",
+		Usage:  "Export the accounts of the state at a block, as a genesis alloc or as CSV",
+		Flags: slices.Concat([]cli.Flag{
+			&cli.Uint64Flag{
+				Name:  "block",
+				Usage: "block number of the exported state (default = latest)",
+			},
+			&cli.StringFlag{
+				Name:     "output",
+				Usage:    "file to write the exported accounts into",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Usage: "export format, json (genesis alloc) or csv (address,balance,nonce,codeHash)",
+				Value: "json",
+			},
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command iterates the state trie at the given block and exports every
+non-empty account. The json format is a genesis alloc which can be used as is in
+a genesis file, the csv format lists the address, balance, nonce and code hash
+of the accounts. Accounts whose address preimage is unknown are skipped, so the
+state should have been built with --cache.preimages.`,
+	}
+	dbPruneHistoryCmd = &cli.Command{
+		Action: pruneStateHistory,
+		Name:   "prune-history",
+		Usage:  "Prune the state histories below a block",
+		Flags: slices.Concat([]cli.Flag{
+			&cli.Uint64Flag{
+				Name:     "tail",
+				Usage:    "block number of the oldest state history to retain",
+				Required: true,
+			},
+			&cli.BoolFlag{
+				Name:  "confirm",
+				Usage: "confirm the removal of the state histories",
+			},
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command removes the state histories of the blocks below the given tail
+from the path-based state database. The states of these blocks can no longer be
+recovered afterwards, the node is not able to serve or rewind to them anymore.
+The tail must not be above the finalized block.
+
+WARNING: it's only supported in path mode(--state.scheme=path).`,
+	}
+	dbVerifyChainCmd = &cli.Command{
+		Action: verifyChain,
+		Name:   "verify-chain",
+		Usage:  "Verify that the stored block bodies match their headers",
+		Flags:  slices.Concat(utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command iterates the canonical chain from the genesis to the head block,
+recomputing the transaction root of every stored block body and comparing it with
+the root in the block header. A mismatch indicates a data corruption. The bodies
+which are not stored, e.g. the pruned chain history, are skipped.`,
+	}
+	dbVerifyStateCmd = &cli.Command{
+		Action: verifyStateRoots,
+		Name:   "verify-state",
+		Usage:  "Verify that the stored state matches the state roots of the block headers",
+		Flags: slices.Concat([]cli.Flag{
+			&cli.Uint64Flag{
+				Name:  "block",
+				Usage: "number of the block to verify the state of (default = head block)",
+			},
+			&cli.BoolFlag{
+				Name:  "all",
+				Usage: "verify the state of all blocks, archive nodes only",
+			},
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command iterates the state of the given block, recomputing the root hash
+of the account trie and of every storage trie from their leaves, and compares the
+results with the state root of the block header and the storage roots of the
+accounts. A mismatch or a missing trie node indicates a data corruption.
+
+With --all, the states of all canonical blocks are verified. The blocks whose
+state is not stored at all are skipped.`,
+	}
+	dbGCTrieCmd = &cli.Command{
+		Action: gcTrie,
+		Name:   "gc-trie",
+		Usage:  "Delete the trie nodes not referenced by the states of a block range",
+		Flags: slices.Concat([]cli.Flag{
+			&cli.Uint64Flag{
+				Name:  "from",
+				Usage: "number of the first block whose state is retained",
+			},
+			&cli.Uint64Flag{
+				Name:  "to",
+				Usage: "number of the last block whose state is retained (default = head block)",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "only count the unreferenced trie nodes, without deleting them",
+			},
+		}, utils.NetworkFlags, utils.DatabaseFlags),
+		Description: `This command traverses the states of the canonical blocks in the given range
+and deletes all the trie nodes of the database which are not referenced by any of
+them, e.g. the nodes left behind by reorgs or by an aborted sync. The states of
+the blocks outside the range are lost, the range must end at the head block.
+
+WARNING: it's only supported in hash mode(--state.scheme=hash).`,
+	}
 )
 
 func removeDB(ctx *cli.Context) error {
@@ -452,6 +592,57 @@ func dbGet(ctx *cli.Context) error {
 	return nil
 }
 
+// dbDumpPrefix shows the keys and values of the database with a given prefix.
+func dbDumpPrefix(ctx *cli.Context) error {
+	if ctx.NArg() != 0 {
+		return fmt.Errorf("unexpected arguments: %v", ctx.Args().Slice())
+	}
+	var prefix []byte
+	if ctx.IsSet("prefix") {
+		var err error
+		if prefix, err = common.ParseHexOrString(ctx.String("prefix")); err != nil {
+			return fmt.Errorf("invalid prefix: %v", err)
+		}
+	}
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	var (
+		limit  = ctx.Uint64("limit")
+		decode = ctx.Bool("decode-key")
+		count  uint64
+	)
+	it := db.NewIterator(prefix, nil)
+	defer it.Release()
+
+	for it.Next() {
+		count++
+		if ctx.Bool("count") {
+			continue
+		}
+		fmt.Printf("%#x = %#x", it.Key(), it.Value())
+		if decode {
+			if desc := rawdb.DescribeKey(it.Key(), it.Value()); desc != "" {
+				fmt.Printf(" (%s)", desc)
+			}
+		}
+		fmt.Println()
+		if count == limit {
+			break
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if ctx.Bool("count") {
+		fmt.Println(count)
+	}
+	return nil
+}
+
 // dbDelete deletes a key from the database
 func dbDelete(ctx *cli.Context) error {
 	if ctx.NArg() != 1 {
@@ -907,3 +1098,253 @@ func inspectHistory(ctx *cli.Context) error {
 	}
 	return inspectStorage(triedb, start, end, address, slot, ctx.Bool("raw"))
 }
+
+func exportState(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	var header *types.Header
+	if ctx.IsSet("block") {
+		number := ctx.Uint64("block")
+		hash := rawdb.ReadCanonicalHash(db, number)
+		if hash == (common.Hash{}) {
+			return fmt.Errorf("block %d not found", number)
+		}
+		header = rawdb.ReadHeader(db, hash, number)
+	} else {
+		header = rawdb.ReadHeadHeader(db)
+	}
+	if header == nil {
+		return errors.New("no head block found")
+	}
+	triedb := utils.MakeTrieDatabase(ctx, db, true, true, false) // always enable preimage lookup
+	defer triedb.Close()
+
+	statedb, err := state.New(header.Root, state.NewDatabase(triedb, nil))
+	if err != nil {
+		return err
+	}
+	return utils.ExportState(statedb, ctx.String("output"), ctx.String("format"))
+}
+
+func pruneStateHistory(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, false)
+	defer db.Close()
+
+	tail := ctx.Uint64("tail")
+
+	// Refuse to discard the histories of blocks which may still be reorged.
+	hash := rawdb.ReadFinalizedBlockHash(db)
+	if hash == (common.Hash{}) {
+		return errors.New("finalized block is not available")
+	}
+	finalized := rawdb.ReadHeaderNumber(db, hash)
+	if finalized == nil {
+		return fmt.Errorf("finalized block %x is not existent", hash)
+	}
+	if tail > *finalized {
+		return fmt.Errorf("tail #%d is above the finalized block #%d", tail, *finalized)
+	}
+	triedb := utils.MakeTrieDatabase(ctx, db, false, false, false)
+	defer triedb.Close()
+
+	first, last, err := triedb.HistoryRange()
+	if err != nil {
+		return err
+	}
+	log.Info("Available state histories", "first", first, "last", last, "tail", tail)
+	if !ctx.Bool("confirm") {
+		return errors.New("state history pruning is irreversible, rerun with --confirm to proceed")
+	}
+	ancient, err := db.AncientDatadir()
+	if err != nil {
+		return err
+	}
+	var (
+		start = time.Now()
+		dir   = filepath.Join(ancient, rawdb.MerkleStateFreezerName)
+		osize = folderSize(dir)
+	)
+	pruned, err := triedb.PruneHistory(tail)
+	if err != nil {
+		return err
+	}
+	nsize := folderSize(dir)
+	log.Info("Pruned state histories", "items", pruned, "reclaimed", common.StorageSize(osize-nsize), "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
+// folderSize returns the total size of the files inside the directory 'dir'.
+func folderSize(dir string) int64 {
+	var size int64
+	filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+func verifyChain(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	hash := rawdb.ReadHeadBlockHash(db)
+	head := rawdb.ReadHeaderNumber(db, hash)
+	if head == nil {
+		return fmt.Errorf("head block %x is not existent", hash)
+	}
+	var (
+		start     = time.Now()
+		logged    = time.Now()
+		missing   uint64
+		corrupted uint64
+	)
+	for number := uint64(0); number <= *head; number++ {
+		hash := rawdb.ReadCanonicalHash(db, number)
+		header := rawdb.ReadHeader(db, hash, number)
+		if header == nil {
+			return fmt.Errorf("header #%d [%x] is not existent", number, hash)
+		}
+		body := rawdb.ReadBody(db, hash, number)
+		if body == nil {
+			missing++
+			continue
+		}
+		if root := types.DeriveSha(types.Transactions(body.Transactions), trie.NewStackTrie(nil)); root != header.TxHash {
+			log.Error("Transaction root mismatch", "number", number, "hash", hash, "header", header.TxHash, "body", root)
+			corrupted++
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Verifying chain", "number", number, "head", *head, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	log.Info("Verified chain", "blocks", *head+1, "missing", missing, "corrupted", corrupted, "elapsed", common.PrettyDuration(time.Since(start)))
+	if corrupted > 0 {
+		return fmt.Errorf("%w in %d blocks", core.ErrBodyTransactionRootMismatch, corrupted)
+	}
+	return nil
+}
+
+func verifyStateRoots(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	db := utils.MakeChainDatabase(ctx, stack, true)
+	defer db.Close()
+
+	triedb := utils.MakeTrieDatabase(ctx, db, false, true, false)
+	defer triedb.Close()
+
+	head := rawdb.ReadHeadBlock(db)
+	if head == nil {
+		return errors.New("no head block")
+	}
+	first, last := head.NumberU64(), head.NumberU64()
+	switch {
+	case ctx.Bool("all"):
+		first = 0
+	case ctx.IsSet("block"):
+		first, last = ctx.Uint64("block"), ctx.Uint64("block")
+	}
+	if last > head.NumberU64() {
+		return fmt.Errorf("block #%d is above the head block #%d", last, head.NumberU64())
+	}
+	var (
+		start     = time.Now()
+		verified  int
+		skipped   int
+		corrupted int
+		previous  common.Hash
+	)
+	for number := first; number <= last; number++ {
+		header := rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, number), number)
+		if header == nil {
+			return fmt.Errorf("header #%d is not existent", number)
+		}
+		// Consecutive blocks often share the same state
+		if header.Root == previous {
+			continue
+		}
+		previous = header.Root
+
+		if _, err := trie.NewStateTrie(trie.StateTrieID(header.Root), triedb); err != nil {
+			if first == last {
+				return fmt.Errorf("state of block #%d is not available: %v", number, err)
+			}
+			skipped++
+			continue
+		}
+		log.Info("Verifying state", "number", number, "root", header.Root)
+		if err := utils.VerifyStateRoot(triedb, header.Root); err != nil {
+			log.Error("State verification failed", "number", number, "root", header.Root, "err", err)
+			corrupted++
+			continue
+		}
+		verified++
+	}
+	log.Info("Verified states", "verified", verified, "skipped", skipped, "corrupted", corrupted, "elapsed", common.PrettyDuration(time.Since(start)))
+	if corrupted > 0 {
+		return fmt.Errorf("state verification failed for %d blocks", corrupted)
+	}
+	return nil
+}
+
+// canonicalReader serves the canonical headers of a chain database.
+type canonicalReader struct {
+	db ethdb.Reader
+}
+
+func (r canonicalReader) GetHeaderByNumber(number uint64) *types.Header {
+	return rawdb.ReadHeader(r.db, rawdb.ReadCanonicalHash(r.db, number), number)
+}
+
+func gcTrie(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	dryRun := ctx.Bool("dry-run")
+	db := utils.MakeChainDatabase(ctx, stack, dryRun)
+	defer db.Close()
+
+	head := rawdb.ReadHeadBlock(db)
+	if head == nil {
+		return errors.New("no head block")
+	}
+	from, to := ctx.Uint64("from"), head.NumberU64()
+	if ctx.IsSet("to") {
+		to = ctx.Uint64("to")
+	}
+	// Refuse to delete the state of the head block, the node couldn't start anymore.
+	if to != head.NumberU64() {
+		return fmt.Errorf("range end #%d is not the head block #%d", to, head.NumberU64())
+	}
+	if from > to {
+		return fmt.Errorf("range start #%d is above the range end #%d", from, to)
+	}
+	if dryRun {
+		count, err := pruner.CountUnreferencedNodes(db, canonicalReader{db}, from, to)
+		if err != nil {
+			return err
+		}
+		log.Info("Found unreferenced trie nodes", "nodes", count)
+		return nil
+	}
+	deleted, err := pruner.GCUnreferencedNodes(db, canonicalReader{db}, from, to)
+	if err != nil {
+		return err
+	}
+	log.Info("Deleted unreferenced trie nodes", "nodes", deleted)
+	return nil
+}
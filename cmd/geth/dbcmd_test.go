@@ -0,0 +1,81 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/pebble"
+)
+
+// Tests that "geth db dump-prefix" prints the keys with the given prefix along
+// with their values, their count, or their meaning.
+func TestDBDumpPrefix(t *testing.T) {
+	t.Parallel()
+
+	datadir := t.TempDir()
+	genesisFile := filepath.Join(datadir, "genesis.json")
+	if err := os.WriteFile(genesisFile, []byte(customGenesisTests[0].genesis), 0600); err != nil {
+		t.Fatalf("failed to write genesis file: %v", err)
+	}
+	runGeth(t, "--datadir", datadir, "init", genesisFile).WaitExit()
+
+	db, err := pebble.New(filepath.Join(datadir, "geth", "chaindata"), 16, 16, "", false)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	code := []byte{0x60, 0x01}
+	codeHash := crypto.Keccak256Hash(code)
+	rawdb.WriteCode(db, codeHash, code)
+	db.Put([]byte("zz-1"), []byte{0x01})
+	db.Put([]byte("zz-2"), []byte{0x02, 0x03})
+	db.Close()
+
+	dump := func(args ...string) string {
+		t.Helper()
+
+		geth := runGeth(t, append([]string{"--datadir", datadir, "db", "dump-prefix"}, args...)...)
+		output := string(geth.Output())
+		geth.WaitExit()
+		if status := geth.ExitStatus(); status != 0 {
+			t.Fatalf("dump-prefix %v failed: %s", args, geth.StderrText())
+		}
+		return output
+	}
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--prefix", "0x7a7a2d"}, "0x7a7a2d31 = 0x01\n0x7a7a2d32 = 0x0203\n"},
+		{[]string{"--prefix", "zz-"}, "0x7a7a2d31 = 0x01\n0x7a7a2d32 = 0x0203\n"},
+		{[]string{"--prefix", "zz-", "--limit", "1"}, "0x7a7a2d31 = 0x01\n"},
+		{[]string{"--prefix", "zz-", "--count"}, "2\n"},
+		{[]string{"--prefix", "zz-", "--decode-key"}, "0x7a7a2d31 = 0x01\n0x7a7a2d32 = 0x0203\n"},
+		{[]string{"--prefix", "0x63", "--decode-key"}, fmt.Sprintf("0x63%x = 0x6001 (code %v)\n", codeHash, codeHash)},
+		{[]string{"--prefix", "0x6a", "--count"}, "0\n"},
+	}
+	for _, tt := range tests {
+		if have := dump(tt.args...); have != tt.want {
+			t.Errorf("dump-prefix %v: output mismatch:\nhave %q\nwant %q", tt.args, have, tt.want)
+		}
+	}
+}
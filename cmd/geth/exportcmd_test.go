@@ -18,11 +18,16 @@ package main
 
 import (
 	"bytes"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // TestExport does a basic test of "geth export", exporting the test-genesis.
@@ -43,3 +48,85 @@ func TestExport(t *testing.T) {
 		t.Fatalf("wrong content exported")
 	}
 }
+
+// TestExportState tests that "geth db export-state" exports the genesis state
+// as the original genesis alloc.
+func TestExportState(t *testing.T) {
+	t.Parallel()
+
+	alloc := `{
+		"0x02f0d131f1f97aef08aec6e3291b957d9efe7105": {"balance": "0x493e0"},
+		"0x000000000000000000000000000000000000c0de": {
+			"balance": "0x1",
+			"nonce": "0x2",
+			"code": "0x6001600055",
+			"storage": {
+				"0x0000000000000000000000000000000000000000000000000000000000000001": "0x00000000000000000000000000000000000000000000000000000000000000ff"
+			}
+		}
+	}`
+	genesis := `{
+		"alloc"      : ` + alloc + `,
+		"difficulty" : "0x20000",
+		"gasLimit"   : "0x2fefd8",
+		"config": {
+			"terminalTotalDifficulty": 0
+		}
+	}`
+	datadir := t.TempDir()
+	genesisFile := filepath.Join(datadir, "genesis.json")
+	if err := os.WriteFile(genesisFile, []byte(genesis), 0600); err != nil {
+		t.Fatalf("failed to write genesis file: %v", err)
+	}
+	runGeth(t, "--datadir", datadir, "--cache.preimages", "init", genesisFile).WaitExit()
+
+	// The JSON export must be the original alloc
+	outfile := filepath.Join(datadir, "alloc.json")
+	geth := runGeth(t, "--datadir", datadir, "db", "export-state", "--block", "0", "--output", outfile)
+	geth.WaitExit()
+	if have, want := geth.ExitStatus(), 0; have != want {
+		t.Fatalf("exit error, have %d want %d", have, want)
+	}
+	blob, err := os.ReadFile(outfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var have, want types.GenesisAlloc
+	if err := json.Unmarshal(blob, &have); err != nil {
+		t.Fatalf("failed to decode exported alloc: %v", err)
+	}
+	if err := json.Unmarshal([]byte(alloc), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(have, want) {
+		t.Fatalf("exported alloc mismatch:\nhave %s\nwant %s", blob, alloc)
+	}
+	// The CSV export must list every account
+	outfile = filepath.Join(datadir, "alloc.csv")
+	geth = runGeth(t, "--datadir", datadir, "db", "export-state", "--output", outfile, "--format", "csv")
+	geth.WaitExit()
+	if have, want := geth.ExitStatus(), 0; have != want {
+		t.Fatalf("exit error, have %d want %d", have, want)
+	}
+	fh, err := os.Open(outfile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fh.Close()
+	records, err := csv.NewReader(fh).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to decode exported csv: %v", err)
+	}
+	if len(records) != len(want)+1 {
+		t.Fatalf("csv record count mismatch: have %d, want %d", len(records), len(want)+1)
+	}
+	for _, record := range records[1:] {
+		account, ok := want[common.HexToAddress(record[0])]
+		if !ok {
+			t.Fatalf("unexpected account %s", record[0])
+		}
+		if record[1] != account.Balance.String() || record[2] != fmt.Sprint(account.Nonce) {
+			t.Errorf("account %s mismatch: have %v", record[0], record)
+		}
+	}
+}
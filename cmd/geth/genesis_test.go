@@ -17,10 +17,16 @@
 package main
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -97,6 +103,129 @@ func TestCustomGenesis(t *testing.T) {
 	}
 }
 
+// Tests that the genesis file can be downloaded over HTTPS, and is checked
+// against its checksum.
+func TestReadGenesisFile(t *testing.T) {
+	t.Parallel()
+
+	genesis := []byte(customGenesisTests[0].genesis)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/genesis.json":
+			w.Write(genesis)
+		case "/redirect":
+			http.Redirect(w, r, "/genesis.json", http.StatusFound)
+		case "/insecure":
+			http.Redirect(w, r, "http://"+r.Host+"/genesis.json", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	sum := sha256.Sum256(genesis)
+	checksum := hex.EncodeToString(sum[:])
+
+	secure := *server.Client()
+	secure.CheckRedirect = checkGenesisRedirect
+
+	for _, path := range []string{"/genesis.json", "/redirect"} {
+		blob, err := readGenesisFile(server.URL+path, checksum, &secure)
+		if err != nil {
+			t.Fatalf("failed to download genesis file from %s: %v", path, err)
+		}
+		if string(blob) != string(genesis) {
+			t.Fatalf("genesis file mismatch from %s: have %s, want %s", path, blob, genesis)
+		}
+	}
+	tests := []struct {
+		name     string
+		url      string
+		checksum string
+		want     string
+	}{
+		{"checksum mismatch", server.URL + "/genesis.json", strings.Repeat("00", sha256.Size), "checksum mismatch"},
+		{"invalid checksum", server.URL + "/genesis.json", "0x1234", "invalid genesis checksum"},
+		{"not found", server.URL + "/missing.json", "", "404"},
+		{"plain http", strings.Replace(server.URL, "https://", "http://", 1) + "/genesis.json", "", "over https"},
+		{"insecure redirect", server.URL + "/insecure", "", "redirected to http://"},
+		{"untrusted certificate", server.URL + "/genesis.json", "", "certificate"},
+	}
+	for _, tt := range tests {
+		client := &secure
+		if tt.name == "untrusted certificate" {
+			client = new(http.Client)
+		}
+		if _, err := readGenesisFile(tt.url, tt.checksum, client); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error mismatch: have %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+// Tests that geth init initializes the chain with a downloaded genesis file,
+// trusting the test server through the certificate file of the child process.
+func TestCustomGenesisURL(t *testing.T) {
+	genesis := customGenesisTests[0]
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(genesis.genesis))
+	}))
+	defer server.Close()
+
+	certs := filepath.Join(t.TempDir(), "certs.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(certs, cert, 0600); err != nil {
+		t.Fatalf("failed to write certificate: %v", err)
+	}
+	t.Setenv("SSL_CERT_FILE", certs)
+
+	// A checksum mismatch aborts the initialization
+	datadir := t.TempDir()
+	geth := runGeth(t, "--datadir", datadir, "init", "--genesis.checksum", strings.Repeat("00", sha256.Size), server.URL)
+	geth.WaitExit()
+	if status := geth.ExitStatus(); status == 0 || !strings.Contains(geth.StderrText(), "genesis checksum mismatch") {
+		t.Fatalf("init with wrong checksum: have exit status %d, output %q", status, geth.StderrText())
+	}
+
+	sum := sha256.Sum256([]byte(genesis.genesis))
+	runGeth(t, "--datadir", datadir, "init", "--genesis.checksum", hex.EncodeToString(sum[:]), server.URL).WaitExit()
+
+	geth = runGeth(t, "--networkid", "1337", "--syncmode=full", "--cache", "16",
+		"--datadir", datadir, "--maxpeers", "0", "--port", "0", "--authrpc.port", "0",
+		"--nodiscover", "--nat", "none", "--ipcdisable",
+		"--exec", genesis.query, "console")
+	geth.ExpectRegexp(genesis.result)
+	geth.ExpectExit()
+}
+
+// Tests that geth init refuses the genesis of an OP-Stack chain lacking some of
+// the required predeploys.
+func TestInitIncompleteOPGenesis(t *testing.T) {
+	t.Parallel()
+
+	datadir := t.TempDir()
+	json := filepath.Join(datadir, "genesis.json")
+	genesis := `{
+		"alloc"      : {
+			"0x4200000000000000000000000000000000000007": {"code": "0x00", "balance": "0x0"}
+		},
+		"difficulty" : "0x0",
+		"gasLimit"   : "0x2fefd8",
+		"config"     : {
+			"chainId": 901,
+			"terminalTotalDifficulty": 0,
+			"optimism": {"eip1559Elasticity": 6, "eip1559Denominator": 50}
+		}
+	}`
+	if err := os.WriteFile(json, []byte(genesis), 0600); err != nil {
+		t.Fatalf("failed to write genesis file: %v", err)
+	}
+	geth := runGeth(t, "--datadir", datadir, "init", json)
+	geth.WaitExit()
+	if status := geth.ExitStatus(); status == 0 || !strings.Contains(geth.StderrText(), "missing L2StandardBridge predeploy") {
+		t.Fatalf("init with incomplete genesis: have exit status %d, output %q", status, geth.StderrText())
+	}
+}
+
 // TestCustomBackend that the backend selection and detection (leveldb vs pebble) works properly.
 func TestCustomBackend(t *testing.T) {
 	t.Parallel()
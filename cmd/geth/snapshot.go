@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/cmd/utils"
@@ -76,6 +77,28 @@ geth snapshot verify-state <state-root>
 will traverse the whole accounts and storages set based on the specified
 snapshot and recalculate the root hash of state for verification.
 In other words, this command does the snapshot to trie conversion.
+`,
+			},
+			{
+				Name:   "verify-storage",
+				Usage:  "Recalculate the storage roots of contracts based on the snapshot for verification",
+				Action: verifyStorage,
+				Flags: slices.Concat([]cli.Flag{
+					&cli.Uint64Flag{
+						Name:  "block",
+						Usage: "block number of the verified state (default = latest)",
+					},
+					&cli.StringFlag{
+						Name:  "contracts",
+						Usage: "comma separated addresses of the contracts to verify (default = all)",
+					},
+				}, utils.NetworkFlags, utils.DatabaseFlags),
+				Description: `
+geth snapshot verify-storage [--block <number>] [--contracts <address,...>]
+will rebuild the storage trie of the given contracts from the storage slots
+in the snapshot, and compare the resulting root with the storage root of the
+contracts in the state trie. Without --contracts, every account with a
+non-empty storage is checked, which is slow on large states.
 `,
 			},
 			{
@@ -261,6 +284,180 @@ func verifyState(ctx *cli.Context) error {
 	}
 }
 
+// accountIterator and storageIterator are the iterators of both the hash based
+// snapshot and the path based state database.
+type (
+	accountIterator interface {
+		Next() bool
+		Error() error
+		Hash() common.Hash
+		Account() []byte
+		Release()
+	}
+	storageIterator interface {
+		Next() bool
+		Error() error
+		Hash() common.Hash
+		Slot() []byte
+		Release()
+	}
+)
+
+// verifyStorage rebuilds the storage tries of contracts from the snapshot and
+// compares their roots with the ones in the state trie.
+func verifyStorage(ctx *cli.Context) error {
+	stack, _ := makeConfigNode(ctx)
+	defer stack.Close()
+
+	chaindb := utils.MakeChainDatabase(ctx, stack, true)
+	defer chaindb.Close()
+
+	headBlock := rawdb.ReadHeadBlock(chaindb)
+	if headBlock == nil {
+		log.Error("Failed to load head block")
+		return errors.New("no head block")
+	}
+	header := headBlock.Header()
+	if ctx.IsSet("block") {
+		number := ctx.Uint64("block")
+		hash := rawdb.ReadCanonicalHash(chaindb, number)
+		if hash == (common.Hash{}) {
+			return fmt.Errorf("block %d not found", number)
+		}
+		if header = rawdb.ReadHeader(chaindb, hash, number); header == nil {
+			return fmt.Errorf("block %d not found", number)
+		}
+	}
+	triedb := utils.MakeTrieDatabase(ctx, chaindb, false, true, false)
+	defer triedb.Close()
+
+	// Resolve the snapshot iterators of the state scheme
+	var (
+		root        = header.Root
+		accountIter func() (accountIterator, error)
+		storageIter func(account common.Hash) (storageIterator, error)
+	)
+	if triedb.Scheme() == rawdb.PathScheme {
+		accountIter = func() (accountIterator, error) {
+			return triedb.AccountIterator(root, common.Hash{})
+		}
+		storageIter = func(account common.Hash) (storageIterator, error) {
+			return triedb.StorageIterator(root, account, common.Hash{})
+		}
+	} else {
+		snapConfig := snapshot.Config{
+			CacheSize:  256,
+			Recovery:   false,
+			NoBuild:    true,
+			AsyncBuild: false,
+		}
+		snaptree, err := snapshot.New(snapConfig, chaindb, triedb, headBlock.Root())
+		if err != nil {
+			log.Error("Failed to open snapshot tree", "err", err)
+			return err
+		}
+		accountIter = func() (accountIterator, error) {
+			return snaptree.AccountIterator(root, common.Hash{})
+		}
+		storageIter = func(account common.Hash) (storageIterator, error) {
+			return snaptree.StorageIterator(root, account, common.Hash{})
+		}
+	}
+	// Gather the contracts to verify along with their storage roots
+	var (
+		hashes []common.Hash
+		roots  = make(map[common.Hash]common.Hash)
+	)
+	if ctx.IsSet("contracts") {
+		statedb, err := state.New(root, state.NewDatabase(triedb, nil))
+		if err != nil {
+			log.Error("Failed to open state", "root", root, "err", err)
+			return err
+		}
+		for _, arg := range strings.Split(ctx.String("contracts"), ",") {
+			if !common.IsHexAddress(arg) {
+				return fmt.Errorf("invalid contract address %q", arg)
+			}
+			addr := common.HexToAddress(arg)
+			hash := crypto.Keccak256Hash(addr.Bytes())
+			hashes = append(hashes, hash)
+			roots[hash] = statedb.GetStorageRoot(addr)
+		}
+	} else {
+		tr, err := trie.NewStateTrie(trie.StateTrieID(root), triedb)
+		if err != nil {
+			log.Error("Failed to open state trie", "root", root, "err", err)
+			return err
+		}
+		it, err := accountIter()
+		if err != nil {
+			log.Error("Failed to open account iterator", "root", root, "err", err)
+			return err
+		}
+		defer it.Release()
+		for it.Next() {
+			account, err := types.FullAccount(it.Account())
+			if err != nil {
+				return err
+			}
+			if account.Root == types.EmptyRootHash {
+				continue
+			}
+			trieAccount, err := tr.GetAccountByHash(it.Hash())
+			if err != nil {
+				return err
+			}
+			want := types.EmptyRootHash
+			if trieAccount != nil {
+				want = trieAccount.Root
+			}
+			hashes = append(hashes, it.Hash())
+			roots[it.Hash()] = want
+		}
+		if err := it.Error(); err != nil {
+			return err
+		}
+	}
+	// Rebuild the storage tries from the snapshot and compare the roots
+	var (
+		mismatches int
+		start      = time.Now()
+		logged     = time.Now()
+	)
+	for i, hash := range hashes {
+		it, err := storageIter(hash)
+		if err != nil {
+			log.Error("Failed to open storage iterator", "account", hash, "err", err)
+			return err
+		}
+		st := trie.NewStackTrie(nil)
+		for it.Next() {
+			if err := st.Update(it.Hash().Bytes(), it.Slot()); err != nil {
+				it.Release()
+				return err
+			}
+		}
+		err = it.Error()
+		it.Release()
+		if err != nil {
+			return err
+		}
+		if have, want := st.Hash(), roots[hash]; have != want {
+			log.Error("Storage root mismatch", "account", hash, "snapshot", have, "trie", want)
+			mismatches++
+		}
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Verifying storage", "accounts", i+1, "total", len(hashes), "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+	}
+	if mismatches > 0 {
+		return fmt.Errorf("%d of %d storage tries mismatched", mismatches, len(hashes))
+	}
+	log.Info("Verified the storage", "root", root, "accounts", len(hashes), "elapsed", common.PrettyDuration(time.Since(start)))
+	return nil
+}
+
 // checkDanglingStorage iterates the snap storage data, and verifies that all
 // storage also has corresponding account data.
 func checkDanglingStorage(ctx *cli.Context) error {
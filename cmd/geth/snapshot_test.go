@@ -0,0 +1,99 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/pebble"
+)
+
+// TestVerifyStorage tests that "geth snapshot verify-storage" detects storage
+// slots of the snapshot diverging from the storage tries.
+func TestVerifyStorage(t *testing.T) {
+	t.Parallel()
+
+	genesis := `{
+		"alloc": {
+			"0x000000000000000000000000000000000000c0de": {
+				"balance": "0x1",
+				"code": "0x6001600055",
+				"storage": {
+					"0x0000000000000000000000000000000000000000000000000000000000000001": "0x00000000000000000000000000000000000000000000000000000000000000ff",
+					"0x0000000000000000000000000000000000000000000000000000000000000002": "0x0000000000000000000000000000000000000000000000000000000000000001"
+				}
+			},
+			"0x000000000000000000000000000000000000beef": {
+				"balance": "0x1",
+				"code": "0x6001600055",
+				"storage": {
+					"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002"
+				}
+			}
+		},
+		"difficulty" : "0x20000",
+		"gasLimit"   : "0x2fefd8",
+		"config": {
+			"terminalTotalDifficulty": 0
+		}
+	}`
+	datadir := t.TempDir()
+	genesisFile := filepath.Join(datadir, "genesis.json")
+	if err := os.WriteFile(genesisFile, []byte(genesis), 0600); err != nil {
+		t.Fatalf("failed to write genesis file: %v", err)
+	}
+	runGeth(t, "--datadir", datadir, "init", genesisFile).WaitExit()
+
+	verify := func(want int, args ...string) string {
+		t.Helper()
+
+		geth := runGeth(t, append([]string{"--datadir", datadir, "snapshot", "verify-storage"}, args...)...)
+		geth.WaitExit()
+		if have := geth.ExitStatus(); have != want {
+			t.Fatalf("exit status mismatch: have %d, want %d\n%s", have, want, geth.StderrText())
+		}
+		return geth.StderrText()
+	}
+	verify(0)
+	verify(0, "--block", "0", "--contracts", "0x000000000000000000000000000000000000c0de,0x000000000000000000000000000000000000beef")
+
+	// Corrupt a storage slot of the first contract in the snapshot
+	db, err := pebble.New(filepath.Join(datadir, "geth", "chaindata"), 16, 16, "", false)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	var (
+		account = crypto.Keccak256Hash(common.HexToAddress("0xc0de").Bytes())
+		slot    = crypto.Keccak256Hash(common.BigToHash(common.Big1).Bytes())
+	)
+	rawdb.WriteStorageSnapshot(db, account, slot, []byte{0x81, 0xfe})
+	db.Close()
+
+	if stderr := verify(1); !strings.Contains(stderr, "Storage root mismatch") {
+		t.Errorf("corrupted storage not reported:\n%s", stderr)
+	}
+	if stderr := verify(1, "--contracts", "0x000000000000000000000000000000000000c0de"); !strings.Contains(stderr, "Storage root mismatch") {
+		t.Errorf("corrupted storage not reported:\n%s", stderr)
+	}
+	verify(0, "--contracts", "0x000000000000000000000000000000000000beef")
+}
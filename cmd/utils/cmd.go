@@ -22,6 +22,8 @@ import (
 	"bytes"
 	"compress/gzip"
 	"crypto/sha256"
+	"encoding/csv"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -37,6 +39,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/state/snapshot"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -48,6 +51,8 @@ import (
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/urfave/cli/v2"
 )
 
@@ -148,7 +153,11 @@ func monitorFreeDiskSpace(sigc chan os.Signal, path string, freeDiskSpaceCritica
 	}
 }
 
-func ImportChain(chain *core.BlockChain, fn string) error {
+// ImportChain imports the blocks of an RLP export file into the chain. If verify
+// is set, the blocks are imported one by one and the state of every block is
+// recomputed from its leaves after its import, stopping at the first mismatch.
+// This is slow, it's meant to be used on small or suspicious files.
+func ImportChain(chain *core.BlockChain, fn string, verify bool) error {
 	// Watch for Ctrl-C while the import is running.
 	// If a signal is received, the import will stop at the next batch.
 	interrupt := make(chan os.Signal, 1)
@@ -224,6 +233,22 @@ func ImportChain(chain *core.BlockChain, fn string) error {
 			log.Info("Skipping batch as all blocks present", "batch", batch, "first", blocks[0].Hash(), "last", blocks[i-1].Hash())
 			continue
 		}
+		if verify {
+			for _, block := range missing {
+				if checkInterrupt() {
+					return ErrImportInterrupted
+				}
+				if _, err := chain.InsertChain(types.Blocks{block}); err != nil {
+					return fmt.Errorf("invalid block %d: %v", block.NumberU64(), err)
+				}
+				if err := VerifyStateRoot(chain.TrieDB(), block.Root()); err != nil {
+					log.Error("State verification failed", "number", block.NumberU64(), "hash", block.Hash(), "root", block.Root(), "err", err)
+					return fmt.Errorf("state verification failed at block %d: %v", block.NumberU64(), err)
+				}
+			}
+			log.Info("Verified imported states", "batch", batch, "first", missing[0].NumberU64(), "last", missing[len(missing)-1].NumberU64())
+			continue
+		}
 		if failindex, err := chain.InsertChain(missing); err != nil {
 			var failnumber uint64
 			if failindex > 0 && failindex < len(missing) {
@@ -237,6 +262,75 @@ func ImportChain(chain *core.BlockChain, fn string) error {
 	return nil
 }
 
+// VerifyStateRoot iterates the state with the given root, recomputing the root
+// hash of the account trie and of every storage trie from their leaves.
+func VerifyStateRoot(triedb *triedb.Database, root common.Hash) error {
+	t, err := trie.NewStateTrie(trie.StateTrieID(root), triedb)
+	if err != nil {
+		return err
+	}
+	acctIt, err := t.NodeIterator(nil)
+	if err != nil {
+		return err
+	}
+	var (
+		accounts   int
+		slots      int
+		lastReport time.Time
+		start      = time.Now()
+		hasher     = trie.NewStackTrie(nil)
+		accIter    = trie.NewIterator(acctIt)
+	)
+	for accIter.Next() {
+		accounts++
+		if err := hasher.Update(accIter.Key, accIter.Value); err != nil {
+			return err
+		}
+		var acc types.StateAccount
+		if err := rlp.DecodeBytes(accIter.Value, &acc); err != nil {
+			return fmt.Errorf("invalid account %x: %v", accIter.Key, err)
+		}
+		if acc.Root != types.EmptyRootHash {
+			id := trie.StorageTrieID(root, common.BytesToHash(accIter.Key), acc.Root)
+			storageTrie, err := trie.NewStateTrie(id, triedb)
+			if err != nil {
+				return fmt.Errorf("failed to open storage trie of account %x: %v", accIter.Key, err)
+			}
+			storageIt, err := storageTrie.NodeIterator(nil)
+			if err != nil {
+				return err
+			}
+			var (
+				storageHasher = trie.NewStackTrie(nil)
+				storageIter   = trie.NewIterator(storageIt)
+			)
+			for storageIter.Next() {
+				slots++
+				if err := storageHasher.Update(storageIter.Key, storageIter.Value); err != nil {
+					return err
+				}
+			}
+			if storageIter.Err != nil {
+				return fmt.Errorf("failed to iterate storage trie of account %x: %v", accIter.Key, storageIter.Err)
+			}
+			if have := storageHasher.Hash(); have != acc.Root {
+				return fmt.Errorf("storage root mismatch of account %x: have %x, want %x", accIter.Key, have, acc.Root)
+			}
+		}
+		if time.Since(lastReport) > time.Second*8 {
+			log.Info("Verifying state", "accounts", accounts, "slots", slots, "elapsed", common.PrettyDuration(time.Since(start)))
+			lastReport = time.Now()
+		}
+	}
+	if accIter.Err != nil {
+		return fmt.Errorf("failed to iterate account trie: %v", accIter.Err)
+	}
+	if have := hasher.Hash(); have != root {
+		return fmt.Errorf("state root mismatch: have %x, want %x", have, root)
+	}
+	return nil
+}
+
 func readList(filename string) ([]string, error) {
 	b, err := os.ReadFile(filename)
 	if err != nil {
@@ -567,6 +661,148 @@ func ExportPreimages(db ethdb.Database, fn string) error {
 	return nil
 }
 
+// ExportState exports every non-empty account of the given state into the file,
+// either as a genesis alloc (json) or as address,balance,nonce,codeHash records
+// (csv). The accounts are streamed to the file while iterating the state trie.
+//
+// Accounts can only be exported if the preimages of their address hashes are
+// available, the others are skipped.
+func ExportState(statedb *state.StateDB, fn string, format string) error {
+	log.Info("Exporting state", "file", fn, "format", format)
+
+	var exporter stateExporter
+	switch format {
+	case "json":
+		exporter = new(allocExporter)
+	case "csv":
+		exporter = new(csvStateExporter)
+	default:
+		return fmt.Errorf("invalid export format %q, supported formats: json, csv", format)
+	}
+	fh, err := os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+
+	writer := bufio.NewWriter(fh)
+	if err := exporter.start(writer); err != nil {
+		return err
+	}
+	collector := &stateCollector{exporter: exporter}
+	statedb.DumpToCollector(collector, nil)
+	if collector.err != nil {
+		return collector.err
+	}
+	if err := exporter.finish(); err != nil {
+		return err
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	log.Info("Exported state", "file", fn, "accounts", collector.accounts, "missing", collector.missing)
+	return nil
+}
+
+// stateExporter serializes the accounts of a state export.
+type stateExporter interface {
+	start(w io.Writer) error
+	export(addr common.Address, account *types.Account, codeHash []byte) error
+	finish() error
+}
+
+// stateCollector is a state.DumpCollector feeding the non-empty accounts of the
+// state into an exporter.
+type stateCollector struct {
+	exporter stateExporter
+	accounts int // number of exported accounts
+	missing  int // number of accounts skipped due to missing preimages
+	err      error
+}
+
+// OnRoot implements state.DumpCollector.
+func (c *stateCollector) OnRoot(common.Hash) {}
+
+// OnAccount implements state.DumpCollector.
+func (c *stateCollector) OnAccount(addr *common.Address, dump state.DumpAccount) {
+	if c.err != nil {
+		return
+	}
+	if addr == nil {
+		c.missing++
+		return
+	}
+	balance, ok := new(big.Int).SetString(dump.Balance, 10)
+	if !ok {
+		c.err = fmt.Errorf("invalid balance %q of account %x", dump.Balance, *addr)
+		return
+	}
+	account := &types.Account{Balance: balance, Nonce: dump.Nonce}
+	if len(dump.Code) > 0 {
+		account.Code = dump.Code
+	}
+	if len(dump.Storage) > 0 {
+		account.Storage = make(map[common.Hash]common.Hash, len(dump.Storage))
+		for key, value := range dump.Storage {
+			account.Storage[key] = common.HexToHash(value)
+		}
+	}
+	if account.Nonce == 0 && account.Balance.Sign() == 0 && account.Code == nil && account.Storage == nil {
+		return
+	}
+	c.err = c.exporter.export(*addr, account, dump.CodeHash)
+	c.accounts++
+}
+
+// allocExporter writes the accounts as a JSON genesis alloc.
+type allocExporter struct {
+	w     io.Writer
+	first bool
+}
+
+func (e *allocExporter) start(w io.Writer) error {
+	e.w, e.first = w, true
+	_, err := io.WriteString(w, "{")
+	return err
+}
+
+func (e *allocExporter) export(addr common.Address, account *types.Account, codeHash []byte) error {
+	blob, err := json.Marshal(account)
+	if err != nil {
+		return err
+	}
+	sep := ",\n"
+	if e.first {
+		sep, e.first = "\n", false
+	}
+	_, err = fmt.Fprintf(e.w, "%s  \"%s\": %s", sep, addr.Hex(), blob)
+	return err
+}
+
+func (e *allocExporter) finish() error {
+	_, err := io.WriteString(e.w, "\n}\n")
+	return err
+}
+
+// csvStateExporter writes the accounts as address,balance,nonce,codeHash records.
+type csvStateExporter struct {
+	w *csv.Writer
+}
+
+func (e *csvStateExporter) start(w io.Writer) error {
+	e.w = csv.NewWriter(w)
+	return e.w.Write([]string{"address", "balance", "nonce", "codeHash"})
+}
+
+func (e *csvStateExporter) export(addr common.Address, account *types.Account, codeHash []byte) error {
+	return e.w.Write([]string{addr.Hex(), account.Balance.String(), fmt.Sprint(account.Nonce), common.BytesToHash(codeHash).Hex()})
+}
+
+func (e *csvStateExporter) finish() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
 // ExportSnapshotPreimages exports the preimages corresponding to the enumeration of
 // the snapshot for a given root.
 func ExportSnapshotPreimages(chaindb ethdb.Database, snaptree *snapshot.Tree, fn string, root common.Hash) error {
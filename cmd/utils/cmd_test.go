@@ -0,0 +1,139 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of go-ethereum.
+//
+// go-ethereum is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// go-ethereum is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with go-ethereum. If not, see <http://www.gnu.org/licenses/>.
+
+package utils
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/triedb"
+	"github.com/holiman/uint256"
+)
+
+// Tests that corrupted state trie nodes are detected by the state verification.
+func TestVerifyStateRoot(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	tdb := triedb.NewDatabase(db, triedb.HashDefaults)
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabase(tdb, nil))
+	var (
+		alice = common.HexToAddress("0xa11ce")
+		bob   = common.HexToAddress("0xb0b")
+	)
+	for i, addr := range []common.Address{alice, bob} {
+		statedb.SetBalance(addr, uint256.NewInt(uint64(i+1)), tracing.BalanceChangeUnspecified)
+		statedb.SetState(addr, common.Hash{0x01}, common.Hash{byte(i + 1)})
+	}
+	root, err := statedb.Commit(0, false, false)
+	if err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	if err := tdb.Commit(root, false); err != nil {
+		t.Fatalf("failed to commit trie: %v", err)
+	}
+	aliceRoot, bobRoot := statedb.GetStorageRoot(alice), statedb.GetStorageRoot(bob)
+
+	if err := VerifyStateRoot(triedb.NewDatabase(db, triedb.HashDefaults), root); err != nil {
+		t.Fatalf("valid state rejected: %v", err)
+	}
+	// Replace the storage trie of alice with the one of bob
+	rawdb.WriteLegacyTrieNode(db, aliceRoot, rawdb.ReadLegacyTrieNode(db, bobRoot))
+	if err := VerifyStateRoot(triedb.NewDatabase(db, triedb.HashDefaults), root); err == nil {
+		t.Fatal("corrupted state accepted")
+	}
+}
+
+// Tests that an import with verification stops at the block whose state root
+// was corrupted in the export file.
+func TestImportChainVerify(t *testing.T) {
+	gspec := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc:  types.GenesisAlloc{common.HexToAddress("0xaa"): {Balance: big.NewInt(1)}},
+	}
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 10, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(common.BigToAddress(big.NewInt(int64(i + 1))))
+	})
+	src, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), nil)
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer src.Stop()
+	if _, err := src.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	var (
+		dir       = t.TempDir()
+		valid     = filepath.Join(dir, "valid.rlp")
+		corrupted = filepath.Join(dir, "corrupted.rlp")
+	)
+	if err := ExportChain(src, valid); err != nil {
+		t.Fatalf("failed to export chain: %v", err)
+	}
+	// Rewrite the export with a wrong state root in block #5
+	fh, err := os.Create(corrupted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, block := range append([]*types.Block{src.Genesis()}, blocks...) {
+		if block.NumberU64() == 5 {
+			header := block.Header()
+			header.Root = common.Hash{0xde, 0xad}
+			block = block.WithSeal(header)
+		}
+		if err := rlp.Encode(fh, block); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fh.Close()
+
+	newChain := func() *core.BlockChain {
+		chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), nil)
+		if err != nil {
+			t.Fatalf("failed to create blockchain: %v", err)
+		}
+		return chain
+	}
+	chain := newChain()
+	defer chain.Stop()
+	if err := ImportChain(chain, valid, true); err != nil {
+		t.Fatalf("failed to import valid chain: %v", err)
+	}
+	if head := chain.CurrentBlock().Number.Uint64(); head != 10 {
+		t.Errorf("head mismatch: have %d, want 10", head)
+	}
+	chain = newChain()
+	defer chain.Stop()
+	err = ImportChain(chain, corrupted, true)
+	if err == nil || !strings.Contains(err.Error(), "block 5") {
+		t.Fatalf("corrupted chain import error mismatch: have %v, want failure at block 5", err)
+	}
+	if head := chain.CurrentBlock().Number.Uint64(); head != 4 {
+		t.Errorf("head mismatch: have %d, want 4", head)
+	}
+}
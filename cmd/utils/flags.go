@@ -49,6 +49,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/bundler"
 	"github.com/ethereum/go-ethereum/eth/catalyst"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/filters"
@@ -169,6 +170,11 @@ var (
 		Usage:    "Ephemeral proof-of-authority network with a pre-funded developer account, mining enabled",
 		Category: flags.DevCategory,
 	}
+	UnsafeImportFlag = &cli.BoolFlag{
+		Name:     "unsafe.import",
+		Usage:    "Enable admin_importTrustedBlocks, inserting blocks without validation (implied by --dev)",
+		Category: flags.DevCategory,
+	}
 	DeveloperPeriodFlag = &cli.Uint64Flag{
 		Name:     "dev.period",
 		Usage:    "Block period to use in developer mode (0 = mine only if transaction pending)",
@@ -427,6 +433,24 @@ var (
 		Value:    ethconfig.Defaults.TxPool.Lifetime,
 		Category: flags.TxPoolCategory,
 	}
+	TxPoolExpiryFlag = &cli.DurationFlag{
+		Name:     "txpool.expiry",
+		Usage:    "Maximum amount of time transactions are kept in the pool, checked against the block time (0 = no expiry)",
+		Value:    ethconfig.Defaults.TxPool.DefaultExpiry,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolReBroadcastAgeFlag = &cli.DurationFlag{
+		Name:     "txpool.rebroadcast",
+		Usage:    "Age of the pending transactions announced to the peers again (0 = disabled)",
+		Value:    ethconfig.Defaults.TxPool.ReBroadcastAge,
+		Category: flags.TxPoolCategory,
+	}
+	TxPoolMaxFromIPFlag = &cli.Uint64Flag{
+		Name:     "txpool.maxfromip",
+		Usage:    "Maximum number of transactions accepted per minute from a single remote IP (0 = no limit)",
+		Value:    ethconfig.Defaults.TxPool.MaxFromIP,
+		Category: flags.TxPoolCategory,
+	}
 	// Blob transaction pool settings
 	BlobPoolDataDirFlag = &cli.StringFlag{
 		Name:     "blobpool.datadir",
@@ -446,6 +470,11 @@ var (
 		Value:    ethconfig.Defaults.BlobPool.PriceBump,
 		Category: flags.BlobPoolCategory,
 	}
+	BlobAvailabilityFlag = &cli.BoolFlag{
+		Name:     "blobpool.availability",
+		Usage:    "Verify the blobs referenced by the imported blocks, fetching the missing ones from peers",
+		Category: flags.BlobPoolCategory,
+	}
 	// Performance tuning settings
 	CacheFlag = &cli.IntFlag{
 		Name:     "cache",
@@ -584,6 +613,38 @@ var (
 		Value:    ethconfig.Defaults.RPCTxFeeCap,
 		Category: flags.APICategory,
 	}
+	TraceCacheSizeFlag = &cli.IntFlag{
+		Name:     "debug.tracecache.size",
+		Usage:    "Number of recent debug_traceTransaction results to cache (0 = disabled)",
+		Value:    ethconfig.Defaults.TraceCacheSize,
+		Category: flags.APICategory,
+	}
+	SequencerMaxAgeFlag = &cli.DurationFlag{
+		Name:     "rollup.sequencer.maxage",
+		Usage:    "Maximum age of the unsafe head block before the sequencer is reported unhealthy",
+		Value:    ethconfig.Defaults.SequencerMaxAge,
+		Category: flags.APICategory,
+	}
+	RollupSequencerFlag = &cli.BoolFlag{
+		Name:     "rollup.sequencer",
+		Usage:    "Mark the node as the sequencer of the rollup chain",
+		Category: flags.APICategory,
+	}
+	RollupL1RPCFlag = &cli.StringFlag{
+		Name:     "rollup.l1rpc",
+		Usage:    "URL of the L1 node the rollup chain is derived from, reported by admin_nodeInfo",
+		Category: flags.APICategory,
+	}
+	RollupForcePausedFlag = &cli.BoolFlag{
+		Name:     "op.forcePaused",
+		Usage:    "Start with block production paused, until resumed with miner_resume",
+		Category: flags.APICategory,
+	}
+	BundlerEntryPointsFlag = &cli.StringFlag{
+		Name:     "bundler.entrypoints",
+		Usage:    "Comma separated ERC-4337 entry point addresses to accept user operations for (enables the bundler API)",
+		Category: flags.APICategory,
+	}
 	// Authenticated RPC HTTP settings
 	AuthListenFlag = &cli.StringFlag{
 		Name:     "authrpc.addr",
@@ -640,6 +701,11 @@ var (
 		Usage:    "Filename for IPC socket/pipe within the datadir (explicit paths escape it)",
 		Category: flags.APICategory,
 	}
+	IPCAllowedUIDFlag = &cli.StringFlag{
+		Name:     "ipc.alloweduid",
+		Usage:    "Comma separated user IDs allowed to connect to the IPC socket (Linux only, default = all)",
+		Category: flags.APICategory,
+	}
 	HTTPEnabledFlag = &cli.BoolFlag{
 		Name:     "http",
 		Usage:    "Enable the HTTP-RPC server",
@@ -1255,6 +1321,16 @@ func setIPC(ctx *cli.Context, cfg *node.Config) {
 	case ctx.IsSet(IPCPathFlag.Name):
 		cfg.IPCPath = ctx.String(IPCPathFlag.Name)
 	}
+	if ctx.IsSet(IPCAllowedUIDFlag.Name) {
+		cfg.IPCAllowedUIDs = nil
+		for _, s := range SplitAndTrim(ctx.String(IPCAllowedUIDFlag.Name)) {
+			uid, err := strconv.ParseUint(s, 10, 32)
+			if err != nil {
+				Fatalf("Invalid IPC user ID %q: %v", s, err)
+			}
+			cfg.IPCAllowedUIDs = append(cfg.IPCAllowedUIDs, uint32(uid))
+		}
+	}
 }
 
 // MakeDatabaseHandles raises out the number of allowed file handles per process
@@ -1495,6 +1571,15 @@ func setTxPool(ctx *cli.Context, cfg *legacypool.Config) {
 	if ctx.IsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.Duration(TxPoolLifetimeFlag.Name)
 	}
+	if ctx.IsSet(TxPoolExpiryFlag.Name) {
+		cfg.DefaultExpiry = ctx.Duration(TxPoolExpiryFlag.Name)
+	}
+	if ctx.IsSet(TxPoolReBroadcastAgeFlag.Name) {
+		cfg.ReBroadcastAge = ctx.Duration(TxPoolReBroadcastAgeFlag.Name)
+	}
+	if ctx.IsSet(TxPoolMaxFromIPFlag.Name) {
+		cfg.MaxFromIP = ctx.Uint64(TxPoolMaxFromIPFlag.Name)
+	}
 }
 
 func setBlobPool(ctx *cli.Context, cfg *blobpool.Config) {
@@ -1570,6 +1655,9 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	setGPO(ctx, &cfg.GPO)
 	setTxPool(ctx, &cfg.TxPool)
 	setBlobPool(ctx, &cfg.BlobPool)
+	if ctx.IsSet(BlobAvailabilityFlag.Name) {
+		cfg.BlobAvailability = ctx.Bool(BlobAvailabilityFlag.Name)
+	}
 	setMiner(ctx, &cfg.Miner)
 	setRequiredBlocks(ctx, cfg)
 
@@ -1710,6 +1798,24 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *ethconfig.Config) {
 	} else {
 		log.Info("Global gas cap disabled")
 	}
+	if ctx.IsSet(TraceCacheSizeFlag.Name) {
+		cfg.TraceCacheSize = ctx.Int(TraceCacheSizeFlag.Name)
+	}
+	if ctx.IsSet(SequencerMaxAgeFlag.Name) {
+		cfg.SequencerMaxAge = ctx.Duration(SequencerMaxAgeFlag.Name)
+	}
+	if ctx.IsSet(RollupSequencerFlag.Name) {
+		cfg.RollupSequencer = ctx.Bool(RollupSequencerFlag.Name)
+	}
+	if ctx.IsSet(RollupL1RPCFlag.Name) {
+		cfg.RollupL1RPC = ctx.String(RollupL1RPCFlag.Name)
+	}
+	if ctx.IsSet(RollupForcePausedFlag.Name) {
+		cfg.RollupForcePaused = ctx.Bool(RollupForcePausedFlag.Name)
+	}
+	if ctx.Bool(UnsafeImportFlag.Name) || ctx.Bool(DeveloperFlag.Name) {
+		cfg.UnsafeImport = true
+	}
 	if ctx.IsSet(RPCGlobalEVMTimeoutFlag.Name) {
 		cfg.RPCEVMTimeout = ctx.Duration(RPCGlobalEVMTimeoutFlag.Name)
 	}
@@ -1977,6 +2083,21 @@ func RegisterEthStatsService(stack *node.Node, backend *eth.EthAPIBackend, url s
 	}
 }
 
+// RegisterBundlerService adds the ERC-4337 bundler API to the node, accepting
+// user operations for the given comma separated entry points.
+func RegisterBundlerService(stack *node.Node, backend ethapi.Backend, entryPoints string) {
+	var addrs []common.Address
+	for _, addr := range SplitAndTrim(entryPoints) {
+		if !common.IsHexAddress(addr) {
+			Fatalf("Invalid bundler entry point address %q", addr)
+		}
+		addrs = append(addrs, common.HexToAddress(addr))
+	}
+	if err := bundler.New(stack, backend, addrs); err != nil {
+		Fatalf("Failed to register the bundler service: %v", err)
+	}
+}
+
 // RegisterGraphQLService adds the GraphQL API to the node.
 func RegisterGraphQLService(stack *node.Node, backend ethapi.Backend, filterSystem *filters.FilterSystem, cfg *node.Config) {
 	err := graphql.New(stack, backend, filterSystem, cfg.GraphQLCors, cfg.GraphQLVirtualHosts)
@@ -258,11 +258,28 @@ func (a Address) String() string {
 	return a.Hex()
 }
 
+// EIP1191Checksum returns the hex string representation of the address, with
+// the chain ID aware checksum of EIP-1191. A nil chain ID yields the EIP-55
+// checksum.
+func EIP1191Checksum(addr Address, chainID *big.Int) string {
+	if chainID == nil {
+		return addr.Hex()
+	}
+	return string(addr.checksumHexWithPrefix([]byte(chainID.String() + "0x")))
+}
+
 func (a *Address) checksumHex() []byte {
+	return a.checksumHexWithPrefix(nil)
+}
+
+// checksumHexWithPrefix computes the checksum over the lowercase hex address,
+// preceded by the given prefix.
+func (a *Address) checksumHexWithPrefix(prefix []byte) []byte {
 	buf := a.hex()
 
 	// compute checksum
 	sha := sha3.NewLegacyKeccak256()
+	sha.Write(prefix)
 	sha.Write(buf[2:])
 	hash := sha.Sum(nil)
 	for i := 2; i < len(buf); i++ {
@@ -149,6 +149,29 @@ func TestAddressHexChecksum(t *testing.T) {
 	}
 }
 
+func TestEIP1191Checksum(t *testing.T) {
+	var tests = []struct {
+		chainID *big.Int
+		input   string
+		output  string
+	}{
+		// Test cases from https://github.com/ethereum/EIPs/blob/master/EIPS/eip-1191.md#test-cases
+		{big.NewInt(30), "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "0x5aaEB6053f3e94c9b9a09f33669435E7ef1bEAeD"},
+		{big.NewInt(30), "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359", "0xFb6916095cA1Df60bb79ce92cE3EA74c37c5d359"},
+		{big.NewInt(30), "0xdbf03b407c01e7cd3cbea99509d93f8dddc8c6fb", "0xDBF03B407c01E7CD3cBea99509D93F8Dddc8C6FB"},
+		{big.NewInt(30), "0xd1220a0cf47c7b9be7a2e6ba89f429762e7b9adb", "0xD1220A0Cf47c7B9BE7a2e6ba89F429762E7B9adB"},
+		{big.NewInt(31), "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "0x5aAeb6053F3e94c9b9A09F33669435E7EF1BEaEd"},
+		{big.NewInt(31), "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359", "0xFb6916095CA1dF60bb79CE92ce3Ea74C37c5D359"},
+		// Without a chain ID the EIP-55 checksum is used
+		{nil, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+	}
+	for i, test := range tests {
+		if output := EIP1191Checksum(HexToAddress(test.input), test.chainID); output != test.output {
+			t.Errorf("test #%d: checksum mismatch: have %s, want %s", i, output, test.output)
+		}
+	}
+}
+
 func BenchmarkAddressHex(b *testing.B) {
 	testAddr := HexToAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
 	for n := 0; n < b.N; n++ {
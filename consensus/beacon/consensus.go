@@ -49,6 +49,8 @@ var (
 	errInvalidNonce     = errors.New("invalid nonce")
 	errInvalidUncleHash = errors.New("invalid uncle hash")
 	errInvalidTimestamp = errors.New("invalid timestamp")
+
+	errInvalidWithdrawalsHash = errors.New("invalid withdrawals hash")
 )
 
 // Beacon is a consensus engine that combines the eth1 consensus and proof-of-stake
@@ -117,8 +119,9 @@ func (beacon *Beacon) VerifyHeader(chain consensus.ChainHeaderReader, header *ty
 	if parent.Difficulty.Sign() == 0 && header.Difficulty.Sign() > 0 {
 		return consensus.ErrInvalidTerminalBlock
 	}
-	// Check >0 TDs with pre-merge, --0 TDs with post-merge rules
-	if header.Difficulty.Sign() > 0 {
+	// Check >0 TDs with pre-merge, --0 TDs with post-merge rules. Rollups are
+	// proof-of-stake from their genesis, so their headers never use pre-merge rules.
+	if header.Difficulty.Sign() > 0 && !chain.Config().IsOptimism() {
 		return beacon.ethone.VerifyHeader(chain, header)
 	}
 	return beacon.verifyHeader(chain, header, parent)
@@ -148,6 +151,9 @@ func (beacon *Beacon) splitHeaders(headers []*types.Header) ([]*types.Header, []
 // a results channel to retrieve the async verifications.
 // VerifyHeaders expect the headers to be ordered and continuous.
 func (beacon *Beacon) VerifyHeaders(chain consensus.ChainHeaderReader, headers []*types.Header) (chan<- struct{}, <-chan error) {
+	if chain.Config().IsOptimism() {
+		return beacon.verifyHeaders(chain, headers, nil)
+	}
 	preHeaders, postHeaders := beacon.splitHeaders(headers)
 	if len(postHeaders) == 0 {
 		return beacon.ethone.VerifyHeaders(chain, headers)
@@ -263,6 +269,11 @@ func (beacon *Beacon) verifyHeader(chain consensus.ChainHeaderReader, header, pa
 	if !shanghai && header.WithdrawalsHash != nil {
 		return fmt.Errorf("invalid withdrawalsHash: have %x, expected nil", header.WithdrawalsHash)
 	}
+	if chain.Config().IsOptimism() {
+		if err := verifyOptimismHeader(chain.Config(), header); err != nil {
+			return err
+		}
+	}
 	// Verify the existence / non-existence of cancun-specific header fields
 	cancun := chain.Config().IsCancun(header.Number, header.Time)
 	if !cancun {
@@ -341,7 +352,10 @@ func (beacon *Beacon) Finalize(chain consensus.ChainHeaderReader, header *types.
 		beacon.ethone.Finalize(chain, header, state, body)
 		return
 	}
-	// Withdrawals processing.
+	// Withdrawals processing, rollups have no validator withdrawals to process.
+	if chain.Config().IsOptimism() {
+		return
+	}
 	for _, w := range body.Withdrawals {
 		// Convert amount from gwei to wei.
 		amount := new(uint256.Int).SetUint64(w.Amount)
@@ -363,6 +377,9 @@ func (beacon *Beacon) FinalizeAndAssemble(chain consensus.ChainHeaderReader, hea
 		if body.Withdrawals == nil {
 			body.Withdrawals = make([]*types.Withdrawal, 0)
 		}
+		if chain.Config().IsOptimism() && len(body.Withdrawals) > 0 {
+			return nil, errors.New("withdrawals set on rollup chain")
+		}
 	} else {
 		if len(body.Withdrawals) > 0 {
 			return nil, errors.New("withdrawals set before Shanghai activation")
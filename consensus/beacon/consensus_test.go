@@ -0,0 +1,72 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package beacon
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+)
+
+// Tests that post-merge blocks are rejected if they commit to or carry uncles.
+func TestVerifyPoSUncles(t *testing.T) {
+	config := *params.MergedTestChainConfig
+	config.CancunTime, config.PragueTime, config.OsakaTime = nil, nil, nil
+
+	parent := &types.Header{
+		Number:          big.NewInt(1),
+		Time:            100,
+		GasLimit:        30_000_000,
+		BaseFee:         big.NewInt(params.InitialBaseFee),
+		Difficulty:      new(big.Int),
+		UncleHash:       types.EmptyUncleHash,
+		WithdrawalsHash: &types.EmptyWithdrawalsHash,
+	}
+	var (
+		chain  = &headerReader{config: &config, headers: map[common.Hash]*types.Header{parent.Hash(): parent}}
+		engine = New(ethash.NewFaker())
+		header = &types.Header{
+			ParentHash:      parent.Hash(),
+			Number:          big.NewInt(2),
+			Time:            112,
+			GasLimit:        parent.GasLimit,
+			BaseFee:         eip1559.CalcBaseFee(&config, parent, 112),
+			Difficulty:      new(big.Int),
+			UncleHash:       types.EmptyUncleHash,
+			WithdrawalsHash: &types.EmptyWithdrawalsHash,
+		}
+	)
+	if err := engine.VerifyHeader(chain, header); err != nil {
+		t.Fatalf("valid header rejected: %v", err)
+	}
+	// A block with an uncle commits to a non-empty uncle hash
+	uncle := &types.Header{Number: big.NewInt(1), Difficulty: new(big.Int)}
+	block := types.NewBlock(header, &types.Body{Uncles: []*types.Header{uncle}}, nil, trie.NewStackTrie(nil))
+	if err := engine.VerifyHeader(chain, block.Header()); !errors.Is(err, errInvalidUncleHash) {
+		t.Errorf("uncle hash error mismatch: have %v, want %v", err, errInvalidUncleHash)
+	}
+	if err := engine.VerifyUncles(nil, block); !errors.Is(err, errTooManyUncles) {
+		t.Errorf("uncles error mismatch: have %v, want %v", err, errTooManyUncles)
+	}
+}
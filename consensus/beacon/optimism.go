@@ -0,0 +1,41 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package beacon
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// verifyOptimismHeader checks the header fields constrained by the OP-Stack on
+// top of the proof-of-stake rules:
+//   - withdrawals are never processed on L2, so the withdrawals hash is the
+//     hash of the empty list once Shanghai is active, until Isthmus repurposes
+//     it for the storage root of the L2ToL1MessagePasser
+//
+// The zero nonce and difficulty are enforced by the proof-of-stake rules, and
+// the gas limit and base fee by the EIP-1559 rules with the rollup parameters.
+// The mix digest carries the randao of the L1 origin, which can't be checked
+// without access to L1.
+func verifyOptimismHeader(config *params.ChainConfig, header *types.Header) error {
+	if config.IsShanghai(header.Number, header.Time) && !config.IsIsthmus(header.Time) && *header.WithdrawalsHash != types.EmptyWithdrawalsHash {
+		return fmt.Errorf("%w: have %x, want %x", errInvalidWithdrawalsHash, *header.WithdrawalsHash, types.EmptyWithdrawalsHash)
+	}
+	return nil
+}
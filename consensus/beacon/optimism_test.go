@@ -0,0 +1,185 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package beacon
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// headerReader is a consensus.ChainHeaderReader serving a fixed set of headers.
+type headerReader struct {
+	config  *params.ChainConfig
+	headers map[common.Hash]*types.Header
+}
+
+func (r *headerReader) Config() *params.ChainConfig  { return r.config }
+func (r *headerReader) CurrentHeader() *types.Header { return nil }
+
+func (r *headerReader) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if header := r.headers[hash]; header != nil && header.Number.Uint64() == number {
+		return header
+	}
+	return nil
+}
+
+func (r *headerReader) GetHeaderByNumber(number uint64) *types.Header { return nil }
+func (r *headerReader) GetHeaderByHash(hash common.Hash) *types.Header {
+	return r.headers[hash]
+}
+
+// Tests that headers of rollup chains are checked against the OP-Stack invariants.
+func TestVerifyOptimismHeader(t *testing.T) {
+	config := *params.MergedTestChainConfig
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+
+	parent := &types.Header{
+		Number:          big.NewInt(1),
+		Time:            100,
+		GasLimit:        30_000_000,
+		GasUsed:         10_000_000,
+		BaseFee:         big.NewInt(params.InitialBaseFee),
+		Difficulty:      new(big.Int),
+		UncleHash:       types.EmptyUncleHash,
+		WithdrawalsHash: &types.EmptyWithdrawalsHash,
+	}
+	var (
+		chain  = &headerReader{config: &config, headers: map[common.Hash]*types.Header{parent.Hash(): parent}}
+		engine = New(ethash.NewFaker())
+	)
+	valid := func() *types.Header {
+		var zero uint64
+		return &types.Header{
+			ParentHash:       parent.Hash(),
+			Number:           big.NewInt(2),
+			Time:             102,
+			GasLimit:         parent.GasLimit,
+			BaseFee:          eip1559.CalcBaseFee(&config, parent, 102),
+			Difficulty:       new(big.Int),
+			MixDigest:        common.HexToHash("0x7a4d0"), // L1 origin randao
+			UncleHash:        types.EmptyUncleHash,
+			WithdrawalsHash:  &types.EmptyWithdrawalsHash,
+			BlobGasUsed:      &zero,
+			ExcessBlobGas:    &zero,
+			ParentBeaconRoot: &common.Hash{},
+		}
+	}
+	if err := engine.VerifyHeader(chain, valid()); err != nil {
+		t.Fatalf("valid header rejected: %v", err)
+	}
+	tests := []struct {
+		name   string
+		modify func(header *types.Header)
+		want   error // nil if only a failure is expected
+	}{
+		{
+			name:   "withdrawals",
+			modify: func(header *types.Header) { header.WithdrawalsHash = &common.Hash{0x01} },
+			want:   errInvalidWithdrawalsHash,
+		},
+		{
+			name:   "nonce",
+			modify: func(header *types.Header) { header.Nonce = types.EncodeNonce(1) },
+			want:   errInvalidNonce,
+		},
+		{
+			name:   "difficulty",
+			modify: func(header *types.Header) { header.Difficulty = big.NewInt(1) },
+		},
+		{
+			name: "gaslimit",
+			modify: func(header *types.Header) {
+				header.GasLimit = parent.GasLimit + parent.GasLimit/params.GasLimitBoundDivisor
+			},
+		},
+		{
+			name:   "basefee",
+			modify: func(header *types.Header) { header.BaseFee = big.NewInt(params.InitialBaseFee) },
+		},
+	}
+	for _, tt := range tests {
+		header := valid()
+		tt.modify(header)
+
+		err := engine.VerifyHeader(chain, header)
+		if err == nil {
+			t.Errorf("%s: invalid header accepted", tt.name)
+			continue
+		}
+		if tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.want)
+		}
+		// The batch verification must reject the header too
+		_, results := engine.VerifyHeaders(chain, []*types.Header{header})
+		if err := <-results; err == nil {
+			t.Errorf("%s: invalid header accepted in batch", tt.name)
+		}
+	}
+	// From Isthmus, the withdrawals hash holds the message passer storage root
+	isthmus := uint64(0)
+	config.IsthmusTime = &isthmus
+
+	header := valid()
+	header.WithdrawalsHash = &common.Hash{0x01}
+	if err := engine.VerifyHeader(chain, header); err != nil {
+		t.Errorf("isthmus header rejected: %v", err)
+	}
+}
+
+// Tests that withdrawals are never processed on rollup chains, while the blocks
+// still commit to the empty withdrawals list once Shanghai is active.
+func TestOptimismWithdrawals(t *testing.T) {
+	config := *params.MergedTestChainConfig
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+
+	var (
+		chain     = &headerReader{config: &config}
+		engine    = New(ethash.NewFaker())
+		recipient = common.HexToAddress("0xdead")
+		header    = func() *types.Header {
+			return &types.Header{Number: big.NewInt(1), Time: 1, Difficulty: new(big.Int), BaseFee: big.NewInt(params.InitialBaseFee)}
+		}
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	block, err := engine.FinalizeAndAssemble(chain, header(), statedb, &types.Body{}, nil)
+	if err != nil {
+		t.Fatalf("failed to assemble block: %v", err)
+	}
+	if hash := block.Header().WithdrawalsHash; hash == nil || *hash != types.EmptyWithdrawalsHash {
+		t.Errorf("withdrawals hash mismatch: have %v, want %x", hash, types.EmptyWithdrawalsHash)
+	}
+	if withdrawals := block.Withdrawals(); withdrawals == nil || len(withdrawals) != 0 {
+		t.Errorf("withdrawals mismatch: have %v, want empty list", withdrawals)
+	}
+	// Withdrawals are neither included nor processed
+	body := &types.Body{Withdrawals: []*types.Withdrawal{{Index: 0, Validator: 1, Address: recipient, Amount: 1}}}
+	if _, err := engine.FinalizeAndAssemble(chain, header(), statedb, body, nil); err == nil {
+		t.Error("block with withdrawals assembled")
+	}
+	engine.Finalize(chain, header(), statedb, body)
+	if balance := statedb.GetBalance(recipient); !balance.IsZero() {
+		t.Errorf("withdrawal processed: balance %v", balance)
+	}
+}
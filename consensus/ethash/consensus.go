@@ -310,6 +310,9 @@ func (ethash *Ethash) CalcDifficulty(chain consensus.ChainHeaderReader, time uin
 // given the parent block's time and difficulty.
 func CalcDifficulty(config *params.ChainConfig, time uint64, parent *types.Header) *big.Int {
 	next := new(big.Int).Add(parent.Number, big1)
+	if delay := config.DifficultyBombDelay(next); delay != nil {
+		return makeDifficultyCalculator(delay)(time, parent)
+	}
 	switch {
 	case config.IsGrayGlacier(next):
 		return calcDifficultyEip5133(time, parent)
@@ -564,12 +567,12 @@ func (ethash *Ethash) SealHash(header *types.Header) (hash common.Hash) {
 	return hash
 }
 
-// accumulateRewards credits the coinbase of the given block with the mining
-// reward. The total reward consists of the static block reward and rewards for
-// included uncles. The coinbase of each uncle block is also rewarded.
-func accumulateRewards(config *params.ChainConfig, stateDB vm.StateDB, header *types.Header, uncles []*types.Header) {
+// BlockRewards returns the mining rewards of the given block: the static block
+// reward and the reward for the included uncles, both credited to the coinbase
+// of the block, and the reward credited to the coinbase of each uncle.
+func BlockRewards(config *params.ChainConfig, header *types.Header, uncles []*types.Header) (blockReward *uint256.Int, inclusionReward *uint256.Int, uncleRewards []*uint256.Int) {
 	// Select the correct block reward based on chain progression
-	blockReward := FrontierBlockReward
+	blockReward = FrontierBlockReward
 	if config.IsByzantium(header.Number) {
 		blockReward = ByzantiumBlockReward
 	}
@@ -577,19 +580,28 @@ func accumulateRewards(config *params.ChainConfig, stateDB vm.StateDB, header *t
 		blockReward = ConstantinopleBlockReward
 	}
 	// Accumulate the rewards for the miner and any included uncles
-	reward := new(uint256.Int).Set(blockReward)
-	r := new(uint256.Int)
+	inclusionReward = new(uint256.Int)
 	hNum, _ := uint256.FromBig(header.Number)
 	for _, uncle := range uncles {
 		uNum, _ := uint256.FromBig(uncle.Number)
-		r.AddUint64(uNum, 8)
+		r := new(uint256.Int).AddUint64(uNum, 8)
 		r.Sub(r, hNum)
 		r.Mul(r, blockReward)
 		r.Rsh(r, 3)
-		stateDB.AddBalance(uncle.Coinbase, r, tracing.BalanceIncreaseRewardMineUncle)
+		uncleRewards = append(uncleRewards, r)
+
+		inclusionReward.Add(inclusionReward, new(uint256.Int).Rsh(blockReward, 5))
+	}
+	return new(uint256.Int).Set(blockReward), inclusionReward, uncleRewards
+}
 
-		r.Rsh(blockReward, 5)
-		reward.Add(reward, r)
+// accumulateRewards credits the coinbase of the given block with the mining
+// reward. The total reward consists of the static block reward and rewards for
+// included uncles. The coinbase of each uncle block is also rewarded.
+func accumulateRewards(config *params.ChainConfig, stateDB vm.StateDB, header *types.Header, uncles []*types.Header) {
+	blockReward, inclusionReward, uncleRewards := BlockRewards(config, header, uncles)
+	for i, uncle := range uncles {
+		stateDB.AddBalance(uncle.Coinbase, uncleRewards[i], tracing.BalanceIncreaseRewardMineUncle)
 	}
-	stateDB.AddBalance(header.Coinbase, reward, tracing.BalanceIncreaseRewardMineBlock)
+	stateDB.AddBalance(header.Coinbase, blockReward.Add(blockReward, inclusionReward), tracing.BalanceIncreaseRewardMineBlock)
 }
@@ -89,6 +89,43 @@ func TestCalcDifficulty(t *testing.T) {
 	}
 }
 
+// Tests that the difficulty bomb delay schedule of the chain config overrides
+// the delays of the forks from its first entry, switching at the boundaries.
+func TestCalcDifficultyBombSchedule(t *testing.T) {
+	config := *params.TestChainConfig
+	config.DifficultyBombDelaySchedule = map[uint64]*big.Int{
+		1_000_000: big.NewInt(500_000),
+		2_000_000: big.NewInt(1_500_000),
+	}
+	if err := params.ValidateDifficultyBombSchedule(&config); err != nil {
+		t.Fatalf("invalid schedule: %v", err)
+	}
+	parentDiff := big.NewInt(1_000_000_000)
+	tests := []struct {
+		number uint64
+		bomb   int64
+	}{
+		{999_999, 0},         // gray glacier delay, no bomb
+		{1_000_000, 1 << 3},  // (999_999 - 499_999) / 100_000 = 5 periods
+		{1_999_999, 1 << 12}, // (1_999_998 - 499_999) / 100_000 = 14 periods
+		{2_000_000, 1 << 3},  // (1_999_999 - 1_499_999) / 100_000 = 5 periods
+	}
+	for _, tt := range tests {
+		// A parent mined 9 seconds earlier keeps the difficulty unchanged,
+		// leaving the bomb as the only difference.
+		parent := &types.Header{
+			Number:     new(big.Int).SetUint64(tt.number - 1),
+			Time:       1000,
+			Difficulty: parentDiff,
+			UncleHash:  types.EmptyUncleHash,
+		}
+		want := new(big.Int).Add(parentDiff, big.NewInt(tt.bomb))
+		if have := CalcDifficulty(&config, 1009, parent); have.Cmp(want) != 0 {
+			t.Errorf("block %d: difficulty mismatch: have %v, want %v", tt.number, have, want)
+		}
+	}
+}
+
 func randSlice(min, max uint32) []byte {
 	var b = make([]byte, 4)
 	crand.Read(b)
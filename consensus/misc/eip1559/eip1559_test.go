@@ -124,7 +124,75 @@ func TestCalcBaseFee(t *testing.T) {
 			GasUsed:  test.parentGasUsed,
 			BaseFee:  big.NewInt(test.parentBaseFee),
 		}
-		if have, want := CalcBaseFee(config(), parent), big.NewInt(test.expectedBaseFee); have.Cmp(want) != 0 {
+		if have, want := CalcBaseFee(config(), parent, 0), big.NewInt(test.expectedBaseFee); have.Cmp(want) != 0 {
+			t.Errorf("test %d: have %d  want %d, ", i, have, want)
+		}
+	}
+}
+
+// TestCalcBaseFeeCanyon checks that OP-Stack chains switch to the Canyon base
+// fee change denominator from the first Canyon block on.
+func TestCalcBaseFeeCanyon(t *testing.T) {
+	canyonTime := uint64(1000)
+	config := config()
+	config.CanyonTime = &canyonTime
+	config.Optimism = &params.OptimismConfig{
+		EIP1559Elasticity:        6,
+		EIP1559Denominator:       50,
+		EIP1559DenominatorCanyon: 250,
+	}
+	parent := &types.Header{
+		Number:   common.Big32,
+		Time:     canyonTime - 2,
+		GasLimit: 30_000_000,
+		GasUsed:  10_000_000, // twice the 5M target
+		BaseFee:  big.NewInt(1_000_000_000),
+	}
+	tests := []struct {
+		time            uint64
+		expectedBaseFee int64
+	}{
+		{canyonTime - 1, 1_000_000_000 + 1_000_000_000/50},
+		{canyonTime, 1_000_000_000 + 1_000_000_000/250},
+		{canyonTime + 1, 1_000_000_000 + 1_000_000_000/250},
+	}
+	for i, test := range tests {
+		if have, want := CalcBaseFee(config, parent, test.time), big.NewInt(test.expectedBaseFee); have.Cmp(want) != 0 {
+			t.Errorf("test %d: have %d  want %d, ", i, have, want)
+		}
+	}
+	// The denominator only changes if the rollup configures one for Canyon
+	config.Optimism.EIP1559DenominatorCanyon = 0
+	if have, want := CalcBaseFee(config, parent, canyonTime), big.NewInt(1_000_000_000+1_000_000_000/50); have.Cmp(want) != 0 {
+		t.Errorf("unset canyon denominator: have %d  want %d, ", have, want)
+	}
+}
+
+// TestCalcBaseFeeElasticity checks that the gas target of OP-Stack chains follows
+// the configured elasticity multiplier.
+func TestCalcBaseFeeElasticity(t *testing.T) {
+	config := config()
+	config.Optimism = &params.OptimismConfig{
+		EIP1559Elasticity:  4,
+		EIP1559Denominator: 8,
+	}
+	tests := []struct {
+		parentGasUsed   uint64
+		expectedBaseFee int64
+	}{
+		{5_000_000, params.InitialBaseFee},   // usage == target
+		{4_000_000, 975_000_000},             // usage below target
+		{10_000_000, 1_125_000_000},          // usage == target with the default elasticity
+		{20_000_000, 1_000_000_000 * 11 / 8}, // full block
+	}
+	for i, test := range tests {
+		parent := &types.Header{
+			Number:   common.Big32,
+			GasLimit: 20_000_000,
+			GasUsed:  test.parentGasUsed,
+			BaseFee:  big.NewInt(params.InitialBaseFee),
+		}
+		if have, want := CalcBaseFee(config, parent, 0), big.NewInt(test.expectedBaseFee); have.Cmp(want) != 0 {
 			t.Errorf("test %d: have %d  want %d, ", i, have, want)
 		}
 	}
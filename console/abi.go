@@ -0,0 +1,193 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package console
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dop251/goja"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/internal/abiutil"
+	"github.com/ethereum/go-ethereum/internal/jsre"
+)
+
+// EncodeFunctionCall implements web3.eth.abi.encodeFunctionCall, returning the
+// calldata of a call to the function of the given ABI item.
+func (b *bridge) EncodeFunctionCall(call jsre.Call) (goja.Value, error) {
+	if len(call.Arguments) != 2 {
+		return nil, errors.New("usage: encodeFunctionCall(<abi item>, <parameters>)")
+	}
+	item, err := stringify(call.VM, call.Argument(0))
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := abi.JSON(strings.NewReader("[" + item + "]"))
+	if err != nil {
+		return nil, err
+	}
+	if len(parsed.Methods) != 1 {
+		return nil, errors.New("abi item is not a function")
+	}
+	var method abi.Method
+	for _, m := range parsed.Methods {
+		method = m
+	}
+	var params []interface{}
+	if err := exportJSON(call.VM, call.Argument(1), &params); err != nil {
+		return nil, err
+	}
+	if len(params) != len(method.Inputs) {
+		return nil, fmt.Errorf("parameter count mismatch: have %d, want %d", len(params), len(method.Inputs))
+	}
+	args := make([]interface{}, len(params))
+	for i, input := range method.Inputs {
+		if args[i], err = abiutil.ToGoValue(input.Type, params[i]); err != nil {
+			return nil, fmt.Errorf("parameter %d: %v", i, err)
+		}
+	}
+	data, err := method.Inputs.Pack(args...)
+	if err != nil {
+		return nil, err
+	}
+	return call.VM.ToValue(hexutil.Encode(append(method.ID, data...))), nil
+}
+
+// EncodeParameter implements web3.eth.abi.encodeParameter, returning the ABI
+// encoding of a single value of the given type.
+func (b *bridge) EncodeParameter(call jsre.Call) (goja.Value, error) {
+	if len(call.Arguments) != 2 {
+		return nil, errors.New("usage: encodeParameter(<type>, <value>)")
+	}
+	typ, err := abi.NewType(call.Argument(0).String(), "", nil)
+	if err != nil {
+		return nil, err
+	}
+	var param interface{}
+	if err := exportJSON(call.VM, call.Argument(1), &param); err != nil {
+		return nil, err
+	}
+	arg, err := abiutil.ToGoValue(typ, param)
+	if err != nil {
+		return nil, err
+	}
+	data, err := abi.Arguments{{Type: typ}}.Pack(arg)
+	if err != nil {
+		return nil, err
+	}
+	return call.VM.ToValue(hexutil.Encode(data)), nil
+}
+
+// DecodeLog implements web3.eth.abi.decodeLog, returning the values of an event
+// log. The topics exclude the event signature, as in web3.js. The values are
+// keyed by both their position and their name, where the dynamic indexed ones
+// are only available as the hash stored in the topic.
+func (b *bridge) DecodeLog(call jsre.Call) (goja.Value, error) {
+	if len(call.Arguments) != 3 {
+		return nil, errors.New("usage: decodeLog(<inputs>, <data>, <topics>)")
+	}
+	inputs, err := stringify(call.VM, call.Argument(0))
+	if err != nil {
+		return nil, err
+	}
+	var args abi.Arguments
+	if err := json.Unmarshal([]byte(inputs), &args); err != nil {
+		return nil, err
+	}
+	data, err := hexutil.Decode(call.Argument(1).String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid data: %v", err)
+	}
+	var hexTopics []string
+	if err := exportJSON(call.VM, call.Argument(2), &hexTopics); err != nil {
+		return nil, err
+	}
+	topics := make([]common.Hash, len(hexTopics))
+	for i, topic := range hexTopics {
+		enc, err := hexutil.Decode(topic)
+		if err != nil || len(enc) != common.HashLength {
+			return nil, fmt.Errorf("invalid topic %d: %q", i, topic)
+		}
+		topics[i] = common.BytesToHash(enc)
+	}
+	// Decode the data and the topics, keying the indexed values by position
+	// as the arguments may be unnamed.
+	unpacked, err := args.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	var indexed abi.Arguments
+	for i, arg := range args {
+		if arg.Indexed {
+			arg.Name = strconv.Itoa(i)
+			indexed = append(indexed, arg)
+		}
+	}
+	parsed := make(map[string]interface{})
+	if err := abi.ParseTopicsIntoMap(parsed, indexed, topics); err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{})
+	for i, arg := range args {
+		var value interface{}
+		if arg.Indexed {
+			value = parsed[strconv.Itoa(i)]
+		} else {
+			value, unpacked = unpacked[0], unpacked[1:]
+		}
+		value = abiutil.FromGoValue(arg.Type, value)
+		result[strconv.Itoa(i)] = value
+		if arg.Name != "" {
+			result[arg.Name] = value
+		}
+	}
+	return call.VM.ToValue(result), nil
+}
+
+// stringify returns the JSON encoding of a JavaScript value.
+func stringify(vm *goja.Runtime, v goja.Value) (string, error) {
+	JSON := vm.Get("JSON").ToObject(vm)
+	stringify, callable := goja.AssertFunction(JSON.Get("stringify"))
+	if !callable {
+		return "", errors.New("JSON.stringify is not a function")
+	}
+	enc, err := stringify(goja.Null(), v)
+	if err != nil {
+		return "", err
+	}
+	if goja.IsUndefined(enc) {
+		return "", errors.New("value is not JSON encodable")
+	}
+	return enc.String(), nil
+}
+
+// exportJSON converts a JavaScript value into a Go value through its JSON
+// encoding, keeping the numbers exact.
+func exportJSON(vm *goja.Runtime, v goja.Value, out interface{}) error {
+	enc, err := stringify(vm, v)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(strings.NewReader(enc))
+	dec.UseNumber() // avoid float64s
+	return dec.Decode(out)
+}
@@ -142,6 +142,7 @@ func (c *Console) init(preload []string) error {
 	// Add bridge overrides for web3.js functionality.
 	c.jsre.Do(func(vm *goja.Runtime) {
 		c.initAdmin(vm, bridge)
+		c.initABI(vm, bridge)
 	})
 
 	// Preload JavaScript files.
@@ -248,6 +249,17 @@ func (c *Console) initAdmin(vm *goja.Runtime, bridge *bridge) {
 	}
 }
 
+// initABI creates the ABI encoding utilities of web3.eth implemented by the bridge.
+func (c *Console) initABI(vm *goja.Runtime, bridge *bridge) {
+	if web3 := getObject(vm, "web3"); web3 != nil {
+		abi := vm.NewObject()
+		abi.Set("encodeFunctionCall", jsre.MakeCallback(vm, bridge.EncodeFunctionCall))
+		abi.Set("encodeParameter", jsre.MakeCallback(vm, bridge.EncodeParameter))
+		abi.Set("decodeLog", jsre.MakeCallback(vm, bridge.DecodeLog))
+		web3.Get("eth").ToObject(vm).Set("abi", abi)
+	}
+}
+
 func (c *Console) clearHistory() {
 	c.history = nil
 	c.prompter.ClearHistory()
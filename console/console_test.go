@@ -28,6 +28,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/console/prompt"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/internal/jsre"
@@ -319,3 +320,76 @@ func TestIndenting(t *testing.T) {
 		}
 	}
 }
+
+// Tests that the ABI utilities of web3.eth encode and decode the values.
+func TestABI(t *testing.T) {
+	tester := newTester(t, nil)
+	defer tester.Close(t)
+
+	tests := []struct {
+		statement string
+		want      string
+	}{
+		{
+			statement: `web3.eth.abi.encodeFunctionCall({name: "transfer", type: "function", inputs: [{type: "address", name: "to"}, {type: "uint256", name: "value"}]}, ["0x000000000000000000000000000000000000dEaD", "1000"])`,
+			want:      "0xa9059cbb000000000000000000000000000000000000000000000000000000000000dead00000000000000000000000000000000000000000000000000000000000003e8",
+		},
+		{
+			statement: `web3.eth.abi.encodeParameter("uint256[]", [1, "0x2"])`,
+			want:      "0x0000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000002",
+		},
+		{
+			statement: `web3.eth.abi.decodeLog([{type: "address", name: "from", indexed: true}, {type: "uint256", name: "value"}], "0x00000000000000000000000000000000000000000000000000000000000003e8", ["0x000000000000000000000000000000000000000000000000000000000000dead"]).from`,
+			want:      "0x000000000000000000000000000000000000dEaD",
+		},
+		{
+			statement: `web3.eth.abi.decodeLog([{type: "address", name: "from", indexed: true}, {type: "uint256", name: "value"}], "0x00000000000000000000000000000000000000000000000000000000000003e8", ["0x000000000000000000000000000000000000000000000000000000000000dead"])[1]`,
+			want:      "1000",
+		},
+	}
+	for _, tt := range tests {
+		tester.output.Reset()
+		tester.console.Evaluate(tt.statement)
+		if output := tester.output.String(); !strings.Contains(output, tt.want) {
+			t.Errorf("%s: output mismatch: have %s, want %s", tt.statement, output, tt.want)
+		}
+	}
+	// Invalid parameters are reported
+	tester.output.Reset()
+	tester.console.Evaluate(`web3.eth.abi.encodeParameter("uint8", 256)`)
+	if output := tester.output.String(); !strings.Contains(output, "overflow") {
+		t.Errorf("overflow not reported: have %s", output)
+	}
+}
+
+// Tests that the contract objects created by eth.contract call the read-only
+// functions of the ABIs reporting only their state mutability, decoding the
+// returned values, and encode the calldata of the other ones.
+func TestContract(t *testing.T) {
+	// The mock token returns a balance of 1000 for any call
+	token := common.HexToAddress("0x000000000000000000000000000000000000c0de")
+	tester := newTester(t, func(config *ethconfig.Config) {
+		config.Genesis.Alloc[token] = types.Account{Code: common.FromHex("0x6103e860005260206000f3")}
+	})
+	defer tester.Close(t)
+
+	tester.console.Evaluate(`var token = eth.contract([
+		{name: "balanceOf", type: "function", stateMutability: "view", inputs: [{name: "owner", type: "address"}], outputs: [{name: "", type: "uint256"}]},
+		{name: "transfer", type: "function", stateMutability: "nonpayable", inputs: [{name: "to", type: "address"}, {name: "value", type: "uint256"}], outputs: [{name: "", type: "bool"}]}
+	]).at("` + token.Hex() + `")`)
+
+	tests := []struct {
+		statement string
+		want      string
+	}{
+		{`token.balanceOf("0x000000000000000000000000000000000000dEaD").toString()`, "1000"},
+		{`token.transfer.getData("0x000000000000000000000000000000000000dEaD", 1000)`, "0xa9059cbb000000000000000000000000000000000000000000000000000000000000dead00000000000000000000000000000000000000000000000000000000000003e8"},
+	}
+	for _, tt := range tests {
+		tester.output.Reset()
+		tester.console.Evaluate(tt.statement)
+		if output := tester.output.String(); !strings.Contains(output, tt.want) {
+			t.Errorf("%s: output mismatch: have %s, want %s", tt.statement, output, tt.want)
+		}
+	}
+}
@@ -208,6 +208,68 @@ func benchInsertChain(b *testing.B, disk bool, gen func(int, *BlockGen)) {
 	}
 }
 
+// Benchmarks the processing of a block of 1000 transfers on a cold cache, with
+// and without the concurrent state prefetching.
+func BenchmarkProcessBlock_prefetch_diskdb(b *testing.B) {
+	benchProcessBlock(b, true)
+}
+func BenchmarkProcessBlock_noprefetch_diskdb(b *testing.B) {
+	benchProcessBlock(b, false)
+}
+
+func benchProcessBlock(b *testing.B, prefetch bool) {
+	gspec := &Genesis{
+		Config:   params.TestChainConfig,
+		GasLimit: 30_000_000,
+		Alloc:    make(types.GenesisAlloc),
+	}
+	for _, addr := range ringAddrs {
+		gspec.Alloc[addr] = types.Account{Balance: benchRootFunds}
+	}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 1, func(i int, gen *BlockGen) {
+		for j, key := range ringKeys {
+			to := common.BigToAddress(big.NewInt(int64(j + 1)))
+			tx, err := types.SignNewTx(key, gen.Signer(), &types.LegacyTx{
+				Nonce:    gen.TxNonce(ringAddrs[j]),
+				To:       &to,
+				Value:    big.NewInt(1),
+				Gas:      params.TxGas,
+				GasPrice: gen.header.BaseFee,
+			})
+			if err != nil {
+				b.Fatal(err)
+			}
+			gen.AddTx(tx)
+		}
+	})
+	config := DefaultConfig()
+	config.NoPrefetch = !prefetch
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		pdb, err := pebble.New(b.TempDir(), 128, 128, "", false)
+		if err != nil {
+			b.Fatalf("cannot create temporary database: %v", err)
+		}
+		db := rawdb.NewDatabase(pdb)
+
+		// Commit the genesis state, reopening the chain to start on cold caches
+		chain, _ := NewBlockChain(db, gspec, ethash.NewFaker(), config)
+		chain.Stop()
+		chain, _ = NewBlockChain(db, gspec, ethash.NewFaker(), config)
+
+		b.StartTimer()
+		if _, err := chain.InsertChain(blocks); err != nil {
+			b.Fatalf("insert error: %v", err)
+		}
+		b.StopTimer()
+		chain.Stop()
+		db.Close()
+	}
+}
+
 func BenchmarkChainRead_header_10k(b *testing.B) {
 	benchReadChain(b, false, 10000)
 }
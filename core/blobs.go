@@ -0,0 +1,81 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+// VerifyBlobAvailability checks that the blobs referenced by every blob
+// transaction of the block are available: each must have a sidecar matching
+// its blob hashes, with valid KZG proofs. The sidecars are looked up by
+// transaction hash, falling back to the ones carried by the transactions.
+func (bc *BlockChain) VerifyBlobAvailability(block *types.Block, sidecars map[common.Hash]*types.BlobTxSidecar) error {
+	osaka := bc.chainConfig.IsOsaka(block.Number(), block.Time())
+	for i, tx := range block.Transactions() {
+		if tx.Type() != types.BlobTxType {
+			continue
+		}
+		sidecar := sidecars[tx.Hash()]
+		if sidecar == nil {
+			sidecar = tx.BlobTxSidecar()
+		}
+		if sidecar == nil {
+			return fmt.Errorf("%w: tx %d [%x]: missing sidecar", ErrBlobUnavailable, i, tx.Hash())
+		}
+		if err := verifyBlobSidecar(sidecar, tx.BlobHashes(), osaka); err != nil {
+			return fmt.Errorf("%w: tx %d [%x]: %v", ErrBlobUnavailable, i, tx.Hash(), err)
+		}
+	}
+	return nil
+}
+
+// verifyBlobSidecar checks that the sidecar contains the blobs of the given
+// hashes, along with the proofs of the sidecar version of the fork.
+func verifyBlobSidecar(sidecar *types.BlobTxSidecar, hashes []common.Hash, osaka bool) error {
+	if len(sidecar.Blobs) != len(hashes) {
+		return fmt.Errorf("invalid number of %d blobs compared to %d blob hashes", len(sidecar.Blobs), len(hashes))
+	}
+	if err := sidecar.ValidateBlobCommitmentHashes(hashes); err != nil {
+		return err
+	}
+	if osaka {
+		if sidecar.Version != 1 {
+			return fmt.Errorf("invalid sidecar version post-osaka: %v", sidecar.Version)
+		}
+		if len(sidecar.Proofs) != len(hashes)*kzg4844.CellProofsPerBlob {
+			return fmt.Errorf("invalid number of %d blob proofs expected %d", len(sidecar.Proofs), len(hashes)*kzg4844.CellProofsPerBlob)
+		}
+		return kzg4844.VerifyCellProofs(sidecar.Blobs, sidecar.Commitments, sidecar.Proofs)
+	}
+	if sidecar.Version != 0 {
+		return fmt.Errorf("invalid sidecar version pre-osaka: %v", sidecar.Version)
+	}
+	if len(sidecar.Proofs) != len(hashes) {
+		return fmt.Errorf("invalid number of %d blob proofs expected %d", len(sidecar.Proofs), len(hashes))
+	}
+	for i := range sidecar.Blobs {
+		if err := kzg4844.VerifyBlobProof(&sidecar.Blobs[i], sidecar.Commitments[i], sidecar.Proofs[i]); err != nil {
+			return fmt.Errorf("invalid blob %d: %v", i, err)
+		}
+	}
+	return nil
+}
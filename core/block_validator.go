@@ -57,6 +57,11 @@ func (v *BlockValidator) ValidateBody(block *types.Block) error {
 	// Header validity is known at this point. Here we verify that uncles, transactions
 	// and withdrawals given in the block body match the header.
 	header := block.Header()
+	if limit, ok := v.config.BlockBodySizeLimit(); ok {
+		if size := block.Body().Size(); size > limit {
+			return fmt.Errorf("%w: size %d, limit %d", ErrBlockBodyTooLarge, size, limit)
+		}
+	}
 	if err := v.bc.engine.VerifyUncles(v.bc, block); err != nil {
 		return err
 	}
@@ -64,7 +69,7 @@ func (v *BlockValidator) ValidateBody(block *types.Block) error {
 		return fmt.Errorf("uncle root hash mismatch (header value %x, calculated %x)", header.UncleHash, hash)
 	}
 	if hash := types.DeriveSha(block.Transactions(), trie.NewStackTrie(nil)); hash != header.TxHash {
-		return fmt.Errorf("transaction root hash mismatch (header value %x, calculated %x)", header.TxHash, hash)
+		return fmt.Errorf("%w (header value %x, calculated %x)", ErrBodyTransactionRootMismatch, header.TxHash, hash)
 	}
 
 	// Withdrawals are present after the Shanghai fork.
@@ -17,6 +17,7 @@
 package core
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 	"time"
@@ -271,3 +272,82 @@ func TestCalcGasLimit(t *testing.T) {
 		}
 	}
 }
+
+// Tests that blocks whose body doesn't match the transaction root of their
+// header are rejected before being written.
+func TestBodyTransactionRootMismatch(t *testing.T) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		gspec   = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{address: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 2, func(i int, b *BlockGen) {
+		b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    b.TxNonce(address),
+			To:       &common.Address{},
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		}))
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// Swap the transactions of the second block with the ones of the first
+	forged := types.NewBlockWithHeader(blocks[1].Header()).WithBody(types.Body{Transactions: blocks[0].Transactions()})
+	if _, err := chain.InsertChain(types.Blocks{blocks[0], forged}); !errors.Is(err, ErrBodyTransactionRootMismatch) {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrBodyTransactionRootMismatch)
+	}
+	if chain.HasBlock(forged.Hash(), forged.NumberU64()) {
+		t.Fatal("forged block written")
+	}
+	if _, err := chain.InsertChain(blocks[1:]); err != nil {
+		t.Fatalf("failed to insert genuine block: %v", err)
+	}
+}
+
+// Tests that blocks whose encoded size exceeds the size limit configured for the
+// chain are rejected, even if they only hold deposits.
+func TestBlockBodyTooLarge(t *testing.T) {
+	limit := uint64(4096)
+	config := *params.TestChainConfig
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+	config.MaxBlockBodySize = &limit
+
+	deposit := func(size int) *types.Transaction {
+		return types.NewTx(&types.OptimismDepositTx{
+			SourceHash: common.Hash{byte(size)},
+			From:       common.HexToAddress("0xdeadbeef"),
+			To:         &common.Address{0x01},
+			Value:      new(big.Int),
+			Gas:        1_000_000,
+			Data:       make([]byte, size),
+		})
+	}
+	gspec := &Genesis{Config: &config, BaseFee: big.NewInt(params.InitialBaseFee)}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 2, func(i int, b *BlockGen) {
+		if i == 0 {
+			b.AddTx(deposit(1024))
+		} else {
+			b.AddTx(deposit(int(limit)))
+		}
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks[:1]); err != nil {
+		t.Fatalf("failed to insert block within the size limit: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks[1:]); !errors.Is(err, ErrBlockBodyTooLarge) {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrBlockBodyTooLarge)
+	}
+}
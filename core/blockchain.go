@@ -103,6 +103,9 @@ var (
 	blockReorgAddMeter  = metrics.NewRegisteredMeter("chain/reorg/add", nil)
 	blockReorgDropMeter = metrics.NewRegisteredMeter("chain/reorg/drop", nil)
 
+	chainReorgDepthHist = metrics.NewRegisteredHistogram("chain/reorg/depth", nil, metrics.NewExpDecaySample(1028, 0.015))
+	chainForksCounter   = metrics.NewRegisteredCounter("chain/forks/total", nil)
+
 	blockPrefetchExecuteTimer    = metrics.NewRegisteredResettingTimer("chain/prefetch/executes", nil)
 	blockPrefetchInterruptMeter  = metrics.NewRegisteredMeter("chain/prefetch/interrupts", nil)
 	blockPrefetchTxsInvalidMeter = metrics.NewRegisteredMeter("chain/prefetch/txs/invalid", nil)
@@ -182,9 +185,10 @@ type BlockChainConfig struct {
 	ChainHistoryMode history.HistoryMode
 
 	// Misc options
-	NoPrefetch bool            // Whether to disable heuristic state prefetching when processing blocks
-	Overrides  *ChainOverrides // Optional chain config overrides
-	VmConfig   vm.Config       // Config options for the EVM Interpreter
+	NoPrefetch       bool            // Whether to disable heuristic state prefetching when processing blocks
+	BlobAvailability bool            // Whether InsertChain verifies the blob sidecars carried by the transactions
+	Overrides        *ChainOverrides // Optional chain config overrides
+	VmConfig         vm.Config       // Config options for the EVM Interpreter
 
 	// TxLookupLimit specifies the maximum number of blocks from head for which
 	// transaction hashes will be indexed.
@@ -296,6 +300,9 @@ type BlockChain struct {
 	rmLogsFeed       event.Feed
 	chainFeed        event.Feed
 	chainHeadFeed    event.Feed
+	chainSideFeed    event.Feed
+	unsafeHeadFeed   event.Feed
+	safeHeadFeed     event.Feed
 	logsFeed         event.Feed
 	blockProcFeed    event.Feed
 	blockProcCounter int32
@@ -311,6 +318,7 @@ type BlockChain struct {
 	currentFinalBlock atomic.Pointer[types.Header] // Latest (consensus) finalized block
 	currentSafeBlock  atomic.Pointer[types.Header] // Latest (consensus) safe block
 	historyPrunePoint atomic.Pointer[history.PrunePoint]
+	blobSource        atomic.Pointer[BlobSource] // Retriever of the blobs missing on import
 
 	bodyCache     *lru.Cache[common.Hash, *types.Body]
 	bodyRLPCache  *lru.Cache[common.Hash, rlp.RawValue]
@@ -631,7 +639,7 @@ func (bc *BlockChain) loadLastState() error {
 	}
 
 	// Restore the last known finalized block and safe block
-	// Note: the safe block is not stored on disk and it is set to the last
+	// Note: if the safe block is not stored on disk, it is set to the last
 	// known finalized block on startup
 	if head := rawdb.ReadFinalizedBlockHash(bc.db); head != (common.Hash{}) {
 		if block := bc.GetBlockByHash(head); block != nil {
@@ -641,6 +649,12 @@ func (bc *BlockChain) loadLastState() error {
 			headSafeBlockGauge.Update(int64(block.NumberU64()))
 		}
 	}
+	if hash, number, ok := rawdb.ReadL2SafeHead(bc.db); ok {
+		if header := bc.GetHeader(hash, number); header != nil {
+			bc.currentSafeBlock.Store(header)
+			headSafeBlockGauge.Update(int64(number))
+		}
+	}
 
 	// Issue a status log for the user
 	var (
@@ -774,14 +788,40 @@ func (bc *BlockChain) SetFinalized(header *types.Header) {
 
 // SetSafe sets the safe block.
 func (bc *BlockChain) SetSafe(header *types.Header) {
-	bc.currentSafeBlock.Store(header)
+	prev := bc.currentSafeBlock.Swap(header)
 	if header != nil {
 		headSafeBlockGauge.Update(int64(header.Number.Uint64()))
+		if prev == nil || prev.Hash() != header.Hash() {
+			// Rollup nodes persist the safe block, so it doesn't need to be
+			// derived again from L1 after a restart
+			if bc.chainConfig.IsOptimism() {
+				rawdb.WriteL2SafeHead(bc.db, header.Hash(), header.Number.Uint64())
+			}
+			bc.safeHeadFeed.Send(SafeHeadEvent{Header: header})
+		}
 	} else {
+		if bc.chainConfig.IsOptimism() {
+			rawdb.DeleteL2SafeHead(bc.db)
+		}
 		headSafeBlockGauge.Update(0)
 	}
 }
 
+// SetSafeAtL1 sets the safe block and records that it was derived from the L1
+// chain up to the given L1 block. Any entries derived from later L1 blocks are
+// dropped, since the derivation pipeline has been reset below them.
+func (bc *BlockChain) SetSafeAtL1(header *types.Header, l1Hash common.Hash, l1Number uint64) {
+	rawdb.DeleteSafeHeadsAfterL1(bc.db, l1Number)
+	rawdb.WriteSafeHeadAtL1(bc.db, &rawdb.SafeHeadEntry{
+		L1Hash:   l1Hash,
+		L1Number: l1Number,
+		L2Hash:   header.Hash(),
+		L2Number: header.Number.Uint64(),
+		L2Time:   header.Time,
+	})
+	bc.SetSafe(header)
+}
+
 // rewindHashHead implements the logic of rewindHead in the context of hash scheme.
 func (bc *BlockChain) rewindHashHead(head *types.Header, root common.Hash) (*types.Header, uint64) {
 	var (
@@ -1678,11 +1718,34 @@ func (bc *BlockChain) writeBlockAndSetHead(block *types.Block, receipts []*types
 	return CanonStatTy, nil
 }
 
+// BlobSource retrieves the sidecars of the blob transactions of a block, keyed
+// by transaction hash, leaving out the ones unavailable.
+type BlobSource func(block *types.Block) map[common.Hash]*types.BlobTxSidecar
+
+// SetBlobSource sets the retriever of the blobs verified by InsertChain when
+// BlockChainConfig.BlobAvailability is set.
+func (bc *BlockChain) SetBlobSource(source BlobSource) {
+	bc.blobSource.Store(&source)
+}
+
 // InsertChain attempts to insert the given batch of blocks in to the canonical
 // chain or, otherwise, create a fork. If an error is returned it will return
 // the index number of the failing block as well an error describing what went
 // wrong. After insertion is done, all accumulated events will be fired.
 func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
+	return bc.insertChainWithSidecars(chain, nil, bc.cfg.BlobAvailability)
+}
+
+// InsertChainWithSidecars inserts the given batch of blocks like InsertChain,
+// after verifying that the blobs of their blob transactions are available in
+// the given sidecars, keyed by transaction hash.
+func (bc *BlockChain) InsertChainWithSidecars(chain types.Blocks, sidecars map[common.Hash]*types.BlobTxSidecar) (int, error) {
+	return bc.insertChainWithSidecars(chain, sidecars, true)
+}
+
+// insertChainWithSidecars implements InsertChain and InsertChainWithSidecars,
+// verifying the availability of the blobs if requested.
+func (bc *BlockChain) insertChainWithSidecars(chain types.Blocks, sidecars map[common.Hash]*types.BlobTxSidecar, verifyBlobs bool) (int, error) {
 	// Sanity check that we have something meaningful to import
 	if len(chain) == 0 {
 		return 0, nil
@@ -1703,6 +1766,20 @@ func (bc *BlockChain) InsertChain(chain types.Blocks) (int, error) {
 				prev.Hash().Bytes()[:4], i, block.NumberU64(), block.Hash().Bytes()[:4], block.ParentHash().Bytes()[:4])
 		}
 	}
+	// Ensure the blobs of the blocks are available if requested, retrieving
+	// them from the blob source if none were given
+	if verifyBlobs {
+		source := bc.blobSource.Load()
+		for i, block := range chain {
+			available := sidecars
+			if available == nil && source != nil {
+				available = (*source)(block)
+			}
+			if err := bc.VerifyBlobAvailability(block, available); err != nil {
+				return i, err
+			}
+		}
+	}
 	// Pre-checks passed, start the full block imports
 	if !bc.chainmu.TryLock() {
 		return 0, errChainStopped
@@ -1902,6 +1979,9 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, makeWitness
 			// After merge we expect few side chains. Simply count
 			// all blocks the CL gives us for GC processing time
 			bc.gcproc += res.procTime
+			if block.ParentHash() != bc.CurrentBlock().Hash() {
+				chainForksCounter.Inc(1)
+			}
 			return witness, it.index, nil // Direct block insertion of a single block
 		}
 		switch res.status {
@@ -1917,6 +1997,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks, setHead bool, makeWitness
 			bc.gcproc += res.procTime
 
 		case SideStatTy:
+			chainForksCounter.Inc(1)
 			log.Debug("Inserted forked block", "number", block.Number(), "hash", block.Hash(),
 				"diff", block.Difficulty(), "elapsed", common.PrettyDuration(time.Since(start)),
 				"txs", len(block.Transactions()), "gas", block.GasUsed(), "uncles", len(block.Uncles()),
@@ -2182,6 +2263,7 @@ func (bc *BlockChain) insertSideChain(block *types.Block, it *insertIterator, ma
 			if err := bc.writeBlockWithoutState(block); err != nil {
 				return nil, it.index, err
 			}
+			chainForksCounter.Inc(1)
 			log.Debug("Injected sidechain block", "number", block.Number(), "hash", block.Hash(),
 				"diff", block.Difficulty(), "elapsed", common.PrettyDuration(time.Since(start)),
 				"txs", len(block.Transactions()), "gas", block.GasUsed(), "uncles", len(block.Uncles()),
@@ -2371,19 +2453,11 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Header) error
 			return errInvalidNewChain
 		}
 	}
-	// Ensure the user sees large reorgs
 	if len(oldChain) > 0 && len(newChain) > 0 {
-		logFn := log.Info
-		msg := "Chain reorg detected"
-		if len(oldChain) > 63 {
-			msg = "Large chain reorg detected"
-			logFn = log.Warn
-		}
-		logFn(msg, "number", commonBlock.Number, "hash", commonBlock.Hash(),
-			"drop", len(oldChain), "dropfrom", oldChain[0].Hash(), "add", len(newChain), "addfrom", newChain[0].Hash())
 		blockReorgAddMeter.Mark(int64(len(newChain)))
 		blockReorgDropMeter.Mark(int64(len(oldChain)))
 		blockReorgMeter.Mark(1)
+		chainReorgDepthHist.Update(int64(len(oldChain)))
 	} else if len(newChain) > 0 {
 		// Special case happens in the post merge stage that current head is
 		// the ancestor of new head while these two blocks are not consecutive
@@ -2431,12 +2505,14 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Header) error
 		}
 	}
 	// Undo old blocks in reverse order
+	oldBlocks := make([]*types.Block, 0, len(oldChain))
 	for i := 0; i < len(oldChain); i++ {
 		// Collect all the deleted transactions
 		block := bc.GetBlock(oldChain[i].Hash(), oldChain[i].Number.Uint64())
 		if block == nil {
 			return errInvalidOldChain // Corrupt database, mostly here to avoid weird panics
 		}
+		oldBlocks = append(oldBlocks, block)
 		for _, tx := range block.Transactions() {
 			deletedTxs = append(deletedTxs, tx.Hash())
 		}
@@ -2472,6 +2548,30 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Header) error
 	if len(rebirthLogs) > 0 {
 		bc.logsFeed.Send(rebirthLogs)
 	}
+	// The new head is processed by the caller, gather its transactions too
+	included := make(map[common.Hash]struct{}, len(rebirthTxs))
+	for _, hash := range rebirthTxs {
+		included[hash] = struct{}{}
+	}
+	if len(newChain) > 0 {
+		if block := bc.GetBlock(newChain[0].Hash(), newChain[0].Number.Uint64()); block != nil {
+			for _, tx := range block.Transactions() {
+				included[tx.Hash()] = struct{}{}
+			}
+		}
+	}
+	// Ensure the user sees large reorgs
+	if len(oldChain) > 0 && len(newChain) > 0 {
+		logFn := log.Info
+		msg := "Chain reorg detected"
+		if len(oldChain) > 63 {
+			msg = "Large chain reorg detected"
+			logFn = log.Warn
+		}
+		logFn(msg, "number", commonBlock.Number, "hash", commonBlock.Hash(),
+			"drop", len(oldChain), "dropfrom", oldChain[0].Hash(), "add", len(newChain), "addfrom", newChain[0].Hash(),
+			"txsadded", len(included), "txsremoved", len(deletedTxs))
+	}
 	// Delete useless indexes right now which includes the non-canonical
 	// transaction indexes, canonical chain indexes which above the head.
 	batch := bc.db.NewBatch()
@@ -2501,6 +2601,16 @@ func (bc *BlockChain) reorg(oldHead *types.Header, newHead *types.Header) error
 	// Release the tx-lookup lock after mutation.
 	bc.txLookupLock.Unlock()
 
+	// Announce the dropped blocks along with the transactions they orphaned
+	for _, block := range oldBlocks {
+		var returned []*types.Transaction
+		for _, tx := range block.Transactions() {
+			if _, ok := included[tx.Hash()]; !ok {
+				returned = append(returned, tx)
+			}
+		}
+		bc.chainSideFeed.Send(ChainSideEvent{Header: block.Header(), ReturnedTxs: returned})
+	}
 	return nil
 }
 
@@ -2519,6 +2629,41 @@ func (bc *BlockChain) InsertBlockWithoutSetHead(block *types.Block, makeWitness
 	return witness, err
 }
 
+// InsertTrustedChain executes the given blocks on top of their parents' state
+// and writes them as the new head, without verifying their headers, bodies
+// or receipts. Only the resulting state root is checked, so that the state of
+// every inserted block is available. It returns the number of blocks inserted.
+//
+// This is only meant to replay known-good chain segments in development.
+func (bc *BlockChain) InsertTrustedChain(chain types.Blocks) (int, error) {
+	if !bc.chainmu.TryLock() {
+		return 0, errChainStopped
+	}
+	defer bc.chainmu.Unlock()
+
+	for i, block := range chain {
+		parent := bc.GetHeader(block.ParentHash(), block.NumberU64()-1)
+		if parent == nil {
+			return i, consensus.ErrUnknownAncestor
+		}
+		statedb, err := state.New(parent.Root, bc.statedb)
+		if err != nil {
+			return i, err
+		}
+		res, err := bc.processor.Process(block, statedb, bc.cfg.VmConfig)
+		if err != nil {
+			return i, err
+		}
+		if root := statedb.IntermediateRoot(bc.chainConfig.IsEIP158(block.Number())); root != block.Root() {
+			return i, fmt.Errorf("invalid merkle root (remote: %x local: %x)", block.Root(), root)
+		}
+		if _, err := bc.writeBlockAndSetHead(block, res.Receipts, res.Logs, statedb, i == len(chain)-1); err != nil {
+			return i, err
+		}
+	}
+	return len(chain), nil
+}
+
 // SetCanonical rewinds the chain to set the new head block as the specified
 // block. It's possible that the state of the new head is missing, and it will
 // be recovered in this function as well.
@@ -2551,6 +2696,7 @@ func (bc *BlockChain) SetCanonical(head *types.Block) (common.Hash, error) {
 		bc.logsFeed.Send(logs)
 	}
 	bc.chainHeadFeed.Send(ChainHeadEvent{Header: head.Header()})
+	bc.unsafeHeadFeed.Send(UnsafeHeadEvent{Header: head.Header()})
 
 	context := []interface{}{
 		"number", head.Number(),
@@ -62,6 +62,12 @@ func (bc *BlockChain) CurrentSafeBlock() *types.Header {
 	return bc.currentSafeBlock.Load()
 }
 
+// SafeHeadAtL1 retrieves the safe block derived from the L1 chain up to the
+// given L1 block number, or nil if no safe head was recorded at that height.
+func (bc *BlockChain) SafeHeadAtL1(l1Number uint64) *rawdb.SafeHeadEntry {
+	return rawdb.ReadSafeHeadAtL1(bc.db, l1Number)
+}
+
 // HasHeader checks if a block header is present in the database or not, caching
 // it if present.
 func (bc *BlockChain) HasHeader(hash common.Hash, number uint64) bool {
@@ -449,11 +455,28 @@ func (bc *BlockChain) SubscribeChainEvent(ch chan<- ChainEvent) event.Subscripti
 	return bc.scope.Track(bc.chainFeed.Subscribe(ch))
 }
 
+// SubscribeChainSideEvent registers a subscription of ChainSideEvent.
+func (bc *BlockChain) SubscribeChainSideEvent(ch chan<- ChainSideEvent) event.Subscription {
+	return bc.scope.Track(bc.chainSideFeed.Subscribe(ch))
+}
+
 // SubscribeChainHeadEvent registers a subscription of ChainHeadEvent.
 func (bc *BlockChain) SubscribeChainHeadEvent(ch chan<- ChainHeadEvent) event.Subscription {
 	return bc.scope.Track(bc.chainHeadFeed.Subscribe(ch))
 }
 
+// SubscribeUnsafeHeadEvent registers a subscription of UnsafeHeadEvent, fired
+// when the forkchoice sets a new canonical head.
+func (bc *BlockChain) SubscribeUnsafeHeadEvent(ch chan<- UnsafeHeadEvent) event.Subscription {
+	return bc.scope.Track(bc.unsafeHeadFeed.Subscribe(ch))
+}
+
+// SubscribeSafeHeadEvent registers a subscription of SafeHeadEvent, fired when
+// the safe block changes.
+func (bc *BlockChain) SubscribeSafeHeadEvent(ch chan<- SafeHeadEvent) event.Subscription {
+	return bc.scope.Track(bc.safeHeadFeed.Subscribe(ch))
+}
+
 // SubscribeLogsEvent registers a subscription of []*types.Log.
 func (bc *BlockChain) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription {
 	return bc.scope.Track(bc.logsFeed.Subscribe(ch))
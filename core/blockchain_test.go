@@ -18,6 +18,7 @@ package core
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	gomath "math"
@@ -33,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/consensus/beacon"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core/history"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -40,9 +42,11 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/core/vm/program"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/pebble"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/holiman/uint256"
@@ -1503,6 +1507,92 @@ func testEIP161AccountRemoval(t *testing.T, scheme string) {
 	}
 }
 
+// Tests that touching an empty precompile account removes it from the state
+// post EIP 161, without affecting the precompile itself.
+func TestEIP161PrecompileRemoval(t *testing.T) {
+	testEIP161PrecompileRemoval(t, rawdb.HashScheme)
+	testEIP161PrecompileRemoval(t, rawdb.PathScheme)
+}
+
+func testEIP161PrecompileRemoval(t *testing.T, scheme string) {
+	var (
+		key, _     = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address    = crypto.PubkeyToAddress(key.PublicKey)
+		funds      = big.NewInt(1000000000)
+		precompile = common.BytesToAddress([]byte{2}) // SHA256
+		caller     = common.HexToAddress("0x000000000000000000000000000000000000aaaa")
+		gspec      = &Genesis{
+			Config: &params.ChainConfig{
+				ChainID:        big.NewInt(1),
+				HomesteadBlock: new(big.Int),
+				EIP155Block:    new(big.Int),
+				EIP150Block:    new(big.Int),
+				EIP158Block:    big.NewInt(2),
+				ByzantiumBlock: big.NewInt(2),
+			},
+			Alloc: types.GenesisAlloc{
+				address: {Balance: funds},
+				// The caller stores the SHA256 of the empty input in slot 0
+				caller: {
+					Code: []byte{
+						byte(vm.PUSH1), 32, // retSize
+						byte(vm.PUSH1), 0, // retOffset
+						byte(vm.PUSH1), 0, // argsSize
+						byte(vm.PUSH1), 0, // argsOffset
+						byte(vm.PUSH1), 2, // address
+						byte(vm.GAS),
+						byte(vm.STATICCALL),
+						byte(vm.POP),
+						byte(vm.PUSH1), 0,
+						byte(vm.MLOAD),
+						byte(vm.PUSH1), 0,
+						byte(vm.SSTORE),
+					},
+				},
+			},
+		}
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 3, func(i int, block *BlockGen) {
+		var (
+			to     = precompile
+			signer = types.LatestSigner(gspec.Config)
+		)
+		if i == 2 {
+			to = caller
+		}
+		tx, err := types.SignTx(types.NewTransaction(block.TxNonce(address), to, new(big.Int), 100000, new(big.Int), nil), signer, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		block.AddTx(tx)
+	})
+	blockchain, _ := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), DefaultConfig().WithStateScheme(scheme))
+	defer blockchain.Stop()
+
+	// precompile account must be created pre eip 161
+	if _, err := blockchain.InsertChain(types.Blocks{blocks[0]}); err != nil {
+		t.Fatal(err)
+	}
+	if st, _ := blockchain.State(); !st.Exist(precompile) {
+		t.Error("expected precompile account to exist")
+	}
+	// precompile account needs to be deleted post eip 161
+	if _, err := blockchain.InsertChain(types.Blocks{blocks[1]}); err != nil {
+		t.Fatal(err)
+	}
+	if st, _ := blockchain.State(); st.Exist(precompile) {
+		t.Error("precompile account should not exist")
+	}
+	// precompile must still be callable
+	if _, err := blockchain.InsertChain(types.Blocks{blocks[2]}); err != nil {
+		t.Fatal(err)
+	}
+	st, _ := blockchain.State()
+	if have, want := st.GetState(caller, common.Hash{}), common.Hash(sha256.Sum256(nil)); have != want {
+		t.Errorf("precompile output mismatch: have %x, want %x", have, want)
+	}
+}
+
 // This is a regression test (i.e. as weird as it is, don't delete it ever), which
 // tests that under weird reorg conditions the blockchain and its internal header-
 // chain return the same latest block/header.
@@ -3227,6 +3317,80 @@ func testEIP2718Transition(t *testing.T, scheme string) {
 	}
 }
 
+// TestEIP3529SelfdestructRefund tests that SELFDESTRUCT doesn't refund any gas
+// after London, even if the value is sent to a contract created by the same
+// transaction.
+func TestEIP3529SelfdestructRefund(t *testing.T) {
+	var (
+		engine = ethash.NewFaker()
+
+		// A sender who makes transactions, has some funds
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		funds   = big.NewInt(1000000000000000)
+		gspec   = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{address: {Balance: funds}},
+		}
+		// The deployed contract creates an empty child contract and selfdestructs
+		// to it, transferring the value of the deployment.
+		initcode = []byte{
+			byte(vm.PUSH1), 0,
+			byte(vm.PUSH1), 0,
+			byte(vm.PUSH1), 0,
+			byte(vm.CREATE),
+			byte(vm.SELFDESTRUCT),
+		}
+		value = big.NewInt(1)
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 1, func(i int, b *BlockGen) {
+		signer := types.LatestSigner(gspec.Config)
+		txs := []types.TxData{
+			&types.LegacyTx{Nonce: 0, Gas: 200000, GasPrice: b.header.BaseFee, Value: value, Data: initcode},
+			&types.AccessListTx{ChainID: gspec.Config.ChainID, Nonce: 1, Gas: 200000, GasPrice: b.header.BaseFee, Value: value, Data: initcode},
+			&types.DynamicFeeTx{ChainID: gspec.Config.ChainID, Nonce: 2, Gas: 200000, GasFeeCap: b.header.BaseFee, Value: value, Data: initcode},
+		}
+		for _, data := range txs {
+			b.AddTx(types.MustSignNewTx(key, signer, data))
+		}
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	intrinsic, err := IntrinsicGas(initcode, nil, nil, true, true, true, false)
+	if err != nil {
+		t.Fatalf("failed to compute intrinsic gas: %v", err)
+	}
+	// Expected gas is intrinsic + 3 * push + create + selfdestruct, the child
+	// being warm and not empty. A refund would be capped at half of it.
+	expected := intrinsic + 3*vm.GasFastestStep + params.CreateGas + params.SelfdestructGasEIP150
+
+	block := chain.GetBlockByNumber(1)
+	receipts := chain.GetReceiptsByHash(block.Hash())
+	if len(receipts) != 3 {
+		t.Fatalf("receipt count mismatch: have %d, want 3", len(receipts))
+	}
+	state, _ := chain.State()
+	for i, receipt := range receipts {
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			t.Fatalf("tx %d: transaction failed", i)
+		}
+		if receipt.GasUsed != expected {
+			t.Errorf("tx %d: gas used mismatch: have %d, want %d", i, receipt.GasUsed, expected)
+		}
+		child := crypto.CreateAddress(crypto.CreateAddress(address, uint64(i)), 1)
+		if balance := state.GetBalance(child); balance.ToBig().Cmp(value) != 0 {
+			t.Errorf("tx %d: beneficiary balance mismatch: have %v, want %v", i, balance, value)
+		}
+	}
+}
+
 // TestEIP1559Transition tests the following:
 //
 //  1. A transaction whose gasFeeCap is greater than the baseFee is valid.
@@ -4076,6 +4240,58 @@ func TestPragueRequests(t *testing.T) {
 	}
 }
 
+// Tests that the EIP-7251 consolidation requests of a block are committed to by
+// its requests hash.
+func TestPragueConsolidationRequests(t *testing.T) {
+	var (
+		key1, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
+		config  = *params.MergedTestChainConfig
+		signer  = types.LatestSigner(&config)
+		engine  = beacon.New(ethash.NewFaker())
+		request = types.ConsolidationRequest{SourceAddress: addr1}
+	)
+	copy(request.SourcePubkey[:], bytes.Repeat([]byte{0x11}, 48))
+	copy(request.TargetPubkey[:], bytes.Repeat([]byte{0x22}, 48))
+
+	gspec := &Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			addr1:                            {Balance: big.NewInt(9999900000000000)},
+			params.ConsolidationQueueAddress: {Code: params.ConsolidationQueueCode},
+		},
+	}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 2, func(i int, b *BlockGen) {
+		if i == 0 {
+			b.AddTx(types.MustSignNewTx(key1, signer, &types.DynamicFeeTx{
+				ChainID:   gspec.Config.ChainID,
+				To:        &params.ConsolidationQueueAddress,
+				Gas:       500_000,
+				GasFeeCap: newGwei(5),
+				GasTipCap: big.NewInt(2),
+				Value:     newGwei(1),
+				Data:      append(request.SourcePubkey[:], request.TargetPubkey[:]...),
+			}))
+		}
+	})
+	empty := types.CalcRequestsHash(nil)
+	if have := *blocks[1].RequestsHash(); have != empty {
+		t.Fatalf("requests hash mismatch without requests: have %v, want %v", have, empty)
+	}
+	want := types.CalcRequestsHash([][]byte{append([]byte{types.ConsolidationRequestType}, request.Encode()...)})
+	if have := *blocks[0].RequestsHash(); have != want {
+		t.Fatalf("requests hash mismatch with a consolidation: have %v, want %v", have, want)
+	}
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, nil)
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+}
+
 // TestEIP7702 deploys two delegation designations and calls them. It writes one
 // value to storage which is verified after.
 func TestEIP7702(t *testing.T) {
@@ -4407,3 +4623,392 @@ func testInsertChainWithCutoff(t *testing.T, cutoff uint64, ancientLimit uint64,
 		}
 	}
 }
+
+// Tests that rollup nodes persist the safe block, restoring it on restart even
+// if it's ahead of the finalized block.
+func TestL2SafeHeadPersistence(t *testing.T) {
+	config := *params.TestChainConfig
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+
+	var (
+		engine  = ethash.NewFaker()
+		genesis = &Genesis{Config: &config, BaseFee: big.NewInt(params.InitialBaseFee)}
+	)
+	_, blocks, _ := GenerateChainWithGenesis(genesis, engine, 10, func(i int, b *BlockGen) {})
+
+	db, _ := rawdb.Open(rawdb.NewMemoryDatabase(), rawdb.OpenOptions{})
+	defer db.Close()
+
+	chain, err := NewBlockChain(db, genesis, engine, DefaultConfig())
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	chain.SetFinalized(blocks[3].Header())
+	chain.SetSafe(blocks[7].Header())
+	chain.Stop()
+
+	// Reopen the chain on the same database
+	chain, err = NewBlockChain(db, genesis, engine, DefaultConfig())
+	if err != nil {
+		t.Fatalf("failed to reopen tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	hash, number, ok := rawdb.ReadL2SafeHead(db)
+	if !ok || hash != blocks[7].Hash() || number != blocks[7].NumberU64() {
+		t.Fatalf("persisted safe head mismatch: have %x #%d (found %v), want %x #%d", hash, number, ok, blocks[7].Hash(), blocks[7].NumberU64())
+	}
+	if safe := chain.CurrentSafeBlock(); safe == nil || safe.Hash() != blocks[7].Hash() {
+		t.Fatalf("restored safe block mismatch: have %v, want #%d", safe, blocks[7].NumberU64())
+	}
+	// Rewinding below the safe block drops it
+	if err := chain.SetHead(5); err != nil {
+		t.Fatalf("failed to rewind chain: %v", err)
+	}
+	if _, _, ok := rawdb.ReadL2SafeHead(db); ok {
+		t.Error("invalidated safe head still persisted")
+	}
+}
+
+// Tests that reorgs and side chain imports are recorded in the metrics.
+func TestReorgMetrics(t *testing.T) {
+	// Not parallel, the metrics are shared by all the chains
+	metrics.Enable()
+
+	var (
+		engine  = ethash.NewFaker()
+		genesis = &Genesis{Config: params.TestChainConfig, BaseFee: big.NewInt(params.InitialBaseFee)}
+	)
+	_, canon, _ := GenerateChainWithGenesis(genesis, engine, 5, func(i int, b *BlockGen) {})
+	_, side, _ := GenerateChainWithGenesis(genesis, engine, 6, func(i int, b *BlockGen) {
+		if i >= 2 {
+			b.SetCoinbase(common.Address{0x01})
+		}
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), genesis, engine, DefaultConfig())
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(canon); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	var (
+		reorgs = blockReorgMeter.Snapshot().Count()
+		depths = chainReorgDepthHist.Snapshot().Count()
+		forks  = chainForksCounter.Snapshot().Count()
+	)
+	// Import the side chain forking off block #2, then make it canonical
+	for _, block := range side[2:] {
+		if _, err := chain.InsertBlockWithoutSetHead(block, false); err != nil {
+			t.Fatalf("failed to insert side block #%d: %v", block.NumberU64(), err)
+		}
+	}
+	if have, want := chainForksCounter.Snapshot().Count()-forks, int64(len(side)-2); have != want {
+		t.Errorf("fork count mismatch: have %d, want %d", have, want)
+	}
+	if _, err := chain.SetCanonical(side[len(side)-1]); err != nil {
+		t.Fatalf("failed to set canonical head: %v", err)
+	}
+	if have := blockReorgMeter.Snapshot().Count() - reorgs; have != 1 {
+		t.Errorf("reorg count mismatch: have %d, want 1", have)
+	}
+	depth := chainReorgDepthHist.Snapshot()
+	if have := depth.Count() - depths; have != 1 {
+		t.Fatalf("reorg depth sample count mismatch: have %d, want 1", have)
+	}
+	if have := depth.Max(); have != 3 {
+		t.Errorf("reorg depth mismatch: have %d, want 3", have)
+	}
+}
+
+// Tests that blocks are rejected on import if the blobs referenced by their blob
+// transactions are not available in the provided sidecars.
+func TestInsertChainWithSidecars(t *testing.T) {
+	t.Parallel()
+
+	config := *params.MergedTestChainConfig
+	config.OsakaTime = nil
+
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address = crypto.PubkeyToAddress(key.PublicKey)
+		engine  = beacon.New(ethash.NewFaker())
+		genesis = &Genesis{
+			Config:  &config,
+			Alloc:   types.GenesisAlloc{address: {Balance: big.NewInt(params.Ether)}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+		sidecar = new(types.BlobTxSidecar)
+	)
+	for i := 0; i < 2; i++ {
+		var blob kzg4844.Blob
+		blob[0] = byte(i + 1)
+		commitment, _ := kzg4844.BlobToCommitment(&blob)
+		proof, _ := kzg4844.ComputeBlobProof(&blob, commitment)
+
+		sidecar.Blobs = append(sidecar.Blobs, blob)
+		sidecar.Commitments = append(sidecar.Commitments, commitment)
+		sidecar.Proofs = append(sidecar.Proofs, proof)
+	}
+	var tx *types.Transaction
+	_, blocks, _ := GenerateChainWithGenesis(genesis, engine, 1, func(i int, b *BlockGen) {
+		tx, _ = types.SignNewTx(key, types.LatestSigner(&config), &types.BlobTx{
+			ChainID:    uint256.MustFromBig(config.ChainID),
+			GasTipCap:  uint256.NewInt(1),
+			GasFeeCap:  uint256.MustFromBig(b.BaseFee()),
+			Gas:        params.TxGas,
+			BlobFeeCap: uint256.NewInt(params.GWei),
+			BlobHashes: sidecar.BlobHashes(),
+		})
+		b.AddTx(tx)
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), genesis, engine, DefaultConfig())
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// The sidecar lacks one of the blobs referenced by the transaction
+	partial := &types.BlobTxSidecar{
+		Blobs:       sidecar.Blobs[:1],
+		Commitments: sidecar.Commitments[:1],
+		Proofs:      sidecar.Proofs[:1],
+	}
+	if _, err := chain.InsertChainWithSidecars(blocks, map[common.Hash]*types.BlobTxSidecar{tx.Hash(): partial}); !errors.Is(err, ErrBlobUnavailable) {
+		t.Fatalf("partial sidecar error mismatch: have %v, want %v", err, ErrBlobUnavailable)
+	}
+	if _, err := chain.InsertChainWithSidecars(blocks, nil); !errors.Is(err, ErrBlobUnavailable) {
+		t.Fatalf("missing sidecar error mismatch: have %v, want %v", err, ErrBlobUnavailable)
+	}
+	if head := chain.CurrentBlock().Number.Uint64(); head != 0 {
+		t.Fatalf("block with unavailable blobs imported: head #%d", head)
+	}
+	// The sidecar with all the blobs makes the block importable
+	if _, err := chain.InsertChainWithSidecars(blocks, map[common.Hash]*types.BlobTxSidecar{tx.Hash(): sidecar}); err != nil {
+		t.Fatalf("failed to insert block with available blobs: %v", err)
+	}
+	if head := chain.CurrentBlock().Hash(); head != blocks[0].Hash() {
+		t.Fatalf("head mismatch: have %x, want %x", head, blocks[0].Hash())
+	}
+}
+
+// Tests a Cancun block exercising all the EIPs of the fork: transient storage
+// (EIP-1153), the blob base fee opcode (EIP-7516), memory copying (EIP-5656),
+// the beacon block root contract (EIP-4788), the restricted self-destruct
+// (EIP-6780) and blob transactions (EIP-4844).
+func TestCancunBlock(t *testing.T) {
+	t.Parallel()
+
+	config := *params.MergedTestChainConfig
+	config.PragueTime = nil
+	config.OsakaTime = nil
+
+	var (
+		key, _      = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		address     = crypto.PubkeyToAddress(key.PublicKey)
+		engine      = beacon.New(ethash.NewFaker())
+		cancun      = common.HexToAddress("0xcc")
+		destructed  = common.HexToAddress("0xdd")
+		beneficiary = common.HexToAddress("0xbb")
+		beaconRoot  = common.HexToHash("0xbeac04")
+		word        = common.HexToHash("0x0102030405060708091011121314151617181920212223242526272829303132")
+
+		runtime      = program.New().Sstore(0, 1).Bytes()
+		deployInit   = program.New().ReturnViaCodeCopy(runtime).Bytes()
+		destructInit = program.New().Selfdestruct(beneficiary).Bytes()
+	)
+	code := program.New().
+		// EIP-1153: slot 0 = TLOAD(1) after TSTORE(1, 0x42)
+		Tstore(1, 0x42).Push(1).Op(vm.TLOAD).Push(0).Op(vm.SSTORE).
+		// EIP-7516: slot 1 = BLOBBASEFEE
+		Op(vm.BLOBBASEFEE).Push(1).Op(vm.SSTORE).
+		// EIP-5656: slot 2 = memory[32:64] after MCOPY(32, 0, 32)
+		Mstore(word.Bytes(), 0).Push(32).Push(0).Push(32).Op(vm.MCOPY).Push(32).Op(vm.MLOAD).Push(2).Op(vm.SSTORE).
+		// EIP-4788: slot 3 = beacon root of the block timestamp
+		Op(vm.TIMESTAMP).Push(0).Op(vm.MSTORE).StaticCall(nil, params.BeaconRootsAddress, 0, 32, 0, 32).Op(vm.POP).
+		Push(0).Op(vm.MLOAD).Push(3).Op(vm.SSTORE).
+		// CREATE2: slot 4 = address of a persisted contract
+		Create2(deployInit, 1).Push(4).Op(vm.SSTORE).
+		// EIP-6780: a contract self-destructed in the transaction creating it
+		// is deleted, a preexisting one only has its balance sent
+		Create2(destructInit, 2).Push(5).Op(vm.SSTORE).
+		Call(nil, destructed, 0, 0, 0, 0, 0).Op(vm.POP).
+		Bytes()
+
+	genesis := &Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			address:                   {Balance: big.NewInt(params.Ether)},
+			cancun:                    {Code: code},
+			destructed:                {Code: program.New().Selfdestruct(beneficiary).Bytes(), Balance: big.NewInt(1000)},
+			params.BeaconRootsAddress: {Nonce: 1, Code: params.BeaconRootsCode},
+		},
+		BaseFee: big.NewInt(params.InitialBaseFee),
+	}
+	var blob kzg4844.Blob
+	commitment, _ := kzg4844.BlobToCommitment(&blob)
+	blobHash := kzg4844.CalcBlobHashV1(sha256.New(), &commitment)
+
+	signer := types.LatestSigner(&config)
+	_, blocks, _ := GenerateChainWithGenesis(genesis, engine, 1, func(i int, b *BlockGen) {
+		b.SetParentBeaconRoot(beaconRoot)
+		b.AddTx(types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     0,
+			To:        &cancun,
+			Gas:       1000000,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: b.BaseFee(),
+		}))
+		b.AddTx(types.MustSignNewTx(key, signer, &types.BlobTx{
+			ChainID:    uint256.MustFromBig(config.ChainID),
+			Nonce:      1,
+			To:         beneficiary,
+			Gas:        params.TxGas,
+			GasTipCap:  uint256.NewInt(1),
+			GasFeeCap:  uint256.MustFromBig(b.BaseFee()),
+			BlobFeeCap: uint256.NewInt(params.GWei),
+			BlobHashes: []common.Hash{blobHash},
+		}))
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), genesis, engine, DefaultConfig())
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	block := chain.CurrentBlock()
+	if block.Hash() != blocks[0].Hash() || block.Root != blocks[0].Root() {
+		t.Fatalf("head mismatch: have %x (root %x), want %x (root %x)", block.Hash(), block.Root, blocks[0].Hash(), blocks[0].Root())
+	}
+	for i, receipt := range chain.GetReceiptsByHash(block.Hash()) {
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			t.Fatalf("transaction %d failed", i)
+		}
+	}
+	if have, want := *block.BlobGasUsed, uint64(params.BlobTxBlobGasPerBlob); have != want {
+		t.Errorf("blob gas used mismatch: have %d, want %d", have, want)
+	}
+	statedb, err := chain.StateAt(block.Root)
+	if err != nil {
+		t.Fatalf("failed to load state: %v", err)
+	}
+	var (
+		deployed = crypto.CreateAddress2(cancun, common.BigToHash(big.NewInt(1)), crypto.Keccak256(deployInit))
+		created  = crypto.CreateAddress2(cancun, common.BigToHash(big.NewInt(2)), crypto.Keccak256(destructInit))
+	)
+	slots := []struct {
+		name string
+		slot int64
+		want common.Hash
+	}{
+		{"transient storage", 0, common.BigToHash(big.NewInt(0x42))},
+		{"blob base fee", 1, common.BigToHash(eip4844.CalcBlobFee(&config, block))},
+		{"memory copy", 2, word},
+		{"beacon root", 3, beaconRoot},
+		{"create2 address", 4, common.BytesToHash(deployed.Bytes())},
+		{"self-destructed address", 5, common.BytesToHash(created.Bytes())},
+	}
+	for _, tt := range slots {
+		if have := statedb.GetState(cancun, common.BigToHash(big.NewInt(tt.slot))); have != tt.want {
+			t.Errorf("%s mismatch: have %x, want %x", tt.name, have, tt.want)
+		}
+	}
+	if have := statedb.GetCode(deployed); !bytes.Equal(have, runtime) {
+		t.Errorf("deployed code mismatch: have %x, want %x", have, runtime)
+	}
+	if statedb.Exist(created) {
+		t.Errorf("contract self-destructed in its creation transaction not deleted")
+	}
+	if !statedb.Exist(destructed) || len(statedb.GetCode(destructed)) == 0 {
+		t.Errorf("preexisting self-destructed contract deleted")
+	}
+	if have := statedb.GetBalance(destructed); !have.IsZero() {
+		t.Errorf("preexisting self-destructed contract balance mismatch: have %v, want 0", have)
+	}
+	if have := statedb.GetBalance(beneficiary); have.Uint64() != 1000 {
+		t.Errorf("beneficiary balance mismatch: have %v, want %d", have, 1000)
+	}
+}
+
+// Tests that a reorg posts a ChainSideEvent for every dropped block, returning
+// the transactions not included by the new chain.
+func TestChainSideEventReturnedTxs(t *testing.T) {
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = &Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+		txs    = make([]*types.Transaction, 3)
+	)
+	for i := range txs {
+		txs[i], _ = types.SignTx(types.NewTransaction(uint64(i), common.Address{0x01}, big.NewInt(1), params.TxGas, big.NewInt(params.InitialBaseFee), nil), signer, key)
+	}
+	// The original chain includes a transaction in each block, the fork keeps
+	// the one of the second block only
+	genDb, chain, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 3, func(i int, gen *BlockGen) {
+		gen.AddTx(txs[i])
+	})
+	fork, _ := GenerateChain(gspec.Config, chain[0], ethash.NewFaker(), genDb, 3, func(i int, gen *BlockGen) {
+		gen.SetCoinbase(common.Address{0xff})
+		if i == 0 {
+			gen.AddTx(txs[1])
+		}
+	})
+	blockchain, _ := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), nil)
+	defer blockchain.Stop()
+
+	if _, err := blockchain.InsertChain(chain); err != nil {
+		t.Fatalf("failed to insert original chain: %v", err)
+	}
+	events := make(chan ChainSideEvent, 10)
+	sub := blockchain.SubscribeChainSideEvent(events)
+	defer sub.Unsubscribe()
+
+	if _, err := blockchain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert forked chain: %v", err)
+	}
+	if head := blockchain.CurrentBlock().Hash(); head != fork[len(fork)-1].Hash() {
+		t.Fatalf("head mismatch: have %v, want %v", head, fork[len(fork)-1].Hash())
+	}
+	want := map[common.Hash][]*types.Transaction{
+		chain[1].Hash(): nil,
+		chain[2].Hash(): {txs[2]},
+	}
+	for n := len(want); n > 0; n-- {
+		select {
+		case ev := <-events:
+			returned, ok := want[ev.Header.Hash()]
+			if !ok {
+				t.Fatalf("unexpected side event for block #%d", ev.Header.Number)
+			}
+			if len(ev.ReturnedTxs) != len(returned) {
+				t.Fatalf("block #%d: returned transactions mismatch: have %d, want %d", ev.Header.Number, len(ev.ReturnedTxs), len(returned))
+			}
+			for i, tx := range ev.ReturnedTxs {
+				if tx.Hash() != returned[i].Hash() {
+					t.Errorf("block #%d: returned transaction %d mismatch: have %v, want %v", ev.Header.Number, i, tx.Hash(), returned[i].Hash())
+				}
+			}
+			delete(want, ev.Header.Hash())
+		default:
+			t.Fatalf("missing side events for %d blocks", len(want))
+		}
+	}
+	select {
+	case ev := <-events:
+		t.Fatalf("unexpected side event for block #%d", ev.Header.Number)
+	default:
+	}
+}
@@ -28,6 +28,18 @@ var (
 
 	// ErrNoGenesis is returned when there is no Genesis Block.
 	ErrNoGenesis = errors.New("genesis not found in chain")
+
+	// ErrBodyTransactionRootMismatch is returned when the transactions of a block
+	// body don't match the transaction root of its header.
+	ErrBodyTransactionRootMismatch = errors.New("transaction root hash mismatch")
+
+	// ErrBlockBodyTooLarge is returned when the encoded size of a block body
+	// exceeds the size limit of the chain.
+	ErrBlockBodyTooLarge = errors.New("block body too large")
+
+	// ErrBlobUnavailable is returned when the blobs referenced by the blob
+	// transactions of a block are missing or don't match their sidecars.
+	ErrBlobUnavailable = errors.New("blobs unavailable")
 )
 
 // List of evm-call-message pre-checking errors. All state transition messages will
@@ -105,6 +117,10 @@ var (
 	// ErrSenderNoEOA is returned if the sender of a transaction is a contract.
 	ErrSenderNoEOA = errors.New("sender not an eoa")
 
+	// ErrSystemTxNotSupported is returned for deposits flagged as system
+	// transactions after Regolith, which removed them.
+	ErrSystemTxNotSupported = errors.New("system transactions are not supported after regolith")
+
 	// -- EIP-4844 errors --
 
 	// ErrBlobFeeCapTooLow is returned if the transaction fee cap is less than the
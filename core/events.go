@@ -33,3 +33,21 @@ type ChainEvent struct {
 type ChainHeadEvent struct {
 	Header *types.Header
 }
+
+// ChainSideEvent is posted for every block dropped from the canonical chain by
+// a reorg, along with its transactions not included by the new chain.
+type ChainSideEvent struct {
+	Header      *types.Header
+	ReturnedTxs []*types.Transaction
+}
+
+// UnsafeHeadEvent is posted when the forkchoice moves the unsafe head, the head
+// of the canonical chain on OP-Stack chains.
+type UnsafeHeadEvent struct {
+	Header *types.Header
+}
+
+// SafeHeadEvent is posted when the forkchoice moves the safe head.
+type SafeHeadEvent struct {
+	Header *types.Header
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// SafeHeadEntry records the L2 safe head that was derived once the rollup
+// derivation pipeline had processed L1 up to and including the given L1 block.
+type SafeHeadEntry struct {
+	L1Hash   common.Hash
+	L1Number uint64
+	L2Hash   common.Hash
+	L2Number uint64
+	L2Time   uint64
+}
+
+// ReadSafeHeadAtL1 retrieves the L2 safe head derived from the L1 chain up to
+// the given L1 block number, i.e. the entry with the highest L1 number which
+// is not above the requested one. Nil is returned if no such entry exists.
+func ReadSafeHeadAtL1(db ethdb.Iteratee, l1Number uint64) *SafeHeadEntry {
+	it := db.NewIterator(safeHeadPrefix, encodeBlockNumber(^l1Number))
+	defer it.Release()
+
+	for it.Next() {
+		if len(it.Key()) != len(safeHeadPrefix)+8 {
+			continue
+		}
+		entry := new(SafeHeadEntry)
+		if err := rlp.DecodeBytes(it.Value(), entry); err != nil {
+			log.Error("Invalid safe head entry RLP", "key", it.Key(), "err", err)
+			return nil
+		}
+		return entry
+	}
+	return nil
+}
+
+// WriteSafeHeadAtL1 stores the L2 safe head derived from the given L1 block.
+func WriteSafeHeadAtL1(db ethdb.KeyValueWriter, entry *SafeHeadEntry) {
+	data, err := rlp.EncodeToBytes(entry)
+	if err != nil {
+		log.Crit("Failed to RLP encode safe head entry", "err", err)
+	}
+	if err := db.Put(safeHeadKey(entry.L1Number), data); err != nil {
+		log.Crit("Failed to store safe head entry", "err", err)
+	}
+}
+
+// DeleteSafeHeadsAfterL1 removes all the safe head entries derived from L1
+// blocks above the given number. It is used to invalidate the mapping when
+// the derivation pipeline is reset to an earlier L1 block.
+func DeleteSafeHeadsAfterL1(db ethdb.KeyValueStore, l1Number uint64) {
+	var (
+		batch = db.NewBatch()
+		it    = db.NewIterator(safeHeadPrefix, nil)
+		end   = safeHeadKey(l1Number)
+	)
+	defer it.Release()
+
+	for it.Next() {
+		// Entries are sorted by descending L1 number, stop at the first one
+		// which is not above the limit.
+		if bytes.Compare(it.Key(), end) >= 0 {
+			break
+		}
+		if err := batch.Delete(it.Key()); err != nil {
+			log.Crit("Failed to delete safe head entry", "err", err)
+		}
+	}
+	if err := batch.Write(); err != nil {
+		log.Crit("Failed to delete safe head entries", "err", err)
+	}
+}
+
+// ReadL2SafeHead retrieves the hash and the number of the latest known L2 safe
+// block, reporting whether it was found.
+func ReadL2SafeHead(db ethdb.KeyValueReader) (common.Hash, uint64, bool) {
+	data, _ := db.Get(l2SafeHeadKey)
+	if len(data) != common.HashLength+8 {
+		return common.Hash{}, 0, false
+	}
+	return common.BytesToHash(data[:common.HashLength]), binary.BigEndian.Uint64(data[common.HashLength:]), true
+}
+
+// WriteL2SafeHead stores the hash and the number of the latest known L2 safe
+// block.
+func WriteL2SafeHead(db ethdb.KeyValueWriter, blockHash common.Hash, blockNumber uint64) {
+	if err := db.Put(l2SafeHeadKey, append(blockHash.Bytes(), encodeBlockNumber(blockNumber)...)); err != nil {
+		log.Crit("Failed to store the L2 safe head", "err", err)
+	}
+}
+
+// DeleteL2SafeHead removes the latest known L2 safe block.
+func DeleteL2SafeHead(db ethdb.KeyValueWriter) {
+	if err := db.Delete(l2SafeHeadKey); err != nil {
+		log.Crit("Failed to delete the L2 safe head", "err", err)
+	}
+}
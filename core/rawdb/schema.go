@@ -20,6 +20,7 @@ package rawdb
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -43,6 +44,9 @@ var (
 	// headFinalizedBlockKey tracks the latest known finalized block hash.
 	headFinalizedBlockKey = []byte("LastFinalized")
 
+	// l2SafeHeadKey tracks the latest known L2 safe block hash and number.
+	l2SafeHeadKey = []byte("L2SafeHead")
+
 	// persistentStateIDKey tracks the id of latest stored state(for path-based only).
 	persistentStateIDKey = []byte("LastStateID")
 
@@ -141,6 +145,8 @@ var (
 
 	CliqueSnapshotPrefix = []byte("clique-")
 
+	safeHeadPrefix = []byte("safe-head-") // safeHeadPrefix + ^l1num (uint64 big endian) -> RLP(SafeHeadEntry)
+
 	BestUpdateKey         = []byte("update-")    // bigEndian64(syncPeriod) -> RLP(types.LightClientUpdate)  (nextCommittee only referenced by root hash)
 	FixedCommitteeRootKey = []byte("fixedRoot-") // bigEndian64(syncPeriod) -> committee root hash
 	SyncCommitteeKey      = []byte("committee-") // bigEndian64(syncPeriod) -> serialized committee
@@ -343,6 +349,49 @@ func IsStorageTrieNode(key []byte) bool {
 	return ok
 }
 
+// DescribeKey returns a human-readable interpretation of a database key of the
+// well known schemas, or an empty string if the key is not recognized. The value
+// is used to tell apart the trie nodes of the hash scheme.
+func DescribeKey(key []byte, value []byte) string {
+	var (
+		num  = func(b []byte) uint64 { return binary.BigEndian.Uint64(b) }
+		hash = func(b []byte) common.Hash { return common.BytesToHash(b) }
+	)
+	switch {
+	case bytes.HasPrefix(key, headerPrefix) && len(key) == 1+8+common.HashLength:
+		return fmt.Sprintf("header #%d %v", num(key[1:9]), hash(key[9:]))
+	case bytes.HasPrefix(key, headerPrefix) && len(key) == 1+8+1 && key[9] == headerHashSuffix[0]:
+		return fmt.Sprintf("canonical hash #%d", num(key[1:9]))
+	case bytes.HasPrefix(key, headerNumberPrefix) && len(key) == 1+common.HashLength:
+		return fmt.Sprintf("header number %v", hash(key[1:]))
+	case bytes.HasPrefix(key, blockBodyPrefix) && len(key) == 1+8+common.HashLength:
+		return fmt.Sprintf("block body #%d %v", num(key[1:9]), hash(key[9:]))
+	case bytes.HasPrefix(key, blockReceiptsPrefix) && len(key) == 1+8+common.HashLength:
+		return fmt.Sprintf("block receipts #%d %v", num(key[1:9]), hash(key[9:]))
+	case bytes.HasPrefix(key, txLookupPrefix) && len(key) == 1+common.HashLength:
+		return fmt.Sprintf("transaction lookup %v", hash(key[1:]))
+	case bytes.HasPrefix(key, SnapshotAccountPrefix) && len(key) == 1+common.HashLength:
+		return fmt.Sprintf("account snapshot %v", hash(key[1:]))
+	case bytes.HasPrefix(key, SnapshotStoragePrefix) && len(key) == 1+2*common.HashLength:
+		return fmt.Sprintf("storage snapshot %v slot %v", hash(key[1:33]), hash(key[33:]))
+	case bytes.HasPrefix(key, PreimagePrefix) && len(key) == len(PreimagePrefix)+common.HashLength:
+		return fmt.Sprintf("preimage %v", hash(key[len(PreimagePrefix):]))
+	}
+	if ok, codeHash := IsCodeKey(key); ok {
+		return fmt.Sprintf("code %v", hash(codeHash))
+	}
+	if ok, path := ResolveAccountTrieNodeKey(key); ok {
+		return fmt.Sprintf("account trie node path %x", path)
+	}
+	if ok, account, path := ResolveStorageTrieNode(key); ok {
+		return fmt.Sprintf("storage trie node %v path %x", account, path)
+	}
+	if IsLegacyTrieNode(key, value) {
+		return fmt.Sprintf("trie node %v (hash scheme)", hash(key))
+	}
+	return ""
+}
+
 // filterMapRowKey = filterMapRowPrefix + mapRowIndex (uint64 big endian)
 func filterMapRowKey(mapRowIndex uint64, base bool) []byte {
 	extLen := 8
@@ -374,6 +423,14 @@ func filterMapBlockLVKey(number uint64) []byte {
 	return key
 }
 
+// safeHeadKey = safeHeadPrefix + ^num (uint64 big endian)
+//
+// The L1 block number is stored inverted so that iterating the prefix yields
+// the entries in descending L1 order.
+func safeHeadKey(l1Number uint64) []byte {
+	return append(safeHeadPrefix, encodeBlockNumber(^l1Number)...)
+}
+
 // accountHistoryIndexKey = StateHistoryAccountMetadataPrefix + addressHash
 func accountHistoryIndexKey(addressHash common.Hash) []byte {
 	return append(StateHistoryAccountMetadataPrefix, addressHash.Bytes()...)
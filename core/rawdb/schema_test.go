@@ -0,0 +1,56 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestDescribeKey(t *testing.T) {
+	var (
+		hash = common.HexToHash("0x01")
+		node = []byte{0xc0}
+	)
+	tests := []struct {
+		key   []byte
+		value []byte
+		want  string
+	}{
+		{headerKey(10, hash), nil, "header #10 " + hash.Hex()},
+		{headerHashKey(10), nil, "canonical hash #10"},
+		{headerNumberKey(hash), nil, "header number " + hash.Hex()},
+		{blockBodyKey(10, hash), nil, "block body #10 " + hash.Hex()},
+		{blockReceiptsKey(10, hash), nil, "block receipts #10 " + hash.Hex()},
+		{txLookupKey(hash), nil, "transaction lookup " + hash.Hex()},
+		{codeKey(hash), nil, "code " + hash.Hex()},
+		{accountSnapshotKey(hash), nil, "account snapshot " + hash.Hex()},
+		{storageSnapshotKey(hash, hash), nil, "storage snapshot " + hash.Hex() + " slot " + hash.Hex()},
+		{accountTrieNodeKey([]byte{0x01, 0x02}), nil, "account trie node path 0102"},
+		{storageTrieNodeKey(hash, []byte{0x03}), nil, "storage trie node " + hash.Hex() + " path 03"},
+		{crypto.Keccak256(node), node, "trie node " + crypto.Keccak256Hash(node).Hex() + " (hash scheme)"},
+		{hash.Bytes(), node, ""},
+		{[]byte("unknown"), nil, ""},
+	}
+	for _, tt := range tests {
+		if have := DescribeKey(tt.key, tt.value); have != tt.want {
+			t.Errorf("key %x: description mismatch: have %q, want %q", tt.key, have, tt.want)
+		}
+	}
+}
@@ -18,6 +18,7 @@ package state
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/lru"
@@ -41,6 +42,9 @@ const (
 
 	// Number of address->curve point associations to keep.
 	pointCacheSize = 4096
+
+	// Number of frequently accessed accounts to cache the trie lookups of.
+	accountPathCacheSize = 1000
 )
 
 // Database wraps access to tries and contract code.
@@ -151,6 +155,7 @@ type CachingDB struct {
 	codeCache     *lru.SizeConstrainedCache[common.Hash, []byte]
 	codeSizeCache *lru.Cache[common.Hash, int]
 	pointCache    *utils.PointCache
+	pathCache     *AccountPathCache
 }
 
 // NewDatabase creates a state database with the provided data sources.
@@ -162,6 +167,7 @@ func NewDatabase(triedb *triedb.Database, snap *snapshot.Tree) *CachingDB {
 		codeCache:     lru.NewSizeConstrainedCache[common.Hash, []byte](codeCacheSize),
 		codeSizeCache: lru.NewCache[common.Hash, int](codeSizeCacheSize),
 		pointCache:    utils.NewPointCache(pointCacheSize),
+		pathCache:     NewAccountPathCache(accountPathCacheSize),
 	}
 }
 
@@ -196,7 +202,7 @@ func (db *CachingDB) Reader(stateRoot common.Hash) (Reader, error) {
 	}
 	// Configure the trie reader, which is expected to be available as the
 	// gatekeeper unless the state is corrupted.
-	tr, err := newTrieReader(stateRoot, db.triedb, db.pointCache)
+	tr, err := newTrieReader(stateRoot, db.triedb, db.pointCache, db.pathCache)
 	if err != nil {
 		return nil, err
 	}
@@ -290,3 +296,82 @@ func mustCopyTrie(t Trie) Trie {
 		panic(fmt.Errorf("unknown trie type %T", t))
 	}
 }
+
+// accountPathMinAccesses is the number of recent accesses of an account needed
+// for its lookups to be cached.
+const accountPathMinAccesses = 2
+
+// accountPathKey identifies an account in a state.
+type accountPathKey struct {
+	root common.Hash
+	addr common.Address
+}
+
+// AccountPathCache caches the trie lookups of the most frequently accessed
+// accounts, such as the popular tokens and pools touched by almost every block.
+// As an account is fully determined by the state root and its address, the leaf
+// resolved at the end of the path is cached, allowing a hit to skip the traversal
+// from the root altogether.
+//
+// The accounts are cached per state root, so readers of different states, like
+// the RPC calls against the head and the import of the next block, don't evict
+// each other. Only the accounts accessed repeatedly are admitted, the least
+// recently used one being evicted once the cache is full.
+type AccountPathCache struct {
+	size     int
+	accounts lru.BasicLRU[accountPathKey, *types.StateAccount] // Cached accounts, nil if not existent
+	counts   map[common.Address]uint64                         // Access counts of the recently seen accounts
+	lock     sync.Mutex
+}
+
+// NewAccountPathCache creates a cache for the trie lookups of the given number
+// of frequently accessed accounts.
+func NewAccountPathCache(size int) *AccountPathCache {
+	return &AccountPathCache{
+		size:     size,
+		accounts: lru.NewBasicLRU[accountPathKey, *types.StateAccount](size),
+		counts:   make(map[common.Address]uint64),
+	}
+}
+
+// Get records an access of the account in the given state, and returns it if
+// it's cached.
+func (c *AccountPathCache) Get(root common.Hash, addr common.Address) (*types.StateAccount, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.counts[addr]++
+	if len(c.counts) > 16*c.size {
+		// Age the access counts, forgetting the accounts seen only once
+		for a, n := range c.counts {
+			if n /= 2; n == 0 {
+				delete(c.counts, a)
+			} else {
+				c.counts[a] = n
+			}
+		}
+	}
+	account, ok := c.accounts.Get(accountPathKey{root, addr})
+	if !ok {
+		return nil, false
+	}
+	if account == nil {
+		return nil, true
+	}
+	return account.Copy(), true
+}
+
+// Add caches the account resolved from the trie of the given state, if it's
+// accessed frequently enough.
+func (c *AccountPathCache) Add(root common.Hash, addr common.Address, account *types.StateAccount) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.counts[addr] < accountPathMinAccesses {
+		return
+	}
+	if account != nil {
+		account = account.Copy()
+	}
+	c.accounts.Add(accountPathKey{root, addr}, account)
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+func TestAccountPathCache(t *testing.T) {
+	var (
+		cache = NewAccountPathCache(2)
+		root1 = common.Hash{0x01}
+		root2 = common.Hash{0x02}
+		acct  = &types.StateAccount{Nonce: 1, Balance: new(uint256.Int), Root: types.EmptyRootHash, CodeHash: types.EmptyCodeHash.Bytes()}
+		hot   = common.Address{0x01}
+		warm  = common.Address{0x02}
+		cold  = common.Address{0x03}
+	)
+	// The accounts accessed only once are not cached
+	if _, ok := cache.Get(root1, cold); ok {
+		t.Fatal("cold account cached before being added")
+	}
+	cache.Add(root1, cold, acct)
+	if _, ok := cache.Get(root1, cold); ok {
+		t.Fatal("cold account cached after a single access")
+	}
+	// The repeatedly accessed ones are, separately for every root
+	for i := 0; i < accountPathMinAccesses; i++ {
+		cache.Get(root1, hot)
+	}
+	cache.Add(root1, hot, acct)
+	cache.Add(root2, hot, nil)
+
+	if have, ok := cache.Get(root1, hot); !ok || have.Nonce != 1 {
+		t.Fatalf("hot account mismatch: have %v, %v", have, ok)
+	}
+	if have, ok := cache.Get(root2, hot); !ok || have != nil {
+		t.Fatalf("missing account mismatch: have %v, %v", have, ok)
+	}
+	// Once full, the least recently used account is evicted
+	cache.Get(root1, hot)
+	for i := 0; i < accountPathMinAccesses; i++ {
+		cache.Get(root1, warm)
+	}
+	cache.Add(root1, warm, acct)
+	if _, ok := cache.Get(root2, hot); ok {
+		t.Fatal("least recently used account not evicted")
+	}
+	if _, ok := cache.Get(root1, hot); !ok {
+		t.Fatal("recently used account evicted")
+	}
+	if _, ok := cache.Get(root1, warm); !ok {
+		t.Fatal("frequent account not cached")
+	}
+}
+
+// Tests that the state readers don't serve the cached accounts of a previous
+// state root.
+func TestAccountPathCacheRootChange(t *testing.T) {
+	var (
+		db   = NewDatabaseForTesting()
+		addr = common.Address{0x01}
+	)
+	state, _ := New(types.EmptyRootHash, db)
+	state.SetBalance(addr, uint256.NewInt(1), 0)
+	root1, _ := state.Commit(0, false, false)
+
+	state, _ = New(root1, db)
+	state.SetBalance(addr, uint256.NewInt(2), 0)
+	root2, _ := state.Commit(1, false, false)
+
+	for i, root := range []common.Hash{root1, root1, root2, root2, root1} {
+		reader, err := db.Reader(root)
+		if err != nil {
+			t.Fatalf("failed to open reader: %v", err)
+		}
+		account, err := reader.Account(addr)
+		if err != nil {
+			t.Fatalf("failed to read account: %v", err)
+		}
+		want := uint64(1)
+		if root == root2 {
+			want = 2
+		}
+		if have := account.Balance.Uint64(); have != want {
+			t.Errorf("read %d: balance mismatch: have %d, want %d", i, have, want)
+		}
+	}
+}
+
+// BenchmarkHotAccountReads measures the lookups of a WETH-like account accessed
+// through fresh readers of the same state, as done by the RPC calls made against
+// the head state. Run with -benchtime=1000000x for a million accesses.
+func BenchmarkHotAccountReads(b *testing.B) {
+	db := NewDatabaseForTesting()
+	state, _ := New(types.EmptyRootHash, db)
+	for i := 0; i < 100_000; i++ {
+		var addr common.Address
+		binary.BigEndian.PutUint64(addr[:], uint64(i))
+		state.SetBalance(addr, uint256.NewInt(uint64(i)+1), 0)
+	}
+	weth := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	state.SetCode(weth, []byte{0x00})
+	root, _ := state.Commit(0, false, false)
+
+	run := func(b *testing.B, cache *AccountPathCache) {
+		db.pathCache = cache
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			reader, err := db.Reader(root)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if account, _ := reader.Account(weth); account == nil {
+				b.Fatal("missing account")
+			}
+		}
+	}
+	b.Run("nocache", func(b *testing.B) { run(b, nil) })
+	b.Run("cache", func(b *testing.B) { run(b, NewAccountPathCache(accountPathCacheSize)) })
+}
+
+// BenchmarkHotAccountReadsMixedRoots measures the lookups of a WETH-like account
+// by concurrent readers alternating between two states, as done by the RPC calls
+// against the head and the import of the next block.
+func BenchmarkHotAccountReadsMixedRoots(b *testing.B) {
+	db := NewDatabaseForTesting()
+	state, _ := New(types.EmptyRootHash, db)
+	for i := 0; i < 100_000; i++ {
+		var addr common.Address
+		binary.BigEndian.PutUint64(addr[:], uint64(i))
+		state.SetBalance(addr, uint256.NewInt(uint64(i)+1), 0)
+	}
+	weth := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	state.SetCode(weth, []byte{0x00})
+	root1, _ := state.Commit(0, false, false)
+
+	state, _ = New(root1, db)
+	state.SetBalance(weth, uint256.NewInt(1), 0)
+	root2, _ := state.Commit(1, false, false)
+
+	run := func(b *testing.B, cache *AccountPathCache) {
+		db.pathCache = cache
+		b.ReportAllocs()
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			roots := []common.Hash{root1, root2}
+			for i := 0; pb.Next(); i++ {
+				reader, err := db.Reader(roots[i%2])
+				if err != nil {
+					b.Fatal(err)
+				}
+				if account, _ := reader.Account(weth); account == nil {
+					b.Fatal("missing account")
+				}
+			}
+		})
+	}
+	b.Run("nocache", func(b *testing.B) { run(b, nil) })
+	b.Run("cache", func(b *testing.B) { run(b, NewAccountPathCache(accountPathCacheSize)) })
+}
@@ -17,6 +17,7 @@
 package state
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -35,8 +36,10 @@ type DumpConfig struct {
 	SkipCode          bool
 	SkipStorage       bool
 	OnlyWithAddresses bool
+	OnlyContracts     bool // Skip the accounts without code
 	Start             []byte
 	Max               uint64
+	MaxStorageSlots   uint64 // Maximum number of storage slots per account, 0 for no limit
 }
 
 // DumpCollector interface which the state trie calls during iteration
@@ -55,8 +58,9 @@ type DumpAccount struct {
 	CodeHash    hexutil.Bytes          `json:"codeHash"`
 	Code        hexutil.Bytes          `json:"code,omitempty"`
 	Storage     map[common.Hash]string `json:"storage,omitempty"`
-	Address     *common.Address        `json:"address,omitempty"` // Address only present in iterative (line-by-line) mode
-	AddressHash hexutil.Bytes          `json:"key,omitempty"`     // If we don't have address, we can output the key
+	Truncated   bool                   `json:"truncated,omitempty"` // Storage cut at MaxStorageSlots
+	Address     *common.Address        `json:"address,omitempty"`   // Address only present in iterative (line-by-line) mode
+	AddressHash hexutil.Bytes          `json:"key,omitempty"`       // If we don't have address, we can output the key
 }
 
 // Dump represents the full dump in a collected format, as one large map.
@@ -97,6 +101,7 @@ func (d iterativeDump) OnAccount(addr *common.Address, account DumpAccount) {
 		CodeHash:    account.CodeHash,
 		Code:        account.Code,
 		Storage:     account.Storage,
+		Truncated:   account.Truncated,
 		AddressHash: account.AddressHash,
 		Address:     addr,
 	}
@@ -145,6 +150,9 @@ func (s *StateDB) DumpToCollector(c DumpCollector, conf *DumpConfig) (nextKey []
 		if err := rlp.DecodeBytes(it.Value, &data); err != nil {
 			panic(err)
 		}
+		if conf.OnlyContracts && bytes.Equal(data.CodeHash, types.EmptyCodeHash[:]) {
+			continue
+		}
 		var (
 			account = DumpAccount{
 				Balance:     data.Balance.String(),
@@ -186,6 +194,10 @@ func (s *StateDB) DumpToCollector(c DumpCollector, conf *DumpConfig) (nextKey []
 			}
 			storageIt := trie.NewIterator(trieIt)
 			for storageIt.Next() {
+				if conf.MaxStorageSlots > 0 && uint64(len(account.Storage)) >= conf.MaxStorageSlots {
+					account.Truncated = true
+					break
+				}
 				_, content, _, err := rlp.Split(storageIt.Value)
 				if err != nil {
 					log.Error("Failed to decode the value returned by iterator", "error", err)
@@ -0,0 +1,226 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pruner
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ethereum/go-ethereum/triedb"
+	"golang.org/x/sync/errgroup"
+)
+
+// BlockReader provides access to the headers of the canonical chain.
+type BlockReader interface {
+	// GetHeaderByNumber retrieves the canonical header of the given number.
+	GetHeaderByNumber(number uint64) *types.Header
+}
+
+// GCUnreferencedNodes deletes the trie nodes of the database which are not
+// referenced by the states of the canonical blocks in the range [from, to],
+// e.g. the nodes left behind by reorgs. It returns the number of the deleted
+// nodes. The states missing in the range are skipped, all the others must be
+// complete. Only the hash-based state scheme is supported.
+func GCUnreferencedNodes(db ethdb.Database, canonical BlockReader, from, to uint64) (deleted int, err error) {
+	return gcUnreferencedNodes(db, canonical, from, to, false)
+}
+
+// CountUnreferencedNodes returns the number of the trie nodes which would be
+// deleted by GCUnreferencedNodes, without deleting them.
+func CountUnreferencedNodes(db ethdb.Database, canonical BlockReader, from, to uint64) (int, error) {
+	return gcUnreferencedNodes(db, canonical, from, to, true)
+}
+
+func gcUnreferencedNodes(db ethdb.Database, canonical BlockReader, from, to uint64, dryRun bool) (int, error) {
+	if from > to {
+		return 0, fmt.Errorf("invalid block range [%d, %d]", from, to)
+	}
+	if rawdb.ReadStateScheme(db) == rawdb.PathScheme {
+		return 0, errors.New("trie garbage collection is not supported in path scheme")
+	}
+	start := time.Now()
+	referenced, err := markReferencedNodes(db, canonical, from, to)
+	if err != nil {
+		return 0, err
+	}
+	log.Info("Marked referenced trie nodes", "from", from, "to", to, "nodes", len(referenced), "elapsed", common.PrettyDuration(time.Since(start)))
+
+	// Sweep every trie node of the database which is not referenced
+	var (
+		count  int
+		size   common.StorageSize
+		logged = time.Now()
+		batch  = db.NewBatch()
+		iter   = db.NewIterator(nil, nil)
+	)
+	for iter.Next() {
+		key := iter.Key()
+		if !rawdb.IsLegacyTrieNode(key, iter.Value()) {
+			continue
+		}
+		if _, ok := referenced[common.BytesToHash(key)]; ok {
+			continue
+		}
+		count++
+		size += common.StorageSize(len(key) + len(iter.Value()))
+		if time.Since(logged) > 8*time.Second {
+			log.Info("Collecting unreferenced trie nodes", "nodes", count, "size", size, "elapsed", common.PrettyDuration(time.Since(start)))
+			logged = time.Now()
+		}
+		if dryRun {
+			continue
+		}
+		batch.Delete(key)
+
+		// Recreate the iterator after every batch commit in order
+		// to allow the underlying compactor to delete the entries.
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				iter.Release()
+				return 0, err
+			}
+			batch.Reset()
+
+			iter.Release()
+			iter = db.NewIterator(nil, key)
+		}
+	}
+	err = iter.Error()
+	iter.Release()
+	if err != nil {
+		return 0, err
+	}
+	if batch.ValueSize() > 0 {
+		if err := batch.Write(); err != nil {
+			return 0, err
+		}
+	}
+	log.Info("Collected unreferenced trie nodes", "nodes", count, "size", size, "dryrun", dryRun, "elapsed", common.PrettyDuration(time.Since(start)))
+	return count, nil
+}
+
+// markReferencedNodes traverses the states of the canonical blocks in the
+// range [from, to] concurrently, returning the hashes of all the trie nodes
+// they reference. The subtries already marked are not traversed again, the
+// states of consecutive blocks sharing most of their nodes.
+func markReferencedNodes(db ethdb.Database, canonical BlockReader, from, to uint64) (map[common.Hash]struct{}, error) {
+	var (
+		lock       sync.Mutex
+		referenced = make(map[common.Hash]struct{})
+		tdb        = triedb.NewDatabase(db, triedb.HashDefaults)
+	)
+	defer tdb.Close()
+
+	// mark adds the node to the referenced set, reporting whether it's new.
+	mark := func(hash common.Hash) bool {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if _, ok := referenced[hash]; ok {
+			return false
+		}
+		referenced[hash] = struct{}{}
+		return true
+	}
+	// traverse marks the nodes of the trie, invoking the callback on its leaves.
+	traverse := func(id *trie.ID, onLeaf func(it trie.NodeIterator) error) error {
+		t, err := trie.New(id, tdb)
+		if err != nil {
+			return err
+		}
+		it, err := t.NodeIterator(nil)
+		if err != nil {
+			return err
+		}
+		for descend := true; it.Next(descend); {
+			// Embedded nodes don't have hash, they are always traversed.
+			descend = true
+			if hash := it.Hash(); hash != (common.Hash{}) {
+				descend = mark(hash)
+			}
+			if it.Leaf() && onLeaf != nil {
+				if err := onLeaf(it); err != nil {
+					return err
+				}
+			}
+		}
+		return it.Error()
+	}
+	var (
+		group errgroup.Group
+		roots = make(chan common.Hash)
+	)
+	for i := 0; i < runtime.NumCPU(); i++ {
+		group.Go(func() error {
+			for root := range roots {
+				err := traverse(trie.StateTrieID(root), func(it trie.NodeIterator) error {
+					var acc types.StateAccount
+					if err := rlp.DecodeBytes(it.LeafBlob(), &acc); err != nil {
+						return err
+					}
+					if acc.Root == types.EmptyRootHash {
+						return nil
+					}
+					return traverse(trie.StorageTrieID(root, common.BytesToHash(it.LeafKey()), acc.Root), nil)
+				})
+				if err != nil {
+					// Drain the remaining roots to release the feeder.
+					for range roots {
+					}
+					return fmt.Errorf("state %x: %w", root, err)
+				}
+			}
+			return nil
+		})
+	}
+	// Feed the state roots of the range, skipping the unavailable ones
+	var err error
+	for number := from; number <= to; number++ {
+		header := canonical.GetHeaderByNumber(number)
+		if header == nil {
+			err = fmt.Errorf("canonical header #%d not found", number)
+			break
+		}
+		if header.Root == types.EmptyRootHash {
+			continue
+		}
+		if !rawdb.HasLegacyTrieNode(db, header.Root) {
+			log.Debug("Skipping unavailable state", "number", number, "root", header.Root)
+			continue
+		}
+		roots <- header.Root
+	}
+	close(roots)
+
+	if gerr := group.Wait(); gerr != nil {
+		return nil, gerr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return referenced, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package pruner
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/triedb"
+)
+
+// Tests that the trie nodes of the states rolled back are collected, while the
+// states of the canonical chain are left intact.
+func TestGCUnreferencedNodes(t *testing.T) {
+	var (
+		db    = rawdb.NewMemoryDatabase()
+		gspec = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{common.HexToAddress("0xaa"): {Balance: big.NewInt(1)}},
+		}
+	)
+	// Reward a distinct coinbase in every block to grow the state
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 100, func(i int, gen *core.BlockGen) {
+		gen.SetCoinbase(common.BigToAddress(big.NewInt(int64(i + 1))))
+	})
+	config := core.DefaultConfig().WithArchive(true)
+	config.SnapshotLimit = 0
+
+	chain, err := core.NewBlockChain(db, gspec, ethash.NewFaker(), config)
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	if err := chain.SetHead(90); err != nil {
+		t.Fatalf("failed to roll back chain: %v", err)
+	}
+	chain.Stop()
+
+	for _, block := range blocks[90:] {
+		if !rawdb.HasLegacyTrieNode(db, block.Root()) {
+			t.Fatalf("state of block #%d missing before collection", block.NumberU64())
+		}
+	}
+	// Nothing is deleted in dry-run mode
+	count, err := CountUnreferencedNodes(db, chain, 0, 90)
+	if err != nil {
+		t.Fatalf("failed to count unreferenced nodes: %v", err)
+	}
+	if count == 0 {
+		t.Fatal("no unreferenced nodes found")
+	}
+	deleted, err := GCUnreferencedNodes(db, chain, 0, 90)
+	if err != nil {
+		t.Fatalf("failed to collect unreferenced nodes: %v", err)
+	}
+	if deleted != count {
+		t.Errorf("deleted nodes mismatch: have %d, want %d", deleted, count)
+	}
+	// The rolled back states are gone, the canonical ones are still complete
+	sdb := state.NewDatabase(triedb.NewDatabase(db, triedb.HashDefaults), nil)
+	for _, block := range blocks[90:] {
+		if _, err := state.New(block.Root(), sdb); err == nil {
+			t.Errorf("state of rolled back block #%d still accessible", block.NumberU64())
+		}
+	}
+	for _, block := range blocks[:90] {
+		statedb, err := state.New(block.Root(), sdb)
+		if err != nil {
+			t.Fatalf("state of block #%d not accessible: %v", block.NumberU64(), err)
+		}
+		for i := uint64(1); i <= block.NumberU64(); i++ {
+			if statedb.GetBalance(common.BigToAddress(new(big.Int).SetUint64(i))).IsZero() {
+				t.Fatalf("state of block #%d: missing reward of block #%d", block.NumberU64(), i)
+			}
+		}
+	}
+	// Another collection finds nothing left to delete
+	if deleted, err := GCUnreferencedNodes(db, chain, 0, 90); err != nil || deleted != 0 {
+		t.Errorf("second collection: have %d, %v, want 0, nil", deleted, err)
+	}
+}
@@ -219,8 +219,9 @@ func (r *flatReader) Storage(addr common.Address, key common.Hash) (common.Hash,
 //
 // trieReader is safe for concurrent read.
 type trieReader struct {
-	root common.Hash      // State root which uniquely represent a state
-	db   *triedb.Database // Database for loading trie
+	root  common.Hash       // State root which uniquely represent a state
+	db    *triedb.Database  // Database for loading trie
+	paths *AccountPathCache // Cache of the hot account lookups, nil if disabled
 
 	// Main trie, resolved in constructor. Note either the Merkle-Patricia-tree
 	// or Verkle-tree is not safe for concurrent read.
@@ -233,7 +234,7 @@ type trieReader struct {
 
 // trieReader constructs a trie reader of the specific state. An error will be
 // returned if the associated trie specified by root is not existent.
-func newTrieReader(root common.Hash, db *triedb.Database, cache *utils.PointCache) (*trieReader, error) {
+func newTrieReader(root common.Hash, db *triedb.Database, cache *utils.PointCache, paths *AccountPathCache) (*trieReader, error) {
 	var (
 		tr  Trie
 		err error
@@ -246,9 +247,14 @@ func newTrieReader(root common.Hash, db *triedb.Database, cache *utils.PointCach
 	if err != nil {
 		return nil, err
 	}
+	// The lookups of the verkle tree are not cached
+	if db.IsVerkle() {
+		paths = nil
+	}
 	return &trieReader{
 		root:     root,
 		db:       db,
+		paths:    paths,
 		mainTrie: tr,
 		subRoots: make(map[common.Address]common.Hash),
 		subTries: make(map[common.Address]Trie),
@@ -257,9 +263,22 @@ func newTrieReader(root common.Hash, db *triedb.Database, cache *utils.PointCach
 
 // account is the inner version of Account and assumes the r.lock is already held.
 func (r *trieReader) account(addr common.Address) (*types.StateAccount, error) {
-	account, err := r.mainTrie.GetAccount(addr)
-	if err != nil {
-		return nil, err
+	var (
+		account *types.StateAccount
+		cached  bool
+		err     error
+	)
+	if r.paths != nil {
+		account, cached = r.paths.Get(r.root, addr)
+	}
+	if !cached {
+		account, err = r.mainTrie.GetAccount(addr)
+		if err != nil {
+			return nil, err
+		}
+		if r.paths != nil {
+			r.paths.Add(r.root, addr, account)
+		}
 	}
 	if account == nil {
 		r.subRoots[addr] = types.EmptyRootHash
@@ -550,6 +550,10 @@ func (s *stateObject) CodeSize() int {
 
 func (s *stateObject) SetCode(codeHash common.Hash, code []byte) (prev []byte) {
 	prev = slices.Clone(s.code)
+	// If the new code is the same as old, skip the journal entry
+	if codeHash == common.BytesToHash(s.data.CodeHash) {
+		return prev
+	}
 	s.db.journal.setCode(s.address, prev)
 	s.setCode(codeHash, code)
 	return prev
@@ -562,6 +566,10 @@ func (s *stateObject) setCode(codeHash common.Hash, code []byte) {
 }
 
 func (s *stateObject) SetNonce(nonce uint64) {
+	// If the new nonce is the same as old, skip the journal entry
+	if nonce == s.data.Nonce {
+		return
+	}
 	s.db.journal.nonceChange(s.address, s.data.Nonce)
 	s.setNonce(nonce)
 }
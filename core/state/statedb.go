@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"math/big"
 	"slices"
 	"sync"
 	"sync/atomic"
@@ -379,6 +380,12 @@ func (s *StateDB) GetState(addr common.Address, hash common.Hash) common.Hash {
 	return common.Hash{}
 }
 
+// GetL1FeeParams retrieves the L1 data fee parameters currently held by the
+// given L1 fee oracle.
+func (s *StateDB) GetL1FeeParams(oracle common.Address) (baseFee, scalar, overhead *big.Int) {
+	return types.ReadL1FeeParams(s, oracle)
+}
+
 // GetCommittedState retrieves the value associated with the specific key
 // without any mutations caused in the current execution.
 func (s *StateDB) GetCommittedState(addr common.Address, hash common.Hash) common.Hash {
@@ -421,6 +428,12 @@ func (s *StateDB) AddBalance(addr common.Address, amount *uint256.Int, reason tr
 	return stateObject.AddBalance(amount)
 }
 
+// MintBalance adds the amount minted by a deposit transaction to the account
+// associated with addr.
+func (s *StateDB) MintBalance(addr common.Address, amount *uint256.Int) {
+	s.AddBalance(addr, amount, tracing.BalanceMint)
+}
+
 // SubBalance subtracts amount from the account associated with addr.
 func (s *StateDB) SubBalance(addr common.Address, amount *uint256.Int, reason tracing.BalanceChangeReason) uint256.Int {
 	stateObject := s.getOrNewStateObject(addr)
@@ -36,6 +36,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/ethereum/go-ethereum/trie/trienode"
@@ -1197,6 +1198,58 @@ func TestStateDBAccessList(t *testing.T) {
 	}
 }
 
+// Tests that Prepare warms the sender, the destination, the precompiles and
+// the access list entries unconditionally, the destination being a precompile
+// or not, and the coinbase from Shanghai on.
+func TestStatePrepare(t *testing.T) {
+	var (
+		sender     = common.HexToAddress("0xaa")
+		coinbase   = common.HexToAddress("0xcc")
+		contract   = common.HexToAddress("0xdd")
+		precompile = common.BytesToAddress([]byte{0x04})
+		slot       = common.HexToHash("0x01")
+		rules      = params.Rules{IsBerlin: true, IsEIP2929: true}
+		list       = types.AccessList{{Address: contract, StorageKeys: []common.Hash{slot}}}
+		state, _   = New(types.EmptyRootHash, NewDatabaseForTesting())
+	)
+	precompiles := []common.Address{common.BytesToAddress([]byte{0x01}), precompile}
+
+	tests := []struct {
+		name  string
+		rules params.Rules
+		dst   *common.Address
+		list  types.AccessList
+		warm  []common.Address
+		cold  []common.Address
+	}{
+		{"call", rules, &contract, nil, []common.Address{sender, contract, precompiles[0], precompile}, []common.Address{coinbase}},
+		{"precompile-call", rules, &precompile, nil, []common.Address{sender, precompiles[0], precompile}, []common.Address{contract, coinbase}},
+		{"create", rules, nil, nil, []common.Address{sender, precompiles[0], precompile}, []common.Address{contract, coinbase}},
+		{"access-list", rules, &precompile, list, []common.Address{sender, contract, precompile}, []common.Address{coinbase}},
+		{"shanghai", params.Rules{IsBerlin: true, IsEIP2929: true, IsShanghai: true}, &precompile, nil, []common.Address{sender, coinbase, precompile}, []common.Address{contract}},
+	}
+	for _, tt := range tests {
+		// Leftovers from a previous transaction are cleared
+		state.AddAddressToAccessList(common.HexToAddress("0xee"))
+
+		state.Prepare(tt.rules, sender, coinbase, tt.dst, precompiles, tt.list)
+		for _, addr := range tt.warm {
+			if !state.AddressInAccessList(addr) {
+				t.Errorf("%s: address %x cold", tt.name, addr)
+			}
+		}
+		for _, addr := range append(tt.cold, common.HexToAddress("0xee")) {
+			if state.AddressInAccessList(addr) {
+				t.Errorf("%s: address %x warm", tt.name, addr)
+			}
+		}
+		_, warm := state.SlotInAccessList(contract, slot)
+		if want := tt.list != nil; warm != want {
+			t.Errorf("%s: slot warmness mismatch: have %v, want %v", tt.name, warm, want)
+		}
+	}
+}
+
 // Tests that account and storage tries are flushed in the correct order and that
 // no data loss occurs.
 func TestFlushOrderDataLoss(t *testing.T) {
@@ -1368,3 +1421,181 @@ func TestStorageDirtiness(t *testing.T) {
 	state.RevertToSnapshot(snap)
 	checkDirty(common.Hash{0x1}, common.Hash{0x1}, true)
 }
+
+// TestStateDatabaseConcurrentSnapshotRollback tests that state instances opened
+// on the same database can be modified, snapshotted and reverted concurrently.
+// A StateDB itself is not safe for concurrent use, so every goroutine operates
+// on its own instance, checking it against a model of the expected state.
+func TestStateDatabaseConcurrentSnapshotRollback(t *testing.T) {
+	const (
+		accounts = 8
+		slots    = 16
+		workers  = 10
+		ops      = 1000
+	)
+	var (
+		db       = NewDatabaseForTesting()
+		base, _  = New(types.EmptyRootHash, db)
+		addrs    = make([]common.Address, accounts)
+		balances = make([]uint64, accounts)
+	)
+	for i := range addrs {
+		addrs[i] = common.BytesToAddress([]byte{byte(i + 1)})
+		balances[i] = 1000
+		base.SetBalance(addrs[i], uint256.NewInt(balances[i]), tracing.BalanceChangeUnspecified)
+		for j := 0; j < slots; j++ {
+			base.SetState(addrs[i], common.Hash{byte(j)}, common.Hash{byte(i), byte(j)})
+		}
+	}
+	root, err := base.Commit(0, false, false)
+	if err != nil {
+		t.Fatalf("failed to commit base state: %v", err)
+	}
+	// model is the expected content of a state instance
+	type model struct {
+		balances []uint64
+		storage  map[common.Address]map[common.Hash]common.Hash
+	}
+	initial := func() *model {
+		m := &model{balances: slices.Clone(balances), storage: make(map[common.Address]map[common.Hash]common.Hash)}
+		for i, addr := range addrs {
+			m.storage[addr] = make(map[common.Hash]common.Hash)
+			for j := 0; j < slots; j++ {
+				m.storage[addr][common.Hash{byte(j)}] = common.Hash{byte(i), byte(j)}
+			}
+		}
+		return m
+	}
+	clone := func(m *model) *model {
+		c := &model{balances: slices.Clone(m.balances), storage: make(map[common.Address]map[common.Hash]common.Hash)}
+		for addr, storage := range m.storage {
+			c.storage[addr] = maps.Clone(storage)
+		}
+		return c
+	}
+	check := func(state *StateDB, m *model) error {
+		for i, addr := range addrs {
+			if have := state.GetBalance(addr); have.Uint64() != m.balances[i] {
+				return fmt.Errorf("account %x: balance mismatch: have %v, want %d", addr, have, m.balances[i])
+			}
+			for key, want := range m.storage[addr] {
+				if have := state.GetState(addr, key); have != want {
+					return fmt.Errorf("account %x slot %x: value mismatch: have %x, want %x", addr, key, have, want)
+				}
+			}
+		}
+		return nil
+	}
+	errs := make(chan error, workers)
+	for w := 0; w < workers; w++ {
+		go func(seed int64) {
+			state, err := New(root, db)
+			if err != nil {
+				errs <- err
+				return
+			}
+			var (
+				rng     = rand.New(rand.NewSource(seed))
+				current = initial()
+				snaps   []int
+				saved   []*model
+			)
+			first := state.Snapshot()
+			for i := 0; i < ops; i++ {
+				n := rng.Intn(accounts)
+				addr := addrs[n]
+				switch rng.Intn(5) {
+				case 0:
+					key, value := common.Hash{byte(rng.Intn(slots))}, common.Hash{byte(rng.Intn(256)), 0xff}
+					state.SetState(addr, key, value)
+					current.storage[addr][key] = value
+				case 1:
+					// Never drive the balances negative
+					amount := uint64(rng.Intn(100))
+					if amount > current.balances[n] {
+						amount = current.balances[n]
+					}
+					state.SubBalance(addr, uint256.NewInt(amount), tracing.BalanceChangeUnspecified)
+					current.balances[n] -= amount
+					state.AddBalance(addrs[(n+1)%accounts], uint256.NewInt(amount), tracing.BalanceChangeUnspecified)
+					current.balances[(n+1)%accounts] += amount
+				case 2:
+					snaps = append(snaps, state.Snapshot())
+					saved = append(saved, clone(current))
+				case 3:
+					if len(snaps) == 0 {
+						continue
+					}
+					idx := rng.Intn(len(snaps))
+					state.RevertToSnapshot(snaps[idx])
+					current = saved[idx]
+					snaps, saved = snaps[:idx], saved[:idx]
+				case 4:
+					key := common.Hash{byte(rng.Intn(slots))}
+					if have, want := state.GetState(addr, key), current.storage[addr][key]; have != want {
+						errs <- fmt.Errorf("worker %d: account %x slot %x: value mismatch: have %x, want %x", seed, addr, key, have, want)
+						return
+					}
+				}
+			}
+			if err := check(state, current); err != nil {
+				errs <- fmt.Errorf("worker %d: %v", seed, err)
+				return
+			}
+			// Only the live snapshots remain valid, and reverting to the first
+			// snapshot restores the base state
+			if have, want := len(state.journal.validRevisions), len(snaps)+1; have != want {
+				errs <- fmt.Errorf("worker %d: valid revisions mismatch: have %d, want %d", seed, have, want)
+				return
+			}
+			state.RevertToSnapshot(first)
+			if len(state.journal.validRevisions) != 0 {
+				errs <- fmt.Errorf("worker %d: dangling revisions after full revert: %d", seed, len(state.journal.validRevisions))
+				return
+			}
+			if err := check(state, initial()); err != nil {
+				errs <- fmt.Errorf("worker %d: after full revert: %v", seed, err)
+				return
+			}
+			errs <- nil
+		}(int64(w))
+	}
+	for w := 0; w < workers; w++ {
+		if err := <-errs; err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+// BenchmarkNoopWrites measures the journal growth caused by writes which don't
+// change the state, which should not be journaled at all.
+func BenchmarkNoopWrites(b *testing.B) {
+	var (
+		addr = common.HexToAddress("0xaaaa")
+		code = []byte{0x60, 0x00}
+	)
+	tests := []struct {
+		name  string
+		write func(state *StateDB)
+	}{
+		{"SetState", func(state *StateDB) { state.SetState(addr, common.Hash{0x01}, common.Hash{0x02}) }},
+		{"SetNonce", func(state *StateDB) { state.SetNonce(addr, 1, tracing.NonceChangeUnspecified) }},
+		{"SetCode", func(state *StateDB) { state.SetCode(addr, code) }},
+	}
+	for _, tt := range tests {
+		b.Run(tt.name, func(b *testing.B) {
+			state, _ := New(types.EmptyRootHash, NewDatabaseForTesting())
+			tt.write(state)
+			state.Finalise(false)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for j := 0; j < 10_000; j++ {
+					tt.write(state)
+				}
+			}
+			b.ReportMetric(float64(state.journal.length())/float64(b.N), "entries/op")
+		})
+	}
+}
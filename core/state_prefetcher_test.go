@@ -0,0 +1,89 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that prefetching, interrupted or not, leaves the state it runs against
+// untouched, the transactions being executed on copies.
+func TestStatePrefetcher(t *testing.T) {
+	gspec := &Genesis{
+		Config: params.TestChainConfig,
+		Alloc:  make(types.GenesisAlloc),
+	}
+	keys, addrs := ringKeys[:16], ringAddrs[:16]
+	for _, addr := range addrs {
+		gspec.Alloc[addr] = types.Account{Balance: big.NewInt(params.Ether)}
+	}
+	_, blocks, _ := GenerateChainWithGenesis(gspec, ethash.NewFaker(), 1, func(i int, gen *BlockGen) {
+		for j, key := range keys {
+			to := addrs[(j+1)%len(addrs)]
+			tx, _ := types.SignNewTx(key, gen.Signer(), &types.LegacyTx{
+				Nonce:    gen.TxNonce(addrs[j]),
+				To:       &to,
+				Value:    big.NewInt(1),
+				Gas:      params.TxGas,
+				GasPrice: gen.header.BaseFee,
+			})
+			gen.AddTx(tx)
+		}
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), nil)
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	root := chain.Genesis().Root()
+	statedb, err := chain.StateAt(root)
+	if err != nil {
+		t.Fatalf("failed to open state: %v", err)
+	}
+	var (
+		prefetcher = newStatePrefetcher(gspec.Config, chain.hc)
+		interrupt  atomic.Bool
+	)
+	interrupt.Store(true)
+	prefetcher.Prefetch(blocks[0], statedb, vm.Config{}, &interrupt)
+	prefetcher.Prefetch(blocks[0], statedb, vm.Config{}, nil)
+
+	if have := statedb.IntermediateRoot(true); have != root {
+		t.Fatalf("state modified by prefetching: have %x, want %x", have, root)
+	}
+	for _, addr := range addrs {
+		if nonce := statedb.GetNonce(addr); nonce != 0 {
+			t.Errorf("nonce of %x modified: have %d, want 0", addr, nonce)
+		}
+	}
+	// The block is still processed correctly on top of the prefetched state
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert block: %v", err)
+	}
+	if have := chain.CurrentBlock().Hash(); have != blocks[0].Hash() {
+		t.Errorf("head mismatch: have %x, want %x", have, blocks[0].Hash())
+	}
+}
@@ -167,6 +167,35 @@ func ApplyTransactionWithEVM(msg *Message, gp *GasPool, statedb *state.StateDB,
 	return MakeReceipt(evm, result, statedb, blockNumber, blockHash, blockTime, tx, *usedGas, root), nil
 }
 
+// SystemCallProcessor applies the OP-Stack L1 info deposit opening every block
+// directly to the L1 fee oracle storage, without going through the EVM.
+type SystemCallProcessor struct {
+	config *params.ChainConfig // Chain configuration options
+}
+
+// NewSystemCallProcessor initialises a new SystemCallProcessor.
+func NewSystemCallProcessor(config *params.ChainConfig) *SystemCallProcessor {
+	return &SystemCallProcessor{config: config}
+}
+
+// Process writes the L1 block info carried by the message into the storage of
+// the L1 fee oracle, using the encoding of the fork active at the block time.
+// Messages other than the L1 info deposit are ignored.
+func (p *SystemCallProcessor) Process(msg *Message, blockTime uint64, statedb vm.StateDB) error {
+	if !p.config.IsOptimism() || !msg.IsDepositTx || msg.From != params.L1InfoDepositerAddress {
+		return nil
+	}
+	info, err := types.ParseL1InfoDepositData(p.config, blockTime, msg.Data)
+	if err != nil {
+		return fmt.Errorf("invalid L1 info deposit: %w", err)
+	}
+	oracle := p.config.L1FeeOracle()
+	for slot, value := range info.OracleStorage() {
+		statedb.SetState(oracle, slot, value)
+	}
+	return nil
+}
+
 // MakeReceipt generates the receipt object for a transaction given its execution result.
 func MakeReceipt(evm *vm.EVM, result *ExecutionResult, statedb *state.StateDB, blockNumber *big.Int, blockHash common.Hash, blockTime uint64, tx *types.Transaction, usedGas uint64, root []byte) *types.Receipt {
 	// Create a new receipt for the transaction, storing the intermediate root and gas used
@@ -275,7 +304,7 @@ func ProcessWithdrawalQueue(requests *[][]byte, evm *vm.EVM) error {
 // ProcessConsolidationQueue calls the EIP-7251 consolidation queue contract.
 // It returns the opaque request data returned by the contract.
 func ProcessConsolidationQueue(requests *[][]byte, evm *vm.EVM) error {
-	return processRequestsSystemCall(requests, evm, 0x02, params.ConsolidationQueueAddress)
+	return processRequestsSystemCall(requests, evm, types.ConsolidationRequestType, params.ConsolidationQueueAddress)
 }
 
 func processRequestsSystemCall(requests *[][]byte, evm *vm.EVM, requestType byte, addr common.Address) error {
@@ -17,7 +17,9 @@
 package core
 
 import (
+	"bytes"
 	"crypto/ecdsa"
+	"errors"
 	"math"
 	"math/big"
 	"testing"
@@ -29,7 +31,11 @@ import (
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
 	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/program"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
@@ -383,7 +389,7 @@ func GenerateBadBlock(parent *types.Block, engine consensus.Engine, txs types.Tr
 		UncleHash:  types.EmptyUncleHash,
 	}
 	if config.IsLondon(header.Number) {
-		header.BaseFee = eip1559.CalcBaseFee(config, parent.Header())
+		header.BaseFee = eip1559.CalcBaseFee(config, parent.Header(), header.Time)
 	}
 	if config.IsShanghai(header.Number, header.Time) {
 		header.WithdrawalsHash = &types.EmptyWithdrawalsHash
@@ -422,3 +428,594 @@ func GenerateBadBlock(parent *types.Block, engine consensus.Engine, txs types.Tr
 	}
 	return types.NewBlock(header, body, receipts, trie.NewStackTrie(nil))
 }
+
+// TestHistoryStorageServeWindow checks that the EIP-2935 history contract serves
+// parent block hashes far beyond the 256 block window of the BLOCKHASH opcode,
+// up to the full serve window.
+func TestHistoryStorageServeWindow(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long chain generation in short mode")
+	}
+	var (
+		engine = beacon.New(ethash.NewFaker())
+		gspec  = &Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				params.HistoryStorageAddress: {Nonce: 1, Code: params.HistoryStorageCode, Balance: common.Big0},
+			},
+		}
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, int(params.HistoryServeWindow)+100, nil)
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	head := chain.CurrentBlock()
+	statedb, err := chain.State()
+	if err != nil {
+		t.Fatalf("failed to retrieve head state: %v", err)
+	}
+	query := func(number uint64) ([]byte, error) {
+		evm := vm.NewEVM(NewEVMBlockContext(head, chain, nil), statedb.Copy(), chain.Config(), vm.Config{})
+		input := common.BigToHash(new(big.Int).SetUint64(number))
+		ret, _, err := evm.Call(common.Address{}, params.HistoryStorageAddress, input.Bytes(), 100_000, new(uint256.Int))
+		return ret, err
+	}
+	for _, distance := range []uint64{1, 256, 8000, params.HistoryServeWindow - 1} {
+		number := head.Number.Uint64() - distance
+		ret, err := query(number)
+		if err != nil {
+			t.Fatalf("block %d: history query failed: %v", number, err)
+		}
+		if have, want := common.BytesToHash(ret), chain.GetHeaderByNumber(number).Hash(); have != want {
+			t.Fatalf("block %d: hash mismatch: have %x, want %x", number, have, want)
+		}
+	}
+	// Hashes evicted from the ring buffer must not be served
+	if _, err := query(head.Number.Uint64() - params.HistoryServeWindow); err == nil {
+		t.Fatal("expected query outside of the serve window to fail")
+	}
+}
+
+// TestHistoryStorageBeforeTransactions checks that the parent block hash is
+// stored in the EIP-2935 history contract before the transactions of the block
+// are executed, so they can already query it.
+func TestHistoryStorageBeforeTransactions(t *testing.T) {
+	var (
+		engine = beacon.New(ethash.NewFaker())
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender = crypto.PubkeyToAddress(key.PublicKey)
+		reader = common.HexToAddress("0xbbbb")
+
+		// Stores the hash of the parent block, as served by the history
+		// contract, in slot 0
+		code = program.New().Push(1).Op(vm.NUMBER, vm.SUB).Push(0).Op(vm.MSTORE).
+			StaticCall(nil, params.HistoryStorageAddress, 0, 32, 0, 32).Op(vm.POP).
+			Push(0).Op(vm.MLOAD).Push(0).Op(vm.SSTORE)
+
+		gspec = &Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				sender:                       {Balance: big.NewInt(params.Ether)},
+				reader:                       {Code: code.Bytes()},
+				params.HistoryStorageAddress: {Nonce: 1, Code: params.HistoryStorageCode, Balance: common.Big0},
+			},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _ := GenerateChainWithGenesis(gspec, engine, 2, func(i int, b *BlockGen) {
+		if i == 1 {
+			b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+				Nonce:    0,
+				To:       &reader,
+				Gas:      100_000,
+				GasPrice: b.BaseFee(),
+			}))
+		}
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	statedb, err := chain.State()
+	if err != nil {
+		t.Fatalf("failed to retrieve head state: %v", err)
+	}
+	if have, want := statedb.GetState(reader, common.Hash{}), blocks[0].Hash(); have != want {
+		t.Fatalf("parent hash seen by the transaction mismatch: have %x, want %x", have, want)
+	}
+}
+
+// TestSystemCallProcessorL1Info tests that the L1 info deposit is applied to the
+// storage of the L1 fee oracle, for both the Bedrock and Ecotone encodings.
+func TestSystemCallProcessorL1Info(t *testing.T) {
+	config := *params.TestChainConfig
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+	config.EcotoneTime = u64(100)
+	oracle := config.L1FeeOracle()
+
+	deposit := func(info *types.L1BlockInfo, from common.Address) *Message {
+		data, err := types.EncodeL1InfoDepositData(info)
+		if err != nil {
+			t.Fatalf("failed to encode L1 info: %v", err)
+		}
+		tx := types.NewTx(&types.OptimismDepositTx{
+			From:  from,
+			To:    &oracle,
+			Value: new(big.Int),
+			Gas:   1_000_000,
+			Data:  data,
+		})
+		msg, err := TransactionToMessage(tx, types.LatestSigner(&config), nil)
+		if err != nil {
+			t.Fatalf("failed to convert deposit to message: %v", err)
+		}
+		return msg
+	}
+	bedrock := &types.L1BlockInfo{
+		Number:         100,
+		Time:           1700000000,
+		BaseFee:        big.NewInt(7_000_000_000),
+		BlockHash:      common.HexToHash("0x1234"),
+		SequenceNumber: 3,
+		BatcherAddr:    common.HexToAddress("0xba7c4e5"),
+		L1FeeOverhead:  common.BigToHash(big.NewInt(188)),
+		L1FeeScalar:    common.BigToHash(big.NewInt(684_000)),
+	}
+	ecotone := &types.L1BlockInfo{
+		Number:            101,
+		Time:              1700000012,
+		BaseFee:           big.NewInt(8_000_000_000),
+		BlockHash:         common.HexToHash("0x5678"),
+		SequenceNumber:    0,
+		BatcherAddr:       common.HexToAddress("0xba7c4e5"),
+		EcotoneVersion:    true,
+		BlobBaseFee:       big.NewInt(1),
+		BaseFeeScalar:     1368,
+		BlobBaseFeeScalar: 810949,
+	}
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	processor := NewSystemCallProcessor(&config)
+
+	// Deposits not sent by the L1 info depositer must leave the oracle alone
+	if err := processor.Process(deposit(bedrock, common.HexToAddress("0xdead")), 0, statedb); err != nil {
+		t.Fatalf("failed to process user deposit: %v", err)
+	}
+	if have := statedb.GetState(oracle, types.L1BaseFeeSlot); have != (common.Hash{}) {
+		t.Fatalf("user deposit updated the oracle: %x", have)
+	}
+	// Bedrock info updates all the fee parameters
+	if err := processor.Process(deposit(bedrock, params.L1InfoDepositerAddress), 0, statedb); err != nil {
+		t.Fatalf("failed to process bedrock L1 info: %v", err)
+	}
+	want := map[common.Hash]common.Hash{
+		types.L1BlockNumberTimeSlot: common.HexToHash("0x00000000000000000000000000000000000000006553f1000000000000000064"),
+		types.L1BaseFeeSlot:         common.BigToHash(bedrock.BaseFee),
+		types.L1BlockHashSlot:       bedrock.BlockHash,
+		types.L1SequenceNumberSlot:  common.BigToHash(big.NewInt(3)),
+		types.L1BatcherHashSlot:     common.BytesToHash(bedrock.BatcherAddr[:]),
+		types.OverheadSlot:          bedrock.L1FeeOverhead,
+		types.ScalarSlot:            bedrock.L1FeeScalar,
+	}
+	for slot, value := range want {
+		if have := statedb.GetState(oracle, slot); have != value {
+			t.Errorf("bedrock slot %x mismatch: have %x, want %x", slot, have, value)
+		}
+	}
+	baseFee, scalar, overhead := statedb.GetL1FeeParams(oracle)
+	if baseFee.Cmp(bedrock.BaseFee) != 0 || scalar.Uint64() != 684_000 || overhead.Uint64() != 188 {
+		t.Errorf("bedrock fee params mismatch: have %v/%v/%v", baseFee, scalar, overhead)
+	}
+	// Ecotone info packs the scalars next to the sequence number and keeps the
+	// legacy overhead and scalar in place
+	if err := processor.Process(deposit(bedrock, params.L1InfoDepositerAddress), 100, statedb); err == nil {
+		t.Fatal("expected bedrock L1 info to be rejected after ecotone")
+	}
+	if err := processor.Process(deposit(ecotone, params.L1InfoDepositerAddress), 100, statedb); err != nil {
+		t.Fatalf("failed to process ecotone L1 info: %v", err)
+	}
+	want = map[common.Hash]common.Hash{
+		types.L1BlockNumberTimeSlot: common.HexToHash("0x00000000000000000000000000000000000000006553f10c0000000000000065"),
+		types.L1BaseFeeSlot:         common.BigToHash(ecotone.BaseFee),
+		types.L1BlockHashSlot:       ecotone.BlockHash,
+		types.L1SequenceNumberSlot:  common.HexToHash("0x0000000000000000000000000000000000000558000c5fc50000000000000000"),
+		types.L1BatcherHashSlot:     common.BytesToHash(ecotone.BatcherAddr[:]),
+		types.OverheadSlot:          bedrock.L1FeeOverhead,
+		types.ScalarSlot:            bedrock.L1FeeScalar,
+		types.L1BlobBaseFeeSlot:     common.BigToHash(ecotone.BlobBaseFee),
+	}
+	for slot, value := range want {
+		if have := statedb.GetState(oracle, slot); have != value {
+			t.Errorf("ecotone slot %x mismatch: have %x, want %x", slot, have, value)
+		}
+	}
+	// Malformed L1 info must be rejected
+	msg := deposit(bedrock, params.L1InfoDepositerAddress)
+	msg.Data = msg.Data[:len(msg.Data)-1]
+	if err := processor.Process(msg, 0, statedb); err == nil {
+		t.Fatal("expected malformed L1 info to be rejected")
+	}
+}
+
+// TestRegolithDepositGasAccounting tests that deposits use up their whole gas
+// limit before Regolith, with system transactions not accounted for at all, and
+// report their actual gas usage afterwards.
+func TestRegolithDepositGasAccounting(t *testing.T) {
+	config := *params.TestChainConfig
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+	config.RegolithTime = u64(100)
+
+	var (
+		sender    = common.HexToAddress("0x5e1de7")
+		recipient = common.HexToAddress("0xdead")
+	)
+	deposit := func(gas uint64, system bool) *types.Transaction {
+		return types.NewTx(&types.OptimismDepositTx{
+			From:                sender,
+			To:                  &recipient,
+			Value:               new(big.Int),
+			Gas:                 gas,
+			IsSystemTransaction: system,
+		})
+	}
+	apply := func(time uint64, txs ...*types.Transaction) ([]*types.Receipt, *GasPool, uint64, error) {
+		var (
+			statedb, _ = state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+			header     = &types.Header{Number: big.NewInt(1), Time: time, GasLimit: 30_000_000, BaseFee: big.NewInt(params.InitialBaseFee), Difficulty: new(big.Int)}
+			evm        = vm.NewEVM(NewEVMBlockContext(header, nil, new(common.Address)), statedb, &config, vm.Config{})
+			gp         = new(GasPool).AddGas(header.GasLimit)
+			usedGas    uint64
+			receipts   []*types.Receipt
+		)
+		for _, tx := range txs {
+			receipt, err := ApplyTransaction(evm, gp, statedb, header, tx, &usedGas)
+			if err != nil {
+				return nil, nil, 0, err
+			}
+			receipts = append(receipts, receipt)
+		}
+		return receipts, gp, usedGas, nil
+	}
+	// Before Regolith the system transaction is free and the user deposit uses
+	// up its whole gas limit
+	receipts, gp, usedGas, err := apply(99, deposit(1_000_000, true), deposit(100_000, false))
+	if err != nil {
+		t.Fatalf("failed to apply pre-regolith deposits: %v", err)
+	}
+	if receipts[0].GasUsed != 0 || receipts[1].GasUsed != 100_000 {
+		t.Errorf("pre-regolith receipt gas mismatch: have %d/%d, want 0/100000", receipts[0].GasUsed, receipts[1].GasUsed)
+	}
+	if usedGas != 100_000 || gp.Gas() != 30_000_000-100_000 {
+		t.Errorf("pre-regolith block gas mismatch: used %d, pool %d", usedGas, gp.Gas())
+	}
+	// After Regolith every deposit counts for the gas it actually used
+	receipts, gp, usedGas, err = apply(100, deposit(1_000_000, false), deposit(100_000, false))
+	if err != nil {
+		t.Fatalf("failed to apply regolith deposits: %v", err)
+	}
+	if receipts[0].GasUsed != params.TxGas || receipts[1].GasUsed != params.TxGas {
+		t.Errorf("regolith receipt gas mismatch: have %d/%d, want %d", receipts[0].GasUsed, receipts[1].GasUsed, params.TxGas)
+	}
+	if usedGas != 2*params.TxGas || gp.Gas() != 30_000_000-2*params.TxGas {
+		t.Errorf("regolith block gas mismatch: used %d, pool %d", usedGas, gp.Gas())
+	}
+	// System transactions are gone after Regolith
+	if _, _, _, err := apply(100, deposit(1_000_000, true)); !errors.Is(err, ErrSystemTxNotSupported) {
+		t.Fatalf("regolith system transaction error mismatch: have %v, want %v", err, ErrSystemTxNotSupported)
+	}
+}
+
+// TestDepositMint tests that the ether minted by a deposit is credited before
+// the execution, visible to the contracts called by the deposit.
+func TestDepositMint(t *testing.T) {
+	config := *params.TestChainConfig
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+
+	var (
+		from   = common.HexToAddress("0xdeadbeef")
+		caller = common.HexToAddress("0xca11")
+		callee = common.HexToAddress("0xca11ee")
+		mint   = big.NewInt(params.Ether)
+	)
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	// PUSH1 0 PUSH1 0 PUSH1 0 PUSH1 0 PUSH1 0 PUSH20 callee GAS CALL STOP
+	statedb.SetCode(caller, append(append(common.FromHex("0x6000600060006000600073"), callee[:]...), 0x5a, 0xf1, 0x00))
+	// ORIGIN BALANCE PUSH1 0 SSTORE STOP
+	statedb.SetCode(callee, common.FromHex("0x323160005500"))
+
+	tx := types.NewTx(&types.OptimismDepositTx{
+		From:  from,
+		To:    &caller,
+		Mint:  mint,
+		Value: new(big.Int),
+		Gas:   100_000,
+	})
+	msg, err := TransactionToMessage(tx, types.LatestSigner(&config), nil)
+	if err != nil {
+		t.Fatalf("failed to convert deposit to message: %v", err)
+	}
+	header := &types.Header{Number: big.NewInt(1), Difficulty: new(big.Int), BaseFee: new(big.Int)}
+	evm := vm.NewEVM(NewEVMBlockContext(header, nil, &common.Address{}), statedb, &config, vm.Config{})
+	res, err := ApplyMessage(evm, msg, new(GasPool).AddGas(header.GasLimit+tx.Gas()))
+	if err != nil {
+		t.Fatalf("failed to apply deposit: %v", err)
+	}
+	if res.Err != nil {
+		t.Fatalf("deposit execution failed: %v", res.Err)
+	}
+	if have := statedb.GetState(callee, common.Hash{}).Big(); have.Cmp(mint) != 0 {
+		t.Errorf("balance seen by the callee mismatch: have %v, want %v", have, mint)
+	}
+	if have := statedb.GetBalance(from).ToBig(); have.Cmp(mint) != 0 {
+		t.Errorf("sender balance mismatch: have %v, want %v", have, mint)
+	}
+}
+
+// TestDepositGasStipend tests that deposits sending ether to a contract get the
+// deposit stipend if enabled, letting the receive function emit events.
+func TestDepositGasStipend(t *testing.T) {
+	var (
+		from     = common.HexToAddress("0xdeadbeef")
+		contract = common.HexToAddress("0xc0de")
+		value    = big.NewInt(params.GWei)
+	)
+	apply := func(stipend bool) *types.Receipt {
+		t.Helper()
+
+		config := *params.TestChainConfig
+		config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50, DepositGasStipend: stipend}
+		config.RegolithTime = u64(0)
+
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		// CALLVALUE PUSH1 0 MSTORE PUSH1 32 PUSH1 0 LOG0 STOP
+		statedb.SetCode(contract, common.FromHex("0x3460005260206000a000"))
+
+		tx := types.NewTx(&types.OptimismDepositTx{
+			From:  from,
+			To:    &contract,
+			Mint:  value,
+			Value: value,
+			Gas:   params.TxGas,
+		})
+		var (
+			header  = &types.Header{Number: big.NewInt(1), GasLimit: 30_000_000, Difficulty: new(big.Int), BaseFee: new(big.Int)}
+			evm     = vm.NewEVM(NewEVMBlockContext(header, nil, &common.Address{}), statedb, &config, vm.Config{})
+			usedGas uint64
+		)
+		statedb.SetTxContext(tx.Hash(), 0)
+		receipt, err := ApplyTransaction(evm, new(GasPool).AddGas(header.GasLimit), statedb, header, tx, &usedGas)
+		if err != nil {
+			t.Fatalf("failed to apply deposit: %v", err)
+		}
+		return receipt
+	}
+	// Without the stipend the receive function runs out of gas
+	if receipt := apply(false); receipt.Status != types.ReceiptStatusFailed || len(receipt.Logs) != 0 {
+		t.Errorf("deposit without stipend: have status %d, %d logs, want failure", receipt.Status, len(receipt.Logs))
+	}
+	// With it the event is emitted, and the stipend is not accounted as used
+	receipt := apply(true)
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("deposit with stipend failed")
+	}
+	if len(receipt.Logs) != 1 || new(big.Int).SetBytes(receipt.Logs[0].Data).Cmp(value) != 0 {
+		t.Errorf("receive event mismatch: have %v", receipt.Logs)
+	}
+	if receipt.GasUsed != params.TxGas {
+		t.Errorf("gas used mismatch: have %d, want %d", receipt.GasUsed, params.TxGas)
+	}
+}
+
+// TestDepositIntrinsicGas tests that deposits are charged the same intrinsic gas
+// as the regular transactions, data included, so that their execution on L2 is
+// bounded by what was paid for on L1.
+func TestDepositIntrinsicGas(t *testing.T) {
+	config := *params.TestChainConfig
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+	config.RegolithTime = u64(0)
+
+	var (
+		from      = common.HexToAddress("0xdeadbeef")
+		recipient = common.HexToAddress("0xdead")
+		data      = make([]byte, 100)
+	)
+	for i := 40; i < len(data); i++ {
+		data[i] = byte(i)
+	}
+	dataGas := uint64(40*params.TxDataZeroGas + 60*params.TxDataNonZeroGasEIP2028)
+
+	tests := []struct {
+		name string
+		to   *common.Address
+		data []byte
+		want uint64
+	}{
+		{"call", &recipient, nil, params.TxGas},
+		{"call with data", &recipient, data, params.TxGas + dataGas},
+		{"creation", nil, nil, params.TxGasContractCreation},
+		{"creation with data", nil, data, params.TxGasContractCreation + dataGas + 4*params.InitCodeWordGas},
+	}
+	for _, tt := range tests {
+		tx := types.NewTx(&types.OptimismDepositTx{
+			From:  from,
+			To:    tt.to,
+			Value: new(big.Int),
+			Gas:   100_000,
+			Data:  tt.data,
+		})
+		msg, err := TransactionToMessage(tx, types.LatestSigner(&config), nil)
+		if err != nil {
+			t.Fatalf("%s: failed to convert deposit to message: %v", tt.name, err)
+		}
+		gas, err := IntrinsicGas(msg.Data, msg.AccessList, msg.SetCodeAuthorizations, msg.To == nil, true, true, true)
+		if err != nil {
+			t.Fatalf("%s: failed to compute intrinsic gas: %v", tt.name, err)
+		}
+		if gas != tt.want {
+			t.Errorf("%s: intrinsic gas mismatch: have %d, want %d", tt.name, gas, tt.want)
+		}
+	}
+	// A call to an account without code uses up exactly the intrinsic gas
+	tx := types.NewTx(&types.OptimismDepositTx{
+		From:  from,
+		To:    &recipient,
+		Value: new(big.Int),
+		Gas:   100_000,
+		Data:  data,
+	})
+	var (
+		statedb, _ = state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		header     = &types.Header{Number: big.NewInt(1), GasLimit: 30_000_000, Difficulty: new(big.Int), BaseFee: new(big.Int)}
+		evm        = vm.NewEVM(NewEVMBlockContext(header, nil, &common.Address{}), statedb, &config, vm.Config{})
+		usedGas    uint64
+	)
+	receipt, err := ApplyTransaction(evm, new(GasPool).AddGas(header.GasLimit), statedb, header, tx, &usedGas)
+	if err != nil {
+		t.Fatalf("failed to apply deposit: %v", err)
+	}
+	if want := params.TxGas + dataGas; receipt.GasUsed != want {
+		t.Errorf("gas used mismatch: have %d, want %d", receipt.GasUsed, want)
+	}
+}
+
+// Tests that the DIFFICULTY opcode returns the difficulty of pre-merge blocks,
+// and the randomness of the beacon chain as PREVRANDAO (EIP-4399) after it.
+func TestPrevRandao(t *testing.T) {
+	var (
+		contract = common.HexToAddress("0xc0de")
+		mix      = common.HexToHash("0x5eed")
+	)
+	tests := []struct {
+		name   string
+		config *params.ChainConfig
+		diff   *big.Int
+		want   common.Hash
+	}{
+		{"pre-merge", params.TestChainConfig, big.NewInt(131072), common.BigToHash(big.NewInt(131072))},
+		{"post-merge", params.MergedTestChainConfig, new(big.Int), mix},
+	}
+	for _, tt := range tests {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		// DIFFICULTY PUSH1 0 SSTORE STOP
+		statedb.SetCode(contract, common.FromHex("0x4460005500"))
+
+		header := &types.Header{Number: big.NewInt(1), GasLimit: 30_000_000, Difficulty: tt.diff, MixDigest: mix, BaseFee: new(big.Int)}
+		evm := vm.NewEVM(NewEVMBlockContext(header, nil, &common.Address{}), statedb, tt.config, vm.Config{})
+		if _, _, err := evm.Call(common.Address{}, contract, nil, 100000, new(uint256.Int)); err != nil {
+			t.Fatalf("%s: call failed: %v", tt.name, err)
+		}
+		if have := statedb.GetState(contract, common.Hash{}); have != tt.want {
+			t.Errorf("%s: DIFFICULTY mismatch: have %x, want %x", tt.name, have, tt.want)
+		}
+	}
+}
+
+// Tests that the withdrawals of a block are credited to their recipients, with
+// the amounts converted from gwei, and committed to in the header.
+func TestProcessWithdrawals(t *testing.T) {
+	var (
+		engine      = beacon.New(ethash.NewFaker())
+		genesis     = &Genesis{Config: params.MergedTestChainConfig, BaseFee: big.NewInt(params.InitialBaseFee)}
+		withdrawals = []*types.Withdrawal{
+			{Validator: 1, Address: common.Address{0x01}, Amount: 1},
+			{Validator: 2, Address: common.Address{0x02}, Amount: 2_000_000_000},
+			{Validator: 3, Address: common.Address{0x01}, Amount: 3},
+		}
+	)
+	_, blocks, _ := GenerateChainWithGenesis(genesis, engine, 1, func(i int, b *BlockGen) {
+		for _, w := range withdrawals {
+			b.AddWithdrawal(w)
+		}
+	})
+	chain, err := NewBlockChain(rawdb.NewMemoryDatabase(), genesis, engine, DefaultConfig())
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	block := chain.GetBlockByNumber(1)
+	if have := len(block.Withdrawals()); have != len(withdrawals) {
+		t.Fatalf("withdrawal count mismatch: have %d, want %d", have, len(withdrawals))
+	}
+	if have, want := *block.Header().WithdrawalsHash, types.DeriveSha(block.Withdrawals(), trie.NewStackTrie(nil)); have != want {
+		t.Errorf("withdrawals hash mismatch: have %x, want %x", have, want)
+	}
+	statedb, err := chain.State()
+	if err != nil {
+		t.Fatalf("failed to retrieve head state: %v", err)
+	}
+	for addr, gwei := range map[common.Address]uint64{{0x01}: 4, {0x02}: 2_000_000_000} {
+		want := new(big.Int).Mul(new(big.Int).SetUint64(gwei), big.NewInt(params.GWei))
+		if have := statedb.GetBalance(addr).ToBig(); have.Cmp(want) != 0 {
+			t.Errorf("balance of %x mismatch: have %v, want %v", addr, have, want)
+		}
+	}
+}
+
+// Tests that the calldata of a transaction is charged at least the EIP-7623
+// floor price after Prague, and at the standard price before.
+func TestFloorDataGas(t *testing.T) {
+	var (
+		from = common.HexToAddress("0xdeadbeef")
+		to   = common.HexToAddress("0xc0ffee")
+		data = bytes.Repeat([]byte{0xff}, 100)
+
+		// 100 non-zero bytes, 16 gas each pre-Prague and 4 tokens of 10 gas
+		// each for the floor
+		standard = params.TxGas + 100*params.TxDataNonZeroGasEIP2028
+		floor    = params.TxGas + 100*params.TxTokenPerNonZeroByte*params.TxCostFloorPerToken
+	)
+	if have, err := FloorDataGas(data); err != nil || have != floor {
+		t.Fatalf("floor data gas mismatch: have %d, %v, want %d", have, err, floor)
+	}
+	cancun := *params.MergedTestChainConfig
+	cancun.PragueTime, cancun.OsakaTime = nil, nil
+
+	tests := []struct {
+		name    string
+		config  *params.ChainConfig
+		gas     uint64
+		err     error
+		gasUsed uint64
+	}{
+		{"cancun", &cancun, standard, nil, standard},
+		{"prague below floor", params.MergedTestChainConfig, standard, ErrFloorDataGas, 0},
+		{"prague at floor", params.MergedTestChainConfig, floor, nil, floor},
+	}
+	for _, tt := range tests {
+		statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+		statedb.SetBalance(from, uint256.NewInt(params.Ether), tracing.BalanceChangeUnspecified)
+
+		msg := &Message{
+			From:      from,
+			To:        &to,
+			Value:     new(big.Int),
+			GasLimit:  tt.gas,
+			GasPrice:  new(big.Int),
+			GasFeeCap: new(big.Int),
+			GasTipCap: new(big.Int),
+			Data:      data,
+		}
+		header := &types.Header{Number: big.NewInt(1), Difficulty: new(big.Int), BaseFee: new(big.Int), GasLimit: 30_000_000}
+		evm := vm.NewEVM(NewEVMBlockContext(header, nil, &common.Address{}), statedb, tt.config, vm.Config{})
+		res, err := ApplyMessage(evm, msg, new(GasPool).AddGas(header.GasLimit))
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if err == nil && res.UsedGas != tt.gasUsed {
+			t.Errorf("%s: gas used mismatch: have %d, want %d", tt.name, res.UsedGas, tt.gasUsed)
+		}
+	}
+}
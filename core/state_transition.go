@@ -157,6 +157,18 @@ type Message struct {
 	BlobHashes            []common.Hash
 	SetCodeAuthorizations []types.SetCodeAuthorization
 
+	// IsDepositTx marks OP-Stack deposit transactions, which are paid for on L1.
+	// IsSystemTx marks deposits exempt from the block gas accounting before
+	// Regolith. Mint is the amount of ether credited to the sender before
+	// execution.
+	IsDepositTx bool
+	IsSystemTx  bool
+	Mint        *big.Int
+
+	// RollupCostData is the encoded size of the transaction, used to charge
+	// the L1 data fee on OP-Stack chains.
+	RollupCostData types.RollupCostData
+
 	// When SkipNonceChecks is true, the message nonce is not checked against the
 	// account nonce in state.
 	//
@@ -185,6 +197,17 @@ func TransactionToMessage(tx *types.Transaction, s types.Signer, baseFee *big.In
 		SkipFromEOACheck:      false,
 		BlobHashes:            tx.BlobHashes(),
 		BlobGasFeeCap:         tx.BlobGasFeeCap(),
+		RollupCostData:        tx.RollupCostData(),
+	}
+	// Deposits are not signed, the sender is carried by the transaction itself
+	// and the nonce is assigned by the state rather than checked against it.
+	if tx.Type() == types.OptimismDepositTxType {
+		msg.From = tx.From()
+		msg.IsDepositTx = true
+		msg.IsSystemTx = tx.IsSystemTx()
+		msg.Mint = tx.Mint()
+		msg.SkipNonceChecks = true
+		return msg, nil
 	}
 	// If baseFee provided, set gasPrice to effectiveGasPrice.
 	if baseFee != nil {
@@ -205,9 +228,19 @@ func TransactionToMessage(tx *types.Transaction, s types.Signer, baseFee *big.In
 // the gas used (which includes gas refunds) and an error if it failed. An error always
 // indicates a core error meaning that the message would always fail for that particular
 // state and would never be accepted within a block.
+//
+// On OP-Stack chains, the L1 info deposit also updates the L1 fee oracle, so the
+// replays of a block see the same fee parameters as its processing.
 func ApplyMessage(evm *vm.EVM, msg *Message, gp *GasPool) (*ExecutionResult, error) {
 	evm.SetTxContext(NewEVMTxContext(msg))
-	return newStateTransition(evm, msg, gp).execute()
+	result, err := newStateTransition(evm, msg, gp).execute()
+	if err != nil {
+		return nil, err
+	}
+	if err := NewSystemCallProcessor(evm.ChainConfig()).Process(msg, evm.Context.Time, evm.StateDB); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 // stateTransition represents a state transition.
@@ -239,6 +272,7 @@ type stateTransition struct {
 	initialGas   uint64
 	state        vm.StateDB
 	evm          *vm.EVM
+	l1Cost       *big.Int // L1 data fee paid on OP-Stack chains, nil if none
 }
 
 // newStateTransition initialises and returns a new state transition object.
@@ -269,6 +303,11 @@ func (st *stateTransition) buyGas() error {
 	}
 	balanceCheck.Add(balanceCheck, st.msg.Value)
 
+	// On OP-Stack chains the sender also pays for posting the transaction to L1
+	if st.l1Cost = st.rollupL1Cost(); st.l1Cost != nil {
+		mgval.Add(mgval, st.l1Cost)
+		balanceCheck.Add(balanceCheck, st.l1Cost)
+	}
 	if st.evm.ChainConfig().IsCancun(st.evm.Context.BlockNumber, st.evm.Context.Time) {
 		if blobGas := st.blobGasUsed(); blobGas > 0 {
 			// Check that the user has enough funds to cover blobGasUsed * tx.BlobGasFeeCap
@@ -303,9 +342,66 @@ func (st *stateTransition) buyGas() error {
 	return nil
 }
 
+// rollupL1Cost returns the L1 data fee of the message, or nil if there is none
+// to be paid. Deposits are paid for on L1 and gasless calls, which don't pay
+// for the L2 execution either, are exempt as well.
+func (st *stateTransition) rollupL1Cost() *big.Int {
+	msg := st.msg
+	if msg.IsDepositTx {
+		return nil
+	}
+	if st.evm.Config.NoBaseFee && msg.GasFeeCap.Sign() == 0 && msg.GasTipCap.Sign() == 0 {
+		return nil
+	}
+	if l1CostFn := types.NewL1CostFunc(st.evm.ChainConfig(), st.evm.Context.Time, st.state); l1CostFn != nil {
+		return l1CostFn(msg.RollupCostData)
+	}
+	return nil
+}
+
+// depositGasStipend returns the free gas granted to a deposit sending ether to a
+// contract without calldata, allowing its receive function to emit events. The
+// stipend is only granted if enabled by the chain config.
+func (st *stateTransition) depositGasStipend() uint64 {
+	msg := st.msg
+	if !msg.IsDepositTx || msg.To == nil || len(msg.Data) != 0 || msg.Value.Sign() == 0 {
+		return 0
+	}
+	if config := st.evm.ChainConfig().Optimism; config == nil || !config.DepositGasStipend {
+		return 0
+	}
+	if st.state.GetCodeSize(*msg.To) == 0 {
+		return 0
+	}
+	return params.DepositGasStipend
+}
+
+// buyDepositGas grants a deposit its gas limit, which was paid for on L1. The
+// gas is taken from the block gas pool, except for system transactions before
+// Regolith which are not accounted for in the block at all.
+func (st *stateTransition) buyDepositGas() error {
+	if st.msg.IsSystemTx {
+		if st.evm.ChainConfig().IsRegolith(st.evm.Context.Time) {
+			return fmt.Errorf("%w: address %v", ErrSystemTxNotSupported, st.msg.From.Hex())
+		}
+	} else if err := st.gp.SubGas(st.msg.GasLimit); err != nil {
+		return err
+	}
+	if st.evm.Config.Tracer != nil && st.evm.Config.Tracer.OnGasChange != nil {
+		st.evm.Config.Tracer.OnGasChange(0, st.msg.GasLimit, tracing.GasChangeTxInitialBalance)
+	}
+	st.gasRemaining = st.msg.GasLimit
+	st.initialGas = st.msg.GasLimit
+	return nil
+}
+
 func (st *stateTransition) preCheck() error {
-	// Only check transactions that are not fake
+	// Deposits were validated on L1 and carry no fee fields to check
 	msg := st.msg
+	if msg.IsDepositTx {
+		return st.buyDepositGas()
+	}
+	// Only check transactions that are not fake
 	if !msg.SkipNonceChecks {
 		// Make sure this transaction's nonce is correct.
 		stNonce := st.state.GetNonce(msg.From)
@@ -418,6 +514,11 @@ func (st *stateTransition) execute() (*ExecutionResult, error) {
 	// 5. there is no overflow when calculating intrinsic gas
 	// 6. caller has enough balance to cover asset transfer for **topmost** call
 
+	// Deposit transactions mint ether on L2 before anything else is checked,
+	// the minted amount is kept even if the execution fails.
+	if mint := st.msg.Mint; mint != nil && mint.Sign() > 0 {
+		st.state.MintBalance(st.msg.From, uint256.MustFromBig(mint))
+	}
 	// Check clauses 1-3, buy gas if everything is correct
 	if err := st.preCheck(); err != nil {
 		return nil, err
@@ -507,10 +608,28 @@ func (st *stateTransition) execute() (*ExecutionResult, error) {
 			st.state.AddAddressToAccessList(addr)
 		}
 
-		// Execute the transaction's call.
-		ret, st.gasRemaining, vmerr = st.evm.Call(msg.From, st.to(), msg.Data, st.gasRemaining, value)
+		// Execute the transaction's call. The deposit stipend is free, the gas
+		// left unused by the callee is not returned.
+		gas := st.gasRemaining
+		ret, st.gasRemaining, vmerr = st.evm.Call(msg.From, st.to(), msg.Data, gas+st.depositGasStipend(), value)
+		st.gasRemaining = min(st.gasRemaining, gas)
 	}
 
+	// Before Regolith deposits report their whole gas limit as used, matching
+	// the gas taken from the block gas pool, and system transactions none. No
+	// refunds nor fees apply to them.
+	if msg.IsDepositTx && !st.evm.ChainConfig().IsRegolith(st.evm.Context.Time) {
+		gasUsed := msg.GasLimit
+		if msg.IsSystemTx {
+			gasUsed = 0
+		}
+		return &ExecutionResult{
+			UsedGas:    gasUsed,
+			MaxUsedGas: gasUsed,
+			Err:        vmerr,
+			ReturnData: ret,
+		}, nil
+	}
 	// Record the gas used excluding gas refunds. This value represents the actual
 	// gas allowance required to complete execution.
 	peakGasUsed := st.gasUsed()
@@ -532,6 +651,17 @@ func (st *stateTransition) execute() (*ExecutionResult, error) {
 	}
 	st.returnGas()
 
+	// From Regolith on deposits report the gas they actually used. Their gas
+	// price is zero, so nothing is returned to the sender nor paid to the
+	// coinbase.
+	if msg.IsDepositTx {
+		return &ExecutionResult{
+			UsedGas:    st.gasUsed(),
+			MaxUsedGas: peakGasUsed,
+			Err:        vmerr,
+			ReturnData: ret,
+		}, nil
+	}
 	effectiveTip := msg.GasPrice
 	if rules.IsLondon {
 		effectiveTip = new(big.Int).Sub(msg.GasPrice, st.evm.Context.BaseFee)
@@ -552,6 +682,9 @@ func (st *stateTransition) execute() (*ExecutionResult, error) {
 			st.evm.AccessEvents.AddAccount(st.evm.Context.Coinbase, true, math.MaxUint64)
 		}
 	}
+	if st.l1Cost != nil && st.l1Cost.Sign() > 0 {
+		st.state.AddBalance(params.L1FeeVaultAddress, uint256.MustFromBig(st.l1Cost), tracing.BalanceIncreaseRewardTransactionFee)
+	}
 
 	return &ExecutionResult{
 		UsedGas:    st.gasUsed(),
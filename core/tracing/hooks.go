@@ -270,6 +270,9 @@ const (
 	// BalanceChangeRevert is emitted when the balance is reverted back to a previous value due to call failure.
 	// It is only emitted when the tracer has opted in to use the journaling wrapper (WrapWithJournal).
 	BalanceChangeRevert BalanceChangeReason = 15
+
+	// BalanceMint is ether minted on L2 by an OP-Stack deposit transaction.
+	BalanceMint BalanceChangeReason = 16
 )
 
 // GasChangeReason is used to indicate the reason for a gas change, useful
@@ -71,4 +71,13 @@ var (
 	// ErrInflightTxLimitReached is returned when the maximum number of in-flight
 	// transactions is reached for specific accounts.
 	ErrInflightTxLimitReached = errors.New("in-flight transaction limit reached for delegated accounts")
+
+	// ErrIPRateLimited is returned if a transaction received from the network
+	// would exceed the number accepted from the remote IP address it came from.
+	ErrIPRateLimited = errors.New("too many transactions from remote IP")
+
+	// ErrDepositTxRejected is returned if a deposit transaction is submitted to
+	// the pool. Deposits are derived from L1 and inserted by the block builder,
+	// they are never gossiped or submitted by users.
+	ErrDepositTxRejected = errors.New("deposit transactions are not accepted by the pool")
 )
@@ -0,0 +1,104 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// healthMaxPending is the number of pending transactions above which the
+	// pool is reported degraded.
+	healthMaxPending = 50_000
+
+	// healthMaxPendingAge is the age of the oldest pending transaction above
+	// which the pool is reported degraded.
+	healthMaxPendingAge = 10 * time.Minute
+
+	// healthOldestRefresh is the interval after which the oldest pending
+	// transaction is looked up again, instead of scanning on every poll.
+	healthOldestRefresh = 5 * time.Second
+)
+
+// Statuses reported in the health summary of the pool.
+const (
+	HealthStatusHealthy  = "healthy"
+	HealthStatusDegraded = "degraded"
+)
+
+// Health is a summary of the state of the transaction pool, meant to be polled
+// by monitoring tools.
+type Health struct {
+	PendingCount     int    `json:"pendingCount"`
+	QueuedCount      int    `json:"queuedCount"`
+	LocalCount       int    `json:"localCount"`
+	OldestPendingAge string `json:"oldestPendingAge"`
+	BaseFee          string `json:"baseFee,omitempty"`
+	Status           string `json:"status"`
+}
+
+// Health summarizes the state of the pool. The pool is degraded if it has too
+// many pending transactions, or if they are not getting included. The count of
+// the local transactions is left to the caller, the pool doesn't track them.
+func (p *TxPool) Health() *Health {
+	pending, queued := p.Stats()
+
+	oldest := p.oldestPending()
+	var age time.Duration
+	if !oldest.IsZero() {
+		age = time.Since(oldest)
+	}
+	health := &Health{
+		PendingCount:     pending,
+		QueuedCount:      queued,
+		OldestPendingAge: age.Round(time.Second).String(),
+		Status:           HealthStatusHealthy,
+	}
+	if baseFee := p.chain.CurrentBlock().BaseFee; baseFee != nil {
+		gwei := new(big.Float).Quo(new(big.Float).SetInt(baseFee), big.NewFloat(params.GWei))
+		health.BaseFee = gwei.Text('f', -1) + " gwei"
+	}
+	if pending > healthMaxPending || age > healthMaxPendingAge {
+		health.Status = HealthStatusDegraded
+	}
+	return health
+}
+
+// oldestPending returns the arrival time of the oldest pending transaction, or
+// the zero time if there is none. Retrieving the pending transactions is costly,
+// so the result is reused for healthOldestRefresh between polls.
+func (p *TxPool) oldestPending() time.Time {
+	p.healthLock.Lock()
+	defer p.healthLock.Unlock()
+
+	if time.Since(p.healthChecked) < healthOldestRefresh {
+		return p.healthOldest
+	}
+	var oldest time.Time
+	for _, txs := range p.Pending(PendingFilter{}) {
+		for _, tx := range txs {
+			if oldest.IsZero() || tx.Time.Before(oldest) {
+				oldest = tx.Time
+			}
+		}
+	}
+	p.healthOldest, p.healthChecked = oldest, time.Now()
+	return oldest
+}
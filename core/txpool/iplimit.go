@@ -0,0 +1,75 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// ipLimitWindow is the period over which the transactions accepted from a
+// remote IP address are counted.
+const ipLimitWindow = time.Minute
+
+// ipLimiter caps the number of transactions accepted from every remote IP
+// address within a fixed window.
+type ipLimiter struct {
+	limit  uint64
+	now    func() time.Time
+	start  time.Time         // Start of the current window
+	counts map[string]uint64 // Transactions accepted per IP in the current window
+	lock   sync.Mutex
+}
+
+func newIPLimiter(limit uint64) *ipLimiter {
+	return &ipLimiter{
+		limit:  limit,
+		now:    time.Now,
+		counts: make(map[string]uint64),
+	}
+}
+
+// reserve claims up to n slots for the given IP address in the current window,
+// returning the number of slots granted.
+func (l *ipLimiter) reserve(ip net.IP, n int) int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if now := l.now(); now.Sub(l.start) >= ipLimitWindow {
+		clear(l.counts)
+		l.start = now
+	}
+	key := ip.String()
+	granted := min(uint64(n), l.limit-l.counts[key])
+	l.counts[key] += granted
+	return int(granted)
+}
+
+// release gives back slots of the given IP address, claimed for transactions
+// the pool eventually rejected.
+func (l *ipLimiter) release(ip net.IP, n int) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	key := ip.String()
+	if count := l.counts[key]; count > uint64(n) {
+		l.counts[key] = count - uint64(n)
+	} else {
+		delete(l.counts, key)
+	}
+}
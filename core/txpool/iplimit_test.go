@@ -0,0 +1,60 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package txpool
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+// Tests that the limiter grants up to the limit per IP address within a window,
+// and restarts counting in the next one.
+func TestIPLimiter(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now     = time.Unix(1000, 0)
+		limiter = newIPLimiter(1000)
+		spammer = net.IPv4(1, 2, 3, 4)
+	)
+	limiter.now = func() time.Time { return now }
+
+	// Simulate 1001 transactions from the same address, one by one
+	for i := 0; i < 1000; i++ {
+		if granted := limiter.reserve(spammer, 1); granted != 1 {
+			t.Fatalf("transaction %d: granted mismatch: have %d, want 1", i, granted)
+		}
+	}
+	if granted := limiter.reserve(spammer, 1); granted != 0 {
+		t.Fatalf("transaction 1001: granted mismatch: have %d, want 0", granted)
+	}
+	// Other addresses are counted separately
+	if granted := limiter.reserve(net.IPv4(5, 6, 7, 8), 1001); granted != 1000 {
+		t.Fatalf("other address granted mismatch: have %d, want 1000", granted)
+	}
+	// Released slots can be claimed again
+	limiter.release(spammer, 10)
+	if granted := limiter.reserve(spammer, 20); granted != 10 {
+		t.Fatalf("released granted mismatch: have %d, want 10", granted)
+	}
+	// The next window starts from scratch
+	now = now.Add(ipLimitWindow)
+	if granted := limiter.reserve(spammer, 1001); granted != 1000 {
+		t.Fatalf("next window granted mismatch: have %d, want 1000", granted)
+	}
+}
@@ -78,6 +78,7 @@ var (
 
 var (
 	evictionInterval    = time.Minute     // Time interval to check for evictable transactions
+	rebroadcastInterval = time.Minute     // Time interval to check for stuck pending transactions
 	statsReportInterval = 8 * time.Second // Time interval to report transaction pool stats
 )
 
@@ -97,6 +98,8 @@ var (
 
 	// General tx metrics
 	knownTxMeter       = metrics.NewRegisteredMeter("txpool/known", nil)
+	expiredTxMeter     = metrics.NewRegisteredMeter("txpool/expired", nil)
+	rebroadcastCounter = metrics.NewRegisteredCounter("txpool/rebroadcast", nil)
 	validTxMeter       = metrics.NewRegisteredMeter("txpool/valid", nil)
 	invalidTxMeter     = metrics.NewRegisteredMeter("txpool/invalid", nil)
 	underpricedTxMeter = metrics.NewRegisteredMeter("txpool/underpriced", nil)
@@ -116,6 +119,15 @@ var (
 	slotsGauge   = metrics.NewRegisteredGauge("txpool/slots", nil)
 
 	reheapTimer = metrics.NewRegisteredTimer("txpool/reheap", nil)
+
+	// Distributions of the properties of the transactions added to the pool
+	gasPriceHistogram = metrics.NewRegisteredHistogram("txpool/transaction/gas_price_gwei", nil, metrics.NewExpDecaySample(1028, 0.015))
+	gasLimitHistogram = metrics.NewRegisteredHistogram("txpool/transaction/gas_limit", nil, metrics.NewExpDecaySample(1028, 0.015))
+	dataSizeHistogram = metrics.NewRegisteredHistogram("txpool/transaction/data_size_bytes", nil, metrics.NewExpDecaySample(1028, 0.015))
+
+	// pendingDurationTimer measures how long transactions stay pending, from
+	// their arrival until their inclusion or eviction.
+	pendingDurationTimer = metrics.NewRegisteredTimer("txpool/pending_duration", nil)
 )
 
 // BlockChain defines the minimal set of methods needed to back a tx pool with
@@ -149,7 +161,12 @@ type Config struct {
 	AccountQueue uint64 // Maximum number of non-executable transaction slots permitted per account
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
 
-	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
+	Lifetime      time.Duration // Maximum amount of time non-executable transaction are queued
+	DefaultExpiry time.Duration // Maximum amount of time transactions are kept until the head block time (0 = no expiry)
+
+	ReBroadcastAge time.Duration // Age of the pending transactions announced to the peers again (0 = disabled)
+
+	MaxFromIP uint64 // Maximum number of transactions accepted per minute from a single remote IP (0 = no limit)
 }
 
 // DefaultConfig contains the default configurations for the transaction pool.
@@ -230,6 +247,7 @@ type LegacyPool struct {
 	chain       BlockChain
 	gasTip      atomic.Pointer[uint256.Int]
 	txFeed      event.Feed
+	rebroadFeed event.Feed // Feed of the pending transactions announced again
 	signer      types.Signer
 	mu          sync.RWMutex
 
@@ -339,12 +357,14 @@ func (pool *LegacyPool) loop() {
 	var (
 		prevPending, prevQueued, prevStales int
 
-		// Start the stats reporting and transaction eviction tickers
-		report = time.NewTicker(statsReportInterval)
-		evict  = time.NewTicker(evictionInterval)
+		// Start the stats reporting, transaction eviction and re-broadcast tickers
+		report      = time.NewTicker(statsReportInterval)
+		evict       = time.NewTicker(evictionInterval)
+		rebroadcast = time.NewTicker(rebroadcastInterval)
 	)
 	defer report.Stop()
 	defer evict.Stop()
+	defer rebroadcast.Stop()
 
 	// Notify tests that the init phase is done
 	close(pool.initDoneCh)
@@ -380,10 +400,38 @@ func (pool *LegacyPool) loop() {
 				}
 			}
 			pool.mu.Unlock()
+
+		// Handle stuck pending transaction re-broadcast
+		case <-rebroadcast.C:
+			if pool.config.ReBroadcastAge > 0 {
+				pool.rebroadcastStuck()
+			}
 		}
 	}
 }
 
+// rebroadcastStuck announces the pending transactions which entered the pool
+// longer than the re-broadcast age ago again, in case their propagation failed.
+func (pool *LegacyPool) rebroadcastStuck() {
+	var stuck []*types.Transaction
+
+	pool.mu.RLock()
+	for _, list := range pool.pending {
+		for _, tx := range list.Flatten() {
+			if time.Since(tx.Time()) > pool.config.ReBroadcastAge {
+				stuck = append(stuck, tx)
+			}
+		}
+	}
+	pool.mu.RUnlock()
+
+	if len(stuck) > 0 {
+		log.Debug("Re-broadcasting stuck transactions", "count", len(stuck))
+		rebroadcastCounter.Inc(int64(len(stuck)))
+		pool.rebroadFeed.Send(core.NewTxsEvent{Txs: stuck})
+	}
+}
+
 // Close terminates the transaction pool.
 func (pool *LegacyPool) Close() error {
 	// Terminate the pool reorger and return
@@ -411,6 +459,12 @@ func (pool *LegacyPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs
 	return pool.txFeed.Subscribe(ch)
 }
 
+// SubscribeRebroadcasts registers a subscription for the pending transactions
+// which are re-broadcast after not being included for a while.
+func (pool *LegacyPool) SubscribeRebroadcasts(ch chan<- core.NewTxsEvent) event.Subscription {
+	return pool.rebroadFeed.Subscribe(ch)
+}
+
 // SetGasTip updates the minimum gas tip required by the transaction pool for a
 // new transaction, and drops all transactions below this threshold.
 func (pool *LegacyPool) SetGasTip(tip *big.Int) {
@@ -687,6 +741,10 @@ func (pool *LegacyPool) add(tx *types.Transaction) (replaced bool, err error) {
 		invalidTxMeter.Mark(1)
 		return false, err
 	}
+	gasPriceHistogram.Update(new(big.Int).Div(tx.GasFeeCap(), big.NewInt(params.GWei)).Int64())
+	gasLimitHistogram.Update(int64(tx.Gas()))
+	dataSizeHistogram.Update(int64(len(tx.Data())))
+
 	// already validated by this point
 	from, _ := types.Sender(pool.signer, tx)
 
@@ -1107,6 +1165,8 @@ func (pool *LegacyPool) removeTx(hash common.Hash, outofbound bool, unreserve bo
 	// Remove the transaction from the pending lists and reset the account nonce
 	if pending := pool.pending[addr]; pending != nil {
 		if removed, invalids := pending.Remove(tx); removed {
+			pendingDurationTimer.UpdateSince(tx.Time())
+
 			// If no more pending transactions are left, remove the list
 			if pending.Empty() {
 				delete(pool.pending, addr)
@@ -1279,9 +1339,12 @@ func (pool *LegacyPool) runReorg(done chan struct{}, reset *txpoolResetRequest,
 	// because of another transaction (e.g. higher gas price).
 	if reset != nil {
 		pool.demoteUnexecutables()
+		if pool.config.DefaultExpiry > 0 {
+			pool.evictExpired(pool.currentHead.Load().Time)
+		}
 		if reset.newHead != nil {
 			if pool.chainconfig.IsLondon(new(big.Int).Add(reset.newHead.Number, big.NewInt(1))) {
-				pendingBaseFee := eip1559.CalcBaseFee(pool.chainconfig, reset.newHead)
+				pendingBaseFee := eip1559.CalcBaseFee(pool.chainconfig, reset.newHead, reset.newHead.Time+1)
 				pool.priced.SetBaseFee(pendingBaseFee)
 			} else {
 				pool.priced.Reheap()
@@ -1525,6 +1588,7 @@ func (pool *LegacyPool) truncatePending() {
 						// Drop the transaction from the global pools too
 						hash := tx.Hash()
 						pool.all.Remove(hash)
+						pendingDurationTimer.UpdateSince(tx.Time())
 
 						// Update the account nonce to the dropped transaction
 						pool.pendingNonces.setIfLower(offenders[i], tx.Nonce())
@@ -1550,6 +1614,7 @@ func (pool *LegacyPool) truncatePending() {
 					// Drop the transaction from the global pools too
 					hash := tx.Hash()
 					pool.all.Remove(hash)
+					pendingDurationTimer.UpdateSince(tx.Time())
 
 					// Update the account nonce to the dropped transaction
 					pool.pendingNonces.setIfLower(addr, tx.Nonce())
@@ -1625,6 +1690,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 		for _, tx := range olds {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pendingDurationTimer.UpdateSince(tx.Time())
 			log.Trace("Removed old pending transaction", "hash", hash)
 		}
 		// Drop all transactions that are too costly (low balance or out of gas), and queue any invalids back for later
@@ -1632,6 +1698,7 @@ func (pool *LegacyPool) demoteUnexecutables() {
 		for _, tx := range drops {
 			hash := tx.Hash()
 			pool.all.Remove(hash)
+			pendingDurationTimer.UpdateSince(tx.Time())
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
 		}
 		pendingNofundsMeter.Mark(int64(len(drops)))
@@ -1667,6 +1734,26 @@ func (pool *LegacyPool) demoteUnexecutables() {
 	}
 }
 
+// evictExpired removes the transactions which entered the pool longer than the
+// configured expiry before the given head block time.
+func (pool *LegacyPool) evictExpired(headTime uint64) {
+	var (
+		deadline = time.Unix(int64(headTime), 0).Add(-pool.config.DefaultExpiry)
+		expired  []common.Hash
+	)
+	pool.all.Range(func(hash common.Hash, tx *types.Transaction) bool {
+		if tx.Time().Before(deadline) {
+			expired = append(expired, hash)
+		}
+		return true
+	})
+	for _, hash := range expired {
+		pool.removeTx(hash, true, true)
+		log.Trace("Removed expired transaction", "hash", hash)
+	}
+	expiredTxMeter.Mark(int64(len(expired)))
+}
+
 // addressByHeartbeat is an account address tagged with its last activity timestamp.
 type addressByHeartbeat struct {
 	address   common.Address
@@ -21,8 +21,10 @@ import (
 	crand "crypto/rand"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"math/rand"
+	"net"
 	"slices"
 	"sync"
 	"sync/atomic"
@@ -38,6 +40,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/metrics"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/trie"
 	"github.com/holiman/uint256"
@@ -433,6 +436,12 @@ func TestInvalidTransactions(t *testing.T) {
 		t.Errorf("want %v have %v", want, err)
 	}
 
+	// Nonce beyond the EIP-2681 limit
+	tx = transaction(math.MaxUint64, 100000, key)
+	if err, want := pool.addRemote(tx), core.ErrNonceTooHigh; !errors.Is(err, want) {
+		t.Errorf("want %v have %v", want, err)
+	}
+
 	tx = transaction(1, 100000, key)
 	pool.gasTip.Store(uint256.NewInt(1000))
 	if err, want := pool.addRemote(tx), txpool.ErrTxGasPriceTooLow; !errors.Is(err, want) {
@@ -440,6 +449,160 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+// Tests that deposit transactions are rejected by the pool, while the other
+// transactions of the same batch are still added.
+func TestRejectDepositTransactions(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	blockchain := newTestBlockChain(params.TestChainConfig, 10000000, statedb, new(event.Feed))
+
+	pool, err := txpool.New(testTxPoolConfig.PriceLimit, blockchain, []txpool.SubPool{New(testTxPoolConfig, blockchain)})
+	if err != nil {
+		t.Fatalf("failed to create tx pool: %v", err)
+	}
+	defer pool.Close()
+
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	statedb.AddBalance(from, uint256.NewInt(1000000), tracing.BalanceChangeUnspecified)
+
+	deposit := types.NewTx(&types.OptimismDepositTx{
+		From:  from,
+		To:    &common.Address{},
+		Value: big.NewInt(100),
+		Gas:   params.TxGas,
+	})
+	errs := pool.Add([]*types.Transaction{deposit, transaction(0, params.TxGas, key)}, true)
+	if !errors.Is(errs[0], txpool.ErrDepositTxRejected) {
+		t.Errorf("deposit error mismatch: have %v, want %v", errs[0], txpool.ErrDepositTxRejected)
+	}
+	if errs[1] != nil {
+		t.Errorf("failed to add transaction: %v", errs[1])
+	}
+	if pool.Has(deposit.Hash()) {
+		t.Error("deposit transaction added to the pool")
+	}
+}
+
+// Tests that the transactions received from a single remote IP address are
+// limited, while the local host and other addresses are not.
+func TestMaxFromIP(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	blockchain := newTestBlockChain(params.TestChainConfig, 10000000, statedb, new(event.Feed))
+
+	pool, err := txpool.New(testTxPoolConfig.PriceLimit, blockchain, []txpool.SubPool{New(testTxPoolConfig, blockchain)})
+	if err != nil {
+		t.Fatalf("failed to create tx pool: %v", err)
+	}
+	defer pool.Close()
+	pool.SetMaxFromIP(1000)
+
+	txs := make([]*types.Transaction, 1003)
+	for i := range txs {
+		key, _ := crypto.GenerateKey()
+		statedb.AddBalance(crypto.PubkeyToAddress(key.PublicKey), uint256.NewInt(1000000), tracing.BalanceChangeUnspecified)
+		txs[i] = transaction(0, params.TxGas, key)
+	}
+	spammer := net.IPv4(1, 2, 3, 4)
+
+	// A rejected transaction doesn't count towards the limit
+	key, _ := crypto.GenerateKey()
+	if errs := pool.AddRemote([]*types.Transaction{transaction(0, 0, key)}, spammer, true); errs[0] == nil {
+		t.Fatal("invalid transaction accepted")
+	}
+	for i, err := range pool.AddRemote(txs[:1000], spammer, true) {
+		if err != nil {
+			t.Fatalf("transaction %d: failed to add: %v", i, err)
+		}
+	}
+	if errs := pool.AddRemote(txs[1000:1001], spammer, true); !errors.Is(errs[0], txpool.ErrIPRateLimited) {
+		t.Fatalf("transaction 1001 error mismatch: have %v, want %v", errs[0], txpool.ErrIPRateLimited)
+	}
+	if pool.Has(txs[1000].Hash()) {
+		t.Fatal("rate limited transaction added to the pool")
+	}
+	if errs := pool.AddRemote(txs[1001:1002], net.IPv4(5, 6, 7, 8), true); errs[0] != nil {
+		t.Fatalf("transaction from another IP rejected: %v", errs[0])
+	}
+	if errs := pool.AddRemote(txs[1002:], net.IPv4(127, 0, 0, 1), true); errs[0] != nil {
+		t.Fatalf("transaction from the local host rejected: %v", errs[0])
+	}
+}
+
+// Tests that deposit transactions never count towards the pending nonce of an
+// account, even if they are submitted to the subpool directly.
+func TestDepositTransactionsNonce(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	blockchain := newTestBlockChain(params.TestChainConfig, 10000000, statedb, new(event.Feed))
+
+	legacy := New(testTxPoolConfig, blockchain)
+	pool, err := txpool.New(testTxPoolConfig.PriceLimit, blockchain, []txpool.SubPool{legacy})
+	if err != nil {
+		t.Fatalf("failed to create tx pool: %v", err)
+	}
+	defer pool.Close()
+
+	key, _ := crypto.GenerateKey()
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	statedb.AddBalance(from, uint256.NewInt(1000000), tracing.BalanceChangeUnspecified)
+
+	if errs := pool.Add([]*types.Transaction{transaction(0, params.TxGas, key)}, true); errs[0] != nil {
+		t.Fatalf("failed to add transaction: %v", errs[0])
+	}
+	for i := 0; i < 3; i++ {
+		deposit := types.NewTx(&types.OptimismDepositTx{
+			SourceHash: common.Hash{byte(i)},
+			From:       from,
+			To:         &common.Address{},
+			Value:      big.NewInt(100),
+			Gas:        params.TxGas,
+		})
+		if errs := pool.Add([]*types.Transaction{deposit}, true); errs[0] == nil {
+			t.Fatal("deposit transaction accepted by the pool")
+		}
+		if errs := legacy.Add([]*types.Transaction{deposit}, true); errs[0] == nil {
+			t.Fatal("deposit transaction accepted by the subpool")
+		}
+	}
+	if nonce := pool.PoolNonce(from); nonce != 1 {
+		t.Errorf("pending nonce mismatch: have %d, want %d", nonce, 1)
+	}
+	if nonce := pool.Nonce(from); nonce != 0 {
+		t.Errorf("latest nonce mismatch: have %d, want %d", nonce, 0)
+	}
+}
+
+// Tests that the distributions of the added transactions are recorded.
+func TestTransactionHistograms(t *testing.T) {
+	// Not parallel, the histograms are shared by all the pools
+	metrics.Enable()
+
+	pool, key := setupPool()
+	defer pool.Close()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	testAddBalance(pool, from, new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(100)))
+
+	before := gasPriceHistogram.Snapshot().Count()
+	for i := 0; i < 100; i++ {
+		tx := pricedTransaction(uint64(i), 100000, big.NewInt(int64(i+1)*params.GWei), key)
+		if err := pool.addRemoteSync(tx); err != nil {
+			t.Fatalf("failed to add transaction %d: %v", i, err)
+		}
+	}
+	if have := gasPriceHistogram.Snapshot().Count() - before; have != 100 {
+		t.Fatalf("gas price observation count mismatch: have %d, want %d", have, 100)
+	}
+	if have := gasPriceHistogram.Snapshot().Max(); have != 100 {
+		t.Fatalf("max gas price mismatch: have %d, want %d", have, 100)
+	}
+}
+
 func TestQueue(t *testing.T) {
 	t.Parallel()
 
@@ -1153,6 +1316,110 @@ func TestQueueTimeLimiting(t *testing.T) {
 	}
 }
 
+// Tests that transactions staying in the pool longer than the expiry, as of the
+// time of the new head block, are evicted on the next reset.
+func TestTransactionExpiry(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.DefaultExpiry = time.Second
+
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), newReserver())
+	defer pool.Close()
+
+	key, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	// Add an executable and a gapped transaction
+	if err := pool.addRemoteSync(pricedTransaction(0, 100000, big.NewInt(1), key)); err != nil {
+		t.Fatalf("failed to add pending transaction: %v", err)
+	}
+	if err := pool.addRemoteSync(pricedTransaction(2, 100000, big.NewInt(1), key)); err != nil {
+		t.Fatalf("failed to add queued transaction: %v", err)
+	}
+	reset := func(timestamp time.Time) {
+		<-pool.requestReset(nil, &types.Header{
+			Number:     new(big.Int),
+			Difficulty: common.Big0,
+			GasLimit:   1000000,
+			BaseFee:    big.NewInt(1),
+			Time:       uint64(timestamp.Unix()),
+		})
+	}
+	// The transactions aren't expired as of a block mined now
+	reset(time.Now())
+	if pending, queued := pool.Stats(); pending != 1 || queued != 1 {
+		t.Fatalf("transactions mismatch before expiry: have %d pending %d queued, want 1 pending 1 queued", pending, queued)
+	}
+	// They are evicted by a block mined after the expiry
+	reset(time.Now().Add(2 * time.Second))
+	if pending, queued := pool.Stats(); pending != 0 || queued != 0 {
+		t.Fatalf("transactions mismatch after expiry: have %d pending %d queued, want none", pending, queued)
+	}
+	if err := validatePoolInternals(pool); err != nil {
+		t.Fatalf("pool internal state corrupted: %v", err)
+	}
+}
+
+// Tests that the pending transactions not included for a while are announced
+// again.
+func TestTransactionRebroadcast(t *testing.T) {
+	// Reduce the re-broadcast interval to a testable amount
+	defer func(old time.Duration) { rebroadcastInterval = old }(rebroadcastInterval)
+	rebroadcastInterval = 100 * time.Millisecond
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	blockchain := newTestBlockChain(params.TestChainConfig, 1000000, statedb, new(event.Feed))
+
+	config := testTxPoolConfig
+	config.ReBroadcastAge = 200 * time.Millisecond
+
+	pool := New(config, blockchain)
+	pool.Init(config.PriceLimit, blockchain.CurrentBlock(), newReserver())
+	defer pool.Close()
+
+	events := make(chan core.NewTxsEvent, 16)
+	sub := pool.SubscribeTransactions(events, false)
+	defer sub.Unsubscribe()
+
+	rebroads := make(chan core.NewTxsEvent, 16)
+	rebroadSub := pool.SubscribeRebroadcasts(rebroads)
+	defer rebroadSub.Unsubscribe()
+
+	key, _ := crypto.GenerateKey()
+	testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(1000000000))
+
+	tx := pricedTransaction(0, 100000, big.NewInt(1), key)
+	if err := pool.addRemoteSync(tx); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	if err := validateEvents(events, 1); err != nil {
+		t.Fatalf("original event firing failed: %v", err)
+	}
+	before := rebroadcastCounter.Snapshot().Count()
+
+	// Without any block including it, the transaction is announced again, but
+	// not fed to the subscribers of new transactions
+	select {
+	case ev := <-rebroads:
+		if len(ev.Txs) != 1 || ev.Txs[0].Hash() != tx.Hash() {
+			t.Fatalf("re-broadcast transactions mismatch: have %d, want %v", len(ev.Txs), tx.Hash())
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("transaction not re-broadcast")
+	}
+	if have := rebroadcastCounter.Snapshot().Count(); have <= before {
+		t.Errorf("re-broadcast counter mismatch: have %d, want > %d", have, before)
+	}
+	if err := validateEvents(events, 0); err != nil {
+		t.Fatalf("re-broadcast fed as new transactions: %v", err)
+	}
+}
+
 // Tests that even if the transaction count belonging to a single account goes
 // above some threshold, as long as the transactions are executable, they are
 // accepted.
@@ -1373,6 +1640,40 @@ func TestPendingMinimumAllowance(t *testing.T) {
 	}
 }
 
+// Tests that the price limit of the pool is enforced on the tip of dynamic fee
+// transactions, even if their fee cap covers the base fee.
+func TestPriceLimitEnforcedOnTip(t *testing.T) {
+	t.Parallel()
+
+	statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+	blockchain := newTestBlockChain(eip1559Config, 10000000, statedb, new(event.Feed))
+
+	txPoolConfig := DefaultConfig
+	txPoolConfig.NoLocals = true
+	txPoolConfig.PriceLimit = 10
+	pool := New(txPoolConfig, blockchain)
+	pool.Init(txPoolConfig.PriceLimit, blockchain.CurrentBlock(), newReserver())
+	defer pool.Close()
+
+	tests := []struct {
+		tip  int64
+		want error
+	}{
+		{0, txpool.ErrTxGasPriceTooLow},
+		{10, nil},
+		{11, nil},
+	}
+	for _, tt := range tests {
+		key, _ := crypto.GenerateKey()
+		testAddBalance(pool, crypto.PubkeyToAddress(key.PublicKey), big.NewInt(params.Ether))
+
+		tx := dynamicFeeTx(0, 100000, big.NewInt(1000), big.NewInt(tt.tip), key)
+		if err := pool.Add([]*types.Transaction{tx}, true)[0]; !errors.Is(err, tt.want) {
+			t.Errorf("tip %d: error mismatch: have %v, want %v", tt.tip, err, tt.want)
+		}
+	}
+}
+
 // Tests that setting the transaction pool gas price to a higher value correctly
 // discards everything cheaper than that and moves any gapped transactions back
 // from the pending pool to the queue.
@@ -72,6 +72,14 @@ func New(journalPath string, journalTime time.Duration, chainConfig *params.Chai
 	return pool
 }
 
+// Count returns the number of tracked transactions.
+func (tracker *TxTracker) Count() int {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	return len(tracker.all)
+}
+
 // Track adds a transaction to the tracked set.
 // Note: blob-type transactions are ignored.
 func (tracker *TxTracker) Track(tx *types.Transaction) {
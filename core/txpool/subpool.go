@@ -155,6 +155,11 @@ type SubPool interface {
 	// or also for reorged out ones.
 	SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription
 
+	// SubscribeRebroadcasts subscribes to the pending transactions announced
+	// again after not being included for a while. These are not new, so they
+	// are kept off the transaction event feed.
+	SubscribeRebroadcasts(ch chan<- core.NewTxsEvent) event.Subscription
+
 	// Nonce returns the next nonce of an account, with all transactions executable
 	// by the pool already applied on top.
 	Nonce(addr common.Address) uint64
@@ -20,7 +20,9 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
@@ -75,6 +77,12 @@ type TxPool struct {
 	term chan struct{}           // Termination channel to detect a closed pool
 
 	sync chan chan error // Testing / simulator channel to block until internal reset is done
+
+	ipLimit *ipLimiter // Limiter of the transactions accepted per remote IP, nil if unlimited
+
+	healthLock    sync.Mutex // Lock protecting the cached oldest pending transaction
+	healthOldest  time.Time  // Arrival time of the oldest pending transaction at the last check
+	healthChecked time.Time  // Time of the last lookup of the oldest pending transaction
 }
 
 // New creates a new transaction pool to gather, sort and filter inbound
@@ -264,6 +272,17 @@ func (p *TxPool) SetGasTip(tip *big.Int) {
 	}
 }
 
+// SetMaxFromIP limits the number of transactions accepted through AddRemote from
+// a single remote IP address per minute. Zero disables the limit. It is meant
+// to be called on startup, before any transaction is added.
+func (p *TxPool) SetMaxFromIP(limit uint64) {
+	if limit == 0 {
+		p.ipLimit = nil
+		return
+	}
+	p.ipLimit = newIPLimiter(limit)
+}
+
 // Has returns an indicator whether the pool has a transaction cached with the
 // given hash.
 func (p *TxPool) Has(hash common.Hash) bool {
@@ -307,10 +326,33 @@ func (p *TxPool) GetMetadata(hash common.Hash) *TxMetadata {
 	return nil
 }
 
+// GetBlobs returns the sidecars of the transactions containing the blobs with
+// the given versioned hashes, nil for the blobs not tracked by any subpool.
+func (p *TxPool) GetBlobs(vhashes []common.Hash) []*types.BlobTxSidecar {
+	sidecars := make([]*types.BlobTxSidecar, len(vhashes))
+	for _, subpool := range p.subpools {
+		blobpool, ok := subpool.(interface {
+			GetBlobs(vhashes []common.Hash) []*types.BlobTxSidecar
+		})
+		if !ok {
+			continue
+		}
+		for i, sidecar := range blobpool.GetBlobs(vhashes) {
+			if sidecars[i] == nil {
+				sidecars[i] = sidecar
+			}
+		}
+	}
+	return sidecars
+}
+
 // Add enqueues a batch of transactions into the pool if they are valid. Due
 // to the large transaction churn, add may postpone fully integrating the tx
 // to a later point to batch multiple ones together.
 //
+// Deposit transactions are rejected: they reach the block builder only through
+// the L1 derivation pipeline, never through the P2P or RPC mempool.
+//
 // Note, if sync is set the method will block until all internal maintenance
 // related to the add is finished. Only use this during tests for determinism.
 func (p *TxPool) Add(txs []*types.Transaction, sync bool) []error {
@@ -327,6 +369,10 @@ func (p *TxPool) Add(txs []*types.Transaction, sync bool) []error {
 		// Mark this transaction belonging to no-subpool
 		splits[i] = -1
 
+		// Reject deposits upfront, no subpool may ever accept them
+		if tx.Type() == types.OptimismDepositTxType {
+			continue
+		}
 		// Try to find a subpool that accepts the transaction
 		for j, subpool := range p.subpools {
 			if subpool.Filter(tx) {
@@ -346,6 +392,10 @@ func (p *TxPool) Add(txs []*types.Transaction, sync bool) []error {
 	for i, split := range splits {
 		// If the transaction was rejected by all subpools, mark it unsupported
 		if split == -1 {
+			if txs[i].Type() == types.OptimismDepositTxType {
+				errs[i] = ErrDepositTxRejected
+				continue
+			}
 			errs[i] = fmt.Errorf("%w: received type %d", core.ErrTxTypeNotSupported, txs[i].Type())
 			continue
 		}
@@ -356,6 +406,32 @@ func (p *TxPool) Add(txs []*types.Transaction, sync bool) []error {
 	return errs
 }
 
+// AddRemote enqueues a batch of transactions received from the given remote IP
+// address, rejecting the ones exceeding the number accepted from that address
+// with ErrIPRateLimited. Transactions from the local host are not limited.
+func (p *TxPool) AddRemote(txs []*types.Transaction, ip net.IP, sync bool) []error {
+	limiter := p.ipLimit
+	if limiter == nil || ip == nil || ip.IsLoopback() {
+		return p.Add(txs, sync)
+	}
+	granted := limiter.reserve(ip, len(txs))
+
+	errs := make([]error, len(txs))
+	copy(errs, p.Add(txs[:granted], sync))
+	for i := granted; i < len(txs); i++ {
+		errs[i] = ErrIPRateLimited
+	}
+	// Only count the transactions actually accepted
+	var rejected int
+	for _, err := range errs[:granted] {
+		if err != nil {
+			rejected++
+		}
+	}
+	limiter.release(ip, rejected)
+	return errs
+}
+
 // Pending retrieves all currently processable transactions, grouped by origin
 // account and sorted by nonce.
 //
@@ -381,6 +457,16 @@ func (p *TxPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool)
 	return p.subs.Track(event.JoinSubscriptions(subs...))
 }
 
+// SubscribeRebroadcasts registers a subscription for the pending transactions
+// announced again after not being included for a while.
+func (p *TxPool) SubscribeRebroadcasts(ch chan<- core.NewTxsEvent) event.Subscription {
+	subs := make([]event.Subscription, len(p.subpools))
+	for i, subpool := range p.subpools {
+		subs[i] = subpool.SubscribeRebroadcasts(ch)
+	}
+	return p.subs.Track(event.JoinSubscriptions(subs...))
+}
+
 // PoolNonce returns the next nonce of an account, with all transactions executable
 // by the pool already applied on top.
 func (p *TxPool) PoolNonce(addr common.Address) uint64 {
@@ -19,6 +19,7 @@ package txpool
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -133,7 +134,10 @@ func ValidateTransaction(tx *types.Transaction, head *types.Header, signer types
 			return fmt.Errorf("%w: gas %v, minimum needed %v", core.ErrFloorDataGas, tx.Gas(), floorDataGas)
 		}
 	}
-	// Ensure the gasprice is high enough to cover the requirement of the calling pool
+	// Ensure the gasprice is high enough to cover the requirement of the calling pool.
+	// The fee cap covering the base fee is not enough, the tip must reach the
+	// minimum as well. For legacy and access list transactions, the tip cap is
+	// the gas price.
 	if tx.GasTipCapIntCmp(opts.MinTip) < 0 {
 		return fmt.Errorf("%w: gas tip cap %v, minimum needed %v", ErrTxGasPriceTooLow, tx.GasTipCap(), opts.MinTip)
 	}
@@ -247,6 +251,11 @@ func ValidateTransactionWithState(tx *types.Transaction, signer types.Signer, op
 	if next > tx.Nonce() {
 		return fmt.Errorf("%w: next nonce %v, tx nonce %v", core.ErrNonceTooLow, next, tx.Nonce())
 	}
+	// Ensure the transaction doesn't exceed the nonce limit of EIP-2681, the
+	// sender nonce could never be incremented past it
+	if tx.Nonce() == math.MaxUint64 {
+		return fmt.Errorf("%w: tx nonce %v, max nonce %v", core.ErrNonceTooHigh, tx.Nonce(), uint64(math.MaxUint64-1))
+	}
 	// Ensure the transaction doesn't produce a nonce gap in pools that do not
 	// support arbitrary orderings
 	if opts.FirstNonceGap != nil {
@@ -20,6 +20,7 @@ package types
 import (
 	"crypto/sha256"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -30,6 +31,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-verkle"
 )
@@ -227,11 +229,40 @@ type Block struct {
 // "external" block encoding. used for eth protocol, etc.
 type extblock struct {
 	Header      *Header
-	Txs         []*Transaction
+	Txs         extblockTxs
 	Uncles      []*Header
 	Withdrawals []*Withdrawal `rlp:"optional"`
 }
 
+// ErrBlockTooLarge is returned when decoding a block whose transaction list
+// exceeds the size limit.
+var ErrBlockTooLarge = errors.New("block too large")
+
+// averageTxRLPSize is the average encoded size of a transaction assumed when
+// bounding the size of the transactions of a block.
+const averageTxRLPSize = 2048
+
+// maxBlockTxsSize is the maximum encoded size of the transactions of a block.
+// At 4 gas per byte of calldata, it's far above the size of the transactions
+// fitting in the gas limit of any chain.
+const maxBlockTxsSize = params.MaxTransactionsPerBlock * averageTxRLPSize
+
+// extblockTxs is the transaction list of an encoded block. Its declared size is
+// checked before decoding, so that huge lists aren't allocated.
+type extblockTxs []*Transaction
+
+// DecodeRLP implements rlp.Decoder.
+func (txs *extblockTxs) DecodeRLP(s *rlp.Stream) error {
+	kind, size, err := s.Kind()
+	if err != nil {
+		return err
+	}
+	if kind == rlp.List && size > maxBlockTxsSize {
+		return fmt.Errorf("%w: transaction list of %d bytes, limit %d", ErrBlockTooLarge, size, maxBlockTxsSize)
+	}
+	return s.Decode((*[]*Transaction)(txs))
+}
+
 // NewBlock creates a new block. The input data is copied, changes to header and to the
 // field values will not affect the block.
 //
@@ -339,7 +370,7 @@ func (b *Block) DecodeRLP(s *rlp.Stream) error {
 	if err := s.Decode(&eb); err != nil {
 		return err
 	}
-	b.header, b.uncles, b.transactions, b.withdrawals = eb.Header, eb.Uncles, eb.Txs, eb.Withdrawals
+	b.header, b.uncles, b.transactions, b.withdrawals = eb.Header, eb.Uncles, Transactions(eb.Txs), eb.Withdrawals
 	b.size.Store(rlp.ListSize(size))
 	return nil
 }
@@ -348,7 +379,7 @@ func (b *Block) DecodeRLP(s *rlp.Stream) error {
 func (b *Block) EncodeRLP(w io.Writer) error {
 	return rlp.Encode(w, &extblock{
 		Header:      b.header,
-		Txs:         b.transactions,
+		Txs:         extblockTxs(b.transactions),
 		Uncles:      b.uncles,
 		Withdrawals: b.withdrawals,
 	})
@@ -444,6 +475,13 @@ func (b *Block) Size() uint64 {
 	return uint64(c)
 }
 
+// Size returns the encoded storage size of the body, the block header excluded.
+func (b *Body) Size() uint64 {
+	c := writeCounter(0)
+	rlp.Encode(&c, b)
+	return uint64(c)
+}
+
 // SanityCheck can be used to prevent that unbounded fields are
 // stuffed with junk data to add processing overhead
 func (b *Block) SanityCheck() error {
@@ -18,6 +18,9 @@ package types
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
 	gomath "math"
 	"math/big"
 	"reflect"
@@ -194,6 +197,63 @@ func TestEIP2718BlockEncoding(t *testing.T) {
 	}
 }
 
+// Tests that blocks declaring a huge transaction list are rejected before the
+// list is decoded.
+func TestBlockDecodeTxsLimit(t *testing.T) {
+	listHeader := func(size uint64) []byte {
+		enc := new(big.Int).SetUint64(size).Bytes()
+		return append([]byte{0xf7 + byte(len(enc))}, enc...)
+	}
+	header, _ := rlp.EncodeToBytes(makeBenchBlock().Header())
+
+	for _, size := range []uint64{maxBlockTxsSize, maxBlockTxsSize + 1} {
+		content := append(append([]byte{}, header...), listHeader(size)...)
+		input := append(listHeader(uint64(len(content))+size), content...)
+
+		// The stream isn't limited, only the declared sizes are known
+		err := rlp.NewStream(io.MultiReader(bytes.NewReader(input)), 0).Decode(new(Block))
+		if size > maxBlockTxsSize && !errors.Is(err, ErrBlockTooLarge) {
+			t.Errorf("size %d: error mismatch: got %v, want %v", size, err, ErrBlockTooLarge)
+		}
+		if size <= maxBlockTxsSize && errors.Is(err, ErrBlockTooLarge) {
+			t.Errorf("size %d: rejected within the limit", size)
+		}
+	}
+}
+
+// Tests that the size of a block accounts for its withdrawals.
+func TestBlockSizeWithdrawals(t *testing.T) {
+	header := &Header{Number: big.NewInt(1), Difficulty: common.Big0, BaseFee: big.NewInt(params.InitialBaseFee)}
+	withdrawals := []*Withdrawal{
+		{Index: 0, Validator: 1, Address: common.Address{0x01}, Amount: 100},
+		{Index: 1, Validator: 2, Address: common.Address{0x02}, Amount: 200},
+		{Index: 2, Validator: 3, Address: common.Address{0x03}, Amount: 300},
+	}
+	block := NewBlock(header, &Body{Withdrawals: withdrawals}, nil, blocktest.NewHasher())
+	enc, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		t.Fatalf("failed to encode block: %v", err)
+	}
+	if have, want := block.Size(), uint64(len(enc)); have != want {
+		t.Errorf("block size mismatch: have %d, want %d", have, want)
+	}
+	if empty := NewBlock(header, &Body{Withdrawals: []*Withdrawal{}}, nil, blocktest.NewHasher()); block.Size() <= empty.Size() {
+		t.Errorf("withdrawals not accounted for: have %d, want more than %d", block.Size(), empty.Size())
+	}
+	// The size of the decoded block, and of the block with a new body
+	var decoded Block
+	if err := rlp.DecodeBytes(enc, &decoded); err != nil {
+		t.Fatalf("failed to decode block: %v", err)
+	}
+	if have, want := decoded.Size(), uint64(len(enc)); have != want {
+		t.Errorf("decoded block size mismatch: have %d, want %d", have, want)
+	}
+	rebuilt := NewBlockWithHeader(block.Header()).WithBody(Body{Withdrawals: withdrawals})
+	if have, want := rebuilt.Size(), block.Size(); have != want {
+		t.Errorf("rebuilt block size mismatch: have %d, want %d", have, want)
+	}
+}
+
 func TestUncleHash(t *testing.T) {
 	uncles := make([]*Header, 0)
 	h := CalcUncleHash(uncles)
@@ -318,3 +378,51 @@ func TestRlpDecodeParentHash(t *testing.T) {
 		}
 	}
 }
+
+// Tests that the requests hash commits to the SHA256 of every request, skipping
+// the request types without any data, as specified by EIP-7685.
+func TestCalcRequestsHash(t *testing.T) {
+	// A deposit request: type 0x00 followed by the 192 bytes of the deposit
+	deposit := append([]byte{0x00}, bytes.Repeat([]byte{0x01}, 192)...)
+
+	inner := sha256.Sum256(deposit)
+	want := common.Hash(sha256.Sum256(inner[:]))
+	if have := CalcRequestsHash([][]byte{deposit}); have != want {
+		t.Errorf("deposit requests hash mismatch: have %v, want %v", have, want)
+	}
+	// Empty withdrawal and consolidation requests don't alter the hash
+	if have := CalcRequestsHash([][]byte{deposit, {0x01}, {0x02}}); have != want {
+		t.Errorf("requests hash with empty types mismatch: have %v, want %v", have, want)
+	}
+	empty := common.Hash(sha256.Sum256(nil))
+	if have := CalcRequestsHash([][]byte{{0x00}, {0x01}, {0x02}}); have != empty {
+		t.Errorf("empty requests hash mismatch: have %v, want %v", have, empty)
+	}
+	// The block carries the hash in its header
+	header := &Header{Number: big.NewInt(1), Difficulty: new(big.Int), RequestsHash: &want}
+	block := NewBlockWithHeader(header)
+	if have := block.RequestsHash(); have == nil || *have != want {
+		t.Errorf("block requests hash mismatch: have %v, want %v", have, want)
+	}
+}
+
+func TestConsolidationRequestEncoding(t *testing.T) {
+	request := ConsolidationRequest{SourceAddress: common.Address{0x01}}
+	request.SourcePubkey[0] = 0x02
+	request.TargetPubkey[47] = 0x03
+
+	enc := request.Encode()
+	if len(enc) != 116 {
+		t.Fatalf("encoding length mismatch: have %d, want 116", len(enc))
+	}
+	decoded, err := DecodeConsolidationRequests(append(enc, enc...))
+	if err != nil {
+		t.Fatalf("failed to decode requests: %v", err)
+	}
+	if len(decoded) != 2 || decoded[0] != request || decoded[1] != request {
+		t.Fatalf("decoded requests mismatch: have %v, want %v twice", decoded, request)
+	}
+	if _, err := DecodeConsolidationRequests(enc[:115]); err == nil {
+		t.Fatal("truncated request decoded")
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// ConsolidationRequestType is the EIP-7685 type of the EIP-7251 requests.
+	ConsolidationRequestType = 0x02
+
+	consolidationRequestSize = common.AddressLength + 48 + 48
+)
+
+// ConsolidationRequest is an EIP-7251 request to consolidate the balance of a
+// source validator into a target one, as dequeued from the consolidation queue
+// contract.
+type ConsolidationRequest struct {
+	SourceAddress common.Address // Withdrawal address of the source validator
+	SourcePubkey  [48]byte
+	TargetPubkey  [48]byte
+}
+
+// Encode returns the flat encoding of the request, as committed to by the
+// requests hash of the block.
+func (r *ConsolidationRequest) Encode() []byte {
+	enc := make([]byte, 0, consolidationRequestSize)
+	enc = append(enc, r.SourceAddress[:]...)
+	enc = append(enc, r.SourcePubkey[:]...)
+	return append(enc, r.TargetPubkey[:]...)
+}
+
+// DecodeConsolidationRequests unpacks the concatenated consolidation requests of
+// a block, without the leading request type.
+func DecodeConsolidationRequests(data []byte) ([]ConsolidationRequest, error) {
+	if len(data)%consolidationRequestSize != 0 {
+		return nil, fmt.Errorf("consolidation requests wrong length: have %d, want multiple of %d", len(data), consolidationRequestSize)
+	}
+	requests := make([]ConsolidationRequest, len(data)/consolidationRequestSize)
+	for i := range requests {
+		enc := data[i*consolidationRequestSize:]
+		copy(requests[i].SourceAddress[:], enc)
+		copy(requests[i].SourcePubkey[:], enc[common.AddressLength:])
+		copy(requests[i].TargetPubkey[:], enc[common.AddressLength+48:])
+	}
+	return requests, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MessageIdentifier uniquely identifies the log initiating a cross-chain
+// message within the OP Superchain, as checked by the CrossL2Inbox.
+type MessageIdentifier struct {
+	Origin      common.Address // Contract emitting the log
+	BlockNumber *big.Int       // Number of the block including the log
+	LogIndex    *big.Int       // Index of the log in the block
+	Timestamp   uint64         // Timestamp of the block including the log
+	ChainID     *big.Int       // Chain the log was emitted on
+}
+
+// Hash returns the keccak256 hash of the ABI encoded identifier.
+func (m *MessageIdentifier) Hash() common.Hash {
+	enc := make([]byte, 0, 5*common.HashLength)
+	enc = append(enc, common.BytesToHash(m.Origin[:]).Bytes()...)
+	enc = append(enc, bigToWord(m.BlockNumber).Bytes()...)
+	enc = append(enc, bigToWord(m.LogIndex).Bytes()...)
+	enc = append(enc, bigToWord(new(big.Int).SetUint64(m.Timestamp)).Bytes()...)
+	enc = append(enc, bigToWord(m.ChainID).Bytes()...)
+	return crypto.Keccak256Hash(enc)
+}
+
+// InteropMessage is a cross-chain message executed through the CrossL2Inbox,
+// committing to the payload of the initiating log.
+type InteropMessage struct {
+	Identifier  MessageIdentifier
+	PayloadHash common.Hash
+}
+
+// InteropPayloadHash returns the hash of the message payload of a log: the
+// keccak256 hash of its topics followed by its data.
+func InteropPayloadHash(log *Log) common.Hash {
+	payload := make([]byte, 0, len(log.Topics)*common.HashLength+len(log.Data))
+	for _, topic := range log.Topics {
+		payload = append(payload, topic[:]...)
+	}
+	return crypto.Keccak256Hash(append(payload, log.Data...))
+}
+
+// ValidateInteropMessage reports whether the message matches one of the logs,
+// which must be logs of the chain the message claims to originate from: the
+// log at the identifier must be emitted by the origin in the identified block
+// and carry the payload the message commits to.
+func ValidateInteropMessage(msg *InteropMessage, logs []*Log) bool {
+	id := &msg.Identifier
+	if id.BlockNumber == nil || !id.BlockNumber.IsUint64() || id.LogIndex == nil || !id.LogIndex.IsUint64() {
+		return false
+	}
+	for _, log := range logs {
+		if log.BlockNumber != id.BlockNumber.Uint64() || uint64(log.Index) != id.LogIndex.Uint64() {
+			continue
+		}
+		return log.Address == id.Origin && log.BlockTimestamp == id.Timestamp && InteropPayloadHash(log) == msg.PayloadHash
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestMessageIdentifierHash(t *testing.T) {
+	id := &MessageIdentifier{
+		Origin:      common.HexToAddress("0x4200000000000000000000000000000000000023"),
+		BlockNumber: big.NewInt(1),
+		LogIndex:    big.NewInt(2),
+		Timestamp:   3,
+		ChainID:     big.NewInt(4),
+	}
+	enc := hexutil.MustDecode("0x" +
+		"0000000000000000000000004200000000000000000000000000000000000023" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0000000000000000000000000000000000000000000000000000000000000003" +
+		"0000000000000000000000000000000000000000000000000000000000000004")
+	if have, want := id.Hash(), crypto.Keccak256Hash(enc); have != want {
+		t.Fatalf("identifier hash mismatch: have %x, want %x", have, want)
+	}
+}
+
+func TestValidateInteropMessage(t *testing.T) {
+	logs := []*Log{
+		{Address: common.Address{0x01}, Topics: []common.Hash{{0xaa}}, BlockNumber: 100, BlockTimestamp: 1200, Index: 0},
+		{
+			Address:        common.HexToAddress("0x4200000000000000000000000000000000000023"),
+			Topics:         []common.Hash{{0x01}, {0x02}},
+			Data:           []byte("hello superchain"),
+			BlockNumber:    100,
+			BlockTimestamp: 1200,
+			Index:          1,
+		},
+	}
+	newMessage := func() *InteropMessage {
+		return &InteropMessage{
+			Identifier: MessageIdentifier{
+				Origin:      logs[1].Address,
+				BlockNumber: big.NewInt(100),
+				LogIndex:    big.NewInt(1),
+				Timestamp:   1200,
+				ChainID:     big.NewInt(10),
+			},
+			PayloadHash: crypto.Keccak256Hash(logs[1].Topics[0][:], logs[1].Topics[1][:], logs[1].Data),
+		}
+	}
+	if !ValidateInteropMessage(newMessage(), logs) {
+		t.Fatal("valid message rejected")
+	}
+	tests := map[string]func(*InteropMessage){
+		"wrong origin":    func(m *InteropMessage) { m.Identifier.Origin = common.Address{0x01} },
+		"wrong block":     func(m *InteropMessage) { m.Identifier.BlockNumber = big.NewInt(101) },
+		"wrong log index": func(m *InteropMessage) { m.Identifier.LogIndex = big.NewInt(0) },
+		"missing log":     func(m *InteropMessage) { m.Identifier.LogIndex = big.NewInt(2) },
+		"wrong timestamp": func(m *InteropMessage) { m.Identifier.Timestamp = 1201 },
+		"wrong payload":   func(m *InteropMessage) { m.PayloadHash[0] ^= 1 },
+		"nil block":       func(m *InteropMessage) { m.Identifier.BlockNumber = nil },
+	}
+	for name, tamper := range tests {
+		msg := newMessage()
+		tamper(msg)
+		if ValidateInteropMessage(msg, logs) {
+			t.Errorf("%s: invalid message accepted", name)
+		}
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/holiman/uint256"
@@ -385,6 +386,52 @@ func TestEffectiveGasPriceNotRequired(t *testing.T) {
 	}
 }
 
+// Tests that pre-Byzantium receipts are marshalled with their intermediate
+// state root, surviving a roundtrip, and that the root is omitted otherwise.
+func TestReceiptJSONPostState(t *testing.T) {
+	root := common.HexToHash("0x2a").Bytes()
+	receipt := &Receipt{
+		PostState:         root,
+		CumulativeGasUsed: 21000,
+		Logs:              []*Log{},
+		TxHash:            common.HexToHash("0x01"),
+		GasUsed:           21000,
+	}
+	enc, err := json.Marshal(receipt)
+	if err != nil {
+		t.Fatal("error marshaling receipt to json:", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(enc, &fields); err != nil {
+		t.Fatal("error unmarshalling receipt fields:", err)
+	}
+	if have, want := fields["root"], hexutil.Encode(root); have != want {
+		t.Errorf("root field mismatch: have %v, want %v", have, want)
+	}
+	var dec Receipt
+	if err := json.Unmarshal(enc, &dec); err != nil {
+		t.Fatal("error unmarshalling receipt from json:", err)
+	}
+	if !bytes.Equal(dec.PostState, root) {
+		t.Errorf("post state mismatch: have %x, want %x", dec.PostState, root)
+	}
+	// Post-byzantium receipts only carry their status
+	receipt.PostState, receipt.Status = nil, ReceiptStatusFailed
+	if enc, err = json.Marshal(receipt); err != nil {
+		t.Fatal("error marshaling receipt to json:", err)
+	}
+	fields = nil
+	if err := json.Unmarshal(enc, &fields); err != nil {
+		t.Fatal("error unmarshalling receipt fields:", err)
+	}
+	if _, ok := fields["root"]; ok {
+		t.Errorf("post-byzantium receipt has a root field: %s", enc)
+	}
+	if have, want := fields["status"], "0x0"; have != want {
+		t.Errorf("status field mismatch: have %v, want %v", have, want)
+	}
+}
+
 // TestTypedReceiptEncodingDecoding reproduces a flaw that existed in the receipt
 // rlp decoder, which failed due to a shadowing error.
 func TestTypedReceiptEncodingDecoding(t *testing.T) {
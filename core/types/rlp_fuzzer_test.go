@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"math/big"
+	"runtime"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/rlp"
@@ -46,6 +47,23 @@ func FuzzRLP(f *testing.F) {
 	f.Fuzz(fuzzRlp)
 }
 
+// FuzzBlockDecode decodes random blocks, checking that decoding never allocates
+// more than 1 GiB.
+func FuzzBlockDecode(f *testing.F) {
+	enc, _ := rlp.EncodeToBytes(makeBenchBlock())
+	f.Add(enc)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		rlp.DecodeBytes(input, new(Block))
+		runtime.ReadMemStats(&after)
+
+		if alloc := after.TotalAlloc - before.TotalAlloc; alloc > 1<<30 {
+			t.Fatalf("decoding allocated %d bytes", alloc)
+		}
+	})
+}
+
 func fuzzRlp(t *testing.T, input []byte) {
 	if len(input) == 0 || len(input) > 500*1024 {
 		return
@@ -0,0 +1,176 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	// l1FeeScalarDivisor is the fixed point precision of the fee scalar.
+	l1FeeScalarDivisor = big.NewInt(1_000_000)
+
+	// ecotoneFeeDivisor is the fixed point precision of the Ecotone scalars,
+	// times the 16 gas per byte the base fee component is scaled by.
+	ecotoneFeeDivisor = big.NewInt(16 * 1_000_000)
+)
+
+// RollupCostData summarizes the encoded size of a transaction, which is all
+// that is needed to compute the L1 data fee it pays on an OP-Stack chain.
+type RollupCostData struct {
+	Zeroes, Ones uint64
+}
+
+// NewRollupCostData counts the zero and non-zero bytes of the given data.
+func NewRollupCostData(data []byte) (out RollupCostData) {
+	for _, b := range data {
+		if b == 0 {
+			out.Zeroes++
+		} else {
+			out.Ones++
+		}
+	}
+	return out
+}
+
+// RollupCostData returns the L1 cost data of the transaction, based on its
+// canonical encoding. Deposits are not posted to L1 by the batcher and thus
+// carry no L1 cost.
+func (tx *Transaction) RollupCostData() RollupCostData {
+	if tx.Type() == OptimismDepositTxType {
+		return RollupCostData{}
+	}
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return RollupCostData{}
+	}
+	return NewRollupCostData(data)
+}
+
+// StateGetter is the state access needed to read the L1 fee parameters.
+type StateGetter interface {
+	GetState(common.Address, common.Hash) common.Hash
+}
+
+// L1CostFunc computes the L1 data fee of a transaction from its cost data.
+type L1CostFunc func(rcd RollupCostData) *big.Int
+
+// L1FeeConfig holds the L1 fee oracle parameters of a block. Bedrock fees are
+// computed from the overhead and scalar, while Ecotone ones are computed from
+// the base fee and blob base fee scalars instead.
+type L1FeeConfig struct {
+	IsEcotone bool
+
+	L1BaseFee *big.Int
+	Overhead  *big.Int // ignored after Ecotone
+	Scalar    *big.Int // ignored after Ecotone
+
+	L1BlobBaseFee     *big.Int // added by Ecotone
+	BaseFeeScalar     uint32   // added by Ecotone
+	BlobBaseFeeScalar uint32   // added by Ecotone
+}
+
+// NewL1CostFunc returns a function computing L1 data fees with the parameters
+// currently held by the L1 fee oracle of the chain, using the fee formula of
+// the fork active at the given block time. Nil is returned for non OP-Stack
+// chains.
+func NewL1CostFunc(config *params.ChainConfig, blockTime uint64, statedb StateGetter) L1CostFunc {
+	if !config.IsOptimism() {
+		return nil
+	}
+	feeConfig := ReadL1FeeConfig(statedb, config.L1FeeOracle(), config.IsEcotone(blockTime))
+	return func(rcd RollupCostData) *big.Int {
+		return ComputeL1DataFee(rcd, feeConfig)
+	}
+}
+
+// ReadL1FeeParams reads the Bedrock L1 data fee parameters from the storage of
+// the given L1 fee oracle.
+func ReadL1FeeParams(statedb StateGetter, oracle common.Address) (baseFee, scalar, overhead *big.Int) {
+	baseFee = statedb.GetState(oracle, L1BaseFeeSlot).Big()
+	scalar = statedb.GetState(oracle, ScalarSlot).Big()
+	overhead = statedb.GetState(oracle, OverheadSlot).Big()
+	return baseFee, scalar, overhead
+}
+
+// ReadL1FeeConfig reads the L1 data fee parameters of the given fork from the
+// storage of the L1 fee oracle. The Ecotone scalars are packed along with the
+// sequence number of the L1 block info.
+func ReadL1FeeConfig(statedb StateGetter, oracle common.Address, isEcotone bool) *L1FeeConfig {
+	if !isEcotone {
+		baseFee, scalar, overhead := ReadL1FeeParams(statedb, oracle)
+		return &L1FeeConfig{L1BaseFee: baseFee, Overhead: overhead, Scalar: scalar}
+	}
+	sequence := statedb.GetState(oracle, L1SequenceNumberSlot)
+	return &L1FeeConfig{
+		IsEcotone:         true,
+		L1BaseFee:         statedb.GetState(oracle, L1BaseFeeSlot).Big(),
+		L1BlobBaseFee:     statedb.GetState(oracle, L1BlobBaseFeeSlot).Big(),
+		BaseFeeScalar:     binary.BigEndian.Uint32(sequence[16:20]),
+		BlobBaseFeeScalar: binary.BigEndian.Uint32(sequence[20:24]),
+	}
+}
+
+// ComputeL1DataFee computes the L1 data fee of a transaction with the formula
+// of the configured fork. After Ecotone the fee is:
+//
+//	(zeroes*4 + ones*16) * (16*baseFeeScalar*l1BaseFee + blobBaseFeeScalar*l1BlobBaseFee) / 16e6
+//
+// which matches the Bedrock fee for a zero overhead, blob base fee and equal
+// scalars.
+func ComputeL1DataFee(rcd RollupCostData, config *L1FeeConfig) *big.Int {
+	if !config.IsEcotone {
+		return L1Cost(rcd, config.L1BaseFee, config.Overhead, config.Scalar)
+	}
+	calldataGas := new(big.Int).SetUint64(L1GasUsed(rcd, config))
+
+	baseFee := new(big.Int).SetUint64(16 * uint64(config.BaseFeeScalar))
+	baseFee.Mul(baseFee, config.L1BaseFee)
+	blobFee := new(big.Int).SetUint64(uint64(config.BlobBaseFeeScalar))
+	blobFee.Mul(blobFee, config.L1BlobBaseFee)
+
+	fee := calldataGas.Mul(calldataGas, baseFee.Add(baseFee, blobFee))
+	return fee.Div(fee, ecotoneFeeDivisor)
+}
+
+// L1GasUsed returns the L1 gas the data of a transaction is charged for, before
+// being priced by the L1 fees and scalars:
+//
+//	zeroes*4 + ones*16 (+ overhead before Ecotone)
+func L1GasUsed(rcd RollupCostData, config *L1FeeConfig) uint64 {
+	gas := rcd.Zeroes*params.TxDataZeroGas + rcd.Ones*params.TxDataNonZeroGasEIP2028
+	if !config.IsEcotone {
+		gas += config.Overhead.Uint64()
+	}
+	return gas
+}
+
+// L1Cost computes the L1 data fee of a transaction:
+//
+//	(zeroes*4 + ones*16 + overhead) * l1BaseFee * scalar / 1e6
+func L1Cost(rcd RollupCostData, l1BaseFee, overhead, scalar *big.Int) *big.Int {
+	l1GasUsed := new(big.Int).SetUint64(rcd.Zeroes*params.TxDataZeroGas + rcd.Ones*params.TxDataNonZeroGasEIP2028)
+	l1GasUsed.Add(l1GasUsed, overhead)
+
+	fee := l1GasUsed.Mul(l1GasUsed, l1BaseFee)
+	fee.Mul(fee, scalar)
+	return fee.Div(fee, l1FeeScalarDivisor)
+}
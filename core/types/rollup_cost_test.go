@@ -0,0 +1,115 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// oracleState is an L1 fee oracle storage backing the fee parameter readers.
+type oracleState map[common.Hash]common.Hash
+
+func (s oracleState) GetState(addr common.Address, slot common.Hash) common.Hash {
+	return s[slot]
+}
+
+func TestComputeL1DataFee(t *testing.T) {
+	var (
+		rcd       = NewRollupCostData([]byte{0x00, 0x00, 0x01, 0x02, 0x03, 0x04, 0x00, 0xff})
+		l1BaseFee = big.NewInt(30_000_000_000)
+		scalar    = uint32(684_000)
+	)
+	bedrock := ComputeL1DataFee(rcd, &L1FeeConfig{
+		L1BaseFee: l1BaseFee,
+		Overhead:  new(big.Int),
+		Scalar:    big.NewInt(int64(scalar)),
+	})
+	ecotone := ComputeL1DataFee(rcd, &L1FeeConfig{
+		IsEcotone:         true,
+		L1BaseFee:         l1BaseFee,
+		L1BlobBaseFee:     new(big.Int),
+		BaseFeeScalar:     scalar,
+		BlobBaseFeeScalar: 810_949,
+	})
+	// (3*4 + 5*16) * 30 gwei * 0.684
+	if want := big.NewInt(92 * 30_000_000_000 * 684 / 1000); bedrock.Cmp(want) != 0 {
+		t.Fatalf("bedrock fee mismatch: have %v, want %v", bedrock, want)
+	}
+	if ecotone.Cmp(bedrock) != 0 {
+		t.Fatalf("ecotone fee without blob base fee mismatch: have %v, want %v", ecotone, bedrock)
+	}
+	// The blob base fee is only weighted by its own scalar
+	ecotone = ComputeL1DataFee(rcd, &L1FeeConfig{
+		IsEcotone:         true,
+		L1BaseFee:         l1BaseFee,
+		L1BlobBaseFee:     big.NewInt(1_000_000),
+		BaseFeeScalar:     scalar,
+		BlobBaseFeeScalar: 810_949,
+	})
+	blobFee := big.NewInt(92 * 1_000_000 * 810_949 / 16_000_000)
+	if want := new(big.Int).Add(bedrock, blobFee); ecotone.Cmp(want) != 0 {
+		t.Fatalf("ecotone fee mismatch: have %v, want %v", ecotone, want)
+	}
+}
+
+func TestReadL1FeeConfig(t *testing.T) {
+	info := &L1BlockInfo{
+		Number:            101,
+		Time:              1700000012,
+		BaseFee:           big.NewInt(8_000_000_000),
+		SequenceNumber:    2,
+		EcotoneVersion:    true,
+		BlobBaseFee:       big.NewInt(3),
+		BaseFeeScalar:     1368,
+		BlobBaseFeeScalar: 810949,
+	}
+	state := oracleState(info.OracleStorage())
+	state[OverheadSlot] = common.BigToHash(big.NewInt(188))
+	state[ScalarSlot] = common.BigToHash(big.NewInt(684_000))
+
+	config := ReadL1FeeConfig(state, common.Address{}, true)
+	if !config.IsEcotone || config.L1BaseFee.Cmp(info.BaseFee) != 0 || config.L1BlobBaseFee.Cmp(info.BlobBaseFee) != 0 {
+		t.Fatalf("ecotone fees mismatch: have %+v", config)
+	}
+	if config.BaseFeeScalar != info.BaseFeeScalar || config.BlobBaseFeeScalar != info.BlobBaseFeeScalar {
+		t.Fatalf("ecotone scalars mismatch: have %d/%d, want %d/%d", config.BaseFeeScalar, config.BlobBaseFeeScalar, info.BaseFeeScalar, info.BlobBaseFeeScalar)
+	}
+	config = ReadL1FeeConfig(state, common.Address{}, false)
+	if config.IsEcotone || config.Overhead.Uint64() != 188 || config.Scalar.Uint64() != 684_000 {
+		t.Fatalf("bedrock parameters mismatch: have %+v", config)
+	}
+}
+
+func TestDepositEffectiveTotalCost(t *testing.T) {
+	deposit := &OptimismDepositTx{Gas: 100_000, Value: new(big.Int)}
+
+	l1Fee := big.NewInt(123456789)
+	if have := deposit.EffectiveTotalCost(l1Fee); have.Cmp(l1Fee) != 0 {
+		t.Errorf("cost mismatch: have %v, want %v", have, l1Fee)
+	}
+	if have := deposit.EffectiveTotalCost(nil); have.Sign() != 0 {
+		t.Errorf("cost without L1 fee mismatch: have %v, want 0", have)
+	}
+	// The effective gas price of the receipts is unaffected
+	if have := deposit.effectiveGasPrice(new(big.Int), big.NewInt(params.InitialBaseFee)); have.Sign() != 0 {
+		t.Errorf("effective gas price mismatch: have %v, want 0", have)
+	}
+}
@@ -0,0 +1,398 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// L1InfoBedrockLen is the length of the Bedrock L1 info deposit calldata:
+	// the selector followed by eight ABI encoded words.
+	L1InfoBedrockLen = 4 + 32*8
+
+	// L1InfoEcotoneLen is the length of the Ecotone L1 info deposit calldata:
+	// the selector followed by tightly packed fields.
+	L1InfoEcotoneLen = 4 + 4 + 4 + 8 + 8 + 8 + 32*4
+
+	// L1InfoIsthmusLen is the length of the Isthmus L1 info deposit calldata:
+	// the Ecotone layout followed by the packed operator fee parameters.
+	L1InfoIsthmusLen = L1InfoEcotoneLen + 4 + 8
+)
+
+// L1 block info encoding versions, following the OP-Stack hard forks.
+const (
+	L1InfoVersionBedrock = iota
+	L1InfoVersionEcotone
+	L1InfoVersionFjord
+	L1InfoVersionHolocene
+	L1InfoVersionIsthmus
+)
+
+var (
+	// L1InfoFuncBedrockSelector is the selector of the Bedrock L1 info setter.
+	L1InfoFuncBedrockSelector = crypto.Keccak256([]byte("setL1BlockValues(uint64,uint64,uint256,bytes32,uint64,bytes32,uint256,uint256)"))[:4]
+
+	// L1InfoFuncEcotoneSelector is the selector of the Ecotone L1 info setter.
+	L1InfoFuncEcotoneSelector = crypto.Keccak256([]byte("setL1BlockValuesEcotone()"))[:4]
+
+	// L1InfoFuncIsthmusSelector is the selector of the Isthmus L1 info setter.
+	L1InfoFuncIsthmusSelector = crypto.Keccak256([]byte("setL1BlockValuesIsthmus()"))[:4]
+
+	// Storage layout of the L1 fee oracle, mirroring the L1Block predeploy. The
+	// L1 block number and time share the first slot, the Ecotone fee scalars
+	// are packed next to the sequence number and the Isthmus operator fee
+	// parameters share a slot after the blob base fee.
+	L1BlockNumberTimeSlot = common.BigToHash(big.NewInt(0))
+	L1BaseFeeSlot         = common.BigToHash(big.NewInt(1))
+	L1BlockHashSlot       = common.BigToHash(big.NewInt(2))
+	L1SequenceNumberSlot  = common.BigToHash(big.NewInt(3))
+	L1BatcherHashSlot     = common.BigToHash(big.NewInt(4))
+	OverheadSlot          = common.BigToHash(big.NewInt(5))
+	ScalarSlot            = common.BigToHash(big.NewInt(6))
+	L1BlobBaseFeeSlot     = common.BigToHash(big.NewInt(7))
+	OperatorFeeParamsSlot = common.BigToHash(big.NewInt(8))
+
+	errL1InfoInvalidLength   = errors.New("invalid L1 info deposit length")
+	errL1InfoInvalidSelector = errors.New("invalid L1 info deposit selector")
+	errL1InfoInvalidVersion  = errors.New("L1 info deposit encoding not active")
+	errL1InfoMissing         = errors.New("block doesn't start with a deposit")
+)
+
+// L1BlockInfo is the L1 origin information carried by the first deposit of
+// every OP-Stack block.
+type L1BlockInfo struct {
+	Number         uint64
+	Time           uint64
+	BaseFee        *big.Int
+	BlockHash      common.Hash
+	SequenceNumber uint64 // number of L2 blocks since the start of the epoch
+	BatcherAddr    common.Address
+
+	L1FeeOverhead common.Hash // ignored after Ecotone
+	L1FeeScalar   common.Hash // ignored after Ecotone
+
+	// Ecotone replaced the ABI encoded overhead and scalar words by tightly
+	// packed 4-byte fee scalars leading the calldata, a layout Fjord and
+	// Holocene retain. Isthmus appends the operator fee parameters.
+
+	EcotoneVersion    bool     // whether the info uses the Ecotone encoding
+	FjordVersion      bool     // whether the info uses the Fjord encoding
+	HoloceneVersion   bool     // whether the info uses the Holocene encoding
+	IsthmusVersion    bool     // whether the info uses the Isthmus encoding
+	BlobBaseFee       *big.Int // added by Ecotone
+	BaseFeeScalar     uint32   // added by Ecotone
+	BlobBaseFeeScalar uint32   // added by Ecotone
+
+	OperatorFeeScalar   uint32 // added by Isthmus
+	OperatorFeeConstant uint64 // added by Isthmus
+}
+
+// SelectL1BlockInfoVersion returns the L1 block info encoding version active
+// at the given L2 block time.
+func SelectL1BlockInfoVersion(config *params.ChainConfig, blockTime uint64) int {
+	switch {
+	case config.IsIsthmus(blockTime):
+		return L1InfoVersionIsthmus
+	case config.IsHolocene(blockTime):
+		return L1InfoVersionHolocene
+	case config.IsFjord(blockTime):
+		return L1InfoVersionFjord
+	case config.IsEcotone(blockTime):
+		return L1InfoVersionEcotone
+	default:
+		return L1InfoVersionBedrock
+	}
+}
+
+// Version returns the encoding version of the L1 block info.
+func (info *L1BlockInfo) Version() int {
+	switch {
+	case info.IsthmusVersion:
+		return L1InfoVersionIsthmus
+	case info.HoloceneVersion:
+		return L1InfoVersionHolocene
+	case info.FjordVersion:
+		return L1InfoVersionFjord
+	case info.EcotoneVersion:
+		return L1InfoVersionEcotone
+	default:
+		return L1InfoVersionBedrock
+	}
+}
+
+// EncodeL1InfoDepositData encodes the L1 block info into the calldata of the
+// L1 info deposit transaction.
+func EncodeL1InfoDepositData(info *L1BlockInfo) ([]byte, error) {
+	switch info.Version() {
+	case L1InfoVersionIsthmus:
+		return info.marshalIsthmus()
+	case L1InfoVersionHolocene, L1InfoVersionFjord, L1InfoVersionEcotone:
+		return info.marshalEcotone()
+	default:
+		return info.marshalBedrock()
+	}
+}
+
+// DecodeL1InfoDepositData decodes the calldata of an L1 info deposit
+// transaction, the encoding is selected based on the function selector.
+func DecodeL1InfoDepositData(data []byte) (*L1BlockInfo, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("%w: %d", errL1InfoInvalidLength, len(data))
+	}
+	info := new(L1BlockInfo)
+	switch {
+	case bytes.Equal(data[:4], L1InfoFuncBedrockSelector):
+		return info, info.unmarshalBedrock(data)
+	case bytes.Equal(data[:4], L1InfoFuncEcotoneSelector):
+		return info, info.unmarshalEcotone(data)
+	case bytes.Equal(data[:4], L1InfoFuncIsthmusSelector):
+		return info, info.unmarshalIsthmus(data)
+	default:
+		return nil, fmt.Errorf("%w: %x", errL1InfoInvalidSelector, data[:4])
+	}
+}
+
+// ParseL1InfoDepositData decodes the calldata of the L1 info deposit opening
+// the L2 block with the given time, rejecting encodings of another fork.
+func ParseL1InfoDepositData(config *params.ChainConfig, blockTime uint64, data []byte) (*L1BlockInfo, error) {
+	info, err := DecodeL1InfoDepositData(data)
+	if err != nil {
+		return nil, err
+	}
+	version := SelectL1BlockInfoVersion(config, blockTime)
+	if l1InfoEncoding(info.Version()) != l1InfoEncoding(version) {
+		return nil, fmt.Errorf("%w: have version %d, want %d", errL1InfoInvalidVersion, info.Version(), version)
+	}
+	// Forks sharing an encoding can't be told apart by the calldata
+	info.FjordVersion = version >= L1InfoVersionFjord
+	info.HoloceneVersion = version >= L1InfoVersionHolocene
+	return info, nil
+}
+
+// L1InfoFromBlock returns the L1 origin information carried by the L1 info deposit
+// opening the given L2 block.
+func L1InfoFromBlock(config *params.ChainConfig, block *Block) (*L1BlockInfo, error) {
+	txs := block.Transactions()
+	if len(txs) == 0 || txs[0].Type() != OptimismDepositTxType {
+		return nil, errL1InfoMissing
+	}
+	return ParseL1InfoDepositData(config, block.Time(), txs[0].Data())
+}
+
+// l1InfoEncoding maps an L1 block info version to the first version using the
+// same calldata encoding.
+func l1InfoEncoding(version int) int {
+	switch version {
+	case L1InfoVersionHolocene, L1InfoVersionFjord:
+		return L1InfoVersionEcotone
+	default:
+		return version
+	}
+}
+
+// OracleStorage returns the storage slots of the L1 fee oracle updated by the
+// L1 block info. Ecotone info leaves the legacy overhead and scalar untouched.
+func (info *L1BlockInfo) OracleStorage() map[common.Hash]common.Hash {
+	var numberTime, sequence common.Hash
+	binary.BigEndian.PutUint64(numberTime[16:24], info.Time)
+	binary.BigEndian.PutUint64(numberTime[24:], info.Number)
+	binary.BigEndian.PutUint64(sequence[24:], info.SequenceNumber)
+
+	storage := map[common.Hash]common.Hash{
+		L1BlockNumberTimeSlot: numberTime,
+		L1BaseFeeSlot:         bigToWord(info.BaseFee),
+		L1BlockHashSlot:       info.BlockHash,
+		L1BatcherHashSlot:     common.BytesToHash(info.BatcherAddr[:]),
+	}
+	if info.Version() != L1InfoVersionBedrock {
+		binary.BigEndian.PutUint32(sequence[20:24], info.BlobBaseFeeScalar)
+		binary.BigEndian.PutUint32(sequence[16:20], info.BaseFeeScalar)
+		storage[L1BlobBaseFeeSlot] = bigToWord(info.BlobBaseFee)
+		if info.IsthmusVersion {
+			var operatorFee common.Hash
+			binary.BigEndian.PutUint32(operatorFee[20:24], info.OperatorFeeScalar)
+			binary.BigEndian.PutUint64(operatorFee[24:], info.OperatorFeeConstant)
+			storage[OperatorFeeParamsSlot] = operatorFee
+		}
+	} else {
+		storage[OverheadSlot] = info.L1FeeOverhead
+		storage[ScalarSlot] = info.L1FeeScalar
+	}
+	storage[L1SequenceNumberSlot] = sequence
+	return storage
+}
+
+func (info *L1BlockInfo) marshalBedrock() ([]byte, error) {
+	w := bytes.NewBuffer(make([]byte, 0, L1InfoBedrockLen))
+	w.Write(L1InfoFuncBedrockSelector)
+	writeUint64Word(w, info.Number)
+	writeUint64Word(w, info.Time)
+	if err := writeBigWord(w, info.BaseFee); err != nil {
+		return nil, err
+	}
+	w.Write(info.BlockHash[:])
+	writeUint64Word(w, info.SequenceNumber)
+	w.Write(common.LeftPadBytes(info.BatcherAddr[:], 32))
+	w.Write(info.L1FeeOverhead[:])
+	w.Write(info.L1FeeScalar[:])
+	return w.Bytes(), nil
+}
+
+func (info *L1BlockInfo) unmarshalBedrock(data []byte) error {
+	if len(data) != L1InfoBedrockLen {
+		return fmt.Errorf("%w: have %d, want %d", errL1InfoInvalidLength, len(data), L1InfoBedrockLen)
+	}
+	var err error
+	word := func(i int) []byte { return data[4+32*i : 4+32*(i+1)] }
+
+	if info.Number, err = readUint64Word(word(0)); err != nil {
+		return fmt.Errorf("invalid L1 block number: %w", err)
+	}
+	if info.Time, err = readUint64Word(word(1)); err != nil {
+		return fmt.Errorf("invalid L1 block time: %w", err)
+	}
+	info.BaseFee = new(big.Int).SetBytes(word(2))
+	info.BlockHash = common.BytesToHash(word(3))
+	if info.SequenceNumber, err = readUint64Word(word(4)); err != nil {
+		return fmt.Errorf("invalid sequence number: %w", err)
+	}
+	info.BatcherAddr = common.BytesToAddress(word(5))
+	info.L1FeeOverhead = common.BytesToHash(word(6))
+	info.L1FeeScalar = common.BytesToHash(word(7))
+	return nil
+}
+
+func (info *L1BlockInfo) marshalEcotone() ([]byte, error) {
+	w := bytes.NewBuffer(make([]byte, 0, L1InfoEcotoneLen))
+	w.Write(L1InfoFuncEcotoneSelector)
+	if err := info.writeEcotoneFields(w); err != nil {
+		return nil, err
+	}
+	return w.Bytes(), nil
+}
+
+// writeEcotoneFields writes the fields of the Ecotone encoding following the
+// selector, shared with the Isthmus encoding.
+func (info *L1BlockInfo) writeEcotoneFields(w *bytes.Buffer) error {
+	binary.Write(w, binary.BigEndian, info.BaseFeeScalar)
+	binary.Write(w, binary.BigEndian, info.BlobBaseFeeScalar)
+	binary.Write(w, binary.BigEndian, info.SequenceNumber)
+	binary.Write(w, binary.BigEndian, info.Time)
+	binary.Write(w, binary.BigEndian, info.Number)
+	if err := writeBigWord(w, info.BaseFee); err != nil {
+		return err
+	}
+	if err := writeBigWord(w, info.BlobBaseFee); err != nil {
+		return err
+	}
+	w.Write(info.BlockHash[:])
+	w.Write(common.LeftPadBytes(info.BatcherAddr[:], 32))
+	return nil
+}
+
+func (info *L1BlockInfo) unmarshalEcotone(data []byte) error {
+	if len(data) != L1InfoEcotoneLen {
+		return fmt.Errorf("%w: have %d, want %d", errL1InfoInvalidLength, len(data), L1InfoEcotoneLen)
+	}
+	info.readEcotoneFields(data)
+	return nil
+}
+
+func (info *L1BlockInfo) marshalIsthmus() ([]byte, error) {
+	w := bytes.NewBuffer(make([]byte, 0, L1InfoIsthmusLen))
+	w.Write(L1InfoFuncIsthmusSelector)
+	if err := info.writeEcotoneFields(w); err != nil {
+		return nil, err
+	}
+	binary.Write(w, binary.BigEndian, info.OperatorFeeScalar)
+	binary.Write(w, binary.BigEndian, info.OperatorFeeConstant)
+	return w.Bytes(), nil
+}
+
+func (info *L1BlockInfo) unmarshalIsthmus(data []byte) error {
+	if len(data) != L1InfoIsthmusLen {
+		return fmt.Errorf("%w: have %d, want %d", errL1InfoInvalidLength, len(data), L1InfoIsthmusLen)
+	}
+	info.readEcotoneFields(data[:L1InfoEcotoneLen])
+	info.IsthmusVersion = true
+	info.OperatorFeeScalar = binary.BigEndian.Uint32(data[164:168])
+	info.OperatorFeeConstant = binary.BigEndian.Uint64(data[168:176])
+	return nil
+}
+
+// readEcotoneFields reads the fields of the Ecotone encoding from calldata of
+// at least L1InfoEcotoneLen bytes.
+func (info *L1BlockInfo) readEcotoneFields(data []byte) {
+	info.EcotoneVersion = true
+	info.BaseFeeScalar = binary.BigEndian.Uint32(data[4:8])
+	info.BlobBaseFeeScalar = binary.BigEndian.Uint32(data[8:12])
+	info.SequenceNumber = binary.BigEndian.Uint64(data[12:20])
+	info.Time = binary.BigEndian.Uint64(data[20:28])
+	info.Number = binary.BigEndian.Uint64(data[28:36])
+	info.BaseFee = new(big.Int).SetBytes(data[36:68])
+	info.BlobBaseFee = new(big.Int).SetBytes(data[68:100])
+	info.BlockHash = common.BytesToHash(data[100:132])
+	info.BatcherAddr = common.BytesToAddress(data[132:164])
+}
+
+// writeUint64Word writes a uint64 as a left padded 32 byte word.
+func writeUint64Word(w *bytes.Buffer, n uint64) {
+	var word [32]byte
+	binary.BigEndian.PutUint64(word[24:], n)
+	w.Write(word[:])
+}
+
+// writeBigWord writes a big integer as a left padded 32 byte word, nil being
+// encoded as zero.
+func writeBigWord(w *bytes.Buffer, n *big.Int) error {
+	var word [32]byte
+	if n != nil {
+		if n.Sign() < 0 || n.BitLen() > 256 {
+			return fmt.Errorf("value %v does not fit into a word", n)
+		}
+		n.FillBytes(word[:])
+	}
+	w.Write(word[:])
+	return nil
+}
+
+// bigToWord converts a big integer into a storage word, nil being zero.
+func bigToWord(n *big.Int) common.Hash {
+	if n == nil {
+		return common.Hash{}
+	}
+	return common.BigToHash(n)
+}
+
+// readUint64Word reads a uint64 from a left padded 32 byte word.
+func readUint64Word(word []byte) (uint64, error) {
+	for _, b := range word[:24] {
+		if b != 0 {
+			return 0, errors.New("value overflows uint64")
+		}
+	}
+	return binary.BigEndian.Uint64(word[24:]), nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestL1InfoDepositDataRoundTrip(t *testing.T) {
+	tests := []*L1BlockInfo{
+		{
+			Number:         100,
+			Time:           1700000000,
+			BaseFee:        big.NewInt(7_000_000_000),
+			BlockHash:      common.HexToHash("0x1234"),
+			SequenceNumber: 3,
+			BatcherAddr:    common.HexToAddress("0xba7c4e5"),
+			L1FeeOverhead:  common.BigToHash(big.NewInt(188)),
+			L1FeeScalar:    common.BigToHash(big.NewInt(684_000)),
+		},
+		{
+			Number:            101,
+			Time:              1700000012,
+			BaseFee:           big.NewInt(8_000_000_000),
+			BlockHash:         common.HexToHash("0x5678"),
+			BatcherAddr:       common.HexToAddress("0xba7c4e5"),
+			EcotoneVersion:    true,
+			BlobBaseFee:       big.NewInt(1),
+			BaseFeeScalar:     1368,
+			BlobBaseFeeScalar: 810949,
+		},
+		{
+			Number:              102,
+			Time:                1700000024,
+			BaseFee:             big.NewInt(9_000_000_000),
+			BlockHash:           common.HexToHash("0x9abc"),
+			BatcherAddr:         common.HexToAddress("0xba7c4e5"),
+			EcotoneVersion:      true,
+			IsthmusVersion:      true,
+			BlobBaseFee:         big.NewInt(2),
+			BaseFeeScalar:       1368,
+			BlobBaseFeeScalar:   810949,
+			OperatorFeeScalar:   1_000,
+			OperatorFeeConstant: 500,
+		},
+	}
+	for i, info := range tests {
+		data, err := EncodeL1InfoDepositData(info)
+		if err != nil {
+			t.Fatalf("test %d: failed to encode: %v", i, err)
+		}
+		want := L1InfoBedrockLen
+		switch {
+		case info.IsthmusVersion:
+			want = L1InfoIsthmusLen
+		case info.EcotoneVersion:
+			want = L1InfoEcotoneLen
+		}
+		if len(data) != want {
+			t.Fatalf("test %d: encoded length mismatch: have %d, want %d", i, len(data), want)
+		}
+		have, err := DecodeL1InfoDepositData(data)
+		if err != nil {
+			t.Fatalf("test %d: failed to decode: %v", i, err)
+		}
+		if info.BlobBaseFee == nil {
+			have.BlobBaseFee = nil
+		}
+		if !reflect.DeepEqual(have, info) {
+			t.Fatalf("test %d: round trip mismatch: have %+v, want %+v", i, have, info)
+		}
+	}
+	if _, err := DecodeL1InfoDepositData([]byte{0xde, 0xad, 0xbe, 0xef}); err == nil {
+		t.Fatal("expected unknown selector to be rejected")
+	}
+}
+
+func TestL1InfoDepositSelectors(t *testing.T) {
+	// Selectors of the L1Block predeploy setters, as listed in the OP-Stack specs
+	if have, want := common.Bytes2Hex(L1InfoFuncBedrockSelector), "015d8eb9"; have != want {
+		t.Errorf("bedrock selector mismatch: have %s, want %s", have, want)
+	}
+	if have, want := common.Bytes2Hex(L1InfoFuncEcotoneSelector), "440a5e20"; have != want {
+		t.Errorf("ecotone selector mismatch: have %s, want %s", have, want)
+	}
+	if have, want := common.Bytes2Hex(L1InfoFuncIsthmusSelector), "098999be"; have != want {
+		t.Errorf("isthmus selector mismatch: have %s, want %s", have, want)
+	}
+}
+
+func TestParseL1InfoDepositData(t *testing.T) {
+	config := *params.TestChainConfig
+	config.Optimism = new(params.OptimismConfig)
+	ecotone, fjord := uint64(100), uint64(200)
+	config.EcotoneTime, config.FjordTime = &ecotone, &fjord
+
+	for _, tt := range []struct {
+		time uint64
+		want int
+	}{{0, L1InfoVersionBedrock}, {99, L1InfoVersionBedrock}, {100, L1InfoVersionEcotone}, {199, L1InfoVersionEcotone}, {200, L1InfoVersionFjord}} {
+		if have := SelectL1BlockInfoVersion(&config, tt.time); have != tt.want {
+			t.Errorf("time %d: version mismatch: have %d, want %d", tt.time, have, tt.want)
+		}
+	}
+	// Packed scalars lead the calldata, followed by the sequence number, the
+	// L1 time and number, the base fees, the L1 block hash and the batcher
+	data := common.FromHex("440a5e20" + "00000558" + "000c5fc5" +
+		"0000000000000002" + "000000006553f10c" + "0000000000000065" +
+		"00000000000000000000000000000000000000000000000000000001dcd65000" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000005678" +
+		"000000000000000000000000000000000000000000000000000000000ba7c4e5")
+	want := &L1BlockInfo{
+		Number:            101,
+		Time:              1700000012,
+		BaseFee:           big.NewInt(8_000_000_000),
+		BlockHash:         common.HexToHash("0x5678"),
+		SequenceNumber:    2,
+		BatcherAddr:       common.HexToAddress("0xba7c4e5"),
+		EcotoneVersion:    true,
+		FjordVersion:      true,
+		BlobBaseFee:       big.NewInt(1),
+		BaseFeeScalar:     1368,
+		BlobBaseFeeScalar: 810949,
+	}
+	info, err := ParseL1InfoDepositData(&config, 200, data)
+	if err != nil {
+		t.Fatalf("failed to parse fjord L1 info: %v", err)
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Fatalf("fjord L1 info mismatch: have %+v, want %+v", info, want)
+	}
+	if enc, err := EncodeL1InfoDepositData(info); err != nil || !bytes.Equal(enc, data) {
+		t.Fatalf("fjord L1 info encoding mismatch: have %x, want %x (err %v)", enc, data, err)
+	}
+	if info, err = ParseL1InfoDepositData(&config, 100, data); err != nil || info.FjordVersion {
+		t.Fatalf("ecotone L1 info mismatch: fjord %v, err %v", info.FjordVersion, err)
+	}
+	// Encodings of other forks must be rejected
+	if _, err := ParseL1InfoDepositData(&config, 99, data); err == nil {
+		t.Fatal("expected ecotone L1 info to be rejected before ecotone")
+	}
+	bedrock, _ := EncodeL1InfoDepositData(&L1BlockInfo{Number: 1})
+	if _, err := ParseL1InfoDepositData(&config, 200, bedrock); err == nil {
+		t.Fatal("expected bedrock L1 info to be rejected after fjord")
+	}
+}
+
+func TestParseL1InfoDepositDataIsthmus(t *testing.T) {
+	config := *params.TestChainConfig
+	config.Optimism = new(params.OptimismConfig)
+	ecotone, holocene, isthmus := uint64(0), uint64(100), uint64(200)
+	config.EcotoneTime, config.FjordTime, config.HoloceneTime, config.IsthmusTime = &ecotone, &ecotone, &holocene, &isthmus
+
+	for _, tt := range []struct {
+		time uint64
+		want int
+	}{{99, L1InfoVersionFjord}, {100, L1InfoVersionHolocene}, {199, L1InfoVersionHolocene}, {200, L1InfoVersionIsthmus}} {
+		if have := SelectL1BlockInfoVersion(&config, tt.time); have != tt.want {
+			t.Errorf("time %d: version mismatch: have %d, want %d", tt.time, have, tt.want)
+		}
+	}
+	info := &L1BlockInfo{
+		Number:            101,
+		Time:              1700000012,
+		BaseFee:           big.NewInt(8_000_000_000),
+		BlockHash:         common.HexToHash("0x5678"),
+		SequenceNumber:    2,
+		BatcherAddr:       common.HexToAddress("0xba7c4e5"),
+		EcotoneVersion:    true,
+		BlobBaseFee:       big.NewInt(1),
+		BaseFeeScalar:     1368,
+		BlobBaseFeeScalar: 810949,
+	}
+	ecotoneData, err := EncodeL1InfoDepositData(info)
+	if err != nil {
+		t.Fatalf("failed to encode ecotone L1 info: %v", err)
+	}
+	// Holocene keeps the Ecotone encoding
+	holoceneInfo, err := ParseL1InfoDepositData(&config, 100, ecotoneData)
+	if err != nil {
+		t.Fatalf("failed to parse holocene L1 info: %v", err)
+	}
+	if !holoceneInfo.HoloceneVersion || !holoceneInfo.FjordVersion || holoceneInfo.IsthmusVersion {
+		t.Fatalf("holocene L1 info version mismatch: have %d, want %d", holoceneInfo.Version(), L1InfoVersionHolocene)
+	}
+	// Isthmus appends the operator fee parameters to the Ecotone encoding
+	info.IsthmusVersion, info.OperatorFeeScalar, info.OperatorFeeConstant = true, 1_000, 500
+	isthmusData, err := EncodeL1InfoDepositData(info)
+	if err != nil {
+		t.Fatalf("failed to encode isthmus L1 info: %v", err)
+	}
+	want := append(append(common.FromHex("098999be"), ecotoneData[4:]...), common.FromHex("000003e8"+"00000000000001f4")...)
+	if !bytes.Equal(isthmusData, want) {
+		t.Fatalf("isthmus L1 info encoding mismatch: have %x, want %x", isthmusData, want)
+	}
+	isthmusInfo, err := ParseL1InfoDepositData(&config, 200, isthmusData)
+	if err != nil {
+		t.Fatalf("failed to parse isthmus L1 info: %v", err)
+	}
+	if isthmusInfo.Version() != L1InfoVersionIsthmus || isthmusInfo.OperatorFeeScalar != 1_000 || isthmusInfo.OperatorFeeConstant != 500 {
+		t.Fatalf("isthmus L1 info mismatch: have %+v", isthmusInfo)
+	}
+	if storage := isthmusInfo.OracleStorage(); storage[OperatorFeeParamsSlot] != common.HexToHash("0x3e800000000000001f4") {
+		t.Fatalf("operator fee slot mismatch: have %x", storage[OperatorFeeParamsSlot])
+	}
+	// Encodings of other forks must be rejected with the versions involved
+	if _, err := ParseL1InfoDepositData(&config, 200, ecotoneData); !errors.Is(err, errL1InfoInvalidVersion) {
+		t.Fatalf("ecotone L1 info after isthmus: have %v, want %v", err, errL1InfoInvalidVersion)
+	} else if have, want := err.Error(), "L1 info deposit encoding not active: have version 1, want 4"; have != want {
+		t.Fatalf("error message mismatch: have %q, want %q", have, want)
+	}
+	if _, err := ParseL1InfoDepositData(&config, 199, isthmusData); !errors.Is(err, errL1InfoInvalidVersion) {
+		t.Fatalf("isthmus L1 info before isthmus: have %v, want %v", err, errL1InfoInvalidVersion)
+	}
+	if _, err := DecodeL1InfoDepositData(isthmusData[:L1InfoEcotoneLen]); !errors.Is(err, errL1InfoInvalidLength) {
+		t.Fatalf("truncated isthmus L1 info: have %v, want %v", err, errL1InfoInvalidLength)
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"math/big"
+	"math/rand"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/internal/blocktest"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// testBlockMaxTxs is the maximum number of transactions in a generated block,
+// unless set with WithTransactions.
+const testBlockMaxTxs = 10
+
+// TestBlockGenerator generates a chain of synthetic blocks for tests, derived
+// from a seed so that failures are reproducible. The blocks have consistent
+// numbers, parent hashes and transaction roots, but their state related fields
+// are random: they can't be executed.
+type TestBlockGenerator struct {
+	rng    *rand.Rand
+	key    *ecdsa.PrivateKey
+	signer Signer
+	hasher TrieHasher
+
+	txs      int // number of transactions per block, random if negative
+	deposits int // number of deposits opening every block
+	time     uint64
+
+	parent *Header
+	nonce  uint64
+}
+
+// NewTestBlockGenerator creates a block generator seeded with the given value.
+// The generated transactions are signed by a key derived from the seed.
+func NewTestBlockGenerator(seed int64) *TestBlockGenerator {
+	var enc [8]byte
+	binary.BigEndian.PutUint64(enc[:], uint64(seed))
+	key, err := crypto.ToECDSA(crypto.Keccak256(enc[:]))
+	if err != nil {
+		panic(err)
+	}
+	return &TestBlockGenerator{
+		rng:    rand.New(rand.NewSource(seed)),
+		key:    key,
+		signer: LatestSignerForChainID(params.TestChainConfig.ChainID),
+		hasher: blocktest.NewHasher(),
+		txs:    -1,
+		time:   uint64(seed) % (1 << 32),
+	}
+}
+
+// WithTransactions sets the number of transactions of the following blocks.
+func (g *TestBlockGenerator) WithTransactions(n int) *TestBlockGenerator {
+	g.txs = n
+	return g
+}
+
+// WithDeposits sets the number of deposit transactions opening the following
+// blocks, on top of the regular transactions.
+func (g *TestBlockGenerator) WithDeposits(n int) *TestBlockGenerator {
+	g.deposits = n
+	return g
+}
+
+// WithTimestamp sets the timestamp of the next block, the following ones being
+// two seconds apart.
+func (g *TestBlockGenerator) WithTimestamp(t uint64) *TestBlockGenerator {
+	g.time = t
+	return g
+}
+
+// WithHasher sets the hasher deriving the transaction root of the blocks. By
+// default a plain hash of the transactions is used rather than a trie root.
+func (g *TestBlockGenerator) WithHasher(hasher TrieHasher) *TestBlockGenerator {
+	g.hasher = hasher
+	return g
+}
+
+// Next generates the next block of the chain, the first one being the genesis.
+func (g *TestBlockGenerator) Next() *Block {
+	header := &Header{
+		Coinbase:   g.address(),
+		Root:       g.hash(),
+		Number:     new(big.Int),
+		Difficulty: new(big.Int),
+		GasLimit:   params.GenesisGasLimit,
+		Time:       g.time,
+		BaseFee:    big.NewInt(params.InitialBaseFee),
+		Extra:      []byte("test block"),
+	}
+	if g.parent != nil {
+		header.ParentHash = g.parent.Hash()
+		header.Number.Add(g.parent.Number, common.Big1)
+	}
+	txs := make([]*Transaction, 0, g.deposits)
+	for i := 0; i < g.deposits; i++ {
+		txs = append(txs, NewTx(&OptimismDepositTx{
+			SourceHash: g.hash(),
+			From:       g.address(),
+			To:         &common.Address{},
+			Mint:       big.NewInt(g.rng.Int63n(params.Ether)),
+			Value:      new(big.Int),
+			Gas:        params.TxGas,
+		}))
+	}
+	n := g.txs
+	if n < 0 {
+		n = g.rng.Intn(testBlockMaxTxs + 1)
+	}
+	for i := 0; i < n; i++ {
+		to := g.address()
+		txs = append(txs, MustSignNewTx(g.key, g.signer, &DynamicFeeTx{
+			ChainID:   g.signer.ChainID(),
+			Nonce:     g.nonce,
+			GasTipCap: big.NewInt(params.GWei),
+			GasFeeCap: new(big.Int).Add(header.BaseFee, big.NewInt(params.GWei)),
+			Gas:       params.TxGas,
+			To:        &to,
+			Value:     big.NewInt(g.rng.Int63n(params.Ether)),
+		}))
+		g.nonce++
+	}
+	header.GasUsed = uint64(len(txs)) * params.TxGas
+	block := NewBlock(header, &Body{Transactions: txs}, nil, g.hasher)
+
+	g.parent = block.Header()
+	g.time += 2
+	return block
+}
+
+// address returns a random address.
+func (g *TestBlockGenerator) address() (addr common.Address) {
+	g.rng.Read(addr[:])
+	return addr
+}
+
+// hash returns a random hash.
+func (g *TestBlockGenerator) hash() (hash common.Hash) {
+	g.rng.Read(hash[:])
+	return hash
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/internal/blocktest"
+)
+
+// Tests that the generated blocks are deterministic and form a chain.
+func TestTestBlockGenerator(t *testing.T) {
+	generate := func(seed int64) []*Block {
+		g := NewTestBlockGenerator(seed).WithTimestamp(1000).WithDeposits(1)
+		blocks := []*Block{g.Next(), g.Next()}
+		g.WithTransactions(3).WithDeposits(0)
+		return append(blocks, g.Next(), g.Next())
+	}
+	blocks := generate(1)
+	for i, block := range generate(1) {
+		if block.Hash() != blocks[i].Hash() {
+			t.Fatalf("block %d: hash mismatch with the same seed: have %x, want %x", i, block.Hash(), blocks[i].Hash())
+		}
+	}
+	if other := generate(2); other[0].Hash() == blocks[0].Hash() {
+		t.Fatal("blocks generated with different seeds are identical")
+	}
+	for i, block := range blocks {
+		if block.NumberU64() != uint64(i) {
+			t.Errorf("block %d: number mismatch: have %d, want %d", i, block.NumberU64(), i)
+		}
+		if want := uint64(1000 + 2*i); block.Time() != want {
+			t.Errorf("block %d: timestamp mismatch: have %d, want %d", i, block.Time(), want)
+		}
+		if i > 0 && block.ParentHash() != blocks[i-1].Hash() {
+			t.Errorf("block %d: parent hash mismatch: have %x, want %x", i, block.ParentHash(), blocks[i-1].Hash())
+		}
+		if root := DeriveSha(block.Transactions(), blocktest.NewHasher()); root != block.TxHash() {
+			t.Errorf("block %d: transaction root mismatch: have %x, want %x", i, block.TxHash(), root)
+		}
+		if i < 2 && block.Transactions()[0].Type() != OptimismDepositTxType {
+			t.Errorf("block %d: first transaction is not a deposit", i)
+		}
+		if i >= 2 && block.Transactions().Len() != 3 {
+			t.Errorf("block %d: transaction count mismatch: have %d, want %d", i, block.Transactions().Len(), 3)
+		}
+	}
+}
@@ -196,6 +196,31 @@ func (tx *Transaction) UnmarshalBinary(b []byte) error {
 	return nil
 }
 
+// DecodeLegacyRLP decodes a legacy RLP transaction as found in old blocks,
+// accepting both unprotected (v = 27 or 28) and EIP-155 (v = chainID*2+35 or
+// chainID*2+36) signatures. Protected transactions must be signed for the given
+// chain ID, which is required. Either format is recovered by the EIP-155 signer
+// of the chain, which falls back to the Homestead rules for unprotected ones.
+func (tx *Transaction) DecodeLegacyRLP(b []byte, chainID *big.Int) error {
+	if chainID == nil {
+		return fmt.Errorf("%w: chain ID missing", ErrInvalidChainId)
+	}
+	if len(b) == 0 || b[0] <= 0x7f {
+		return ErrInvalidTxType
+	}
+	var data LegacyTx
+	if err := rlp.DecodeBytes(b, &data); err != nil {
+		return err
+	}
+	if isProtectedV(data.V) {
+		if have := deriveChainId(data.V); have.Cmp(chainID) != 0 {
+			return fmt.Errorf("%w: have %d want %d", ErrInvalidChainId, have, chainID)
+		}
+	}
+	tx.setDecoded(&data, uint64(len(b)))
+	return nil
+}
+
 // decodeTyped decodes a typed transaction from the canonical format.
 func (tx *Transaction) decodeTyped(b []byte) (TxData, error) {
 	if len(b) <= 1 {
@@ -211,6 +236,8 @@ func (tx *Transaction) decodeTyped(b []byte) (TxData, error) {
 		inner = new(BlobTx)
 	case SetCodeTxType:
 		inner = new(SetCodeTx)
+	case OptimismDepositTxType:
+		inner = new(OptimismDepositTx)
 	default:
 		return nil, ErrTxTypeNotSupported
 	}
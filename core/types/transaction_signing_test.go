@@ -139,6 +139,99 @@ func TestChainId(t *testing.T) {
 	}
 }
 
+// Tests that access list transactions are always replay protected: they can't be
+// signed without a chain ID, and only recover under their own chain ID.
+func TestAccessListChainId(t *testing.T) {
+	key, _ := defaultTestKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	txdata := func(chainID *big.Int) *AccessListTx {
+		return &AccessListTx{ChainID: chainID, Gas: params.TxGas, GasPrice: big.NewInt(params.GWei), To: &common.Address{}}
+	}
+	// Signers without a chain ID only support legacy transactions
+	for _, signer := range []Signer{LatestSignerForChainID(nil), NewEIP155Signer(nil)} {
+		if _, err := SignNewTx(key, signer, txdata(nil)); !errors.Is(err, ErrTxTypeNotSupported) {
+			t.Errorf("%T: error mismatch: have %v, want %v", signer, err, ErrTxTypeNotSupported)
+		}
+	}
+	// Typed transaction signers can't be created without a chain ID
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("London signer created without chain ID")
+			}
+		}()
+		NewLondonSigner(nil)
+	}()
+	// An unset chain ID is filled in by the signer
+	signer := NewLondonSigner(big.NewInt(1))
+	tx, err := SignNewTx(key, signer, txdata(nil))
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	if tx.ChainId().Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("chain ID mismatch: have %v, want 1", tx.ChainId())
+	}
+	if from, err := Sender(signer, tx); err != nil || from != addr {
+		t.Errorf("sender mismatch: have %v (%v), want %v", from, err, addr)
+	}
+	if _, err := Sender(NewLondonSigner(big.NewInt(2)), tx); !errors.Is(err, ErrInvalidChainId) {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrInvalidChainId)
+	}
+	// A chain ID different from the signer's is rejected
+	if _, err := SignNewTx(key, signer, txdata(big.NewInt(2))); !errors.Is(err, ErrInvalidChainId) {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrInvalidChainId)
+	}
+}
+
+// Tests that the transactions of all types signed by the latest signer commit
+// to the chain ID, so they can't be replayed on another chain.
+func TestLatestSignerReplayProtection(t *testing.T) {
+	key, _ := defaultTestKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	var (
+		optimism = LatestSignerForChainID(big.NewInt(10))
+		mainnet  = LatestSignerForChainID(big.NewInt(1))
+		to       = common.Address{0x01}
+	)
+	for _, txdata := range []TxData{
+		&LegacyTx{Gas: params.TxGas, GasPrice: big.NewInt(params.GWei), To: &to},
+		&AccessListTx{Gas: params.TxGas, GasPrice: big.NewInt(params.GWei), To: &to},
+		&DynamicFeeTx{Gas: params.TxGas, GasFeeCap: big.NewInt(params.GWei), GasTipCap: big.NewInt(params.GWei), To: &to},
+	} {
+		tx, err := SignNewTx(key, optimism, txdata)
+		if err != nil {
+			t.Fatalf("type %d: failed to sign transaction: %v", txdata.txType(), err)
+		}
+		if from, err := Sender(optimism, tx); err != nil || from != addr {
+			t.Fatalf("type %d: sender mismatch: have %v (%v), want %v", tx.Type(), from, err, addr)
+		}
+		if tx.ChainId().Cmp(big.NewInt(10)) != 0 {
+			t.Errorf("type %d: chain ID mismatch: have %v, want 10", tx.Type(), tx.ChainId())
+		}
+		// The other chain rejects the transaction
+		if _, err := Sender(mainnet, tx); !errors.Is(err, ErrInvalidChainId) {
+			t.Errorf("type %d: replay error mismatch: have %v, want %v", tx.Type(), err, ErrInvalidChainId)
+		}
+		// And the signature doesn't recover the sender over its signing hash
+		v, r, s := tx.RawSignatureValues()
+		sig := make([]byte, crypto.SignatureLength)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:64])
+		sig[64] = byte(v.Uint64())
+		if tx.Type() == LegacyTxType {
+			sig[64] = byte(v.Uint64() - 35 - 2*10) // EIP-155 v of chain 10
+		}
+		if pub, err := crypto.SigToPub(optimism.Hash(tx).Bytes(), sig); err != nil || crypto.PubkeyToAddress(*pub) != addr {
+			t.Fatalf("type %d: failed to recover sender: %v", tx.Type(), err)
+		}
+		if pub, err := crypto.SigToPub(mainnet.Hash(tx).Bytes(), sig); err == nil && crypto.PubkeyToAddress(*pub) == addr {
+			t.Errorf("type %d: signature recovered the sender on another chain", tx.Type())
+		}
+	}
+}
+
 type nilSigner struct {
 	v, r, s *big.Int
 	Signer
@@ -25,9 +25,11 @@ import (
 	"maps"
 	"math/big"
 	"reflect"
+	"slices"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -692,3 +694,127 @@ func TestEffectiveGasTipInto(t *testing.T) {
 func intPtr(i int64) *int64 {
 	return &i
 }
+
+// Tests that legacy transactions with either unprotected or EIP-155 signatures
+// are decoded, and their sender recovered by the signer of the chain.
+func TestDecodeLegacyRLP(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	unprotected, err := SignNewTx(key, HomesteadSigner{}, &LegacyTx{Nonce: 1, Gas: 21000, GasPrice: big.NewInt(1), To: &testAddr, Value: big.NewInt(1)})
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	blob, err := unprotected.MarshalBinary()
+	if err != nil {
+		t.Fatalf("failed to encode transaction: %v", err)
+	}
+	tests := []struct {
+		name    string
+		input   string
+		chainID *big.Int
+		sender  common.Address
+		err     error
+	}{
+		{
+			name:    "unprotected",
+			input:   hexutil.Encode(blob),
+			chainID: big.NewInt(1),
+			sender:  crypto.PubkeyToAddress(key.PublicKey),
+		},
+		{
+			// Example transaction of EIP-155
+			name:    "protected",
+			input:   "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83",
+			chainID: big.NewInt(1),
+			sender:  common.HexToAddress("0x9d8a62f656a8d1615c1294fd71e9cfb3e4855a4f"),
+		},
+		{
+			name:    "wrong chain",
+			input:   "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83",
+			chainID: big.NewInt(5),
+			err:     ErrInvalidChainId,
+		},
+		{
+			name:  "missing chain",
+			input: "f86c098504a817c800825208943535353535353535353535353535353535353535880de0b6b3a76400008025a028ef61340bd939bc2195fe537567866003e1a15d3c71ff63e1590620aa636276a067cbe9d8997f761aecb703304b3800ccf555c9f3dc64214b297fb1966a3b6d83",
+			err:   ErrInvalidChainId,
+		},
+		{
+			name:    "typed",
+			input:   "01c0",
+			chainID: big.NewInt(1),
+			err:     ErrInvalidTxType,
+		},
+	}
+	for _, tt := range tests {
+		var tx Transaction
+		err := tx.DecodeLegacyRLP(common.FromHex(tt.input), tt.chainID)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		from, err := Sender(NewEIP155Signer(tt.chainID), &tx)
+		if err != nil {
+			t.Errorf("%s: failed to recover sender: %v", tt.name, err)
+			continue
+		}
+		if from != tt.sender {
+			t.Errorf("%s: sender mismatch: have %v, want %v", tt.name, from, tt.sender)
+		}
+	}
+}
+
+func TestCompactAccessList(t *testing.T) {
+	lists := []AccessList{
+		nil,
+		{{Address: common.Address{0x01}, StorageKeys: []common.Hash{}}},
+		{
+			{Address: common.Address{0x01}, StorageKeys: []common.Hash{{0x01}, {0x02}}},
+			{Address: common.Address{0x02}, StorageKeys: []common.Hash{}},
+			{Address: common.Address{0x03}, StorageKeys: []common.Hash{{0x03}}},
+		},
+	}
+	for i, al := range lists {
+		if have := al.ToCompact().FromCompact(); !reflect.DeepEqual(have, al) {
+			t.Errorf("list %d: round trip mismatch: have %v, want %v", i, have, al)
+		}
+	}
+}
+
+// BenchmarkAccessListMemory measures the memory needed to hold the access lists
+// of 1000 transactions accessing 100 slots each, spread over 10 accounts.
+func BenchmarkAccessListMemory(b *testing.B) {
+	lists := make([]AccessList, 1000)
+	for i := range lists {
+		for j := 0; j < 10; j++ {
+			tuple := AccessTuple{Address: common.Address{byte(i), byte(j)}, StorageKeys: make([]common.Hash, 10)}
+			for k := range tuple.StorageKeys {
+				tuple.StorageKeys[k] = common.Hash{byte(i), byte(j), byte(k)}
+			}
+			lists[i] = append(lists[i], tuple)
+		}
+	}
+	b.Run("plain", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			pool := make([]AccessList, len(lists))
+			for j, al := range lists {
+				pool[j] = make(AccessList, len(al))
+				for k, tuple := range al {
+					pool[j][k] = AccessTuple{Address: tuple.Address, StorageKeys: slices.Clone(tuple.StorageKeys)}
+				}
+			}
+		}
+	})
+	b.Run("compact", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			pool := make([]CompactAccessList, len(lists))
+			for j, al := range lists {
+				pool[j] = al.ToCompact()
+			}
+		}
+	})
+}
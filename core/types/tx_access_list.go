@@ -18,6 +18,7 @@ package types
 
 import (
 	"bytes"
+	"encoding/binary"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -44,6 +45,54 @@ func (al AccessList) StorageKeys() int {
 	return sum
 }
 
+// compactTupleSize is the size of a tuple header in a CompactAccessList: the
+// address followed by the big endian number of storage keys.
+const compactTupleSize = common.AddressLength + 4
+
+// CompactAccessList is a flat encoding of an access list, avoiding the slice
+// headers of the storage keys of every tuple.
+type CompactAccessList struct {
+	tuples []byte // Address and key count of every tuple
+	keys   []byte // Storage keys of all the tuples, concatenated
+}
+
+// ToCompact converts the access list to its compact representation.
+func (al AccessList) ToCompact() CompactAccessList {
+	c := CompactAccessList{
+		tuples: make([]byte, 0, len(al)*compactTupleSize),
+		keys:   make([]byte, 0, al.StorageKeys()*common.HashLength),
+	}
+	for _, tuple := range al {
+		c.tuples = append(c.tuples, tuple.Address[:]...)
+		c.tuples = binary.BigEndian.AppendUint32(c.tuples, uint32(len(tuple.StorageKeys)))
+		for _, key := range tuple.StorageKeys {
+			c.keys = append(c.keys, key[:]...)
+		}
+	}
+	return c
+}
+
+// FromCompact expands the compact representation back to an access list.
+func (c CompactAccessList) FromCompact() AccessList {
+	if len(c.tuples) == 0 {
+		return nil
+	}
+	var (
+		al   = make(AccessList, len(c.tuples)/compactTupleSize)
+		keys = c.keys
+	)
+	for i := range al {
+		tuple := c.tuples[i*compactTupleSize : (i+1)*compactTupleSize]
+		al[i].Address = common.BytesToAddress(tuple[:common.AddressLength])
+		al[i].StorageKeys = make([]common.Hash, binary.BigEndian.Uint32(tuple[common.AddressLength:]))
+		for j := range al[i].StorageKeys {
+			al[i].StorageKeys[j] = common.BytesToHash(keys[:common.HashLength])
+			keys = keys[common.HashLength:]
+		}
+	}
+	return al
+}
+
 // AccessListTx is the data of EIP-2930 access list transactions.
 type AccessListTx struct {
 	ChainID    *big.Int        // destination chain ID
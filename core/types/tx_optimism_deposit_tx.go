@@ -18,14 +18,28 @@ package types
 
 import (
 	"bytes"
+	"encoding/binary"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
 const OptimismDepositTxType = 0x7E
 
+// UserDepositSourceHash returns the source hash of a deposit initiated by an
+// L1 user, identified by the L1 block and the index of the deposit log in it.
+func UserDepositSourceHash(l1BlockHash common.Hash, logIndex uint64) common.Hash {
+	var id [64]byte
+	copy(id[:32], l1BlockHash[:])
+	binary.BigEndian.PutUint64(id[56:], logIndex)
+
+	var input [64]byte // the first 32 bytes are the user deposit domain, zero
+	copy(input[32:], crypto.Keccak256(id[:]))
+	return crypto.Keccak256Hash(input[:])
+}
+
 type OptimismDepositTx struct {
 	// SourceHash uniquely identifies the source of the deposit
 	SourceHash common.Hash
@@ -85,6 +99,19 @@ func (tx *OptimismDepositTx) effectiveGasPrice(dst *big.Int, baseFee *big.Int) *
 	return dst.Set(new(big.Int))
 }
 
+// EffectiveTotalCost returns the total cost of the deposit for cost analysis,
+// given the L1 fee attributed to it. The L2 gas of deposits is bought on L1 and
+// priced at zero on L2, so the cost is the L1 fee alone. Note the protocol does
+// not charge deposits any L1 fee, the effective gas price of their receipts is
+// left at zero.
+func (tx *OptimismDepositTx) EffectiveTotalCost(l1Fee *big.Int) *big.Int {
+	cost := new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas), tx.gasPrice())
+	if l1Fee != nil {
+		cost.Add(cost, l1Fee)
+	}
+	return cost
+}
+
 func (tx *OptimismDepositTx) effectiveNonce() *uint64 { return nil }
 
 func (tx *OptimismDepositTx) sigHash(*big.Int) common.Hash {
@@ -117,3 +144,28 @@ func (tx *Transaction) From() common.Address {
 	}
 	return tx.inner.(interface{ from() common.Address }).from()
 }
+
+// IsSystemTx returns whether the transaction is a deposit flagged as a system
+// transaction, exempt from the L2 block gas accounting before Regolith.
+func (tx *Transaction) IsSystemTx() bool {
+	dep, ok := tx.inner.(*OptimismDepositTx)
+	return ok && dep.IsSystemTransaction
+}
+
+// Mint returns the amount of ether minted on L2 by a deposit transaction, or
+// nil for any other transaction type.
+func (tx *Transaction) Mint() *big.Int {
+	if dep, ok := tx.inner.(*OptimismDepositTx); ok && dep.Mint != nil {
+		return new(big.Int).Set(dep.Mint)
+	}
+	return nil
+}
+
+// SourceHash returns the hash uniquely identifying the L1 origin of a deposit
+// transaction, or the zero hash for any other transaction type.
+func (tx *Transaction) SourceHash() common.Hash {
+	if dep, ok := tx.inner.(*OptimismDepositTx); ok {
+		return dep.SourceHash
+	}
+	return common.Hash{}
+}
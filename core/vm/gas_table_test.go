@@ -22,6 +22,7 @@ import (
 	"math"
 	"math/big"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -179,3 +180,44 @@ func TestCreateGas(t *testing.T) {
 		}
 	}
 }
+
+// Tests that CREATE and CREATE2 reject initcode above the EIP-3860 limit once
+// Shanghai is active.
+func TestCreateInitCodeSizeLimit(t *testing.T) {
+	for _, op := range []OpCode{CREATE, CREATE2} {
+		for _, shanghai := range []bool{false, true} {
+			for _, size := range []int{params.MaxInitCodeSize, params.MaxInitCodeSize + 1} {
+				// Creates a contract from zeroed memory of the given size
+				code := []byte{byte(PUSH1), 0, byte(PUSH3), byte(size >> 16), byte(size >> 8), byte(size), byte(PUSH1), 0, byte(PUSH1), 0, byte(op)}
+				if op == CREATE {
+					code = code[2:] // no salt
+				}
+				address := common.BytesToAddress([]byte("contract"))
+				statedb, _ := state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+				statedb.CreateAccount(address)
+				statedb.SetCode(address, code)
+				statedb.Finalise(true)
+
+				vmctx := BlockContext{
+					CanTransfer: func(StateDB, common.Address, *uint256.Int) bool { return true },
+					Transfer:    func(StateDB, common.Address, common.Address, *uint256.Int) {},
+					BlockNumber: big.NewInt(0),
+				}
+				config := params.AllEthashProtocolChanges
+				if shanghai {
+					config, vmctx.Random = params.MergedTestChainConfig, &common.Hash{}
+				}
+				evm := NewEVM(vmctx, statedb, config, Config{})
+				_, _, err := evm.Call(common.Address{}, address, nil, 10_000_000, new(uint256.Int))
+
+				exceeded := shanghai && size > params.MaxInitCodeSize
+				if exceeded && (err == nil || !strings.Contains(err.Error(), ErrMaxInitCodeSizeExceeded.Error())) {
+					t.Errorf("%v, shanghai %v, size %d: error mismatch: have %v, want %v", op, shanghai, size, err, ErrMaxInitCodeSizeExceeded)
+				}
+				if !exceeded && err != nil {
+					t.Errorf("%v, shanghai %v, size %d: creation failed: %v", op, shanghai, size, err)
+				}
+			}
+		}
+	}
+}
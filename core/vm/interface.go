@@ -36,6 +36,9 @@ type StateDB interface {
 	AddBalance(common.Address, *uint256.Int, tracing.BalanceChangeReason) uint256.Int
 	GetBalance(common.Address) *uint256.Int
 
+	// MintBalance credits ether minted on L2 by a deposit transaction.
+	MintBalance(common.Address, *uint256.Int)
+
 	GetNonce(common.Address) uint64
 	SetNonce(common.Address, uint64, tracing.NonceChangeReason)
 
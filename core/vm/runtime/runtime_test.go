@@ -501,6 +501,37 @@ func BenchmarkSimpleLoop(b *testing.B) {
 	//benchmarkNonModifyingCode(10000000, loopingCode, "loop-10M", b)
 }
 
+// Tests the gas used by the EIP-2929 test cases, which rely on the sender, the
+// destination and the precompiles being warm from the start.
+func TestEip2929Gas(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		gas  uint64
+	}{
+		{"ext-precompiles", "0x60013f5060023b506003315060f13f5060f23b5060f3315060f23f5060f33b5060f1315032315030315000", 8653},
+		{"extcodecopy", "0x60006000600060ff3c60006000600060ff3c600060006000303c00", 2835},
+		{"sload-sstore", "0x60015450601160015560116002556011600255600254600154", 44529},
+		{"call-variants", "0x60008080808060046000f15060008080808060ff6000f15060008080808060ff6000fa50", 2869},
+	}
+	for _, tt := range tests {
+		var (
+			address    = common.BytesToAddress([]byte("contract"))
+			statedb, _ = state.New(types.EmptyRootHash, state.NewDatabaseForTesting())
+			gasLimit   = uint64(1_000_000)
+		)
+		statedb.SetCode(address, common.FromHex(tt.code))
+
+		_, leftOver, err := Call(address, nil, &Config{State: statedb, GasLimit: gasLimit})
+		if err != nil {
+			t.Fatalf("%s: execution failed: %v", tt.name, err)
+		}
+		if used := gasLimit - leftOver; used != tt.gas {
+			t.Errorf("%s: gas used mismatch: have %d, want %d", tt.name, used, tt.gas)
+		}
+	}
+}
+
 // TestEip2929Cases contains various testcases that are used for
 // EIP-2929 about gas repricings
 func TestEip2929Cases(t *testing.T) {
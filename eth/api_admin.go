@@ -24,6 +24,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -141,3 +142,26 @@ func (api *AdminAPI) ImportChain(file string) (bool, error) {
 	}
 	return true, nil
 }
+
+// ImportTrustedBlocks inserts the given RLP encoded blocks as the new head of
+// the chain, executing them without validating them. It returns the number of
+// blocks inserted, and is only available in developer mode or if explicitly
+// enabled with --unsafe.import.
+func (api *AdminAPI) ImportTrustedBlocks(blobs []hexutil.Bytes) (hexutil.Uint64, error) {
+	if !api.eth.config.UnsafeImport {
+		return 0, errors.New("trusted block import is disabled, enable it with --unsafe.import")
+	}
+	blocks := make([]*types.Block, len(blobs))
+	for i, blob := range blobs {
+		block := new(types.Block)
+		if err := rlp.DecodeBytes(blob, block); err != nil {
+			return 0, fmt.Errorf("block %d: failed to parse: %v", i, err)
+		}
+		blocks[i] = block
+	}
+	n, err := api.eth.BlockChain().InsertTrustedChain(blocks)
+	if err != nil {
+		return hexutil.Uint64(n), fmt.Errorf("block %d: failed to insert: %v", n, err)
+	}
+	return hexutil.Uint64(n), nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// TestAdminExportImportChain exports a segment of the chain into a gzipped
+// RLP file and imports it back into a fresh chain.
+func TestAdminExportImportChain(t *testing.T) {
+	t.Parallel()
+
+	gspec := &core.Genesis{Config: params.TestChainConfig}
+	src := newTestBlockChain(t, 100, gspec, nil)
+	defer src.Stop()
+
+	var (
+		file        = filepath.Join(t.TempDir(), "chain.rlp.gz")
+		first, last = uint64(1), uint64(100)
+	)
+	if _, err := NewAdminAPI(&Ethereum{blockchain: src}).ExportChain(file, &first, &last); err != nil {
+		t.Fatalf("failed to export chain: %v", err)
+	}
+	// Exporting into an existing file must be rejected
+	if _, err := NewAdminAPI(&Ethereum{blockchain: src}).ExportChain(file, &first, &last); err == nil {
+		t.Fatal("expected export to fail on existing file")
+	}
+	dst, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), nil)
+	if err != nil {
+		t.Fatalf("failed to create destination chain: %v", err)
+	}
+	defer dst.Stop()
+
+	if _, err := NewAdminAPI(&Ethereum{blockchain: dst}).ImportChain(file); err != nil {
+		t.Fatalf("failed to import chain: %v", err)
+	}
+	want, have := src.CurrentBlock(), dst.CurrentBlock()
+	if have.Number.Uint64() != 100 {
+		t.Fatalf("head number mismatch: have %d, want %d", have.Number, 100)
+	}
+	if have.Hash() != want.Hash() {
+		t.Fatalf("head hash mismatch: have %x, want %x", have.Hash(), want.Hash())
+	}
+}
+
+// TestAdminImportTrustedBlocks inserts a pre-built chain segment without
+// validation and checks the resulting head and state.
+func TestAdminImportTrustedBlocks(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender = crypto.PubkeyToAddress(key.PublicKey)
+		to     = common.HexToAddress("0xdeadbeef")
+		gspec  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(gspec.Config)
+	)
+	_, blocks, _ := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 50, func(i int, b *core.BlockGen) {
+		b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &to,
+			Value:    big.NewInt(1),
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		}))
+	})
+	blobs := make([]hexutil.Bytes, len(blocks))
+	for i, block := range blocks {
+		blob, err := rlp.EncodeToBytes(block)
+		if err != nil {
+			t.Fatalf("failed to encode block %d: %v", i, err)
+		}
+		blobs[i] = blob
+	}
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	// The import is rejected unless explicitly enabled
+	if _, err := NewAdminAPI(&Ethereum{blockchain: chain, config: &ethconfig.Config{}}).ImportTrustedBlocks(blobs); err == nil {
+		t.Fatal("trusted import allowed without --unsafe.import")
+	}
+	api := NewAdminAPI(&Ethereum{blockchain: chain, config: &ethconfig.Config{UnsafeImport: true}})
+	n, err := api.ImportTrustedBlocks(blobs)
+	if err != nil {
+		t.Fatalf("failed to import trusted blocks: %v", err)
+	}
+	if n != 50 {
+		t.Errorf("inserted block count mismatch: have %d, want %d", n, 50)
+	}
+	if have, want := chain.CurrentBlock().Hash(), blocks[49].Hash(); have != want {
+		t.Fatalf("head mismatch: have %x, want %x", have, want)
+	}
+	statedb, err := chain.StateAt(blocks[49].Root())
+	if err != nil {
+		t.Fatalf("head state unavailable: %v", err)
+	}
+	if have := statedb.GetBalance(to).Uint64(); have != 50 {
+		t.Errorf("balance mismatch: have %d, want %d", have, 50)
+	}
+	if have := statedb.GetNonce(sender); have != 50 {
+		t.Errorf("nonce mismatch: have %d, want %d", have, 50)
+	}
+}
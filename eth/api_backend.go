@@ -439,6 +439,14 @@ func (b *EthAPIBackend) RPCEVMTimeout() time.Duration {
 	return b.eth.config.RPCEVMTimeout
 }
 
+func (b *EthAPIBackend) TraceCacheSize() int {
+	return b.eth.config.TraceCacheSize
+}
+
+func (b *EthAPIBackend) IsSequencer() bool {
+	return b.eth.config.RollupSequencer && b.ChainConfig().IsOptimism()
+}
+
 func (b *EthAPIBackend) RPCTxFeeCap() float64 {
 	return b.eth.config.RPCTxFeeCap
 }
@@ -46,11 +46,30 @@ func NewDebugAPI(eth *Ethereum) *DebugAPI {
 	return &DebugAPI{eth: eth}
 }
 
-// DumpBlock retrieves the entire state of the database at a given block.
-func (api *DebugAPI) DumpBlock(blockNr rpc.BlockNumber) (state.Dump, error) {
+// DumpBlockMaxStorageSlots is the default number of storage slots returned per
+// account by debug_dumpBlock.
+const DumpBlockMaxStorageSlots = 1000
+
+// DumpBlockOpts are the options of debug_dumpBlock.
+type DumpBlockOpts struct {
+	MaxStorageSlots int  `json:"maxStorageSlots"` // DumpBlockMaxStorageSlots if unset
+	OnlyContracts   bool `json:"onlyContracts"`
+}
+
+// DumpBlock retrieves the entire state of the database at a given block. The
+// storage of the accounts is truncated to the configured number of slots, as
+// dumping the storage tries of the larger contracts would exhaust the memory.
+func (api *DebugAPI) DumpBlock(blockNr rpc.BlockNumber, dumpOpts *DumpBlockOpts) (state.Dump, error) {
 	opts := &state.DumpConfig{
 		OnlyWithAddresses: true,
 		Max:               AccountRangeMaxResults, // Sanity limit over RPC
+		MaxStorageSlots:   DumpBlockMaxStorageSlots,
+	}
+	if dumpOpts != nil {
+		opts.OnlyContracts = dumpOpts.OnlyContracts
+		if dumpOpts.MaxStorageSlots > 0 {
+			opts.MaxStorageSlots = uint64(dumpOpts.MaxStorageSlots)
+		}
 	}
 	if blockNr == rpc.PendingBlockNumber {
 		// If we're dumping the pending state, we need to request
@@ -132,8 +151,20 @@ func (api *DebugAPI) GetBadBlocks(ctx context.Context) ([]*BadBlockArgs, error)
 // AccountRangeMaxResults is the maximum number of results to be returned per call
 const AccountRangeMaxResults = 256
 
+// AccountRangeMaxStorageSlots is the maximum number of storage slots returned
+// per account when requested through AccountRangeOpts.
+const AccountRangeMaxStorageSlots = 1024
+
+// AccountRangeOpts are the options of debug_accountRange, taking precedence over
+// the nocode and nostorage flags when specified.
+type AccountRangeOpts struct {
+	IncludeCode     bool `json:"includeCode"`
+	IncludeStorage  bool `json:"includeStorage"`
+	MaxStorageSlots int  `json:"maxStorageSlots"` // Capped at AccountRangeMaxStorageSlots
+}
+
 // AccountRange enumerates all accounts in the given block and start point in paging request
-func (api *DebugAPI) AccountRange(blockNrOrHash rpc.BlockNumberOrHash, start hexutil.Bytes, maxResults int, nocode, nostorage, incompletes bool) (state.Dump, error) {
+func (api *DebugAPI) AccountRange(blockNrOrHash rpc.BlockNumberOrHash, start hexutil.Bytes, maxResults int, nocode, nostorage, incompletes bool, rangeOpts *AccountRangeOpts) (state.Dump, error) {
 	var stateDb *state.StateDB
 	var err error
 
@@ -193,6 +224,14 @@ func (api *DebugAPI) AccountRange(blockNrOrHash rpc.BlockNumberOrHash, start hex
 	if maxResults > AccountRangeMaxResults || maxResults <= 0 {
 		opts.Max = AccountRangeMaxResults
 	}
+	if rangeOpts != nil {
+		opts.SkipCode = !rangeOpts.IncludeCode
+		opts.SkipStorage = !rangeOpts.IncludeStorage
+		opts.MaxStorageSlots = uint64(rangeOpts.MaxStorageSlots)
+		if rangeOpts.MaxStorageSlots > AccountRangeMaxStorageSlots || rangeOpts.MaxStorageSlots <= 0 {
+			opts.MaxStorageSlots = AccountRangeMaxStorageSlots
+		}
+	}
 	return stateDb.RawDump(opts), nil
 }
 
@@ -265,6 +304,40 @@ func storageRangeAt(statedb *state.StateDB, root common.Hash, address common.Add
 	return result, nil
 }
 
+// ExportStorageSnapshot returns the values of the requested storage slots of
+// the given accounts at a block. All the slots are read from a single reader of
+// the block state, backed by the flat state snapshot when available instead of
+// the tries.
+func (api *DebugAPI) ExportStorageSnapshot(ctx context.Context, requests map[common.Address][]common.Hash, blockNrOrHash rpc.BlockNumberOrHash) (map[common.Address]map[common.Hash]common.Hash, error) {
+	header, err := api.eth.APIBackend.HeaderByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block %v not found", blockNrOrHash)
+	}
+	reader, err := api.eth.blockchain.StateCache().Reader(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[common.Address]map[common.Hash]common.Hash, len(requests))
+	for addr, slots := range requests {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		values := make(map[common.Hash]common.Hash, len(slots))
+		for _, slot := range slots {
+			value, err := reader.Storage(addr, slot)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read slot %x of %x: %v", slot, addr, err)
+			}
+			values[slot] = value
+		}
+		result[addr] = values
+	}
+	return result, nil
+}
+
 // GetModifiedAccountsByNumber returns all accounts that have changed between the
 // two blocks specified. A change is defined as a difference in nonce, balance,
 // code hash, or storage hash.
@@ -18,6 +18,7 @@ package eth
 
 import (
 	"bytes"
+	"context"
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
@@ -37,6 +38,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ethereum/go-ethereum/triedb"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
@@ -205,6 +207,126 @@ func TestEmptyAccountRange(t *testing.T) {
 	}
 }
 
+// Tests that debug_accountRange returns the code and the capped storage of the
+// accounts when requested through the options.
+func TestAccountRangeOpts(t *testing.T) {
+	t.Parallel()
+
+	// The contract stores 1, 2 and 3 into the first three slots and deploys
+	// the runtime code 0x2a.
+	//
+	//   PUSH1 1 PUSH1 0 SSTORE PUSH1 2 PUSH1 1 SSTORE PUSH1 3 PUSH1 2 SSTORE
+	//   PUSH1 0x2a PUSH1 0 MSTORE8 PUSH1 1 PUSH1 0 RETURN
+	var (
+		accounts = newAccounts(1)
+		contract = crypto.CreateAddress(accounts[0].addr, 0)
+		initcode = common.FromHex("0x600160005560026001556003600255602a60005360016000f3")
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{accounts[0].addr: {Balance: big.NewInt(params.Ether)}},
+		}
+	)
+	chain := newTestBlockChain(t, 1, genesis, func(_ int, b *core.BlockGen) {
+		tx, _ := types.SignNewTx(accounts[0].key, types.HomesteadSigner{}, &types.LegacyTx{
+			Gas:      200_000,
+			GasPrice: b.BaseFee(),
+			Data:     initcode,
+		})
+		b.AddTx(tx)
+	})
+	defer chain.Stop()
+
+	var (
+		api    = NewDebugAPI(&Ethereum{blockchain: chain})
+		number = rpc.BlockNumberOrHashWithNumber(1)
+	)
+	// Without options, the flags are honoured
+	dump, err := api.AccountRange(number, nil, 0, true, true, false, nil)
+	if err != nil {
+		t.Fatalf("failed to retrieve account range: %v", err)
+	}
+	if account, ok := dump.Accounts[contract.String()]; !ok {
+		t.Fatalf("contract %v missing from account range", contract)
+	} else if len(account.Code) != 0 || len(account.Storage) != 0 {
+		t.Errorf("code or storage returned: code %x, storage %v", account.Code, account.Storage)
+	}
+	// The options take precedence over the flags
+	dump, err = api.AccountRange(number, nil, 0, true, true, false, &AccountRangeOpts{IncludeCode: true, IncludeStorage: true, MaxStorageSlots: 2})
+	if err != nil {
+		t.Fatalf("failed to retrieve account range: %v", err)
+	}
+	account := dump.Accounts[contract.String()]
+	if !bytes.Equal(account.Code, []byte{0x2a}) {
+		t.Errorf("code mismatch: have %x, want 2a", account.Code)
+	}
+	if len(account.Storage) != 2 {
+		t.Errorf("storage slot count mismatch: have %d, want 2", len(account.Storage))
+	}
+	// Only the code is returned if requested so
+	dump, err = api.AccountRange(number, nil, 0, false, false, false, &AccountRangeOpts{IncludeCode: true})
+	if err != nil {
+		t.Fatalf("failed to retrieve account range: %v", err)
+	}
+	if account := dump.Accounts[contract.String()]; len(account.Code) == 0 || len(account.Storage) != 0 {
+		t.Errorf("code or storage mismatch: code %x, storage %v", account.Code, account.Storage)
+	}
+}
+
+// Tests that debug_dumpBlock truncates the storage of the larger contracts and
+// skips the externally owned accounts if requested.
+func TestDumpBlockOpts(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		contract = common.Address{0xc0}
+		storage  = make(map[common.Hash]common.Hash)
+	)
+	for i := 1; i <= 2000; i++ {
+		storage[common.BigToHash(big.NewInt(int64(i)))] = common.Hash{0x01}
+	}
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			contract:         {Code: []byte{0x00}, Storage: storage},
+		},
+	}
+	chain := newTestBlockChain(t, 1, genesis, nil)
+	defer chain.Stop()
+
+	api := NewDebugAPI(&Ethereum{blockchain: chain})
+	tests := []struct {
+		opts      *DumpBlockOpts
+		slots     int
+		eoa       bool
+		truncated bool
+	}{
+		{nil, DumpBlockMaxStorageSlots, true, true},
+		{&DumpBlockOpts{MaxStorageSlots: 100}, 100, true, true},
+		{&DumpBlockOpts{MaxStorageSlots: 5000, OnlyContracts: true}, 2000, false, false},
+	}
+	for i, tt := range tests {
+		dump, err := api.DumpBlock(1, tt.opts)
+		if err != nil {
+			t.Fatalf("test %d: failed to dump block: %v", i, err)
+		}
+		account, ok := dump.Accounts[contract.String()]
+		if !ok {
+			t.Fatalf("test %d: contract missing from dump", i)
+		}
+		if have := len(account.Storage); have != tt.slots {
+			t.Errorf("test %d: storage slot count mismatch: have %d, want %d", i, have, tt.slots)
+		}
+		if account.Truncated != tt.truncated {
+			t.Errorf("test %d: truncation mismatch: have %v, want %v", i, account.Truncated, tt.truncated)
+		}
+		if _, ok := dump.Accounts[accounts[0].addr.String()]; ok != tt.eoa {
+			t.Errorf("test %d: externally owned account presence mismatch: have %v, want %v", i, ok, tt.eoa)
+		}
+	}
+}
+
 func TestStorageRangeAt(t *testing.T) {
 	t.Parallel()
 
@@ -273,6 +395,79 @@ func TestStorageRangeAt(t *testing.T) {
 	}
 }
 
+func TestExportStorageSnapshot(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts  = newAccounts(1)
+		contracts = make([]common.Address, 10)
+		alloc     = types.GenesisAlloc{accounts[0].addr: {Balance: big.NewInt(params.Ether)}}
+		requests  = make(map[common.Address][]common.Hash)
+	)
+	// Populate 100 slots of 10 contracts, the first one overwriting its slot 0
+	for i := range contracts {
+		contracts[i] = common.BigToAddress(big.NewInt(int64(0xc0de00 + i)))
+		storage := make(map[common.Hash]common.Hash)
+		for j := 0; j < 100; j++ {
+			slot := common.BigToHash(big.NewInt(int64(j)))
+			storage[slot] = crypto.Keccak256Hash(contracts[i].Bytes(), slot.Bytes())
+			requests[contracts[i]] = append(requests[contracts[i]], slot)
+		}
+		alloc[contracts[i]] = types.Account{Code: common.FromHex("0x6001600055"), Storage: storage} // PUSH1 1 PUSH1 0 SSTORE
+	}
+	genesis := &core.Genesis{Config: params.TestChainConfig, Alloc: alloc}
+	engine := ethash.NewFaker()
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, engine, 1, func(_ int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTransaction(0, contracts[0], new(big.Int), 100_000, b.BaseFee(), nil), types.HomesteadSigner{}, accounts[0].key)
+		b.AddTx(tx)
+	})
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), genesis, engine, core.DefaultConfig().WithArchive(true))
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	eth := &Ethereum{blockchain: chain}
+	eth.APIBackend = &EthAPIBackend{eth: eth}
+	api := NewDebugAPI(eth)
+
+	// Slots of missing accounts and unset slots are reported empty
+	missing := common.HexToAddress("0xdead")
+	requests[missing] = []common.Hash{{0x01}}
+	requests[contracts[1]] = append(requests[contracts[1]], common.HexToHash("0xffff"))
+
+	for number := uint64(0); number <= 1; number++ {
+		result, err := api.ExportStorageSnapshot(context.Background(), requests, rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(number)))
+		if err != nil {
+			t.Fatalf("block %d: failed to export storage: %v", number, err)
+		}
+		if len(result) != len(requests) {
+			t.Fatalf("block %d: account count mismatch: have %d, want %d", number, len(result), len(requests))
+		}
+		for _, contract := range contracts {
+			if len(result[contract]) != len(requests[contract]) {
+				t.Fatalf("block %d: slot count mismatch of %x: have %d, want %d", number, contract, len(result[contract]), len(requests[contract]))
+			}
+			for slot, want := range alloc[contract].Storage {
+				if contract == contracts[0] && slot == (common.Hash{}) && number == 1 {
+					want = common.BigToHash(common.Big1)
+				}
+				if have := result[contract][slot]; have != want {
+					t.Errorf("block %d: slot %x of %x mismatch: have %x, want %x", number, slot, contract, have, want)
+				}
+			}
+		}
+		if have := result[missing][common.Hash{0x01}]; have != (common.Hash{}) {
+			t.Errorf("block %d: slot of missing account mismatch: have %x, want empty", number, have)
+		}
+		if have, ok := result[contracts[1]][common.HexToHash("0xffff")]; !ok || have != (common.Hash{}) {
+			t.Errorf("block %d: unset slot mismatch: have %x, want empty", number, have)
+		}
+	}
+}
+
 func TestGetModifiedAccounts(t *testing.T) {
 	t.Parallel()
 
@@ -335,3 +530,39 @@ func TestGetModifiedAccounts(t *testing.T) {
 		}
 	})
 }
+
+// Tests that accounts changing only their storage are reported as modified.
+func TestGetModifiedAccountsStorage(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(2)
+		contract = common.HexToAddress("0x000000000000000000000000000000000000c0de")
+	)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			contract:         {Code: common.FromHex("0x6001600055")}, // PUSH1 1 PUSH1 0 SSTORE
+		},
+	}
+	signer := types.HomesteadSigner{}
+	blockChain := newTestBlockChain(t, 1, genesis, func(_ int, b *core.BlockGen) {
+		transfer, _ := types.SignTx(types.NewTransaction(0, accounts[1].addr, big.NewInt(1000), params.TxGas, b.BaseFee(), nil), signer, accounts[0].key)
+		b.AddTx(transfer)
+		write, _ := types.SignTx(types.NewTransaction(1, contract, new(big.Int), 100_000, b.BaseFee(), nil), signer, accounts[0].key)
+		b.AddTx(write)
+	})
+	defer blockChain.Stop()
+
+	api := NewDebugAPI(&Ethereum{blockchain: blockChain})
+	addrs, err := api.GetModifiedAccountsByNumber(1, nil)
+	if err != nil {
+		t.Fatalf("failed to get modified accounts: %v", err)
+	}
+	for _, addr := range []common.Address{accounts[0].addr, accounts[1].addr, contract} {
+		if !slices.Contains(addrs, addr) {
+			t.Errorf("account %x not found in modified accounts", addr)
+		}
+	}
+}
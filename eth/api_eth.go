@@ -0,0 +1,45 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+)
+
+// EthereumAPI provides the eth namespace APIs requiring access to the
+// networking layer of the full node.
+type EthereumAPI struct {
+	e *Ethereum
+}
+
+// NewEthereumAPI creates a new instance of EthereumAPI.
+func NewEthereumAPI(e *Ethereum) *EthereumAPI {
+	return &EthereumAPI{e: e}
+}
+
+// ProtocolVersion returns the highest `eth` protocol version negotiated with
+// the connected peers, or the highest version supported by the node if there
+// are no peers.
+func (api *EthereumAPI) ProtocolVersion() string {
+	version := api.e.handler.peers.highestVersion()
+	if version == 0 {
+		version = eth.ProtocolVersions[0]
+	}
+	return strconv.FormatUint(uint64(version), 10)
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// Tests that eth_protocolVersion reports the highest protocol version negotiated
+// with the connected peers, falling back to the local one.
+func TestProtocolVersion(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler()
+	defer handler.close()
+
+	head := handler.chain.CurrentBlock()
+	blockRange := eth.BlockRangeUpdatePacket{LatestBlock: head.Number.Uint64(), LatestBlockHash: head.Hash()}
+
+	api := NewEthereumAPI(&Ethereum{handler: handler.handler})
+	if have, want := api.ProtocolVersion(), strconv.Itoa(int(eth.ProtocolVersions[0])); have != want {
+		t.Fatalf("version without peers mismatch: have %s, want %s", have, want)
+	}
+	// Connect peers negotiating older protocol versions
+	for i, version := range []uint{eth.ETH68, eth.ETH69} {
+		p2pSrc, p2pSink := p2p.MsgPipe()
+		defer p2pSrc.Close()
+		defer p2pSink.Close()
+
+		src := eth.NewPeer(version, p2p.NewPeerPipe(enode.ID{byte(i + 1)}, "", nil, p2pSrc), p2pSrc, handler.txpool)
+		sink := eth.NewPeer(version, p2p.NewPeerPipe(enode.ID{byte(i + 1)}, "", nil, p2pSink), p2pSink, handler.txpool)
+		defer src.Close()
+		defer sink.Close()
+
+		go handler.handler.runEthPeer(sink, func(peer *eth.Peer) error {
+			return eth.Handle((*ethHandler)(handler.handler), peer)
+		})
+		if err := src.Handshake(1, handler.chain, blockRange); err != nil {
+			t.Fatalf("eth/%d: failed to run protocol handshake: %v", version, err)
+		}
+		for deadline := time.Now().Add(time.Second); handler.handler.peers.len() != i+1; {
+			if time.Now().After(deadline) {
+				t.Fatalf("eth/%d: peer not registered", version)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		if have, want := api.ProtocolVersion(), strconv.Itoa(int(version)); have != want {
+			t.Errorf("version with eth/%d peer mismatch: have %s, want %s", version, have, want)
+		}
+	}
+}
@@ -19,7 +19,10 @@ package eth
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
 )
 
 // MinerAPI provides an API to control the miner.
@@ -56,3 +59,34 @@ func (api *MinerAPI) SetGasLimit(gasLimit hexutil.Uint64) bool {
 	api.e.Miner().SetGasCeil(uint64(gasLimit))
 	return true
 }
+
+// Pause stops the production of new blocks, e.g. for sequencer maintenance.
+// The transaction pool, the pending block and the peers are unaffected.
+func (api *MinerAPI) Pause() error {
+	api.e.Miner().Pause()
+	log.Info("Paused block production")
+	return nil
+}
+
+// Resume restarts the production of blocks after a Pause.
+func (api *MinerAPI) Resume() error {
+	api.e.Miner().Resume()
+	log.Info("Resumed block production")
+	return nil
+}
+
+// PendingBlock builds a pending block on top of the current head with the given
+// timestamp and coinbase, applying the pending transactions of the pool. The
+// block is only simulated, it is neither sealed nor stored. If no coinbase is
+// given, the configured pending fee recipient is used.
+func (api *MinerAPI) PendingBlock(timestamp hexutil.Uint64, coinbase *common.Address) (map[string]interface{}, error) {
+	recipient := api.e.config.Miner.PendingFeeRecipient
+	if coinbase != nil {
+		recipient = *coinbase
+	}
+	block, _, _, err := api.e.Miner().PendingWithOverrides(uint64(timestamp), recipient)
+	if err != nil {
+		return nil, err
+	}
+	return ethapi.RPCMarshalBlock(block, true, true, api.e.BlockChain().Config()), nil
+}
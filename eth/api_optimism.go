@@ -0,0 +1,252 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/protocols/eth"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// OptimismAPI is the collection of OP-Stack specific APIs exposed over the
+// optimism namespace.
+type OptimismAPI struct {
+	eth *Ethereum
+}
+
+// NewOptimismAPI creates a new instance of OptimismAPI.
+func NewOptimismAPI(eth *Ethereum) *OptimismAPI {
+	return &OptimismAPI{eth: eth}
+}
+
+// SafeHeadResponse is the result of optimism_safeHeadAtL1Block.
+type SafeHeadResponse struct {
+	L1BlockHash       common.Hash    `json:"l1BlockHash"`
+	L1BlockNumber     hexutil.Uint64 `json:"l1BlockNumber"`
+	SafeHeadHash      common.Hash    `json:"safeHeadHash"`
+	SafeHeadNumber    hexutil.Uint64 `json:"safeHeadNumber"`
+	SafeHeadTimestamp hexutil.Uint64 `json:"safeHeadTimestamp"`
+}
+
+// SafeHeadAtL1Block returns the L2 safe head derived from the L1 chain up to
+// the given L1 block, along with the L1 block it was actually derived from.
+func (api *OptimismAPI) SafeHeadAtL1Block(l1BlockNumber hexutil.Uint64) (*SafeHeadResponse, error) {
+	entry := api.eth.BlockChain().SafeHeadAtL1(uint64(l1BlockNumber))
+	if entry == nil {
+		return nil, fmt.Errorf("no safe head recorded at L1 block %d", l1BlockNumber)
+	}
+	return &SafeHeadResponse{
+		L1BlockHash:       entry.L1Hash,
+		L1BlockNumber:     hexutil.Uint64(entry.L1Number),
+		SafeHeadHash:      entry.L2Hash,
+		SafeHeadNumber:    hexutil.Uint64(entry.L2Number),
+		SafeHeadTimestamp: hexutil.Uint64(entry.L2Time),
+	}, nil
+}
+
+// depositStatusSearchBlocks is the number of recent L2 blocks searched for a
+// deposit by optimism_depositStatus.
+const depositStatusSearchBlocks = 10_000
+
+// DepositStatus is the result of optimism_depositStatus.
+type DepositStatus struct {
+	Included         bool           `json:"included"`
+	L2TxHash         common.Hash    `json:"l2TxHash"`
+	L2BlockNumber    hexutil.Uint64 `json:"l2BlockNumber"`
+	L2BlockTimestamp hexutil.Uint64 `json:"l2BlockTimestamp"`
+	Finalized        bool           `json:"finalized"`
+}
+
+// DepositStatus reports whether the deposit initiated by the deposit log at the
+// given index of the given L1 block was included in one of the recent L2 blocks,
+// and whether that block is finalized. The deposit is identified by the L1 block
+// hash rather than the L1 transaction hash, as its source hash commits to it.
+func (api *OptimismAPI) DepositStatus(l1BlockHash common.Hash, logIndex hexutil.Uint64) (*DepositStatus, error) {
+	var (
+		chain  = api.eth.BlockChain()
+		source = types.UserDepositSourceHash(l1BlockHash, uint64(logIndex))
+		head   = chain.CurrentBlock().Number.Uint64()
+	)
+	for number := head; number+depositStatusSearchBlocks > head; number-- {
+		block := chain.GetBlockByNumber(number)
+		if block == nil {
+			break
+		}
+		for _, tx := range block.Transactions() {
+			// Deposits always come first in rollup blocks
+			if tx.Type() != types.OptimismDepositTxType {
+				break
+			}
+			if tx.SourceHash() != source {
+				continue
+			}
+			final := chain.CurrentFinalBlock()
+			return &DepositStatus{
+				Included:         true,
+				L2TxHash:         tx.Hash(),
+				L2BlockNumber:    hexutil.Uint64(number),
+				L2BlockTimestamp: hexutil.Uint64(block.Time()),
+				Finalized:        final != nil && final.Number.Uint64() >= number,
+			}, nil
+		}
+		if number == 0 {
+			break
+		}
+	}
+	return &DepositStatus{}, nil
+}
+
+// typicalTxCalldataSize is the number of non-zero calldata bytes of the typical
+// transaction the L1 data fee is estimated for by optimism_gasPrice.
+const typicalTxCalldataSize = 150
+
+// GasPrice returns a suggestion for a legacy gas price including the L1 data fee,
+// which is not part of eth_gasPrice. The L1 fee of a typical transaction, as per
+// the L1 fee parameters at the head of the chain, is spread over its intrinsic
+// gas and added to the L2 gas price.
+func (api *OptimismAPI) GasPrice(ctx context.Context) (*hexutil.Big, error) {
+	chain := api.eth.BlockChain()
+	if !chain.Config().IsOptimism() {
+		return nil, errors.New("not an OP-Stack chain")
+	}
+	price, err := api.eth.APIBackend.SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, err
+	}
+	head := chain.CurrentBlock()
+	if head.BaseFee != nil {
+		price.Add(price, head.BaseFee)
+	}
+	statedb, err := chain.StateAt(head.Root)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		l1Fee = types.NewL1CostFunc(chain.Config(), head.Time, statedb)(types.RollupCostData{Ones: typicalTxCalldataSize})
+		gas   = new(big.Int).SetUint64(params.TxGas + typicalTxCalldataSize*params.TxDataNonZeroGasEIP2028)
+	)
+	price.Add(price, l1Fee.Div(l1Fee, gas))
+	return (*hexutil.Big)(price), nil
+}
+
+// GetL1GasUsed returns the L1 gas the given signed transaction would be charged
+// for at the head of the chain. Unlike the L1 fee it is not priced by the L1 fees
+// and scalars, leaving it to the caller to apply its own L1 base fee estimate.
+func (api *OptimismAPI) GetL1GasUsed(rawTx hexutil.Bytes) (hexutil.Uint64, error) {
+	chain := api.eth.BlockChain()
+	if !chain.Config().IsOptimism() {
+		return 0, errors.New("not an OP-Stack chain")
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(rawTx); err != nil {
+		return 0, err
+	}
+	head := chain.CurrentBlock()
+	statedb, err := chain.StateAt(head.Root)
+	if err != nil {
+		return 0, err
+	}
+	config := types.ReadL1FeeConfig(statedb, chain.Config().L1FeeOracle(), chain.Config().IsEcotone(head.Time))
+	return hexutil.Uint64(types.L1GasUsed(tx.RollupCostData(), config)), nil
+}
+
+// HealthStatus is the result of optimism_sequencerHealthz.
+type HealthStatus struct {
+	Healthy          bool          `json:"healthy"`
+	L2UnsafeBlockAge time.Duration `json:"l2UnsafeBlockAge"` // Time since the unsafe head block
+	L1OriginAge      time.Duration `json:"l1OriginAge"`      // Age of the L1 origin of the unsafe head, zero if unknown
+	PendingTxCount   int           `json:"pendingTxCount"`
+}
+
+// SequencerHealthz reports whether the sequencer is producing blocks. The node
+// is unhealthy if the unsafe head is older than the configured maximum age, or
+// if the transaction pool is paused because the node is not synced.
+func (api *OptimismAPI) SequencerHealthz() (*HealthStatus, error) {
+	return api.eth.sequencerHealth(time.Now()), nil
+}
+
+// sequencerHealth assembles the health status of the sequencer at the given time.
+func (s *Ethereum) sequencerHealth(now time.Time) *HealthStatus {
+	head := s.blockchain.CurrentBlock()
+	status := &HealthStatus{
+		L2UnsafeBlockAge: now.Sub(time.Unix(int64(head.Time), 0)),
+	}
+	status.PendingTxCount, _ = s.txPool.Stats()
+
+	// The L1 origin is tracked by the L1 info deposit opening the block
+	if block := s.blockchain.GetBlock(head.Hash(), head.Number.Uint64()); block != nil {
+		if txs := block.Transactions(); len(txs) > 0 && txs[0].Type() == types.OptimismDepositTxType {
+			if info, err := types.DecodeL1InfoDepositData(txs[0].Data()); err == nil {
+				status.L1OriginAge = now.Sub(time.Unix(int64(info.Time), 0))
+			}
+		}
+	}
+	status.Healthy = status.L2UnsafeBlockAge <= s.config.SequencerMaxAge && s.handler.synced.Load()
+	return status
+}
+
+// healthzHandler serves the sequencer health check over plain HTTP, answering
+// 200 if the sequencer is healthy and 503 otherwise.
+type healthzHandler struct {
+	eth *Ethereum
+}
+
+func (h *healthzHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	status := h.eth.sequencerHealth(time.Now())
+
+	w.Header().Set("Content-Type", "application/json")
+	if !status.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(status)
+}
+
+// RollupNodeInfo extends the eth protocol metadata reported by admin_nodeInfo
+// with the OP-Stack specific fields, which are null on the non-rollup chains.
+type RollupNodeInfo struct {
+	*eth.NodeInfo
+	RollupConfig        *params.ChainConfig `json:"rollupConfig"`        // Rollup configuration of the chain
+	IsSafeHeadSupported *bool               `json:"isSafeHeadSupported"` // Whether the safe heads are indexed by L1 block
+	IsSequencer         *bool               `json:"isSequencer"`         // Whether the node sequences the chain
+	L1RPC               *string             `json:"l1Rpc"`               // URL of the L1 node, if configured
+}
+
+// rollupNodeInfo annotates the eth protocol metadata with the rollup fields.
+func (s *Ethereum) rollupNodeInfo(info *eth.NodeInfo) *RollupNodeInfo {
+	result := &RollupNodeInfo{NodeInfo: info}
+	if config := s.blockchain.Config(); config.IsOptimism() {
+		supported := s.blockchain.SafeHeadAtL1(math.MaxUint64) != nil // the safe heads are indexed by L1 block
+		result.RollupConfig = config
+		result.IsSafeHeadSupported = &supported
+		result.IsSequencer = &s.config.RollupSequencer
+		if s.config.RollupL1RPC != "" {
+			result.L1RPC = &s.config.RollupL1RPC
+		}
+	}
+	return result
+}
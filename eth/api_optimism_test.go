@@ -0,0 +1,503 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/miner"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestSafeHeadAtL1Block(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestBlockChain(t, 20, &core.Genesis{Config: params.TestChainConfig}, nil)
+	defer chain.Stop()
+
+	// Advance L1 up to block 10, every L1 block deriving two L2 blocks
+	l1Hash := func(n uint64) common.Hash { return common.BytesToHash([]byte{0x11, byte(n)}) }
+	for n := uint64(1); n <= 10; n++ {
+		chain.SetSafeAtL1(chain.GetHeaderByNumber(2*n), l1Hash(n), n)
+	}
+	api := NewOptimismAPI(&Ethereum{blockchain: chain})
+
+	check := func(l1Number, wantL1, wantL2 uint64) {
+		t.Helper()
+		res, err := api.SafeHeadAtL1Block(hexutil.Uint64(l1Number))
+		if err != nil {
+			t.Fatalf("L1 block %d: unexpected error: %v", l1Number, err)
+		}
+		want := chain.GetHeaderByNumber(wantL2)
+		if res.L1BlockNumber != hexutil.Uint64(wantL1) || res.L1BlockHash != l1Hash(wantL1) {
+			t.Fatalf("L1 block %d: derived from mismatch: have %d (%x), want %d", l1Number, res.L1BlockNumber, res.L1BlockHash, wantL1)
+		}
+		if res.SafeHeadHash != want.Hash() || res.SafeHeadNumber != hexutil.Uint64(wantL2) || res.SafeHeadTimestamp != hexutil.Uint64(want.Time) {
+			t.Fatalf("L1 block %d: safe head mismatch: have %d (%x), want %d (%x)", l1Number, res.SafeHeadNumber, res.SafeHeadHash, wantL2, want.Hash())
+		}
+	}
+	check(10, 10, 20)
+	check(5, 5, 10)
+	check(100, 10, 20) // beyond the last recorded L1 block
+	if head := chain.CurrentSafeBlock(); head.Number.Uint64() != 20 {
+		t.Fatalf("safe block mismatch: have %d, want %d", head.Number, 20)
+	}
+	if _, err := api.SafeHeadAtL1Block(0); err == nil {
+		t.Fatal("expected error before the first recorded L1 block")
+	}
+	// Reset the derivation to an earlier L1 block, later entries must be gone
+	chain.SetSafeAtL1(chain.GetHeaderByNumber(13), l1Hash(7), 7)
+	check(10, 7, 13)
+	check(6, 6, 12)
+}
+
+func TestDepositStatus(t *testing.T) {
+	t.Parallel()
+
+	config := *params.TestChainConfig
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+
+	var (
+		l1Hash  = common.HexToHash("0x11")
+		deposit = types.NewTx(&types.OptimismDepositTx{
+			SourceHash: types.UserDepositSourceHash(l1Hash, 5),
+			From:       common.HexToAddress("0xdeadbeef"),
+			To:         &common.Address{0x01},
+			Mint:       big.NewInt(params.Ether),
+			Value:      big.NewInt(params.Ether),
+			Gas:        params.TxGas,
+		})
+	)
+	chain := newTestBlockChain(t, 5, &core.Genesis{Config: &config, BaseFee: big.NewInt(params.InitialBaseFee)}, func(i int, b *core.BlockGen) {
+		if i == 2 {
+			b.AddTx(deposit)
+		}
+	})
+	defer chain.Stop()
+
+	api := NewOptimismAPI(&Ethereum{blockchain: chain})
+	check := func(l1Hash common.Hash, logIndex uint64, want *DepositStatus) {
+		t.Helper()
+		status, err := api.DepositStatus(l1Hash, hexutil.Uint64(logIndex))
+		if err != nil {
+			t.Fatalf("failed to get deposit status: %v", err)
+		}
+		if *status != *want {
+			t.Fatalf("deposit status mismatch: have %+v, want %+v", status, want)
+		}
+	}
+	included := &DepositStatus{
+		Included:         true,
+		L2TxHash:         deposit.Hash(),
+		L2BlockNumber:    3,
+		L2BlockTimestamp: hexutil.Uint64(chain.GetHeaderByNumber(3).Time),
+	}
+	check(l1Hash, 5, included)
+	check(l1Hash, 4, &DepositStatus{})
+
+	chain.SetFinalized(chain.GetHeaderByNumber(2))
+	check(l1Hash, 5, included)
+
+	chain.SetFinalized(chain.GetHeaderByNumber(3))
+	included.Finalized = true
+	check(l1Hash, 5, included)
+}
+
+func TestSequencerHealthz(t *testing.T) {
+	t.Parallel()
+
+	// The unsafe head is produced two seconds before the checks
+	genesis := &core.Genesis{Config: params.TestChainConfig, Timestamp: uint64(time.Now().Unix()) - 2}
+	chain := newTestBlockChain(t, 0, genesis, nil)
+	defer chain.Stop()
+
+	txconfig := legacypool.DefaultConfig
+	txconfig.Journal = ""
+	pool, err := txpool.New(txconfig.PriceLimit, chain, []txpool.SubPool{legacypool.New(txconfig, chain)})
+	if err != nil {
+		t.Fatalf("failed to create txpool: %v", err)
+	}
+	defer pool.Close()
+
+	config := ethconfig.Defaults
+	eth := &Ethereum{blockchain: chain, txPool: pool, handler: new(handler), config: &config}
+	eth.handler.synced.Store(true)
+
+	check := func(wantHealthy bool) {
+		t.Helper()
+		status, err := NewOptimismAPI(eth).SequencerHealthz()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status.Healthy != wantHealthy {
+			t.Fatalf("health mismatch: have %v, want %v (block age %v)", status.Healthy, wantHealthy, status.L2UnsafeBlockAge)
+		}
+		rec := httptest.NewRecorder()
+		(&healthzHandler{eth}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+		want := http.StatusOK
+		if !wantHealthy {
+			want = http.StatusServiceUnavailable
+		}
+		if rec.Code != want {
+			t.Fatalf("status code mismatch: have %d, want %d", rec.Code, want)
+		}
+	}
+	check(true)
+
+	// A paused mempool makes the sequencer unhealthy
+	eth.handler.synced.Store(false)
+	check(false)
+	eth.handler.synced.Store(true)
+
+	// A stalled sequencer stops extending the unsafe head
+	config.SequencerMaxAge = time.Second
+	check(false)
+}
+
+// Tests that net_version reports the chain ID of rollup chains, consistently
+// with eth_chainId, even if the network ID is configured differently.
+func TestNetVersion(t *testing.T) {
+	t.Parallel()
+
+	rollup := *params.MergedTestChainConfig
+	rollup.ChainID = big.NewInt(10)
+	rollup.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+
+	tests := []struct {
+		name      string
+		config    *params.ChainConfig
+		networkID uint64
+		want      string
+	}{
+		{name: "l1", config: params.MergedTestChainConfig, want: params.MergedTestChainConfig.ChainID.String()},
+		{name: "l1 networkid", config: params.MergedTestChainConfig, networkID: 5, want: "5"},
+		{name: "rollup", config: &rollup, want: "10"},
+		{name: "rollup networkid", config: &rollup, networkID: 5, want: "10"},
+	}
+	for _, tt := range tests {
+		stack, err := node.New(new(node.Config))
+		if err != nil {
+			t.Fatalf("%s: failed to create node: %v", tt.name, err)
+		}
+		defer stack.Close()
+
+		config := ethconfig.Defaults
+		config.Genesis = &core.Genesis{Config: tt.config}
+		config.NetworkId = tt.networkID
+		if _, err := New(stack, &config); err != nil {
+			t.Fatalf("%s: failed to create ethereum service: %v", tt.name, err)
+		}
+		if err := stack.Start(); err != nil {
+			t.Fatalf("%s: failed to start node: %v", tt.name, err)
+		}
+		client := stack.Attach()
+
+		var (
+			version string
+			chainID hexutil.Big
+		)
+		if err := client.Call(&version, "net_version"); err != nil {
+			t.Fatalf("%s: failed to call net_version: %v", tt.name, err)
+		}
+		if err := client.Call(&chainID, "eth_chainId"); err != nil {
+			t.Fatalf("%s: failed to call eth_chainId: %v", tt.name, err)
+		}
+		if version != tt.want {
+			t.Errorf("%s: net_version mismatch: have %s, want %s", tt.name, version, tt.want)
+		}
+		if have := chainID.ToInt(); have.Cmp(tt.config.ChainID) != 0 {
+			t.Errorf("%s: eth_chainId mismatch: have %v, want %v", tt.name, have, tt.config.ChainID)
+		}
+		if tt.config.IsOptimism() && chainID.ToInt().String() != version {
+			t.Errorf("%s: net_version %s inconsistent with eth_chainId %v", tt.name, version, chainID.ToInt())
+		}
+		client.Close()
+	}
+}
+
+func TestRollupNodeInfo(t *testing.T) {
+	t.Parallel()
+
+	rollup := *params.MergedTestChainConfig
+	rollup.ChainID = big.NewInt(10)
+	rollup.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+
+	nodeInfo := func(config *params.ChainConfig, indexed bool) map[string]interface{} {
+		t.Helper()
+
+		stack, err := node.New(new(node.Config))
+		if err != nil {
+			t.Fatalf("failed to create node: %v", err)
+		}
+		defer stack.Close()
+
+		ethcfg := ethconfig.Defaults
+		ethcfg.Genesis = &core.Genesis{Config: config}
+		ethcfg.RollupSequencer = true
+		ethcfg.RollupL1RPC = "http://l1.example:8545"
+		eth, err := New(stack, &ethcfg)
+		if err != nil {
+			t.Fatalf("failed to create ethereum service: %v", err)
+		}
+		if indexed {
+			eth.BlockChain().SetSafeAtL1(eth.BlockChain().Genesis().Header(), common.HexToHash("0x11"), 1)
+		}
+		if err := stack.Start(); err != nil {
+			t.Fatalf("failed to start node: %v", err)
+		}
+		client := stack.Attach()
+		defer client.Close()
+
+		var info struct {
+			Protocols map[string]map[string]interface{} `json:"protocols"`
+		}
+		if err := client.Call(&info, "admin_nodeInfo"); err != nil {
+			t.Fatalf("failed to call admin_nodeInfo: %v", err)
+		}
+		return info.Protocols["eth"]
+	}
+	// The rollup fields are reported on the rollup chains
+	info := nodeInfo(&rollup, true)
+	config, ok := info["rollupConfig"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("rollupConfig mismatch: have %v, want object", info["rollupConfig"])
+	}
+	if have := config["chainId"]; have != float64(10) {
+		t.Errorf("rollup chain ID mismatch: have %v, want %v", have, 10)
+	}
+	if have := info["isSafeHeadSupported"]; have != true {
+		t.Errorf("isSafeHeadSupported mismatch: have %v, want %v", have, true)
+	}
+	if have := info["isSequencer"]; have != true {
+		t.Errorf("isSequencer mismatch: have %v, want %v", have, true)
+	}
+	if have := info["l1Rpc"]; have != "http://l1.example:8545" {
+		t.Errorf("l1Rpc mismatch: have %v, want %v", have, "http://l1.example:8545")
+	}
+	// Safe heads are only supported once they are indexed
+	if have := nodeInfo(&rollup, false)["isSafeHeadSupported"]; have != false {
+		t.Errorf("isSafeHeadSupported mismatch: have %v, want %v", have, false)
+	}
+	// And null on the others
+	info = nodeInfo(params.MergedTestChainConfig, false)
+	for _, field := range []string{"rollupConfig", "isSafeHeadSupported", "isSequencer", "l1Rpc"} {
+		if have, ok := info[field]; !ok || have != nil {
+			t.Errorf("%s mismatch: have %v, want null", field, have)
+		}
+	}
+	if info["config"] == nil {
+		t.Error("missing chain config")
+	}
+}
+
+func TestOptimismGasPrice(t *testing.T) {
+	t.Parallel()
+
+	rollup := *params.MergedTestChainConfig
+	rollup.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+
+	stack, err := node.New(new(node.Config))
+	if err != nil {
+		t.Fatalf("failed to create node: %v", err)
+	}
+	defer stack.Close()
+
+	config := ethconfig.Defaults
+	config.Genesis = &core.Genesis{
+		Config:  &rollup,
+		BaseFee: big.NewInt(params.InitialBaseFee),
+		Alloc: types.GenesisAlloc{
+			rollup.L1FeeOracle(): {
+				Balance: new(big.Int),
+				Storage: map[common.Hash]common.Hash{
+					types.L1BaseFeeSlot: common.BigToHash(big.NewInt(30 * params.GWei)),
+					types.OverheadSlot:  common.BigToHash(big.NewInt(188)),
+					types.ScalarSlot:    common.BigToHash(big.NewInt(684_000)),
+				},
+			},
+		},
+	}
+	if _, err := New(stack, &config); err != nil {
+		t.Fatalf("failed to create ethereum service: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("failed to start node: %v", err)
+	}
+	client := stack.Attach()
+	defer client.Close()
+
+	var l2Price, price hexutil.Big
+	if err := client.Call(&l2Price, "eth_gasPrice"); err != nil {
+		t.Fatalf("failed to call eth_gasPrice: %v", err)
+	}
+	if err := client.Call(&price, "optimism_gasPrice"); err != nil {
+		t.Fatalf("failed to call optimism_gasPrice: %v", err)
+	}
+	// (150*16 + 188) * 30 gwei * 0.684 spread over 21000 + 150*16 gas
+	l1Fee := big.NewInt((150*16 + 188) * 30 * params.GWei * 684_000 / 1_000_000)
+	want := new(big.Int).Add(l2Price.ToInt(), l1Fee.Div(l1Fee, big.NewInt(21000+150*16)))
+	if price.ToInt().Cmp(want) != 0 {
+		t.Fatalf("gas price mismatch: have %v, want %v", price.ToInt(), want)
+	}
+}
+
+func TestGetL1GasUsed(t *testing.T) {
+	t.Parallel()
+
+	key, _ := crypto.GenerateKey()
+	tx, _ := types.SignNewTx(key, types.LatestSignerForChainID(params.MergedTestChainConfig.ChainID), &types.DynamicFeeTx{
+		ChainID:   params.MergedTestChainConfig.ChainID,
+		Nonce:     3,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(params.GWei),
+		Gas:       params.TxGas,
+		To:        &common.Address{0x01},
+		Data:      []byte{0x00, 0x00, 0x01, 0x02},
+	})
+	raw, _ := tx.MarshalBinary()
+
+	var zeroes, ones uint64
+	for _, b := range raw {
+		if b == 0 {
+			zeroes++
+		} else {
+			ones++
+		}
+	}
+	ecotone := uint64(0)
+	tests := []struct {
+		name    string
+		ecotone *uint64
+		want    uint64
+	}{
+		{"bedrock", nil, zeroes*4 + ones*16 + 188},
+		{"ecotone", &ecotone, zeroes*4 + ones*16},
+	}
+	for _, tt := range tests {
+		rollup := *params.MergedTestChainConfig
+		rollup.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+		rollup.EcotoneTime = tt.ecotone
+
+		genesis := &core.Genesis{
+			Config:  &rollup,
+			BaseFee: big.NewInt(params.InitialBaseFee),
+			Alloc: types.GenesisAlloc{
+				rollup.L1FeeOracle(): {
+					Balance: new(big.Int),
+					Storage: map[common.Hash]common.Hash{
+						types.L1BaseFeeSlot: common.BigToHash(big.NewInt(30 * params.GWei)),
+						types.OverheadSlot:  common.BigToHash(big.NewInt(188)),
+						types.ScalarSlot:    common.BigToHash(big.NewInt(684_000)),
+					},
+				},
+			},
+		}
+		chain := newTestBlockChain(t, 0, genesis, nil)
+		defer chain.Stop()
+
+		have, err := NewOptimismAPI(&Ethereum{blockchain: chain}).GetL1GasUsed(raw)
+		if err != nil {
+			t.Fatalf("%s: failed to compute L1 gas: %v", tt.name, err)
+		}
+		if uint64(have) != tt.want {
+			t.Errorf("%s: L1 gas mismatch: have %d, want %d", tt.name, have, tt.want)
+		}
+	}
+	// Non-rollup chains have no L1 gas
+	chain := newTestBlockChain(t, 0, &core.Genesis{Config: params.TestChainConfig}, nil)
+	defer chain.Stop()
+	if _, err := NewOptimismAPI(&Ethereum{blockchain: chain}).GetL1GasUsed(raw); err == nil {
+		t.Error("L1 gas computed on a non OP-Stack chain")
+	}
+}
+
+// Tests that a node started with RollupForcePaused doesn't build payloads until
+// resumed, and that it builds them again once restarted without it.
+func TestRollupForcePaused(t *testing.T) {
+	t.Parallel()
+
+	datadir := t.TempDir()
+	startNode := func(paused bool) (*node.Node, *Ethereum) {
+		t.Helper()
+
+		stack, err := node.New(&node.Config{DataDir: datadir})
+		if err != nil {
+			t.Fatalf("failed to create node: %v", err)
+		}
+		ethcfg := ethconfig.Defaults
+		ethcfg.Genesis = &core.Genesis{Config: params.MergedTestChainConfig, Difficulty: common.Big0}
+		ethcfg.RollupForcePaused = paused
+		ethservice, err := New(stack, &ethcfg)
+		if err != nil {
+			t.Fatalf("failed to create ethereum service: %v", err)
+		}
+		if err := stack.Start(); err != nil {
+			t.Fatalf("failed to start node: %v", err)
+		}
+		return stack, ethservice
+	}
+	buildPayload := func(ethservice *Ethereum) error {
+		head := ethservice.BlockChain().CurrentBlock()
+		_, err := ethservice.Miner().BuildPayload(&miner.BuildPayloadArgs{
+			Parent:      head.Hash(),
+			Timestamp:   head.Time + 1,
+			Withdrawals: []*types.Withdrawal{},
+			BeaconRoot:  &common.Hash{},
+		}, false)
+		return err
+	}
+	// Blocks are not built while paused, the RPC being served
+	stack, ethservice := startNode(true)
+	if err := buildPayload(ethservice); !errors.Is(err, miner.ErrPaused) {
+		t.Fatalf("payload built while paused: have %v, want %v", err, miner.ErrPaused)
+	}
+	client := stack.Attach()
+	var pending map[string]interface{}
+	if err := client.Call(&pending, "eth_getBlockByNumber", "pending", false); err != nil || pending == nil {
+		t.Fatalf("failed to get pending block: %v", err)
+	}
+	if err := client.Call(nil, "miner_resume"); err != nil {
+		t.Fatalf("failed to resume block production: %v", err)
+	}
+	if err := buildPayload(ethservice); err != nil {
+		t.Fatalf("failed to build payload after resume: %v", err)
+	}
+	client.Close()
+	stack.Close()
+
+	// And the pause doesn't outlive a restart without the flag
+	stack, ethservice = startNode(false)
+	defer stack.Close()
+	if err := buildPayload(ethservice); err != nil {
+		t.Fatalf("failed to build payload after restart: %v", err)
+	}
+}
@@ -44,6 +44,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/gasprice"
+	"github.com/ethereum/go-ethereum/eth/protocols/blobs"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/eth/tracers"
@@ -60,6 +61,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rollup"
 	"github.com/ethereum/go-ethereum/rpc"
 	gethversion "github.com/ethereum/go-ethereum/version"
 )
@@ -175,10 +177,13 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 
 	// Here we determine genesis hash and active ChainConfig.
 	// We need these to figure out the consensus parameters and to set up history pruning.
-	chainConfig, _, err := core.LoadChainConfig(chainDb, config.Genesis)
+	chainConfig, genesisHash, err := core.LoadChainConfig(chainDb, config.Genesis)
 	if err != nil {
 		return nil, err
 	}
+	if err := rollup.ValidateGenesisHash(chainConfig, genesisHash); err != nil {
+		return nil, err
+	}
 	engine, err := ethconfig.CreateConsensusEngine(chainConfig, chainDb)
 	if err != nil {
 		return nil, err
@@ -236,6 +241,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 			StateScheme:      scheme,
 			ChainHistoryMode: config.HistoryMode,
 			TxLookupLimit:    int64(min(config.TransactionHistory, math.MaxInt64)),
+			BlobAvailability: config.BlobAvailability,
 			VmConfig:         vmConfig,
 		}
 	)
@@ -297,6 +303,7 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	if err != nil {
 		return nil, err
 	}
+	eth.txPool.SetMaxFromIP(config.TxPool.MaxFromIP)
 
 	if !config.TxPool.NoLocals {
 		rejournal := config.TxPool.Rejournal
@@ -329,6 +336,10 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	eth.miner = miner.New(eth, config.Miner, eth.engine)
 	eth.miner.SetExtra(makeExtraData(config.Miner.ExtraData))
 	eth.miner.SetPrioAddresses(config.TxPool.Locals)
+	if config.RollupForcePaused {
+		eth.miner.Pause()
+		log.Warn("Block production is paused, resume it with miner_resume")
+	}
 
 	eth.APIBackend = &EthAPIBackend{stack.Config().ExtRPCEnabled(), stack.Config().AllowUnprotectedTxs, eth, nil}
 	if eth.APIBackend.allowUnprotectedTxs {
@@ -337,10 +348,19 @@ func New(stack *node.Node, config *ethconfig.Config) (*Ethereum, error) {
 	eth.APIBackend.gpo = gasprice.NewOracle(eth.APIBackend, config.GPO, config.Miner.GasPrice)
 
 	// Start the RPC service
-	eth.netRPCService = ethapi.NewNetAPI(eth.p2pServer, networkID)
+	// Rollup tooling detects the network with net_version, which must report
+	// the chain ID even if the p2p network ID is configured differently.
+	netVersion := networkID
+	if chainConfig.IsOptimism() {
+		netVersion = chainConfig.ChainID.Uint64()
+	}
+	eth.netRPCService = ethapi.NewNetAPI(eth.p2pServer, netVersion)
 
 	// Register the backend on the node
 	stack.RegisterAPIs(eth.APIs())
+	if chainConfig.IsOptimism() {
+		stack.RegisterHandler("Sequencer health", "/healthz", &healthzHandler{eth})
+	}
 	stack.RegisterProtocols(eth.Protocols())
 	stack.RegisterLifecycle(eth)
 
@@ -375,6 +395,9 @@ func (s *Ethereum) APIs() []rpc.API {
 	// Append all the local APIs and return
 	return append(apis, []rpc.API{
 		{
+			Namespace: "eth",
+			Service:   NewEthereumAPI(s),
+		}, {
 			Namespace: "miner",
 			Service:   NewMinerAPI(s),
 		}, {
@@ -386,6 +409,9 @@ func (s *Ethereum) APIs() []rpc.API {
 		}, {
 			Namespace: "debug",
 			Service:   NewDebugAPI(s),
+		}, {
+			Namespace: "optimism",
+			Service:   NewOptimismAPI(s),
 		}, {
 			Namespace: "net",
 			Service:   s.netRPCService,
@@ -415,9 +441,18 @@ func (s *Ethereum) ArchiveMode() bool                  { return s.config.NoPruni
 // network protocols to start.
 func (s *Ethereum) Protocols() []p2p.Protocol {
 	protos := eth.MakeProtocols((*ethHandler)(s.handler), s.networkID, s.discmix)
+	for i := range protos {
+		info := protos[i].NodeInfo
+		protos[i].NodeInfo = func() interface{} {
+			return s.rollupNodeInfo(info().(*eth.NodeInfo))
+		}
+	}
 	if s.config.SnapshotCache > 0 {
 		protos = append(protos, snap.MakeProtocols((*snapHandler)(s.handler))...)
 	}
+	if s.config.BlobAvailability {
+		protos = append(protos, blobs.MakeProtocols((*blobsHandler)(s.handler))...)
+	}
 	return protos
 }
 
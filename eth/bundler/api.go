@@ -0,0 +1,86 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bundler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// API offers the ERC-4337 bundler RPC methods.
+type API struct {
+	s *Service
+}
+
+// SendUserOperation validates a user operation against the entry point and
+// adds it to the pool, returning its hash.
+func (api *API) SendUserOperation(ctx context.Context, op UserOperation, entryPoint common.Address) (common.Hash, error) {
+	if !slices.Contains(api.s.entryPoints, entryPoint) {
+		return common.Hash{}, fmt.Errorf("unsupported entry point %v", entryPoint)
+	}
+	if err := op.validate(); err != nil {
+		return common.Hash{}, err
+	}
+	if err := api.s.simulateValidation(ctx, &op, entryPoint); err != nil {
+		return common.Hash{}, err
+	}
+	hash := op.Hash(entryPoint, api.s.backend.ChainConfig().ChainID)
+	if err := api.s.pool.add(hash, &op, entryPoint); err != nil {
+		return common.Hash{}, err
+	}
+	return hash, nil
+}
+
+// UserOperationResponse is a user operation known to the bundler, along with
+// the location of its inclusion in the chain. The location is left empty for
+// the pending user operations.
+type UserOperationResponse struct {
+	UserOperation   *UserOperation  `json:"userOperation"`
+	EntryPoint      common.Address  `json:"entryPoint"`
+	BlockNumber     *hexutil.Uint64 `json:"blockNumber"`
+	BlockHash       *common.Hash    `json:"blockHash"`
+	TransactionHash *common.Hash    `json:"transactionHash"`
+}
+
+// GetUserOperationByHash returns a pending or included user operation, or nil
+// if it's unknown.
+func (api *API) GetUserOperationByHash(hash common.Hash) (*UserOperationResponse, error) {
+	entry := api.s.pool.get(hash)
+	if entry == nil {
+		return nil, nil
+	}
+	result := &UserOperationResponse{
+		UserOperation: entry.op,
+		EntryPoint:    entry.entryPoint,
+	}
+	if entry.included {
+		result.BlockNumber = (*hexutil.Uint64)(&entry.blockNumber)
+		result.BlockHash = &entry.blockHash
+		result.TransactionHash = &entry.txHash
+	}
+	return result, nil
+}
+
+// SupportedEntryPoints returns the entry points the bundler accepts user
+// operations for.
+func (api *API) SupportedEntryPoints() []common.Address {
+	return slices.Clone(api.s.entryPoints)
+}
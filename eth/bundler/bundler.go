@@ -0,0 +1,203 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package bundler implements the user operation pool and RPC API of an
+// ERC-4337 bundler.
+package bundler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// chainEventChanSize is the size of the channel listening to ChainEvent.
+const chainEventChanSize = 10
+
+// entryPointABI is the subset of the v0.6 entry point contract interface used
+// by the bundler.
+const entryPointABI = `[
+	{"type": "function", "name": "simulateValidation", "inputs": [{"name": "userOp", "type": "tuple", "components": [
+		{"name": "sender", "type": "address"},
+		{"name": "nonce", "type": "uint256"},
+		{"name": "initCode", "type": "bytes"},
+		{"name": "callData", "type": "bytes"},
+		{"name": "callGasLimit", "type": "uint256"},
+		{"name": "verificationGasLimit", "type": "uint256"},
+		{"name": "preVerificationGas", "type": "uint256"},
+		{"name": "maxFeePerGas", "type": "uint256"},
+		{"name": "maxPriorityFeePerGas", "type": "uint256"},
+		{"name": "paymasterAndData", "type": "bytes"},
+		{"name": "signature", "type": "bytes"}
+	]}]},
+	{"type": "error", "name": "FailedOp", "inputs": [
+		{"name": "opIndex", "type": "uint256"},
+		{"name": "reason", "type": "string"}
+	]}
+]`
+
+var (
+	entryPointContract = func() abi.ABI {
+		parsed, err := abi.JSON(strings.NewReader(entryPointABI))
+		if err != nil {
+			panic(err)
+		}
+		return parsed
+	}()
+
+	// Selectors of the errors simulateValidation reverts with on success
+	validationResultID                = crypto.Keccak256([]byte("ValidationResult((uint256,uint256,bool,uint48,uint48,bytes),(uint256,uint256),(uint256,uint256),(uint256,uint256))"))[:4]
+	validationResultWithAggregationID = crypto.Keccak256([]byte("ValidationResultWithAggregation((uint256,uint256,bool,uint48,uint48,bytes),(uint256,uint256),(uint256,uint256),(uint256,uint256),(address,(uint256,uint256)))"))[:4]
+
+	// userOperationEventTopic is the topic of the UserOperationEvent emitted by
+	// the entry point for every executed user operation, its first indexed
+	// argument being the hash of the operation.
+	userOperationEventTopic = crypto.Keccak256Hash([]byte("UserOperationEvent(bytes32,address,address,uint256,bool,uint256,uint256)"))
+)
+
+// Service tracks the user operations submitted to the bundler and their
+// inclusion in the chain.
+type Service struct {
+	backend     ethapi.Backend
+	entryPoints []common.Address
+	pool        *pool
+
+	chainSub event.Subscription
+	quit     chan struct{}
+}
+
+// New creates the bundler service accepting user operations for the given entry
+// points, and registers its RPC API and lifecycle with the node.
+func New(stack *node.Node, backend ethapi.Backend, entryPoints []common.Address) error {
+	if len(entryPoints) == 0 {
+		return errors.New("no entry points specified")
+	}
+	s := &Service{
+		backend:     backend,
+		entryPoints: entryPoints,
+		pool:        newPool(),
+		quit:        make(chan struct{}),
+	}
+	stack.RegisterAPIs([]rpc.API{{
+		Namespace: "eth",
+		Service:   &API{s},
+	}})
+	stack.RegisterLifecycle(s)
+	return nil
+}
+
+// Start implements node.Lifecycle, starting to track the inclusion of the
+// user operations.
+func (s *Service) Start() error {
+	chainCh := make(chan core.ChainEvent, chainEventChanSize)
+	s.chainSub = s.backend.SubscribeChainEvent(chainCh)
+	go s.loop(chainCh)
+
+	log.Info("Started user operation bundler", "entrypoints", len(s.entryPoints))
+	return nil
+}
+
+// Stop implements node.Lifecycle, terminating the bundler.
+func (s *Service) Stop() error {
+	s.chainSub.Unsubscribe()
+	close(s.quit)
+	log.Info("Stopped user operation bundler")
+	return nil
+}
+
+// loop marks the user operations included by the blocks added to the chain, and
+// drops the ones awaiting inclusion for too long.
+func (s *Service) loop(chainCh chan core.ChainEvent) {
+	for {
+		select {
+		case ev := <-chainCh:
+			receipts, err := s.backend.GetReceipts(context.Background(), ev.Header.Hash())
+			if err != nil {
+				log.Warn("Failed to retrieve receipts", "number", ev.Header.Number, "hash", ev.Header.Hash(), "err", err)
+				continue
+			}
+			for _, receipt := range receipts {
+				for _, l := range receipt.Logs {
+					if len(l.Topics) < 2 || l.Topics[0] != userOperationEventTopic || !slices.Contains(s.entryPoints, l.Address) {
+						continue
+					}
+					s.pool.markIncluded(l.Topics[1], ev.Header.Number.Uint64(), ev.Header.Hash(), receipt.TxHash)
+				}
+			}
+			if dropped := s.pool.expire(); dropped > 0 {
+				log.Debug("Dropped expired user operations", "count", dropped)
+			}
+		case <-s.chainSub.Err():
+			return
+		case <-s.quit:
+			return
+		}
+	}
+}
+
+// simulateValidation runs the simulateValidation method of the entry point
+// against the latest state, returning the reason of the rejection if any.
+func (s *Service) simulateValidation(ctx context.Context, op *UserOperation, addr common.Address) error {
+	input, err := entryPointContract.Pack("simulateValidation", op.toABI())
+	if err != nil {
+		return err
+	}
+	args := ethapi.TransactionArgs{
+		To:    &addr,
+		Input: (*hexutil.Bytes)(&input),
+	}
+	result, err := ethapi.DoCall(ctx, s.backend, args, rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber), nil, nil, s.backend.RPCEVMTimeout(), s.backend.RPCGasCap())
+	if err != nil {
+		return err
+	}
+	// simulateValidation always reverts, with the validation result on success
+	revert := result.Revert()
+	if len(revert) < 4 {
+		if result.Failed() {
+			return fmt.Errorf("validation failed: %v", result.Err)
+		}
+		return errors.New("validation failed: simulateValidation did not revert")
+	}
+	failedOp := entryPointContract.Errors["FailedOp"]
+	switch selector := revert[:4]; {
+	case bytes.Equal(selector, validationResultID), bytes.Equal(selector, validationResultWithAggregationID):
+		return nil
+	case bytes.Equal(selector, failedOp.ID[:4]):
+		values, err := failedOp.Unpack(revert)
+		if err != nil {
+			return fmt.Errorf("validation failed: invalid FailedOp: %v", err)
+		}
+		return fmt.Errorf("validation failed: %v", values.([]interface{})[1])
+	}
+	if reason, err := abi.UnpackRevert(revert); err == nil {
+		return fmt.Errorf("validation failed: %s", reason)
+	}
+	return fmt.Errorf("validation failed: unexpected revert data %#x", revert)
+}
@@ -0,0 +1,251 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bundler
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/program"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// revertCode returns the code of a contract reverting with the given data.
+func revertCode(data []byte) *program.Program {
+	return program.New().Mstore(data, 0).Push(len(data)).Push(0).Op(vm.REVERT)
+}
+
+// mockEntryPoint returns the code of an entry point accepting all the user
+// operations in simulateValidation, and emitting the UserOperationEvent of the
+// user operation hash it is called with.
+func mockEntryPoint() []byte {
+	reverter := revertCode(validationResultID).Bytes()
+
+	// Jump over the revert if called with a single word: PUSH1 32, CALLDATASIZE,
+	// EQ, PUSH1 dest, JUMPI
+	code := program.New().Push(32).Op(vm.CALLDATASIZE, vm.EQ).Push(7 + len(reverter)).Op(vm.JUMPI)
+	code.Append(reverter)
+	code.Op(vm.JUMPDEST)
+	code.Push(0).Op(vm.CALLDATALOAD).Push(userOperationEventTopic).Push(0).Push(0).Op(vm.LOG2, vm.STOP)
+	return code.Bytes()
+}
+
+func newTestUserOp() UserOperation {
+	return UserOperation{
+		Sender:               common.HexToAddress("0x1111"),
+		Nonce:                (*hexutil.Big)(big.NewInt(1)),
+		CallData:             hexutil.Bytes{0xde, 0xad, 0xbe, 0xef},
+		CallGasLimit:         (*hexutil.Big)(big.NewInt(100000)),
+		VerificationGasLimit: (*hexutil.Big)(big.NewInt(100000)),
+		PreVerificationGas:   (*hexutil.Big)(big.NewInt(21000)),
+		MaxFeePerGas:         (*hexutil.Big)(big.NewInt(params.GWei)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(big.NewInt(params.GWei)),
+		Signature:            hexutil.Bytes{0x01},
+	}
+}
+
+// Tests that the user operations validated by the entry point are stored in
+// the pool, the invalid ones rejected, and the inclusion of the operations is
+// tracked.
+func TestSendUserOperation(t *testing.T) {
+	var (
+		key, _    = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender    = crypto.PubkeyToAddress(key.PublicKey)
+		accepting = common.HexToAddress("0xee01")
+		rejecting = common.HexToAddress("0xee02")
+		unknown   = common.HexToAddress("0xee03")
+		op        = newTestUserOp()
+	)
+	failed := entryPointContract.Errors["FailedOp"]
+	failedOp, err := failed.Inputs.Pack(big.NewInt(0), "AA21 didn't pay prefund")
+	if err != nil {
+		t.Fatalf("failed to pack FailedOp: %v", err)
+	}
+	genesis := &core.Genesis{
+		Config: params.AllEthashProtocolChanges,
+		Alloc: types.GenesisAlloc{
+			sender:    {Balance: big.NewInt(params.Ether)},
+			accepting: {Code: mockEntryPoint()},
+			rejecting: {Code: revertCode(append(failed.ID[:4], failedOp...)).Bytes()},
+		},
+	}
+	hash := op.Hash(accepting, genesis.Config.ChainID)
+
+	// The block including the user operation
+	signer := types.LatestSigner(genesis.Config)
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, ethash.NewFaker(), 1, func(i int, b *core.BlockGen) {
+		b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    0,
+			To:       &accepting,
+			Gas:      100000,
+			GasPrice: b.BaseFee(),
+			Data:     hash.Bytes(),
+		}))
+	})
+	stack, err := node.New(new(node.Config))
+	if err != nil {
+		t.Fatalf("failed to create node: %v", err)
+	}
+	defer stack.Close()
+
+	ethservice, err := eth.New(stack, &ethconfig.Config{Genesis: genesis})
+	if err != nil {
+		t.Fatalf("failed to create ethereum service: %v", err)
+	}
+	if err := New(stack, ethservice.APIBackend, []common.Address{accepting, rejecting}); err != nil {
+		t.Fatalf("failed to create bundler: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("failed to start node: %v", err)
+	}
+	client := stack.Attach()
+	defer client.Close()
+
+	var have common.Hash
+	if err := client.Call(&have, "eth_sendUserOperation", op, accepting); err != nil {
+		t.Fatalf("failed to send user operation: %v", err)
+	}
+	if have != hash {
+		t.Fatalf("user operation hash mismatch: have %v, want %v", have, hash)
+	}
+	var res *UserOperationResponse
+	if err := client.Call(&res, "eth_getUserOperationByHash", hash); err != nil {
+		t.Fatalf("failed to get user operation: %v", err)
+	}
+	if res == nil || res.EntryPoint != accepting || res.UserOperation.Sender != op.Sender || res.BlockNumber != nil {
+		t.Fatalf("pending user operation mismatch: have %+v", res)
+	}
+	// Invalid user operations are rejected
+	large := newTestUserOp()
+	large.CallData = make(hexutil.Bytes, maxUserOpSize+1)
+
+	fees := newTestUserOp()
+	fees.MaxPriorityFeePerGas = (*hexutil.Big)(big.NewInt(2 * params.GWei))
+
+	tests := []struct {
+		name       string
+		op         UserOperation
+		entryPoint common.Address
+		want       string
+	}{
+		{"duplicate", op, accepting, ErrAlreadyKnown.Error()},
+		{"unknown entry point", newTestUserOp(), unknown, "unsupported entry point"},
+		{"failed validation", newTestUserOp(), rejecting, "AA21 didn't pay prefund"},
+		{"too large", large, accepting, "user operation too large"},
+		{"priority fee too high", fees, accepting, "maxPriorityFeePerGas higher than maxFeePerGas"},
+	}
+	for _, tt := range tests {
+		err := client.Call(new(common.Hash), "eth_sendUserOperation", tt.op, tt.entryPoint)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error mismatch: have %v, want %q", tt.name, err, tt.want)
+		}
+	}
+	// Unknown user operations are null
+	res = new(UserOperationResponse)
+	if err := client.Call(&res, "eth_getUserOperationByHash", common.Hash{0x01}); err != nil || res != nil {
+		t.Fatalf("unknown user operation: have %+v, %v, want nil", res, err)
+	}
+	// The user operation is included by the block
+	if _, err := ethservice.BlockChain().InsertChain(blocks); err != nil {
+		t.Fatalf("failed to import blocks: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	for {
+		if err := client.CallContext(ctx, &res, "eth_getUserOperationByHash", hash); err != nil {
+			t.Fatalf("failed to get user operation: %v", err)
+		}
+		if res.BlockNumber != nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if uint64(*res.BlockNumber) != 1 || *res.BlockHash != blocks[0].Hash() || *res.TransactionHash != blocks[0].Transactions()[0].Hash() {
+		t.Errorf("included user operation mismatch: have block %d %v tx %v, want block 1 %v tx %v",
+			*res.BlockNumber, *res.BlockHash, *res.TransactionHash, blocks[0].Hash(), blocks[0].Transactions()[0].Hash())
+	}
+}
+
+// Tests that the numeric fields of the user operations must fit the uint256
+// words of the entry point.
+func TestUserOperationValidate(t *testing.T) {
+	op := newTestUserOp()
+	if err := op.validate(); err != nil {
+		t.Fatalf("valid user operation rejected: %v", err)
+	}
+	op.MaxFeePerGas = (*hexutil.Big)(new(big.Int).Lsh(big.NewInt(1), 256))
+	if err := op.validate(); err == nil || !strings.Contains(err.Error(), "maxFeePerGas exceeds 256 bits") {
+		t.Errorf("error mismatch: have %v, want %q", err, "maxFeePerGas exceeds 256 bits")
+	}
+}
+
+// Tests that the user operations never included are dropped once expired, so
+// they can't keep the pool full.
+func TestPoolExpiry(t *testing.T) {
+	t.Parallel()
+
+	var (
+		now  = time.Unix(1000, 0)
+		pool = newPool()
+		op   = newTestUserOp()
+	)
+	pool.now = func() time.Time { return now }
+
+	for i := 0; i < maxPendingOps; i++ {
+		if err := pool.add(common.BigToHash(big.NewInt(int64(i))), &op, common.Address{}); err != nil {
+			t.Fatalf("operation %d: failed to add: %v", i, err)
+		}
+	}
+	overflow := common.BigToHash(big.NewInt(maxPendingOps))
+	if err := pool.add(overflow, &op, common.Address{}); err != ErrPoolFull {
+		t.Fatalf("full pool error mismatch: have %v, want %v", err, ErrPoolFull)
+	}
+	// Included operations are kept, the pending ones dropped once expired
+	pool.markIncluded(common.Hash{}, 1, common.Hash{0x01}, common.Hash{0x02})
+	if err := pool.add(overflow, &op, common.Address{}); err != nil {
+		t.Fatalf("failed to add after inclusion: %v", err)
+	}
+	now = now.Add(pendingOpLifetime + time.Second)
+	if err := pool.add(common.Hash{0xff}, &op, common.Address{}); err != nil {
+		t.Fatalf("failed to add to expired pool: %v", err)
+	}
+	if dropped := pool.expire(); dropped != 0 {
+		t.Errorf("dropped count mismatch: have %d, want 0", dropped)
+	}
+	if entry := pool.get(common.Hash{}); entry == nil || !entry.included {
+		t.Error("included operation dropped")
+	}
+	if entry := pool.get(common.BigToHash(big.NewInt(1))); entry != nil {
+		t.Error("expired operation retained")
+	}
+	if pool.pending != 1 {
+		t.Errorf("pending count mismatch: have %d, want 1", pool.pending)
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bundler
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// maxPendingOps is the maximum number of user operations awaiting inclusion.
+	maxPendingOps = 4096
+
+	// pendingOpLifetime is the maximum time a user operation awaits inclusion
+	// before being dropped from the pool.
+	pendingOpLifetime = 30 * time.Minute
+
+	// maxIncludedOps is the number of included user operations retained for
+	// eth_getUserOperationByHash.
+	maxIncludedOps = 4096
+)
+
+var (
+	// ErrAlreadyKnown is returned if the user operation is already in the pool.
+	ErrAlreadyKnown = errors.New("user operation already known")
+
+	// ErrPoolFull is returned if the pool can't accept more user operations.
+	ErrPoolFull = errors.New("user operation pool is full")
+)
+
+// poolEntry is a user operation tracked by the pool.
+type poolEntry struct {
+	op         *UserOperation
+	entryPoint common.Address
+	added      time.Time // Time the user operation entered the pool
+
+	// Set once the user operation is included in a block
+	included    bool
+	blockNumber uint64
+	blockHash   common.Hash
+	txHash      common.Hash
+}
+
+// pool is an in-memory pool of user operations, keyed by their hash. The
+// included operations are retained for a while so they can be looked up.
+type pool struct {
+	entries  map[common.Hash]*poolEntry
+	pending  int
+	included []common.Hash // FIFO of the included operations, oldest first
+	now      func() time.Time
+	lock     sync.RWMutex
+}
+
+func newPool() *pool {
+	return &pool{
+		entries: make(map[common.Hash]*poolEntry),
+		now:     time.Now,
+	}
+}
+
+// add inserts a pending user operation.
+func (p *pool) add(hash common.Hash, op *UserOperation, entryPoint common.Address) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if _, ok := p.entries[hash]; ok {
+		return ErrAlreadyKnown
+	}
+	if p.pending >= maxPendingOps {
+		p.expireLocked()
+	}
+	if p.pending >= maxPendingOps {
+		return ErrPoolFull
+	}
+	p.entries[hash] = &poolEntry{op: op, entryPoint: entryPoint, added: p.now()}
+	p.pending++
+	return nil
+}
+
+// get returns a copy of the entry of a user operation, or nil if unknown.
+func (p *pool) get(hash common.Hash) *poolEntry {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	entry, ok := p.entries[hash]
+	if !ok {
+		return nil
+	}
+	cpy := *entry
+	return &cpy
+}
+
+// markIncluded records the inclusion of a pending user operation. Unknown or
+// already included operations are ignored.
+func (p *pool) markIncluded(hash common.Hash, blockNumber uint64, blockHash common.Hash, txHash common.Hash) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	entry, ok := p.entries[hash]
+	if !ok || entry.included {
+		return
+	}
+	entry.included = true
+	entry.blockNumber, entry.blockHash, entry.txHash = blockNumber, blockHash, txHash
+	p.pending--
+
+	p.included = append(p.included, hash)
+	if len(p.included) > maxIncludedOps {
+		delete(p.entries, p.included[0])
+		p.included = p.included[1:]
+	}
+}
+
+// expire drops the pending user operations awaiting inclusion for longer than
+// pendingOpLifetime, returning the number of operations dropped.
+func (p *pool) expire() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	return p.expireLocked()
+}
+
+// expireLocked is the lock-free version of expire, the caller must hold the
+// write lock.
+func (p *pool) expireLocked() int {
+	var (
+		now     = p.now()
+		dropped int
+	)
+	for hash, entry := range p.entries {
+		if !entry.included && now.Sub(entry.added) > pendingOpLifetime {
+			delete(p.entries, hash)
+			p.pending--
+			dropped++
+		}
+	}
+	return dropped
+}
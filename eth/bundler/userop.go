@@ -0,0 +1,132 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bundler
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// maxUserOpSize is the maximum total size of the byte fields of a user operation.
+const maxUserOpSize = 32 * 1024
+
+// UserOperation is an ERC-4337 user operation, as accepted by the v0.6 entry
+// point contract.
+type UserOperation struct {
+	Sender               common.Address `json:"sender"`
+	Nonce                *hexutil.Big   `json:"nonce"`
+	InitCode             hexutil.Bytes  `json:"initCode"`
+	CallData             hexutil.Bytes  `json:"callData"`
+	CallGasLimit         *hexutil.Big   `json:"callGasLimit"`
+	VerificationGasLimit *hexutil.Big   `json:"verificationGasLimit"`
+	PreVerificationGas   *hexutil.Big   `json:"preVerificationGas"`
+	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	PaymasterAndData     hexutil.Bytes  `json:"paymasterAndData"`
+	Signature            hexutil.Bytes  `json:"signature"`
+}
+
+// validate checks that the fields of the user operation are set and within
+// their size limits. The numeric fields are uint256 words of the entry point.
+func (op *UserOperation) validate() error {
+	fields := []struct {
+		name  string
+		value *hexutil.Big
+	}{
+		{"nonce", op.Nonce},
+		{"callGasLimit", op.CallGasLimit},
+		{"verificationGasLimit", op.VerificationGasLimit},
+		{"preVerificationGas", op.PreVerificationGas},
+		{"maxFeePerGas", op.MaxFeePerGas},
+		{"maxPriorityFeePerGas", op.MaxPriorityFeePerGas},
+	}
+	for _, field := range fields {
+		if field.value == nil {
+			return fmt.Errorf("missing %s", field.name)
+		}
+		if field.value.ToInt().Sign() < 0 {
+			return fmt.Errorf("negative %s", field.name)
+		}
+		if field.value.ToInt().BitLen() > 256 {
+			return fmt.Errorf("%s exceeds 256 bits", field.name)
+		}
+	}
+	if op.MaxPriorityFeePerGas.ToInt().Cmp(op.MaxFeePerGas.ToInt()) > 0 {
+		return errors.New("maxPriorityFeePerGas higher than maxFeePerGas")
+	}
+	if size := len(op.InitCode) + len(op.CallData) + len(op.PaymasterAndData) + len(op.Signature); size > maxUserOpSize {
+		return fmt.Errorf("user operation too large: size %d, limit %d", size, maxUserOpSize)
+	}
+	return nil
+}
+
+// Hash returns the hash identifying the user operation, as computed by the
+// getUserOpHash method of the entry point contract.
+func (op *UserOperation) Hash(entryPoint common.Address, chainID *big.Int) common.Hash {
+	word := func(v *big.Int) []byte {
+		return common.LeftPadBytes(v.Bytes(), 32)
+	}
+	var packed []byte
+	packed = append(packed, common.LeftPadBytes(op.Sender.Bytes(), 32)...)
+	packed = append(packed, word(op.Nonce.ToInt())...)
+	packed = append(packed, crypto.Keccak256(op.InitCode)...)
+	packed = append(packed, crypto.Keccak256(op.CallData)...)
+	packed = append(packed, word(op.CallGasLimit.ToInt())...)
+	packed = append(packed, word(op.VerificationGasLimit.ToInt())...)
+	packed = append(packed, word(op.PreVerificationGas.ToInt())...)
+	packed = append(packed, word(op.MaxFeePerGas.ToInt())...)
+	packed = append(packed, word(op.MaxPriorityFeePerGas.ToInt())...)
+	packed = append(packed, crypto.Keccak256(op.PaymasterAndData)...)
+
+	return crypto.Keccak256Hash(crypto.Keccak256(packed), common.LeftPadBytes(entryPoint.Bytes(), 32), word(chainID))
+}
+
+// abiUserOp is the ABI encodable representation of a user operation.
+type abiUserOp struct {
+	Sender               common.Address
+	Nonce                *big.Int
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte
+	Signature            []byte
+}
+
+func (op *UserOperation) toABI() abiUserOp {
+	return abiUserOp{
+		Sender:               op.Sender,
+		Nonce:                op.Nonce.ToInt(),
+		InitCode:             op.InitCode,
+		CallData:             op.CallData,
+		CallGasLimit:         op.CallGasLimit.ToInt(),
+		VerificationGasLimit: op.VerificationGasLimit.ToInt(),
+		PreVerificationGas:   op.PreVerificationGas.ToInt(),
+		MaxFeePerGas:         op.MaxFeePerGas.ToInt(),
+		MaxPriorityFeePerGas: op.MaxPriorityFeePerGas.ToInt(),
+		PaymasterAndData:     op.PaymasterAndData,
+		Signature:            op.Signature,
+	}
+}
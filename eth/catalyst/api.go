@@ -341,6 +341,11 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 		// If the specified head matches with our local head, do nothing and keep
 		// generating the payload. It's a special corner case that a few slots are
 		// missing and we are requested to generate the payload in slot.
+	} else if api.eth.BlockChain().Config().IsOptimism() {
+		// The forkchoice head of the rollup node may trail the local chain, while
+		// the safe and finalized blocks derived from L1 still advance. Skip the
+		// head update, but keep the labels in sync for the RPC.
+		log.Debug("Keeping local head on rollup forkchoice update", "number", block.NumberU64(), "hash", update.HeadBlockHash, "have", api.eth.BlockChain().CurrentBlock().Number)
 	} else {
 		// If the head block is already in our canonical chain, the beacon client is
 		// probably resyncing. Ignore the update.
@@ -375,8 +380,17 @@ func (api *ConsensusAPI) forkchoiceUpdated(update engine.ForkchoiceStateV1, payl
 			log.Warn("Safe block not in canonical chain")
 			return engine.STATUS_INVALID, engine.InvalidForkChoiceState.With(errors.New("safe block not in canonical chain"))
 		}
-		// Set the safe block
-		api.eth.BlockChain().SetSafe(safeBlock.Header())
+		// Set the safe block, indexing it by its L1 origin on the rollups
+		if config := api.eth.BlockChain().Config(); config.IsOptimism() {
+			if info, err := types.L1InfoFromBlock(config, safeBlock); err != nil {
+				log.Debug("Safe block without L1 origin", "number", safeBlock.NumberU64(), "err", err)
+				api.eth.BlockChain().SetSafe(safeBlock.Header())
+			} else {
+				api.eth.BlockChain().SetSafeAtL1(safeBlock.Header(), info.BlockHash, info.Number)
+			}
+		} else {
+			api.eth.BlockChain().SetSafe(safeBlock.Header())
+		}
 	}
 	// If payload generation was requested, create a new block to be potentially
 	// sealed by the beacon client. The payload will be requested later, and we
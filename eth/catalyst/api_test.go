@@ -374,6 +374,158 @@ func TestEth2NewBlock(t *testing.T) {
 	}
 }
 
+// Tests that forkchoice updates moving the unsafe and safe heads are announced
+// on the chain event subscriptions.
+func TestForkchoiceHeadEvents(t *testing.T) {
+	genesis, preMergeBlocks := generateMergeChain(10, false)
+	n, ethservice := startEthService(t, genesis, preMergeBlocks)
+	defer n.Close()
+
+	var (
+		api      = NewConsensusAPI(ethservice)
+		parent   = preMergeBlocks[len(preMergeBlocks)-1]
+		unsafeCh = make(chan core.UnsafeHeadEvent, 10)
+		safeCh   = make(chan core.SafeHeadEvent, 10)
+	)
+	ethservice.BlockChain().SubscribeUnsafeHeadEvent(unsafeCh)
+	ethservice.BlockChain().SubscribeSafeHeadEvent(safeCh)
+
+	var blocks []*types.Block
+	for i := 0; i < 2; i++ {
+		execData, err := assembleBlock(api, parent.Hash(), &engine.PayloadAttributes{
+			Timestamp: parent.Time() + 5,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create the executable data %v", err)
+		}
+		block, err := engine.ExecutableDataToBlock(*execData, nil, nil, nil)
+		if err != nil {
+			t.Fatalf("Failed to convert executable data to block %v", err)
+		}
+		if resp, err := api.NewPayloadV1(*execData); err != nil || resp.Status != engine.VALID {
+			t.Fatalf("Failed to insert block: %v", err)
+		}
+		blocks = append(blocks, block)
+		parent = block
+	}
+	checkEvents := func(wantUnsafe, wantSafe *common.Hash) {
+		t.Helper()
+		select {
+		case ev := <-unsafeCh:
+			if wantUnsafe == nil {
+				t.Fatalf("unexpected unsafe head event: %x", ev.Header.Hash())
+			} else if ev.Header.Hash() != *wantUnsafe {
+				t.Fatalf("unsafe head mismatch: have %x, want %x", ev.Header.Hash(), *wantUnsafe)
+			}
+		default:
+			if wantUnsafe != nil {
+				t.Fatal("missing unsafe head event")
+			}
+		}
+		select {
+		case ev := <-safeCh:
+			if wantSafe == nil {
+				t.Fatalf("unexpected safe head event: %x", ev.Header.Hash())
+			} else if ev.Header.Hash() != *wantSafe {
+				t.Fatalf("safe head mismatch: have %x, want %x", ev.Header.Hash(), *wantSafe)
+			}
+		default:
+			if wantSafe != nil {
+				t.Fatal("missing safe head event")
+			}
+		}
+	}
+	unsafe, safe := blocks[1].Hash(), blocks[0].Hash()
+	fcState := engine.ForkchoiceStateV1{HeadBlockHash: unsafe, SafeBlockHash: safe}
+	if _, err := api.ForkchoiceUpdatedV1(fcState, nil); err != nil {
+		t.Fatalf("Failed to update forkchoice: %v", err)
+	}
+	checkEvents(&unsafe, &safe)
+
+	// Repeating the forkchoice doesn't move any head
+	if _, err := api.ForkchoiceUpdatedV1(fcState, nil); err != nil {
+		t.Fatalf("Failed to update forkchoice: %v", err)
+	}
+	checkEvents(nil, nil)
+
+	// Moving the safe head only fires the safe head event
+	fcState.SafeBlockHash = unsafe
+	if _, err := api.ForkchoiceUpdatedV1(fcState, nil); err != nil {
+		t.Fatalf("Failed to update forkchoice: %v", err)
+	}
+	checkEvents(nil, &unsafe)
+}
+
+// Tests that the finalized and safe blocks set by the rollup node through the
+// forkchoice are served by the RPC, even if the forkchoice head trails the
+// local chain.
+func TestOptimismForkchoiceLabels(t *testing.T) {
+	genesis, _ := generateMergeChain(0, true)
+	genesis.Config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+	oracle := genesis.Config.L1FeeOracle()
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, beacon.New(ethash.NewFaker()), 10, func(i int, g *core.BlockGen) {
+		g.OffsetTime(2)
+
+		// Every block is derived from its own L1 origin
+		info, err := types.EncodeL1InfoDepositData(&types.L1BlockInfo{
+			Number:    uint64(100 + i),
+			BlockHash: common.Hash{byte(i)},
+			BaseFee:   common.Big1,
+		})
+		if err != nil {
+			t.Fatalf("failed to encode L1 info: %v", err)
+		}
+		g.AddTx(types.NewTx(&types.OptimismDepositTx{
+			From:  params.L1InfoDepositerAddress,
+			To:    &oracle,
+			Value: new(big.Int),
+			Gas:   1_000_000,
+			Data:  info,
+		}))
+	})
+	n, ethservice := startEthService(t, genesis, blocks)
+	defer n.Close()
+
+	client := n.Attach()
+	defer client.Close()
+
+	var (
+		api   = NewConsensusAPI(ethservice)
+		head  = blocks[9].Hash()
+		check = func(tag string, want *types.Block) {
+			t.Helper()
+			var block map[string]interface{}
+			if err := client.Call(&block, "eth_getBlockByNumber", tag, false); err != nil {
+				t.Fatalf("failed to retrieve %s block: %v", tag, err)
+			}
+			if have := common.HexToHash(block["hash"].(string)); have != want.Hash() {
+				t.Fatalf("%s block mismatch: have %x, want %x", tag, have, want.Hash())
+			}
+		}
+	)
+	fcState := engine.ForkchoiceStateV1{HeadBlockHash: head, SafeBlockHash: blocks[7].Hash(), FinalizedBlockHash: blocks[4].Hash()}
+	if _, err := api.ForkchoiceUpdatedV3(fcState, nil); err != nil {
+		t.Fatalf("failed to update forkchoice: %v", err)
+	}
+	check("latest", blocks[9])
+	check("safe", blocks[7])
+	check("finalized", blocks[4])
+
+	// The safe block is indexed by its L1 origin
+	if entry := ethservice.BlockChain().SafeHeadAtL1(107); entry == nil || entry.L2Hash != blocks[7].Hash() || entry.L1Hash != (common.Hash{7}) {
+		t.Fatalf("safe head at L1 mismatch: have %+v, want block %x", entry, blocks[7].Hash())
+	}
+
+	// A forkchoice trailing the local head still moves the labels
+	fcState = engine.ForkchoiceStateV1{HeadBlockHash: blocks[8].Hash(), SafeBlockHash: blocks[8].Hash(), FinalizedBlockHash: blocks[6].Hash()}
+	if _, err := api.ForkchoiceUpdatedV3(fcState, nil); err != nil {
+		t.Fatalf("failed to update forkchoice: %v", err)
+	}
+	check("latest", blocks[9])
+	check("safe", blocks[8])
+	check("finalized", blocks[6])
+}
+
 func TestEth2DeepReorg(t *testing.T) {
 	// TODO (MariusVanDerWijden) TestEth2DeepReorg is currently broken, because it tries to reorg
 	// before the totalTerminalDifficulty threshold
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -208,3 +209,38 @@ func TestOnDemandSpam(t *testing.T) {
 		}
 	}
 }
+
+// Tests that the extra data set through miner_setExtra is used in the blocks
+// sealed afterwards.
+func TestSimulatedBeaconSetExtra(t *testing.T) {
+	genesis := core.DeveloperGenesisBlock(10_000_000, nil)
+	n, _, sim := startSimulatedBeaconEthService(t, genesis, 0)
+	defer n.Close()
+
+	client := n.Attach()
+	defer client.Close()
+
+	var ok bool
+	if err := client.Call(&ok, "miner_setExtra", string(make([]byte, params.MaximumExtraDataSize+1))); err == nil {
+		t.Fatal("oversized extra data accepted")
+	}
+	extra := "rollup sequencer"
+	if err := client.Call(&ok, "miner_setExtra", extra); err != nil || !ok {
+		t.Fatalf("failed to set extra data: %v", err)
+	}
+	sim.Commit()
+
+	var block struct {
+		Number    hexutil.Uint64 `json:"number"`
+		ExtraData hexutil.Bytes  `json:"extraData"`
+	}
+	if err := client.Call(&block, "eth_getBlockByNumber", "latest", false); err != nil {
+		t.Fatalf("failed to retrieve block: %v", err)
+	}
+	if block.Number != 1 {
+		t.Fatalf("block number mismatch: have %d, want 1", block.Number)
+	}
+	if string(block.ExtraData) != extra {
+		t.Errorf("extra data mismatch: have %q, want %q", block.ExtraData, extra)
+	}
+}
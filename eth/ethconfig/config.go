@@ -69,6 +69,8 @@ var Defaults = Config{
 	RPCEVMTimeout:      5 * time.Second,
 	GPO:                FullNodeGPO,
 	RPCTxFeeCap:        1, // 1 ether
+	TraceCacheSize:     1024,
+	SequencerMaxAge:    10 * time.Second,
 }
 
 //go:generate go run github.com/fjl/gencodec -type Config -formats toml -out gen_config.go
@@ -138,6 +140,11 @@ type Config struct {
 	TxPool   legacypool.Config
 	BlobPool blobpool.Config
 
+	// BlobAvailability enables the verification of the blobs referenced by the
+	// imported blocks, the ones missing locally being fetched over the `blobs`
+	// protocol.
+	BlobAvailability bool
+
 	// Gas Price Oracle options
 	GPO gasprice.Config
 
@@ -158,6 +165,28 @@ type Config struct {
 	// send-transaction variants. The unit is ether.
 	RPCTxFeeCap float64
 
+	// TraceCacheSize is the number of transaction trace results kept by the
+	// debug API (0 = disabled).
+	TraceCacheSize int
+
+	// SequencerMaxAge is the maximum age of the unsafe head block before the
+	// sequencer health check reports the node unhealthy.
+	SequencerMaxAge time.Duration
+
+	// RollupSequencer marks the node as the sequencer of the rollup chain.
+	RollupSequencer bool
+
+	// RollupL1RPC is the URL of the L1 node the rollup chain is derived from,
+	// reported by admin_nodeInfo.
+	RollupL1RPC string `toml:",omitempty"`
+
+	// RollupForcePaused starts the node with block production paused.
+	RollupForcePaused bool
+
+	// UnsafeImport enables admin_importTrustedBlocks, inserting blocks without
+	// validating them.
+	UnsafeImport bool
+
 	// OverridePrague (TODO: remove after the fork)
 	OverridePrague *uint64 `toml:",omitempty"`
 
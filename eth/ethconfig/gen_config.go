@@ -47,6 +47,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Miner                   miner.Config
 		TxPool                  legacypool.Config
 		BlobPool                blobpool.Config
+		BlobAvailability        bool
 		GPO                     gasprice.Config
 		EnablePreimageRecording bool
 		VMTrace                 string
@@ -54,6 +55,12 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		RPCGasCap               uint64
 		RPCEVMTimeout           time.Duration
 		RPCTxFeeCap             float64
+		TraceCacheSize          int
+		SequencerMaxAge         time.Duration
+		RollupSequencer         bool
+		RollupL1RPC             string `toml:",omitempty"`
+		RollupForcePaused       bool
+		UnsafeImport            bool
 		OverridePrague          *uint64 `toml:",omitempty"`
 		OverrideVerkle          *uint64 `toml:",omitempty"`
 	}
@@ -88,6 +95,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Miner = c.Miner
 	enc.TxPool = c.TxPool
 	enc.BlobPool = c.BlobPool
+	enc.BlobAvailability = c.BlobAvailability
 	enc.GPO = c.GPO
 	enc.EnablePreimageRecording = c.EnablePreimageRecording
 	enc.VMTrace = c.VMTrace
@@ -95,6 +103,12 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.RPCGasCap = c.RPCGasCap
 	enc.RPCEVMTimeout = c.RPCEVMTimeout
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
+	enc.TraceCacheSize = c.TraceCacheSize
+	enc.SequencerMaxAge = c.SequencerMaxAge
+	enc.RollupSequencer = c.RollupSequencer
+	enc.RollupL1RPC = c.RollupL1RPC
+	enc.RollupForcePaused = c.RollupForcePaused
+	enc.UnsafeImport = c.UnsafeImport
 	enc.OverridePrague = c.OverridePrague
 	enc.OverrideVerkle = c.OverrideVerkle
 	return &enc, nil
@@ -133,6 +147,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Miner                   *miner.Config
 		TxPool                  *legacypool.Config
 		BlobPool                *blobpool.Config
+		BlobAvailability        *bool
 		GPO                     *gasprice.Config
 		EnablePreimageRecording *bool
 		VMTrace                 *string
@@ -140,6 +155,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		RPCGasCap               *uint64
 		RPCEVMTimeout           *time.Duration
 		RPCTxFeeCap             *float64
+		TraceCacheSize          *int
+		SequencerMaxAge         *time.Duration
+		RollupSequencer         *bool
+		RollupL1RPC             *string `toml:",omitempty"`
+		RollupForcePaused       *bool
+		UnsafeImport            *bool
 		OverridePrague          *uint64 `toml:",omitempty"`
 		OverrideVerkle          *uint64 `toml:",omitempty"`
 	}
@@ -237,6 +258,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.BlobPool != nil {
 		c.BlobPool = *dec.BlobPool
 	}
+	if dec.BlobAvailability != nil {
+		c.BlobAvailability = *dec.BlobAvailability
+	}
 	if dec.GPO != nil {
 		c.GPO = *dec.GPO
 	}
@@ -258,6 +282,24 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.RPCTxFeeCap != nil {
 		c.RPCTxFeeCap = *dec.RPCTxFeeCap
 	}
+	if dec.TraceCacheSize != nil {
+		c.TraceCacheSize = *dec.TraceCacheSize
+	}
+	if dec.SequencerMaxAge != nil {
+		c.SequencerMaxAge = *dec.SequencerMaxAge
+	}
+	if dec.RollupSequencer != nil {
+		c.RollupSequencer = *dec.RollupSequencer
+	}
+	if dec.RollupL1RPC != nil {
+		c.RollupL1RPC = *dec.RollupL1RPC
+	}
+	if dec.RollupForcePaused != nil {
+		c.RollupForcePaused = *dec.RollupForcePaused
+	}
+	if dec.UnsafeImport != nil {
+		c.UnsafeImport = *dec.UnsafeImport
+	}
 	if dec.OverridePrague != nil {
 		c.OverridePrague = dec.OverridePrague
 	}
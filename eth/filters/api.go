@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/history"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/internal/ethapi"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -40,6 +41,7 @@ var (
 	errInvalidBlockRange      = errors.New("invalid block range params")
 	errPendingLogsUnsupported = errors.New("pending logs are not supported")
 	errExceedMaxTopics        = errors.New("exceed max topics")
+	errExceedMaxTxAddresses   = errors.New("exceed max addresses")
 )
 
 // The maximum number of topic criteria allowed, vm.LOG4 - vm.LOG0
@@ -48,6 +50,9 @@ const maxTopics = 4
 // The maximum number of allowed topics within a topic criteria
 const maxSubTopics = 1000
 
+// The maximum number of addresses a pending transaction subscription can filter on
+const maxTxAddresses = 100
+
 // filter is a helper struct that holds meta information over the filter type
 // and associated subscription in the event system.
 type filter struct {
@@ -195,6 +200,48 @@ func (api *FilterAPI) NewPendingTransactions(ctx context.Context, fullTx *bool)
 	return rpcSub, nil
 }
 
+// NewPendingTransactionsByAddress creates a subscription that is triggered each
+// time a transaction sent to one of the given addresses enters the transaction
+// pool. If fullTx is true the full tx is sent to the client, otherwise the hash
+// is sent.
+func (api *FilterAPI) NewPendingTransactionsByAddress(ctx context.Context, addresses []common.Address, fullTx *bool) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	txs := make(chan []*types.Transaction, 128)
+	pendingTxSub, err := api.events.SubscribePendingTxsByAddress(addresses, txs)
+	if err != nil {
+		return nil, err
+	}
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		defer pendingTxSub.Unsubscribe()
+
+		chainConfig := api.sys.backend.ChainConfig()
+
+		for {
+			select {
+			case txs := <-txs:
+				latest := api.sys.backend.CurrentHeader()
+				for _, tx := range txs {
+					if fullTx != nil && *fullTx {
+						rpcTx := ethapi.NewRPCPendingTransaction(tx, latest, chainConfig)
+						notifier.Notify(rpcSub.ID, rpcTx)
+					} else {
+						notifier.Notify(rpcSub.ID, tx.Hash())
+					}
+				}
+			case <-rpcSub.Err():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
 // NewBlockFilter creates a filter that fetches blocks that are imported into the chain.
 // It is part of the filter package since polling goes with eth_getFilterChanges.
 func (api *FilterAPI) NewBlockFilter() rpc.ID {
@@ -256,6 +303,8 @@ func (api *FilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
 }
 
 // Logs creates a subscription that fires for all new log that match the given filter criteria.
+// If the criteria start from a past block, the matching logs from that block up to
+// the current head are replayed before any new log.
 func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subscription, error) {
 	notifier, supported := rpc.NotifierFromContext(ctx)
 	if !supported {
@@ -271,15 +320,61 @@ func (api *FilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc.Subsc
 	if err != nil {
 		return nil, err
 	}
-
+	// If the subscription starts from a past block, replay the logs up to the
+	// head first. This lets reconnecting clients catch up on the missed logs.
+	var (
+		backfillCtx, cancel = context.WithCancel(context.Background())
+		backfill            <-chan logsBackfill
+	)
+	if crit.FromBlock != nil && crit.FromBlock.Sign() >= 0 {
+		backfill = api.events.backfillLogs(backfillCtx, ethereum.FilterQuery(crit))
+	}
 	go func() {
+		defer cancel()
 		defer logsSub.Unsubscribe()
+
+		var (
+			queued   [][]*types.Log           // logs mined during the replay
+			replayed map[common.Hash]struct{} // blocks whose logs were replayed
+			head     uint64                   // last block covered by the replay
+		)
+		deliver := func(logs []*types.Log) {
+			for _, log := range logs {
+				if replayed != nil && !log.Removed {
+					// Logs of the replayed blocks may still be in flight in the
+					// live feed, skip them until the first log past the replay.
+					if _, ok := replayed[log.BlockHash]; ok {
+						continue
+					}
+					if log.BlockNumber > head {
+						replayed = nil
+					}
+				}
+				notifier.Notify(rpcSub.ID, &log)
+			}
+		}
 		for {
 			select {
 			case logs := <-matchedLogs:
-				for _, log := range logs {
+				if backfill != nil {
+					queued = append(queued, logs)
+					continue
+				}
+				deliver(logs)
+			case res := <-backfill:
+				backfill = nil
+				if res.err != nil {
+					log.Warn("Failed to replay subscription logs", "id", rpcSub.ID, "from", crit.FromBlock, "err", res.err)
+				}
+				replayed, head = make(map[common.Hash]struct{}), res.head
+				for _, log := range res.logs {
+					replayed[log.BlockHash] = struct{}{}
 					notifier.Notify(rpcSub.ID, &log)
 				}
+				for _, logs := range queued {
+					deliver(logs)
+				}
+				queued = nil
 			case <-rpcSub.Err(): // client send an unsubscribe request
 				return
 			}
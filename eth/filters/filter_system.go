@@ -155,6 +155,9 @@ const (
 	// PendingTransactionsSubscription queries for pending transactions entering
 	// the pending state
 	PendingTransactionsSubscription
+	// PendingTxsByAddressSubscription queries for pending transactions sent to
+	// one of the given addresses
+	PendingTxsByAddressSubscription
 	// BlocksSubscription queries hashes for blocks that are imported
 	BlocksSubscription
 	// LastIndexSubscription keeps track of the last index
@@ -178,6 +181,7 @@ type subscription struct {
 	typ       Type
 	created   time.Time
 	logsCrit  ethereum.FilterQuery
+	txAddrs   map[common.Address]struct{}
 	logs      chan []*types.Log
 	txs       chan []*types.Transaction
 	headers   chan *types.Header
@@ -348,6 +352,37 @@ func (es *EventSystem) subscribeLogs(crit ethereum.FilterQuery, logs chan []*typ
 	return es.subscribe(sub)
 }
 
+// logsBackfill is the outcome of replaying the historical logs of a subscription.
+type logsBackfill struct {
+	logs []*types.Log
+	head uint64 // last block covered by the replay
+	err  error
+}
+
+// backfillLogs retrieves in the background the logs matching the given criteria
+// from its starting block up to the current head. Logs subscriptions starting
+// from a past block replay these before streaming the newly mined logs.
+//
+// The live subscription must be installed before calling this method, since any
+// log mined after the head is picked is only delivered by that subscription.
+func (es *EventSystem) backfillLogs(ctx context.Context, crit ethereum.FilterQuery) <-chan logsBackfill {
+	result := make(chan logsBackfill, 1)
+	go func() {
+		head := es.backend.CurrentHeader().Number.Uint64()
+		if crit.ToBlock != nil && crit.ToBlock.Sign() >= 0 && crit.ToBlock.Uint64() < head {
+			head = crit.ToBlock.Uint64()
+		}
+		if crit.FromBlock.Uint64() > head {
+			result <- logsBackfill{head: head}
+			return
+		}
+		filter := es.sys.NewRangeFilter(crit.FromBlock.Int64(), int64(head), crit.Addresses, crit.Topics)
+		logs, err := filter.Logs(ctx)
+		result <- logsBackfill{logs: logs, head: head, err: err}
+	}()
+	return result
+}
+
 // SubscribeNewHeads creates a subscription that writes the header of a block that is
 // imported in the chain.
 func (es *EventSystem) SubscribeNewHeads(headers chan *types.Header) *Subscription {
@@ -380,6 +415,30 @@ func (es *EventSystem) SubscribePendingTxs(txs chan []*types.Transaction) *Subsc
 	return es.subscribe(sub)
 }
 
+// SubscribePendingTxsByAddress creates a subscription that writes the transactions
+// entering the transaction pool, which are sent to one of the given addresses.
+func (es *EventSystem) SubscribePendingTxsByAddress(addresses []common.Address, txs chan []*types.Transaction) (*Subscription, error) {
+	if len(addresses) > maxTxAddresses {
+		return nil, errExceedMaxTxAddresses
+	}
+	txAddrs := make(map[common.Address]struct{}, len(addresses))
+	for _, addr := range addresses {
+		txAddrs[addr] = struct{}{}
+	}
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       PendingTxsByAddressSubscription,
+		created:   time.Now(),
+		txAddrs:   txAddrs,
+		logs:      make(chan []*types.Log),
+		txs:       txs,
+		headers:   make(chan *types.Header),
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub), nil
+}
+
 type filterIndex map[Type]map[rpc.ID]*subscription
 
 func (es *EventSystem) handleLogs(filters filterIndex, ev []*types.Log) {
@@ -398,6 +457,19 @@ func (es *EventSystem) handleTxsEvent(filters filterIndex, ev core.NewTxsEvent)
 	for _, f := range filters[PendingTransactionsSubscription] {
 		f.txs <- ev.Txs
 	}
+	for _, f := range filters[PendingTxsByAddressSubscription] {
+		var matched []*types.Transaction
+		for _, tx := range ev.Txs {
+			if to := tx.To(); to != nil {
+				if _, ok := f.txAddrs[*to]; ok {
+					matched = append(matched, tx)
+				}
+			}
+		}
+		if len(matched) > 0 {
+			f.txs <- matched
+		}
+	}
 }
 
 func (es *EventSystem) handleChainEvent(filters filterIndex, ev core.ChainEvent) {
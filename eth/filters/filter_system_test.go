@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/filtermaps"
@@ -36,6 +37,7 @@ import (
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/triedb"
 )
 
 type testBackend struct {
@@ -372,6 +374,62 @@ func TestPendingTxFilterFullTx(t *testing.T) {
 	}
 }
 
+// TestPendingTxsByAddressSubscription tests whether the pending transaction
+// subscriptions filtered by address only receive the transactions sent to them.
+func TestPendingTxsByAddressSubscription(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(db, Config{})
+		api          = NewFilterAPI(sys)
+
+		watched = common.HexToAddress("0xb794f5ea0ba39494ce83a213fffba74279579268")
+		other   = common.HexToAddress("0x71562b71999873db5b286df957af199ec94617f7")
+
+		transactions = []*types.Transaction{
+			types.NewTransaction(0, watched, new(big.Int), 0, new(big.Int), nil),
+			types.NewTransaction(1, other, new(big.Int), 0, new(big.Int), nil),
+			types.NewContractCreation(2, new(big.Int), 0, new(big.Int), nil),
+			types.NewTransaction(3, watched, new(big.Int), 0, new(big.Int), nil),
+		}
+	)
+	if _, err := api.events.SubscribePendingTxsByAddress(make([]common.Address, maxTxAddresses+1), nil); err != errExceedMaxTxAddresses {
+		t.Fatalf("error mismatch: have %v, want %v", err, errExceedMaxTxAddresses)
+	}
+	txs := make(chan []*types.Transaction)
+	sub, err := api.events.SubscribePendingTxsByAddress([]common.Address{watched}, txs)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	backend.txFeed.Send(core.NewTxsEvent{Txs: transactions[:2]})
+	backend.txFeed.Send(core.NewTxsEvent{Txs: transactions[2:]})
+
+	var hashes []common.Hash
+	timeout := time.After(time.Second)
+	for len(hashes) < 2 {
+		select {
+		case batch := <-txs:
+			for _, tx := range batch {
+				hashes = append(hashes, tx.Hash())
+			}
+		case <-timeout:
+			t.Fatalf("timeout waiting for transactions, have %d", len(hashes))
+		}
+	}
+	want := []common.Hash{transactions[0].Hash(), transactions[3].Hash()}
+	if !reflect.DeepEqual(hashes, want) {
+		t.Errorf("transactions mismatch: have %x, want %x", hashes, want)
+	}
+	select {
+	case batch := <-txs:
+		t.Errorf("unexpected transactions: %v", batch)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
 // TestLogFilterCreation test whether a given filter criteria makes sense.
 // If not it must return an error.
 func TestLogFilterCreation(t *testing.T) {
@@ -587,6 +645,89 @@ func TestLogFilter(t *testing.T) {
 	}
 }
 
+// TestLogsSubscriptionBackfill tests that a logs subscription starting from a past
+// block replays the historical logs before streaming the new ones.
+func TestLogsSubscriptionBackfill(t *testing.T) {
+	t.Parallel()
+
+	var (
+		db           = rawdb.NewMemoryDatabase()
+		backend, sys = newTestFilterSystem(db, Config{})
+		api          = NewFilterAPI(sys)
+		addr         = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		topic        = common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+		gspec        = &core.Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	// Every block emits a single log
+	_, chain, receipts := core.GenerateChainWithGenesis(gspec, ethash.NewFaker(), 20, func(i int, gen *core.BlockGen) {
+		receipt := types.NewReceipt(nil, false, 0)
+		receipt.Logs = []*types.Log{{Address: addr, Topics: []common.Hash{topic}}}
+		receipt.Bloom = types.CreateBloom(receipt)
+		gen.AddUncheckedReceipt(receipt)
+		gen.AddUncheckedTx(types.NewTransaction(uint64(i), common.HexToAddress("0x999"), big.NewInt(999), 999, gen.BaseFee(), nil))
+	})
+	gspec.MustCommit(db, triedb.NewDatabase(db, triedb.HashDefaults))
+	for i, block := range chain {
+		rawdb.WriteBlock(db, block)
+		rawdb.WriteCanonicalHash(db, block.Hash(), block.NumberU64())
+		rawdb.WriteHeadBlockHash(db, block.Hash())
+		rawdb.WriteReceipts(db, block.Hash(), block.NumberU64(), receipts[i])
+	}
+	backend.startFilterMaps(0, true, filtermaps.DefaultParams)
+	defer backend.stopFilterMaps()
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("eth", api); err != nil {
+		t.Fatal(err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	head := chain[len(chain)-1].NumberU64()
+	logs := make(chan types.Log)
+	sub, err := client.EthSubscribe(context.Background(), logs, "logs", map[string]interface{}{
+		"address":   addr,
+		"fromBlock": hexutil.Uint64(head - 10),
+	})
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	next := func() types.Log {
+		t.Helper()
+		select {
+		case log := <-logs:
+			return log
+		case err := <-sub.Err():
+			t.Fatalf("subscription failed: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for log")
+		}
+		return types.Log{}
+	}
+	// The logs from the starting block up to the head are replayed in order
+	for number := head - 10; number <= head; number++ {
+		if log := next(); log.BlockNumber != number || log.BlockHash != chain[number-1].Hash() {
+			t.Fatalf("replayed log mismatch: have block %d (%x), want %d", log.BlockNumber, log.BlockHash, number)
+		}
+	}
+	// The replayed logs are not delivered again, the new logs are streamed
+	var (
+		replayed = &types.Log{Address: addr, Topics: []common.Hash{topic}, BlockNumber: head, BlockHash: chain[head-1].Hash()}
+		live     = &types.Log{Address: addr, Topics: []common.Hash{topic}, BlockNumber: head + 1, BlockHash: common.Hash{0x01}}
+	)
+	backend.logsFeed.Send([]*types.Log{replayed, live})
+	if log := next(); log.BlockNumber != live.BlockNumber || log.BlockHash != live.BlockHash {
+		t.Fatalf("streamed log mismatch: have block %d (%x), want %d", log.BlockNumber, log.BlockHash, live.BlockNumber)
+	}
+}
+
 // TestPendingTxFilterDeadlock tests if the event loop hangs when pending
 // txes arrive at the same time that one of multiple filters is timing out.
 // Please refer to #22131 for more details.
@@ -21,6 +21,7 @@ import (
 	"maps"
 	"math"
 	"math/big"
+	"net"
 	"slices"
 	"sync"
 	"sync/atomic"
@@ -35,6 +36,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/downloader"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/eth/fetcher"
+	"github.com/ethereum/go-ethereum/eth/protocols/blobs"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/eth/protocols/snap"
 	"github.com/ethereum/go-ethereum/ethdb"
@@ -80,9 +82,17 @@ type txPool interface {
 	// given transaction hash.
 	GetMetadata(hash common.Hash) *txpool.TxMetadata
 
+	// GetBlobs retrieves the sidecars of the transactions containing the blobs
+	// with the given versioned hashes.
+	GetBlobs(vhashes []common.Hash) []*types.BlobTxSidecar
+
 	// Add should add the given transactions to the pool.
 	Add(txs []*types.Transaction, sync bool) []error
 
+	// AddRemote should add the given transactions received from the remote IP
+	// address to the pool, subject to the per IP limits.
+	AddRemote(txs []*types.Transaction, ip net.IP, sync bool) []error
+
 	// Pending should return pending transactions.
 	// The slice should be modifiable by the caller.
 	Pending(filter txpool.PendingFilter) map[common.Address][]*txpool.LazyTransaction
@@ -91,6 +101,10 @@ type txPool interface {
 	// can decide whether to receive notifications only for newly seen transactions
 	// or also for reorged out ones.
 	SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bool) event.Subscription
+
+	// SubscribeRebroadcasts subscribes to the pending transactions announced
+	// again after not being included for a while.
+	SubscribeRebroadcasts(ch chan<- core.NewTxsEvent) event.Subscription
 }
 
 // handlerConfig is the collection of initialization parameters to create a full
@@ -126,10 +140,16 @@ type handler struct {
 	eventMux   *event.TypeMux
 	txsCh      chan core.NewTxsEvent
 	txsSub     event.Subscription
+	rebroadCh  chan core.NewTxsEvent
+	rebroadSub event.Subscription
 	blockRange *blockRangeState
 
 	requiredBlocks map[uint64]common.Hash
 
+	blobPeers map[string]*blobs.Peer  // Peers connected on `blobs`
+	blobReqs  map[uint64]*blobRequest // Blob requests waiting for a response
+	blobLock  sync.Mutex              // Protects the blob peers and requests
+
 	// channels for fetcher, syncer, txsyncLoop
 	quitSync chan struct{}
 
@@ -154,6 +174,8 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		chain:          config.Chain,
 		peers:          newPeerSet(),
 		requiredBlocks: config.RequiredBlocks,
+		blobPeers:      make(map[string]*blobs.Peer),
+		blobReqs:       make(map[uint64]*blobRequest),
 		quitSync:       make(chan struct{}),
 		handlerDoneCh:  make(chan struct{}),
 		handlerStartCh: make(chan struct{}),
@@ -200,10 +222,19 @@ func newHandler(config *handlerConfig) (*handler, error) {
 		}
 		return p.RequestTxs(hashes)
 	}
-	addTxs := func(txs []*types.Transaction) []error {
-		return h.txpool.Add(txs, false)
+	addTxs := func(peer string, txs []*types.Transaction) []error {
+		var ip net.IP
+		if p := h.peers.peer(peer); p != nil {
+			if addr, ok := p.RemoteAddr().(*net.TCPAddr); ok {
+				ip = addr.IP
+			}
+		}
+		return h.txpool.AddRemote(txs, ip, false)
 	}
 	h.txFetcher = fetcher.NewTxFetcher(h.txpool.Has, addTxs, fetchTx, h.removePeer)
+
+	// Retrieve the blobs missing on import from the peers
+	h.chain.SetBlobSource(h.fetchBlobs)
 	return h, nil
 }
 
@@ -431,6 +462,12 @@ func (h *handler) Start(maxPeers int) {
 	h.txsSub = h.txpool.SubscribeTransactions(h.txsCh, false)
 	go h.txBroadcastLoop()
 
+	// announce the stuck transactions again to the peers not knowing them
+	h.wg.Add(1)
+	h.rebroadCh = make(chan core.NewTxsEvent, txChanSize)
+	h.rebroadSub = h.txpool.SubscribeRebroadcasts(h.rebroadCh)
+	go h.txRebroadcastLoop()
+
 	// broadcast block range
 	h.wg.Add(1)
 	h.blockRange = newBlockRangeState(h.chain, h.eventMux)
@@ -445,7 +482,8 @@ func (h *handler) Start(maxPeers int) {
 }
 
 func (h *handler) Stop() {
-	h.txsSub.Unsubscribe() // quits txBroadcastLoop
+	h.txsSub.Unsubscribe()     // quits txBroadcastLoop
+	h.rebroadSub.Unsubscribe() // quits txRebroadcastLoop
 	h.blockRange.stop()
 	h.txFetcher.Stop()
 	h.downloader.Terminate()
@@ -555,6 +593,38 @@ func (h *handler) txBroadcastLoop() {
 	}
 }
 
+// AnnounceTransactions announces a batch of transactions to all the peers which
+// are not known to already have them, without sending them directly.
+func (h *handler) AnnounceTransactions(txs types.Transactions) {
+	var (
+		annCount int                                // Number of transactions announced across all peers (duplicates included)
+		annos    = make(map[*ethPeer][]common.Hash) // Set peer->hash to announce
+	)
+	for _, tx := range txs {
+		for _, peer := range h.peers.peersWithoutTransaction(tx.Hash()) {
+			annos[peer] = append(annos[peer], tx.Hash())
+		}
+	}
+	for peer, hashes := range annos {
+		annCount += len(hashes)
+		peer.AsyncSendPooledTransactionHashes(hashes)
+	}
+	log.Debug("Announced transactions", "txs", len(txs), "annpeers", len(annos), "anncount", annCount)
+}
+
+// txRebroadcastLoop announces the stuck transactions again to connected peers.
+func (h *handler) txRebroadcastLoop() {
+	defer h.wg.Done()
+	for {
+		select {
+		case event := <-h.rebroadCh:
+			h.AnnounceTransactions(event.Txs)
+		case <-h.rebroadSub.Err():
+			return
+		}
+	}
+}
+
 // enableSyncedFeatures enables the post-sync functionalities when the initial
 // sync is finished.
 func (h *handler) enableSyncedFeatures() {
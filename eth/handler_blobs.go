@@ -0,0 +1,286 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"crypto/sha256"
+	"math/rand"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/eth/protocols/blobs"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+const (
+	// blobFetchTimeout is the maximum time allowed to retrieve the blobs of a
+	// block from the peers, all of them being queried at once.
+	blobFetchTimeout = 10 * time.Second
+
+	// blobFetchPeers is the maximum number of peers queried for the blobs of a
+	// block.
+	blobFetchPeers = 4
+)
+
+// blobRequest is a blob retrieval waiting for the response of a peer.
+type blobRequest struct {
+	peer string                  // Peer the request was sent to
+	sink chan *blobs.BlobsPacket // Channel to deliver the response on
+}
+
+// blobsPeerInfo represents a short summary of the `blobs` sub-protocol metadata
+// known about a connected peer.
+type blobsPeerInfo struct {
+	Version uint `json:"version"` // Blob protocol version negotiated
+}
+
+// blobsHandler implements the blobs.Backend interface to serve the blobs of the
+// local pool and to deliver the ones requested from the peers.
+type blobsHandler handler
+
+func (h *blobsHandler) TxPool() blobs.TxPool { return h.txpool }
+
+// RunPeer is invoked when a peer joins on the `blobs` protocol.
+func (h *blobsHandler) RunPeer(peer *blobs.Peer, hand blobs.Handler) error {
+	if !(*handler)(h).incHandlers() {
+		return p2p.DiscQuitting
+	}
+	defer (*handler)(h).decHandlers()
+
+	h.blobLock.Lock()
+	h.blobPeers[peer.ID()] = peer
+	h.blobLock.Unlock()
+
+	defer func() {
+		h.blobLock.Lock()
+		delete(h.blobPeers, peer.ID())
+		h.blobLock.Unlock()
+	}()
+	return hand(peer)
+}
+
+// PeerInfo retrieves all known `blobs` information about a peer.
+func (h *blobsHandler) PeerInfo(id enode.ID) interface{} {
+	h.blobLock.Lock()
+	defer h.blobLock.Unlock()
+
+	if peer := h.blobPeers[id.String()]; peer != nil {
+		return &blobsPeerInfo{Version: peer.Version()}
+	}
+	return nil
+}
+
+// Handle is invoked from a peer's message handler when it receives a new remote
+// message that the handler couldn't consume and serve itself.
+func (h *blobsHandler) Handle(peer *blobs.Peer, packet blobs.Packet) error {
+	res, ok := packet.(*blobs.BlobsPacket)
+	if !ok {
+		return nil
+	}
+	h.blobLock.Lock()
+	req := h.blobReqs[res.ID]
+	if req != nil && req.peer == peer.ID() {
+		delete(h.blobReqs, res.ID)
+	} else {
+		req = nil
+	}
+	h.blobLock.Unlock()
+
+	// Late or unsolicited responses are dropped, the sink is buffered to hold
+	// the responses of all the peers queried
+	if req != nil {
+		req.sink <- res
+	}
+	return nil
+}
+
+// requestBlobs asks a few of the `blobs` peers not asked yet for the given blobs,
+// delivering their responses on the sink. The peers asked are added to the set,
+// and the ids of the requests sent are returned.
+func (h *handler) requestBlobs(hashes []common.Hash, sink chan *blobs.BlobsPacket, asked map[string]struct{}) []uint64 {
+	h.blobLock.Lock()
+	peers := make([]*blobs.Peer, 0, blobFetchPeers)
+	for id, peer := range h.blobPeers {
+		if len(peers) == blobFetchPeers {
+			break
+		}
+		if _, ok := asked[id]; ok {
+			continue
+		}
+		asked[id] = struct{}{}
+		peers = append(peers, peer)
+	}
+	h.blobLock.Unlock()
+
+	var ids []uint64
+	for _, peer := range peers {
+		id := rand.Uint64()
+
+		h.blobLock.Lock()
+		h.blobReqs[id] = &blobRequest{peer: peer.ID(), sink: sink}
+		h.blobLock.Unlock()
+
+		if err := peer.RequestBlobs(id, hashes); err != nil {
+			h.blobLock.Lock()
+			delete(h.blobReqs, id)
+			h.blobLock.Unlock()
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// fetchBlobs assembles the sidecars of the blob transactions of a block, keyed
+// by transaction hash. The blobs missing from the local pool are requested from
+// the peers serving them, each blob received being checked against its KZG proof.
+// The sidecars of the transactions with unavailable blobs are left out, their
+// verification is up to the caller.
+func (h *handler) fetchBlobs(block *types.Block) map[common.Hash]*types.BlobTxSidecar {
+	var vhashes []common.Hash
+	for _, tx := range block.Transactions() {
+		vhashes = append(vhashes, tx.BlobHashes()...)
+	}
+	sidecars := make(map[common.Hash]*types.BlobTxSidecar)
+	if len(vhashes) == 0 {
+		return sidecars
+	}
+	// Gather the blobs known locally, requesting the rest from the peers
+	var (
+		hasher = sha256.New()
+		known  = make(map[common.Hash]*types.BlobTxSidecar)
+	)
+	collect := func(sidecar *types.BlobTxSidecar, verify bool) {
+		perBlob := 1
+		if sidecar.Version != 0 {
+			perBlob = kzg4844.CellProofsPerBlob
+		}
+		for i := range sidecar.Commitments {
+			if i >= len(sidecar.Blobs) || (i+1)*perBlob > len(sidecar.Proofs) {
+				return
+			}
+			vhash := kzg4844.CalcBlobHashV1(hasher, &sidecar.Commitments[i])
+			if known[vhash] != nil {
+				continue
+			}
+			blob := &types.BlobTxSidecar{
+				Version:     sidecar.Version,
+				Blobs:       sidecar.Blobs[i : i+1],
+				Commitments: sidecar.Commitments[i : i+1],
+				Proofs:      sidecar.Proofs[i*perBlob : (i+1)*perBlob],
+			}
+			if verify {
+				if err := verifyBlob(blob); err != nil {
+					log.Debug("Invalid blob received", "number", block.Number(), "vhash", common.Hash(vhash), "err", err)
+					continue
+				}
+			}
+			known[vhash] = blob
+		}
+	}
+	// The blobs of the local pool were verified on admission
+	for _, sidecar := range h.txpool.GetBlobs(vhashes) {
+		if sidecar != nil {
+			collect(sidecar, false)
+		}
+	}
+	missing := func() []common.Hash {
+		var hashes []common.Hash
+		for _, vhash := range vhashes {
+			if known[vhash] == nil {
+				hashes = append(hashes, vhash)
+			}
+		}
+		return hashes
+	}
+	if len(missing()) > 0 {
+		// Query a few peers at once under a single deadline, moving on to the
+		// others while blobs are left missing or fail verification
+		var (
+			sink    = make(chan *blobs.BlobsPacket, blobFetchPeers)
+			asked   = make(map[string]struct{})
+			ids     []uint64
+			timeout = time.NewTimer(blobFetchTimeout)
+		)
+	fetch:
+		for hashes := missing(); len(hashes) > 0; hashes = missing() {
+			round := h.requestBlobs(hashes, sink, asked)
+			if len(round) == 0 {
+				break
+			}
+			ids = append(ids, round...)
+			for pending := len(round); pending > 0 && len(missing()) > 0; pending-- {
+				select {
+				case res := <-sink:
+					for _, sidecar := range res.Sidecars {
+						collect(sidecar, true)
+					}
+				case <-timeout.C:
+					log.Debug("Blob retrieval timed out", "number", block.Number(), "hash", block.Hash(), "peers", pending)
+					break fetch
+				}
+			}
+		}
+		timeout.Stop()
+
+		h.blobLock.Lock()
+		for _, id := range ids {
+			delete(h.blobReqs, id)
+		}
+		h.blobLock.Unlock()
+	}
+	// Assemble the sidecars of the transactions from the gathered blobs, leaving
+	// out the incomplete ones for the availability check to reject
+	for _, tx := range block.Transactions() {
+		if tx.Type() != types.BlobTxType {
+			continue
+		}
+		sidecar := new(types.BlobTxSidecar)
+		for i, vhash := range tx.BlobHashes() {
+			blob := known[vhash]
+			if blob == nil {
+				sidecar = nil
+				break
+			}
+			if i == 0 {
+				sidecar.Version = blob.Version
+			}
+			sidecar.Blobs = append(sidecar.Blobs, blob.Blobs...)
+			sidecar.Commitments = append(sidecar.Commitments, blob.Commitments...)
+			sidecar.Proofs = append(sidecar.Proofs, blob.Proofs...)
+		}
+		if sidecar != nil {
+			sidecars[tx.Hash()] = sidecar
+		}
+	}
+	if hashes := missing(); len(hashes) > 0 {
+		log.Debug("Blobs unavailable", "number", block.Number(), "hash", block.Hash(), "missing", len(hashes))
+	}
+	return sidecars
+}
+
+// verifyBlob checks a single-blob sidecar against its KZG proofs.
+func verifyBlob(sidecar *types.BlobTxSidecar) error {
+	if sidecar.Version == 0 {
+		return kzg4844.VerifyBlobProof(&sidecar.Blobs[0], sidecar.Commitments[0], sidecar.Proofs[0])
+	}
+	return kzg4844.VerifyCellProofs(sidecar.Blobs, sidecar.Commitments, sidecar.Proofs)
+}
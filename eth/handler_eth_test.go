@@ -19,6 +19,7 @@ package eth
 import (
 	"fmt"
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,12 +28,16 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/protocols/blobs"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/holiman/uint256"
 )
 
 // testEthHandler is a mock event handler to listen for inbound network requests
@@ -225,6 +230,70 @@ func testForkIDSplit(t *testing.T, protocol uint) {
 	}
 }
 
+// Tests that OP-Stack nodes of different networks, sharing the same chain config
+// but not their genesis, reject each other in the protocol handshake.
+func TestGenesisSplit68(t *testing.T) { testGenesisSplit(t, eth.ETH68) }
+
+func testGenesisSplit(t *testing.T, protocol uint) {
+	t.Parallel()
+
+	config := *params.TestChainConfig
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+
+	newNode := func(extra string) (*core.BlockChain, *handler) {
+		var (
+			db       = rawdb.NewMemoryDatabase()
+			gspec    = &core.Genesis{Config: &config, ExtraData: []byte(extra), BaseFee: big.NewInt(params.InitialBaseFee)}
+			chain, _ = core.NewBlockChain(db, gspec, ethash.NewFaker(), nil)
+		)
+		h, _ := newHandler(&handlerConfig{
+			Database:   db,
+			Chain:      chain,
+			TxPool:     newTestTxPool(),
+			Network:    1,
+			Sync:       ethconfig.FullSync,
+			BloomCache: 1,
+		})
+		h.Start(1000)
+		t.Cleanup(func() {
+			h.Stop()
+			chain.Stop()
+		})
+		return chain, h
+	}
+	chainMain, ethMain := newNode("mainnet")
+	chainTest, ethTest := newNode("testnet")
+	if chainMain.Genesis().Hash() == chainTest.Genesis().Hash() {
+		t.Fatal("networks share the same genesis")
+	}
+	p2pMain, p2pTest := p2p.MsgPipe()
+	defer p2pMain.Close()
+	defer p2pTest.Close()
+
+	peerMain := eth.NewPeer(protocol, p2p.NewPeerPipe(enode.ID{1}, "", nil, p2pMain), p2pMain, nil)
+	peerTest := eth.NewPeer(protocol, p2p.NewPeerPipe(enode.ID{2}, "", nil, p2pTest), p2pTest, nil)
+	defer peerMain.Close()
+	defer peerTest.Close()
+
+	errc := make(chan error, 2)
+	go func() {
+		errc <- ethMain.runEthPeer(peerTest, func(peer *eth.Peer) error { return nil })
+	}()
+	go func() {
+		errc <- ethTest.runEthPeer(peerMain, func(peer *eth.Peer) error { return nil })
+	}()
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-errc:
+			if err == nil || !strings.Contains(err.Error(), "genesis mismatch") {
+				t.Fatalf("handshake error mismatch: have %v, want genesis mismatch", err)
+			}
+		case <-time.After(250 * time.Millisecond):
+			t.Fatalf("split peers not rejected")
+		}
+	}
+}
+
 // Tests that received transactions are added to the local pool.
 func TestRecvTransactions68(t *testing.T) { testRecvTransactions(t, eth.ETH68) }
 
@@ -424,3 +493,207 @@ func testTransactionPropagation(t *testing.T, protocol uint) {
 		}
 	}
 }
+
+// Tests that the blobs of a block missing from the local pool are retrieved
+// from the peers holding them.
+func TestFetchBlobs(t *testing.T) {
+	t.Parallel()
+
+	// Create a source holding the sidecar of a blob transaction and a sink
+	// lacking it
+	source := newTestHandler()
+	source.handler.snapSync.Store(false)
+	defer source.close()
+
+	sink := newTestHandler()
+	sink.handler.snapSync.Store(false)
+	defer sink.close()
+
+	sidecar := new(types.BlobTxSidecar)
+	for i := 0; i < 2; i++ {
+		var blob kzg4844.Blob
+		blob[0] = byte(i + 1)
+		commitment, _ := kzg4844.BlobToCommitment(&blob)
+		proof, _ := kzg4844.ComputeBlobProof(&blob, commitment)
+
+		sidecar.Blobs = append(sidecar.Blobs, blob)
+		sidecar.Commitments = append(sidecar.Commitments, commitment)
+		sidecar.Proofs = append(sidecar.Proofs, proof)
+	}
+	tx, _ := types.SignNewTx(testKey, types.NewCancunSigner(params.TestChainConfig.ChainID), &types.BlobTx{
+		ChainID:    uint256.MustFromBig(params.TestChainConfig.ChainID),
+		Gas:        params.TxGas,
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	})
+	source.txpool.lock.Lock()
+	source.txpool.pool[tx.Hash()] = tx // Avoid announcing it to the sink
+	source.txpool.lock.Unlock()
+
+	block := types.NewBlock(&types.Header{Number: big.NewInt(1)}, &types.Body{Transactions: []*types.Transaction{tx.WithoutBlobTxSidecar()}}, nil, trie.NewStackTrie(nil))
+	if err := sink.chain.VerifyBlobAvailability(block, sink.handler.fetchBlobs(block)); err == nil {
+		t.Fatal("blobs available without any peers")
+	}
+	// Connect a peer that never answers and a source holding the blobs, the
+	// retrieval should not wait for the silent peer
+	silentPipe, silentRemote := p2p.MsgPipe()
+	defer silentPipe.Close()
+	defer silentRemote.Close()
+	go func() {
+		for {
+			msg, err := silentRemote.ReadMsg()
+			if err != nil {
+				return
+			}
+			msg.Discard()
+		}
+	}()
+	silentPeer := blobs.NewPeer(blobs.BLOBS1, p2p.NewPeerPipe(enode.ID{3}, "", nil, silentPipe), silentPipe)
+	go (*blobsHandler)(sink.handler).RunPeer(silentPeer, func(peer *blobs.Peer) error {
+		return blobs.Handle((*blobsHandler)(sink.handler), peer)
+	})
+	sourcePipe, sinkPipe := p2p.MsgPipe()
+	defer sourcePipe.Close()
+	defer sinkPipe.Close()
+
+	sourcePeer := blobs.NewPeer(blobs.BLOBS1, p2p.NewPeerPipe(enode.ID{1}, "", nil, sourcePipe), sourcePipe)
+	sinkPeer := blobs.NewPeer(blobs.BLOBS1, p2p.NewPeerPipe(enode.ID{2}, "", nil, sinkPipe), sinkPipe)
+
+	go (*blobsHandler)(source.handler).RunPeer(sourcePeer, func(peer *blobs.Peer) error {
+		return blobs.Handle((*blobsHandler)(source.handler), peer)
+	})
+	go (*blobsHandler)(sink.handler).RunPeer(sinkPeer, func(peer *blobs.Peer) error {
+		return blobs.Handle((*blobsHandler)(sink.handler), peer)
+	})
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		sink.handler.blobLock.Lock()
+		peers := len(sink.handler.blobPeers)
+		sink.handler.blobLock.Unlock()
+		if peers == 2 {
+			break
+		}
+		if time.Since(start) > 2*time.Second {
+			t.Fatal("peer connection timed out")
+		}
+	}
+	start := time.Now()
+	sidecars := sink.handler.fetchBlobs(block)
+	if elapsed := time.Since(start); elapsed >= blobFetchTimeout {
+		t.Errorf("retrieval waited for the silent peer: %v", elapsed)
+	}
+	if err := sink.chain.VerifyBlobAvailability(block, sidecars); err != nil {
+		t.Fatalf("fetched blobs unavailable: %v", err)
+	}
+	if have := sidecars[tx.Hash()]; len(have.Blobs) != len(sidecar.Blobs) {
+		t.Errorf("blob count mismatch: have %d, want %d", len(have.Blobs), len(sidecar.Blobs))
+	}
+	if sink.txpool.Has(tx.Hash()) {
+		t.Error("blob transaction leaked into the sink pool")
+	}
+}
+
+// Tests that the blobs failing their KZG proof check are discarded, the
+// retrieval moving on to other peers until the valid blobs are found.
+func TestFetchBlobsInvalid(t *testing.T) {
+	t.Parallel()
+
+	source := newTestHandler()
+	source.handler.snapSync.Store(false)
+	defer source.close()
+
+	sink := newTestHandler()
+	sink.handler.snapSync.Store(false)
+	defer sink.close()
+
+	var blob kzg4844.Blob
+	blob[0] = 0x01
+	commitment, _ := kzg4844.BlobToCommitment(&blob)
+	proof, _ := kzg4844.ComputeBlobProof(&blob, commitment)
+	sidecar := &types.BlobTxSidecar{
+		Blobs:       []kzg4844.Blob{blob},
+		Commitments: []kzg4844.Commitment{commitment},
+		Proofs:      []kzg4844.Proof{proof},
+	}
+	tx, _ := types.SignNewTx(testKey, types.NewCancunSigner(params.TestChainConfig.ChainID), &types.BlobTx{
+		ChainID:    uint256.MustFromBig(params.TestChainConfig.ChainID),
+		Gas:        params.TxGas,
+		BlobHashes: sidecar.BlobHashes(),
+		Sidecar:    sidecar,
+	})
+	source.txpool.lock.Lock()
+	source.txpool.pool[tx.Hash()] = tx // Avoid announcing it to the sink
+	source.txpool.lock.Unlock()
+
+	run := func(id enode.ID, pipe *p2p.MsgPipeRW) {
+		peer := blobs.NewPeer(blobs.BLOBS1, p2p.NewPeerPipe(id, "", nil, pipe), pipe)
+		go (*blobsHandler)(sink.handler).RunPeer(peer, func(peer *blobs.Peer) error {
+			return blobs.Handle((*blobsHandler)(sink.handler), peer)
+		})
+	}
+	waitPeers := func(n int) {
+		for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+			sink.handler.blobLock.Lock()
+			peers := len(sink.handler.blobPeers)
+			sink.handler.blobLock.Unlock()
+			if peers == n {
+				return
+			}
+			if time.Since(start) > 2*time.Second {
+				t.Fatal("peer connection timed out")
+			}
+		}
+	}
+	// Connect more liars than queried at once, answering with a tampered blob
+	// under the genuine commitment and proof
+	tampered := blob
+	tampered[1] = 0xff
+	for i := 0; i < blobFetchPeers; i++ {
+		pipe, remote := p2p.MsgPipe()
+		defer pipe.Close()
+		defer remote.Close()
+		go func() {
+			for {
+				msg, err := remote.ReadMsg()
+				if err != nil {
+					return
+				}
+				var req blobs.GetBlobsPacket
+				if err := msg.Decode(&req); err != nil {
+					return
+				}
+				p2p.Send(remote, blobs.BlobsMsg, &blobs.BlobsPacket{ID: req.ID, Sidecars: []*types.BlobTxSidecar{{
+					Blobs:       []kzg4844.Blob{tampered},
+					Commitments: sidecar.Commitments,
+					Proofs:      sidecar.Proofs,
+				}}})
+			}
+		}()
+		run(enode.ID{byte(0x10 + i)}, pipe)
+	}
+	waitPeers(blobFetchPeers)
+
+	block := types.NewBlock(&types.Header{Number: big.NewInt(1)}, &types.Body{Transactions: []*types.Transaction{tx.WithoutBlobTxSidecar()}}, nil, trie.NewStackTrie(nil))
+	if sidecars := sink.handler.fetchBlobs(block); len(sidecars) != 0 {
+		t.Fatal("tampered blobs accepted")
+	}
+	// Connect the source as well, the honest peer being found at the latest
+	// once the liars are exhausted
+	sourcePipe, sinkPipe := p2p.MsgPipe()
+	defer sourcePipe.Close()
+	defer sinkPipe.Close()
+
+	sourcePeer := blobs.NewPeer(blobs.BLOBS1, p2p.NewPeerPipe(enode.ID{1}, "", nil, sourcePipe), sourcePipe)
+	go (*blobsHandler)(source.handler).RunPeer(sourcePeer, func(peer *blobs.Peer) error {
+		return blobs.Handle((*blobsHandler)(source.handler), peer)
+	})
+	run(enode.ID{2}, sinkPipe)
+	waitPeers(blobFetchPeers + 1)
+
+	sidecars := sink.handler.fetchBlobs(block)
+	if err := sink.chain.VerifyBlobAvailability(block, sidecars); err != nil {
+		t.Fatalf("fetched blobs unavailable: %v", err)
+	}
+	if have := sidecars[tx.Hash()].Blobs[0]; have != blob {
+		t.Error("fetched blob mismatch")
+	}
+}
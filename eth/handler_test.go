@@ -18,6 +18,8 @@ package eth
 
 import (
 	"math/big"
+	"net"
+	"slices"
 	"sort"
 	"sync"
 
@@ -50,8 +52,9 @@ var (
 type testTxPool struct {
 	pool map[common.Hash]*types.Transaction // Hash map of collected transactions
 
-	txFeed event.Feed   // Notification feed to allow waiting for inclusion
-	lock   sync.RWMutex // Protects the transaction pool
+	txFeed      event.Feed   // Notification feed to allow waiting for inclusion
+	rebroadFeed event.Feed   // Notification feed of the transactions announced again
+	lock        sync.RWMutex // Protects the transaction pool
 }
 
 // newTestTxPool creates a mock transaction pool.
@@ -108,6 +111,23 @@ func (p *testTxPool) GetMetadata(hash common.Hash) *txpool.TxMetadata {
 	return nil
 }
 
+// GetBlobs returns the sidecars of the transactions containing the blobs with
+// the given versioned hashes.
+func (p *testTxPool) GetBlobs(vhashes []common.Hash) []*types.BlobTxSidecar {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	sidecars := make([]*types.BlobTxSidecar, len(vhashes))
+	for _, tx := range p.pool {
+		for _, vhash := range tx.BlobHashes() {
+			if i := slices.Index(vhashes, vhash); i >= 0 {
+				sidecars[i] = tx.BlobTxSidecar()
+			}
+		}
+	}
+	return sidecars
+}
+
 // Add appends a batch of transactions to the pool, and notifies any
 // listeners if the addition channel is non nil
 func (p *testTxPool) Add(txs []*types.Transaction, sync bool) []error {
@@ -121,6 +141,12 @@ func (p *testTxPool) Add(txs []*types.Transaction, sync bool) []error {
 	return make([]error, len(txs))
 }
 
+// AddRemote appends a batch of transactions to the pool, without any limits on
+// the remote IP address they came from.
+func (p *testTxPool) AddRemote(txs []*types.Transaction, ip net.IP, sync bool) []error {
+	return p.Add(txs, sync)
+}
+
 // Pending returns all the transactions known to the pool
 func (p *testTxPool) Pending(filter txpool.PendingFilter) map[common.Address][]*txpool.LazyTransaction {
 	p.lock.RLock()
@@ -157,6 +183,12 @@ func (p *testTxPool) SubscribeTransactions(ch chan<- core.NewTxsEvent, reorgs bo
 	return p.txFeed.Subscribe(ch)
 }
 
+// SubscribeRebroadcasts should return an event subscription of the transactions
+// announced again and send events to the given channel.
+func (p *testTxPool) SubscribeRebroadcasts(ch chan<- core.NewTxsEvent) event.Subscription {
+	return p.rebroadFeed.Subscribe(ch)
+}
+
 // testHandler is a live implementation of the Ethereum protocol handler, just
 // preinitialized with some sane testing defaults and the transaction pool mocked
 // out.
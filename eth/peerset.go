@@ -216,6 +216,19 @@ func (ps *peerSet) len() int {
 	return len(ps.peers)
 }
 
+// highestVersion returns the highest `eth` protocol version negotiated with the
+// peers in the set, or zero if there are none.
+func (ps *peerSet) highestVersion() uint {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	var version uint
+	for _, p := range ps.peers {
+		version = max(version, p.Version())
+	}
+	return version
+}
+
 // snapLen returns if the current number of `snap` peers in the set.
 func (ps *peerSet) snapLen() int {
 	ps.lock.RLock()
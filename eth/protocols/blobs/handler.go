@@ -0,0 +1,202 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobs
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+const (
+	// softResponseLimit is the target maximum size of replies to data retrievals.
+	softResponseLimit = 2 * 1024 * 1024
+
+	// maxBlobsServe is the maximum number of blobs to serve. With 128KB blobs,
+	// the practical limit will always be softResponseLimit.
+	maxBlobsServe = 64
+
+	// maxRequestRate is the number of blob requests per second served to a peer,
+	// once its burst allowance of maxRequestBurst requests is used up.
+	maxRequestRate  = 4
+	maxRequestBurst = 16
+)
+
+// Handler is a callback to invoke from an outside runner after the boilerplate
+// exchanges have passed.
+type Handler func(peer *Peer) error
+
+// TxPool defines the methods needed by the protocol handler to serve blobs.
+type TxPool interface {
+	// GetBlobs retrieves the sidecars of the transactions containing the blobs
+	// with the given versioned hashes, nil for the unknown ones.
+	GetBlobs(vhashes []common.Hash) []*types.BlobTxSidecar
+}
+
+// Backend defines the data retrieval methods to serve remote requests and the
+// callback methods to invoke on remote deliveries.
+type Backend interface {
+	// TxPool retrieves the transaction pool object to serve data.
+	TxPool() TxPool
+
+	// RunPeer is invoked when a peer joins on the `blobs` protocol. The handler
+	// should do any peer maintenance work. If all is passed, control should be
+	// given back to the `handler` to process the inbound messages going forward.
+	RunPeer(peer *Peer, handler Handler) error
+
+	// PeerInfo retrieves all known `blobs` information about a peer.
+	PeerInfo(id enode.ID) interface{}
+
+	// Handle is a callback to be invoked when a data packet is received from
+	// the remote peer. Only packets not consumed by the protocol handler will
+	// be forwarded to the backend.
+	Handle(peer *Peer, packet Packet) error
+}
+
+// MakeProtocols constructs the P2P protocol definitions for `blobs`.
+func MakeProtocols(backend Backend) []p2p.Protocol {
+	protocols := make([]p2p.Protocol, len(ProtocolVersions))
+	for i, version := range ProtocolVersions {
+		protocols[i] = p2p.Protocol{
+			Name:    ProtocolName,
+			Version: version,
+			Length:  protocolLengths[version],
+			Run: func(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+				return backend.RunPeer(NewPeer(version, p, rw), func(peer *Peer) error {
+					return Handle(backend, peer)
+				})
+			},
+			NodeInfo: func() interface{} {
+				return &NodeInfo{}
+			},
+			PeerInfo: func(id enode.ID) interface{} {
+				return backend.PeerInfo(id)
+			},
+		}
+	}
+	return protocols
+}
+
+// Handle is the callback invoked to manage the life cycle of a `blobs` peer.
+// When this function terminates, the peer is disconnected.
+func Handle(backend Backend, peer *Peer) error {
+	for {
+		if err := HandleMessage(backend, peer); err != nil {
+			peer.Log().Debug("Message handling failed in `blobs`", "err", err)
+			return err
+		}
+	}
+}
+
+// HandleMessage is invoked whenever an inbound message is received from a
+// remote peer on the `blobs` protocol. The remote connection is torn down upon
+// returning any error.
+func HandleMessage(backend Backend, peer *Peer) error {
+	// Read the next message from the remote peer, and ensure it's fully consumed
+	msg, err := peer.rw.ReadMsg()
+	if err != nil {
+		return err
+	}
+	if msg.Size > maxMessageSize {
+		return fmt.Errorf("%w: %v > %v", errMsgTooLarge, msg.Size, maxMessageSize)
+	}
+	defer msg.Discard()
+
+	// Handle the message depending on its contents
+	switch msg.Code {
+	case GetBlobsMsg:
+		// Decode the blob retrieval request
+		var req GetBlobsPacket
+		if err := msg.Decode(&req); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		// Requests beyond the rate limit of the peer are answered empty
+		if !peer.limiter.Allow() {
+			peer.Log().Debug("Blob request rate limit exceeded", "reqid", req.ID)
+			return peer.ReplyBlobs(req.ID, nil)
+		}
+		// Service the request, potentially returning nothing for unknown blobs
+		return peer.ReplyBlobs(req.ID, ServiceGetBlobsQuery(backend.TxPool(), &req))
+
+	case BlobsMsg:
+		// A batch of blobs arrived to one of our previous requests
+		res := new(BlobsPacket)
+		if err := msg.Decode(res); err != nil {
+			return fmt.Errorf("%w: message %v: %v", errDecode, msg, err)
+		}
+		for i, sidecar := range res.Sidecars {
+			if len(sidecar.Blobs) != 1 || len(sidecar.Commitments) != 1 {
+				return fmt.Errorf("%w: sidecar %d holds %d blobs", errDecode, i, len(sidecar.Blobs))
+			}
+		}
+		return backend.Handle(peer, res)
+
+	default:
+		return fmt.Errorf("%w: %v", errInvalidMsgCode, msg.Code)
+	}
+}
+
+// ServiceGetBlobsQuery assembles the response to a blob query, with one
+// single-blob sidecar for each requested blob known to the pool. It is exposed
+// to allow external packages to test protocol behavior.
+func ServiceGetBlobsQuery(pool TxPool, req *GetBlobsPacket) []*types.BlobTxSidecar {
+	query := req.Hashes
+	if len(query) > maxBlobsServe {
+		query = query[:maxBlobsServe]
+	}
+	var (
+		bytes    int
+		hasher   = sha256.New()
+		response []*types.BlobTxSidecar
+	)
+	for i, sidecar := range pool.GetBlobs(query) {
+		if bytes >= softResponseLimit {
+			break
+		}
+		if sidecar == nil {
+			continue
+		}
+		for j := range sidecar.Commitments {
+			if kzg4844.CalcBlobHashV1(hasher, &sidecar.Commitments[j]) != query[i] {
+				continue
+			}
+			blob := &types.BlobTxSidecar{
+				Version:     sidecar.Version,
+				Blobs:       []kzg4844.Blob{sidecar.Blobs[j]},
+				Commitments: []kzg4844.Commitment{sidecar.Commitments[j]},
+			}
+			if sidecar.Version == 0 {
+				blob.Proofs = []kzg4844.Proof{sidecar.Proofs[j]}
+			} else {
+				blob.Proofs = sidecar.Proofs[j*kzg4844.CellProofsPerBlob : (j+1)*kzg4844.CellProofsPerBlob]
+			}
+			response = append(response, blob)
+			bytes += len(sidecar.Blobs[j])
+			break
+		}
+	}
+	return response
+}
+
+// NodeInfo represents a short summary of the `blobs` sub-protocol metadata
+// known about the host peer.
+type NodeInfo struct{}
@@ -0,0 +1,85 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobs
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/p2p"
+	"github.com/ethereum/go-ethereum/p2p/enode"
+)
+
+// testBackend is a blobs backend serving a fixed set of blobs.
+type testBackend struct {
+	sidecar *types.BlobTxSidecar
+}
+
+func (b *testBackend) TxPool() TxPool                { return b }
+func (b *testBackend) RunPeer(*Peer, Handler) error  { return nil }
+func (b *testBackend) PeerInfo(enode.ID) interface{} { return nil }
+func (b *testBackend) Handle(*Peer, Packet) error    { return nil }
+func (b *testBackend) GetBlobs(vhashes []common.Hash) []*types.BlobTxSidecar {
+	sidecars := make([]*types.BlobTxSidecar, len(vhashes))
+	for i := range vhashes {
+		sidecars[i] = b.sidecar
+	}
+	return sidecars
+}
+
+// Tests that the blob requests of a peer beyond its rate limit are answered
+// without any blobs.
+func TestServeRateLimit(t *testing.T) {
+	var blob kzg4844.Blob
+	commitment, _ := kzg4844.BlobToCommitment(&blob)
+	proof, _ := kzg4844.ComputeBlobProof(&blob, commitment)
+	backend := &testBackend{sidecar: &types.BlobTxSidecar{
+		Blobs:       []kzg4844.Blob{blob},
+		Commitments: []kzg4844.Commitment{commitment},
+		Proofs:      []kzg4844.Proof{proof},
+	}}
+	local, remote := p2p.MsgPipe()
+	defer local.Close()
+	defer remote.Close()
+
+	peer := NewPeer(BLOBS1, p2p.NewPeerPipe(enode.ID{1}, "", nil, local), local)
+	go Handle(backend, peer)
+
+	hashes := backend.sidecar.BlobHashes()
+	for id := uint64(0); id <= maxRequestBurst; id++ {
+		if err := p2p.Send(remote, GetBlobsMsg, &GetBlobsPacket{ID: id, Hashes: hashes}); err != nil {
+			t.Fatalf("failed to send request %d: %v", id, err)
+		}
+		msg, err := remote.ReadMsg()
+		if err != nil {
+			t.Fatalf("failed to read response %d: %v", id, err)
+		}
+		var res BlobsPacket
+		if err := msg.Decode(&res); err != nil {
+			t.Fatalf("failed to decode response %d: %v", id, err)
+		}
+		want := 1
+		if id == maxRequestBurst {
+			want = 0
+		}
+		if res.ID != id || len(res.Sidecars) != want {
+			t.Errorf("response %d mismatch: have id %d with %d blobs, want %d blobs", id, res.ID, len(res.Sidecars), want)
+		}
+	}
+}
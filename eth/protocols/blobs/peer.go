@@ -0,0 +1,83 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobs
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/p2p"
+	"golang.org/x/time/rate"
+)
+
+// Peer is a collection of relevant information we have about a `blobs` peer.
+type Peer struct {
+	id string // Unique ID for the peer, cached
+
+	*p2p.Peer                   // The embedded P2P package peer
+	rw        p2p.MsgReadWriter // Input/output streams for blobs
+	version   uint              // Protocol version negotiated
+
+	limiter *rate.Limiter // Rate limit of the blob requests served to the peer
+	logger  log.Logger    // Contextual logger with the peer id injected
+}
+
+// NewPeer creates a wrapper for a network connection and negotiated protocol
+// version.
+func NewPeer(version uint, p *p2p.Peer, rw p2p.MsgReadWriter) *Peer {
+	id := p.ID().String()
+	return &Peer{
+		id:      id,
+		Peer:    p,
+		rw:      rw,
+		version: version,
+		limiter: rate.NewLimiter(maxRequestRate, maxRequestBurst),
+		logger:  log.New("peer", id[:8]),
+	}
+}
+
+// ID retrieves the peer's unique identifier.
+func (p *Peer) ID() string {
+	return p.id
+}
+
+// Version retrieves the peer's negotiated `blobs` protocol version.
+func (p *Peer) Version() uint {
+	return p.version
+}
+
+// Log overrides the P2P logger with the higher level one containing only the id.
+func (p *Peer) Log() log.Logger {
+	return p.logger
+}
+
+// RequestBlobs fetches a batch of blobs by versioned hash from a remote node.
+func (p *Peer) RequestBlobs(id uint64, hashes []common.Hash) error {
+	p.logger.Trace("Fetching batch of blobs", "reqid", id, "count", len(hashes))
+	return p2p.Send(p.rw, GetBlobsMsg, &GetBlobsPacket{
+		ID:     id,
+		Hashes: hashes,
+	})
+}
+
+// ReplyBlobs is the response to GetBlobs.
+func (p *Peer) ReplyBlobs(id uint64, sidecars []*types.BlobTxSidecar) error {
+	return p2p.Send(p.rw, BlobsMsg, &BlobsPacket{
+		ID:       id,
+		Sidecars: sidecars,
+	})
+}
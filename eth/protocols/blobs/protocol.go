@@ -0,0 +1,81 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package blobs
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Constants to match up protocol versions and messages
+const (
+	BLOBS1 = 1
+)
+
+// ProtocolName is the official short name of the `blobs` protocol used during
+// devp2p capability negotiation.
+const ProtocolName = "blobs"
+
+// ProtocolVersions are the supported versions of the `blobs` protocol (first
+// is primary).
+var ProtocolVersions = []uint{BLOBS1}
+
+// protocolLengths are the number of implemented message corresponding to
+// different protocol versions.
+var protocolLengths = map[uint]uint64{BLOBS1: 2}
+
+// maxMessageSize is the maximum cap on the size of a protocol message.
+const maxMessageSize = 10 * 1024 * 1024
+
+const (
+	GetBlobsMsg = 0x00
+	BlobsMsg    = 0x01
+)
+
+var (
+	errMsgTooLarge    = errors.New("message too long")
+	errDecode         = errors.New("invalid message")
+	errInvalidMsgCode = errors.New("invalid message code")
+)
+
+// Packet represents a p2p message in the `blobs` protocol.
+type Packet interface {
+	Name() string // Name returns a string corresponding to the message type.
+	Kind() byte   // Kind returns the message type.
+}
+
+// GetBlobsPacket represents a blob query by versioned hash.
+type GetBlobsPacket struct {
+	ID     uint64        // Request ID to match up responses with
+	Hashes []common.Hash // Versioned hashes of the blobs to retrieve
+}
+
+// BlobsPacket represents a blob query response. Each sidecar contains a single
+// blob along with its commitment and proofs, only the requested blobs known to
+// the remote node are included.
+type BlobsPacket struct {
+	ID       uint64                 // ID of the request this is a response for
+	Sidecars []*types.BlobTxSidecar // Single-blob sidecars of the requested blobs
+}
+
+func (*GetBlobsPacket) Name() string { return "GetBlobs" }
+func (*GetBlobsPacket) Kind() byte   { return GetBlobsMsg }
+
+func (*BlobsPacket) Name() string { return "Blobs" }
+func (*BlobsPacket) Kind() byte   { return BlobsMsg }
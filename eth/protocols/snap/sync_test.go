@@ -1908,6 +1908,82 @@ func testSyncAccountPerformance(t *testing.T, scheme string) {
 	}
 }
 
+// TestSyncResumption tests that an interrupted sync resumes from the persisted
+// progress, without retrieving the already synced accounts again.
+func TestSyncResumption(t *testing.T) {
+	// These tests must not run in parallel: they modify the
+	// global var accountConcurrency
+	// t.Parallel()
+	testSyncResumption(t, rawdb.HashScheme)
+	testSyncResumption(t, rawdb.PathScheme)
+}
+
+func testSyncResumption(t *testing.T, scheme string) {
+	// Split the account range into two halves, the sync is interrupted once
+	// the first half is persisted.
+	defer func(old int) { accountConcurrency = old }(accountConcurrency)
+	accountConcurrency = 2
+
+	var (
+		once   sync.Once
+		cancel = make(chan struct{})
+		term   = func() {
+			once.Do(func() {
+				close(cancel)
+			})
+		}
+		// The last account of the first half
+		half = common.BigToHash(new(big.Int).Rsh(common.MaxHash.Big(), 1))
+	)
+	nodeScheme, sourceAccountTrie, elems := makeBoundaryAccountTrie(scheme, 100)
+
+	src := newTestPeer("source", t, term)
+	src.accountTrie = sourceAccountTrie.Copy()
+	src.accountValues = elems
+	src.accountRequestHandler = func(t *testPeer, id uint64, root common.Hash, origin common.Hash, limit common.Hash, cap uint64) error {
+		if bytes.Compare(origin[:], half[:]) <= 0 {
+			return defaultAccountRequestHandler(t, id, root, origin, limit, cap)
+		}
+		// Interrupt the sync once the first half is persisted
+		for len(rawdb.ReadAccountSnapshot(t.remote.db, half)) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		t.term()
+		return nil
+	}
+	syncer := setupSyncer(nodeScheme, src)
+	if err := syncer.Sync(sourceAccountTrie.Hash(), cancel); err != ErrCancelled {
+		t.Fatalf("sync error mismatch: have %v, want %v", err, ErrCancelled)
+	}
+	// Restart the sync on the same database, only the second half is expected
+	// to be retrieved.
+	var (
+		lock   sync.Mutex
+		origin = common.MaxHash
+	)
+	resumed := newTestPeer("resumed", t, func() {})
+	resumed.accountTrie = sourceAccountTrie.Copy()
+	resumed.accountValues = elems
+	resumed.accountRequestHandler = func(t *testPeer, id uint64, root common.Hash, start common.Hash, limit common.Hash, cap uint64) error {
+		lock.Lock()
+		if bytes.Compare(start[:], origin[:]) < 0 {
+			origin = start
+		}
+		lock.Unlock()
+		return defaultAccountRequestHandler(t, id, root, start, limit, cap)
+	}
+	syncer = NewSyncer(syncer.db, nodeScheme)
+	syncer.Register(resumed)
+	resumed.remote = syncer
+	if err := syncer.Sync(sourceAccountTrie.Hash(), make(chan struct{})); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	verifyTrie(scheme, syncer.db, sourceAccountTrie.Hash(), t)
+	if bytes.Compare(origin[:], half[:]) <= 0 {
+		t.Errorf("synced accounts retrieved again, first origin %x", origin)
+	}
+}
+
 func TestSlotEstimation(t *testing.T) {
 	for i, tc := range []struct {
 		last  common.Hash
@@ -25,11 +25,15 @@ import (
 	"math/big"
 	"os"
 	"runtime"
+	"runtime/debug"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/consensus"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
@@ -67,9 +71,28 @@ const (
 	// for tracing. The creation of trace state will be paused if the unused
 	// trace states exceed this limit.
 	maximumPendingTraceStates = 128
+
+	// maxTracerPanicSize is the maximum length of the panic message of a tracer
+	// returned to the caller.
+	maxTracerPanicSize = 256
+)
+
+var (
+	errTxNotFound   = errors.New("transaction not found")
+	errTraceSkipped = errors.New("not traced, an earlier transaction of the block failed")
 )
 
-var errTxNotFound = errors.New("transaction not found")
+// tracerPanicError is returned if the tracer panicked while tracing. It is
+// reported as an internal error, carrying the panic message as data.
+type tracerPanicError struct{ msg string }
+
+func (e *tracerPanicError) Error() string          { return "tracer panicked: " + e.msg }
+func (e *tracerPanicError) ErrorCode() int         { return -32603 }
+func (e *tracerPanicError) ErrorData() interface{} { return e.msg }
+
+// ErrParentStateNotAvailable is returned if the parent of a block to trace is
+// not known, so its state can't be retrieved.
+var ErrParentStateNotAvailable = errors.New("parent state not available")
 
 // StateReleaseFunc is used to deallocate resources held by constructing a
 // historical state for tracing purposes.
@@ -85,6 +108,7 @@ type Backend interface {
 	GetTransaction(txHash common.Hash) (bool, *types.Transaction, common.Hash, uint64, uint64)
 	TxIndexDone() bool
 	RPCGasCap() uint64
+	TraceCacheSize() int
 	ChainConfig() *params.ChainConfig
 	Engine() consensus.Engine
 	ChainDb() ethdb.Database
@@ -92,14 +116,28 @@ type Backend interface {
 	StateAtTransaction(ctx context.Context, block *types.Block, txIndex int, reexec uint64) (*types.Transaction, vm.BlockContext, *state.StateDB, StateReleaseFunc, error)
 }
 
+// txTraceKey identifies a cached transaction trace. The hash of the block the
+// transaction was looked up in is part of the key, so traces of transactions
+// reorged out of the canonical chain are never served again.
+type txTraceKey struct {
+	txHash    common.Hash
+	blockHash common.Hash
+	config    string // JSON encoding of the trace config
+}
+
 // API is the collection of tracing APIs exposed over the private debugging endpoint.
 type API struct {
 	backend Backend
+	traces  *lru.Cache[txTraceKey, interface{}] // Recent transaction traces, nil if disabled
 }
 
 // NewAPI creates a new API definition for the tracing methods of the Ethereum service.
 func NewAPI(backend Backend) *API {
-	return &API{backend: backend}
+	api := &API{backend: backend}
+	if size := backend.TraceCacheSize(); size > 0 {
+		api.traces = lru.NewCache[txTraceKey, interface{}](size)
+	}
+	return api
 }
 
 // chainContext constructs the context reader which is used by the evm for reading
@@ -284,6 +322,11 @@ func (api *API) traceChain(start, end *types.Block, config *TraceConfig, closed
 					if err != nil {
 						task.results[i] = &txTraceResult{TxHash: tx.Hash(), Error: err.Error()}
 						log.Warn("Tracing failed", "hash", tx.Hash(), "block", task.block.NumberU64(), "err", err)
+
+						// The state is left mid-transaction, don't trace the rest on top
+						for j, tx := range task.block.Transactions()[i+1:] {
+							task.results[i+1+j] = &txTraceResult{TxHash: tx.Hash(), Error: errTraceSkipped.Error()}
+						}
 						break
 					}
 					task.results[i] = &txTraceResult{TxHash: tx.Hash(), Result: res}
@@ -462,12 +505,19 @@ func (api *API) TraceBlockByHash(ctx context.Context, hash common.Hash, config *
 }
 
 // TraceBlock returns the structured logs created during the execution of EVM
-// and returns them as a JSON object.
+// and returns them as a JSON object. The RLP encoded block doesn't need to be
+// part of the local chain, e.g. it can be a block of a different fork, but its
+// parent must be known.
 func (api *API) TraceBlock(ctx context.Context, blob hexutil.Bytes, config *TraceConfig) ([]*txTraceResult, error) {
 	block := new(types.Block)
 	if err := rlp.DecodeBytes(blob, block); err != nil {
 		return nil, fmt.Errorf("could not decode block: %v", err)
 	}
+	if block.NumberU64() > 0 {
+		if parent, _ := api.backend.BlockByHash(ctx, block.ParentHash()); parent == nil {
+			return nil, fmt.Errorf("%w: parent block %#x not found", ErrParentStateNotAvailable, block.ParentHash())
+		}
+	}
 	return api.traceBlock(ctx, block, config)
 }
 
@@ -652,6 +702,8 @@ func (api *API) traceBlockParallel(ctx context.Context, block *types.Block, stat
 		blockHash = block.Hash()
 		signer    = types.MakeSigner(api.backend.ChainConfig(), block.Number(), block.Time())
 		results   = make([]*txTraceResult, len(txs))
+		panicked  = make([]bool, len(txs)) // Transactions whose tracer panicked
+		aborted   atomic.Bool              // Whether to stop feeding the transactions
 		pend      sync.WaitGroup
 	)
 	threads := runtime.NumCPU()
@@ -680,6 +732,10 @@ func (api *API) traceBlockParallel(ctx context.Context, block *types.Block, stat
 				res, err := api.traceTx(ctx, txs[task.index], msg, txctx, blockCtx, task.statedb, config, nil)
 				if err != nil {
 					results[task.index] = &txTraceResult{TxHash: txs[task.index].Hash(), Error: err.Error()}
+					if errors.As(err, new(*tracerPanicError)) {
+						panicked[task.index] = true
+						aborted.Store(true)
+					}
 					continue
 				}
 				results[task.index] = &txTraceResult{TxHash: txs[task.index].Hash(), Result: res}
@@ -694,6 +750,10 @@ func (api *API) traceBlockParallel(ctx context.Context, block *types.Block, stat
 
 txloop:
 	for i, tx := range txs {
+		// Stop feeding the transactions if a tracer panicked
+		if aborted.Load() {
+			break
+		}
 		// Send the trace task over for execution
 		task := &txTraceTask{statedb: statedb.Copy(), index: i}
 		select {
@@ -722,6 +782,13 @@ txloop:
 	if failed != nil {
 		return nil, failed
 	}
+	// A panicking tracer is not trusted with the rest of the block, so fail all
+	// the transactions after the first one it panicked on, traced or not
+	if i := slices.Index(panicked, true); i >= 0 {
+		for j := i + 1; j < len(txs); j++ {
+			results[j] = &txTraceResult{TxHash: txs[j].Hash(), Error: errTraceSkipped.Error()}
+		}
+	}
 	return results, nil
 }
 
@@ -880,6 +947,17 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 	if config != nil && config.Reexec != nil {
 		reexec = *config.Reexec
 	}
+	var key txTraceKey
+	if api.traces != nil {
+		enc, err := json.Marshal(config)
+		if err != nil {
+			return nil, err
+		}
+		key = txTraceKey{txHash: hash, blockHash: blockHash, config: string(enc)}
+		if result, ok := api.traces.Get(key); ok {
+			return result, nil
+		}
+	}
 	block, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(blockNumber), blockHash)
 	if err != nil {
 		return nil, err
@@ -900,7 +978,94 @@ func (api *API) TraceTransaction(ctx context.Context, hash common.Hash, config *
 		TxIndex:     int(index),
 		TxHash:      hash,
 	}
-	return api.traceTx(ctx, tx, msg, txctx, vmctx, statedb, config, nil)
+	result, err := api.traceTx(ctx, tx, msg, txctx, vmctx, statedb, config, nil)
+	if err != nil {
+		return nil, err
+	}
+	if api.traces != nil {
+		api.traces.Add(key, result)
+	}
+	return result, nil
+}
+
+// TraceTransactionWithOverrides returns the structured logs created during the
+// execution of EVM, like TraceTransaction. The state overrides are applied to the
+// state of the parent block, before the transactions preceding the traced one
+// are re-executed on top of it.
+func (api *API) TraceTransactionWithOverrides(ctx context.Context, hash common.Hash, overrides *override.StateOverride, config *TraceConfig) (interface{}, error) {
+	found, _, blockHash, blockNumber, index := api.backend.GetTransaction(hash)
+	if !found {
+		// Warn in case tx indexer is not done.
+		if !api.backend.TxIndexDone() {
+			return nil, ethapi.NewTxIndexingError()
+		}
+		// Only mined txes are supported
+		return nil, errTxNotFound
+	}
+	// It shouldn't happen in practice.
+	if blockNumber == 0 {
+		return nil, errors.New("genesis is not traceable")
+	}
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	block, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(blockNumber), blockHash)
+	if err != nil {
+		return nil, err
+	}
+	parent, err := api.blockByNumberAndHash(ctx, rpc.BlockNumber(blockNumber-1), block.ParentHash())
+	if err != nil {
+		return nil, err
+	}
+	statedb, release, err := api.backend.StateAtBlock(ctx, parent, reexec, nil, true, false)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var (
+		chainConfig = api.backend.ChainConfig()
+		blockCtx    = core.NewEVMBlockContext(block.Header(), api.chainContext(ctx), nil)
+		rules       = chainConfig.Rules(blockCtx.BlockNumber, blockCtx.Random != nil, blockCtx.Time)
+		precompiles = vm.ActivePrecompiledContracts(rules)
+	)
+	if err := overrides.Apply(statedb, precompiles); err != nil {
+		return nil, err
+	}
+	evm := vm.NewEVM(blockCtx, statedb, chainConfig, vm.Config{})
+	evm.SetPrecompiles(precompiles)
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		core.ProcessBeaconBlockRoot(*beaconRoot, evm)
+	}
+	if chainConfig.IsPrague(block.Number(), block.Time()) {
+		core.ProcessParentBlockHash(block.ParentHash(), evm)
+	}
+	// Recompute the preceding transactions on top of the overridden state
+	var (
+		txs    = block.Transactions()
+		signer = types.MakeSigner(chainConfig, block.Number(), block.Time())
+	)
+	for i, tx := range txs[:index] {
+		msg, _ := core.TransactionToMessage(tx, signer, block.BaseFee())
+		statedb.SetTxContext(tx.Hash(), i)
+		if _, err := core.ApplyMessage(evm, msg, new(core.GasPool).AddGas(tx.Gas())); err != nil {
+			return nil, fmt.Errorf("transaction %#x failed: %v", tx.Hash(), err)
+		}
+		statedb.Finalise(chainConfig.IsEIP158(block.Number()))
+	}
+	tx := txs[index]
+	msg, err := core.TransactionToMessage(tx, signer, block.BaseFee())
+	if err != nil {
+		return nil, err
+	}
+	txctx := &Context{
+		BlockHash:   blockHash,
+		BlockNumber: block.Number(),
+		TxIndex:     int(index),
+		TxHash:      hash,
+	}
+	return api.traceTx(ctx, tx, msg, txctx, blockCtx, statedb, config, precompiles)
 }
 
 // TraceCall lets you trace a given eth_call. It collects the structured logs
@@ -992,13 +1157,24 @@ func (api *API) TraceCall(ctx context.Context, args ethapi.TransactionArgs, bloc
 // traceTx configures a new tracer according to the provided configuration, and
 // executes the given message in the provided environment. The return value will
 // be tracer dependent.
-func (api *API) traceTx(ctx context.Context, tx *types.Transaction, message *core.Message, txctx *Context, vmctx vm.BlockContext, statedb *state.StateDB, config *TraceConfig, precompiles vm.PrecompiledContracts) (interface{}, error) {
+func (api *API) traceTx(ctx context.Context, tx *types.Transaction, message *core.Message, txctx *Context, vmctx vm.BlockContext, statedb *state.StateDB, config *TraceConfig, precompiles vm.PrecompiledContracts) (res interface{}, err error) {
 	var (
 		tracer  *Tracer
-		err     error
 		timeout = defaultTraceTimeout
 		usedGas uint64
 	)
+	// A buggy tracer must not take the node down, report its panic instead
+	defer func() {
+		if r := recover(); r != nil {
+			log.Debug("Tracer panicked", "hash", txctx.TxHash, "err", r, "stack", string(debug.Stack()))
+
+			msg := fmt.Sprint(r)
+			if len(msg) > maxTracerPanicSize {
+				msg = msg[:maxTracerPanicSize]
+			}
+			res, err = nil, &tracerPanicError{msg}
+		}
+	}()
 	if config == nil {
 		config = &TraceConfig{}
 	}
@@ -26,6 +26,7 @@ import (
 	"os"
 	"reflect"
 	"slices"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -47,6 +48,7 @@ import (
 	"github.com/ethereum/go-ethereum/internal/ethapi"
 	"github.com/ethereum/go-ethereum/internal/ethapi/override"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -63,6 +65,8 @@ type testBackend struct {
 
 	refHook func() // Hook is invoked when the requested state is referenced
 	relHook func() // Hook is invoked when the requested state is released
+
+	traceCacheSize int // Number of transaction traces cached by the API
 }
 
 // newTestBackend creates a new test backend. OBS: After test is done, teardown must be
@@ -130,6 +134,10 @@ func (b *testBackend) RPCGasCap() uint64 {
 	return 25000000
 }
 
+func (b *testBackend) TraceCacheSize() int {
+	return b.traceCacheSize
+}
+
 func (b *testBackend) ChainConfig() *params.ChainConfig {
 	return b.chainConfig
 }
@@ -553,6 +561,392 @@ func TestTraceTransaction(t *testing.T) {
 	}
 }
 
+// Tests that replaying a block on an OP-Stack chain applies the L1 info deposit
+// to the L1 fee oracle, so the transactions after it are charged the same L1
+// data fee as during block processing.
+func TestTraceTransactionL1Fee(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		config   = *params.TestChainConfig
+		vault    = common.HexToAddress("0xbbbb")
+	)
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+	oracle := config.L1FeeOracle()
+
+	// The vault reader returns the balance of the L1 fee vault
+	code := append([]byte{byte(vm.PUSH20)}, params.L1FeeVaultAddress.Bytes()...)
+	code = append(code, byte(vm.BALANCE), byte(vm.PUSH1), 0, byte(vm.MSTORE), byte(vm.PUSH1), 32, byte(vm.PUSH1), 0, byte(vm.RETURN))
+
+	genesis := &core.Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			vault:            {Code: code},
+			oracle:           {Code: []byte{byte(vm.STOP)}}, // keep the oracle from being swept as empty
+		},
+	}
+	info, err := types.EncodeL1InfoDepositData(&types.L1BlockInfo{
+		Number:        100,
+		Time:          1700000000,
+		BaseFee:       big.NewInt(7_000_000_000),
+		BlockHash:     common.HexToHash("0x1234"),
+		BatcherAddr:   common.HexToAddress("0xba7c4e5"),
+		L1FeeOverhead: common.BigToHash(big.NewInt(188)),
+		L1FeeScalar:   common.BigToHash(big.NewInt(684_000)),
+	})
+	if err != nil {
+		t.Fatalf("failed to encode L1 info: %v", err)
+	}
+	var (
+		signer   = types.LatestSigner(&config)
+		transfer *types.Transaction
+		target   common.Hash
+	)
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		b.AddTx(types.NewTx(&types.OptimismDepositTx{
+			From:  params.L1InfoDepositerAddress,
+			To:    &oracle,
+			Value: new(big.Int),
+			Gas:   1_000_000,
+			Data:  info,
+		}))
+		transfer, _ = types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    0,
+			To:       &accounts[0].addr,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		}), signer, accounts[0].key)
+		b.AddTx(transfer)
+
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    1,
+			To:       &vault,
+			Gas:      100_000,
+			GasPrice: b.BaseFee(),
+		}), signer, accounts[0].key)
+		b.AddTx(tx)
+		target = tx.Hash()
+	})
+	defer backend.chain.Stop()
+
+	// The vault must hold the L1 fee of the transfer, priced by the canonical oracle
+	statedb, err := backend.chain.StateAt(backend.chain.CurrentBlock().Root)
+	if err != nil {
+		t.Fatalf("failed to retrieve head state: %v", err)
+	}
+	want := types.NewL1CostFunc(&config, backend.chain.CurrentBlock().Time, statedb)(transfer.RollupCostData())
+	if want.Sign() == 0 {
+		t.Fatal("transfer paid no L1 fee")
+	}
+	check := func(name string, result interface{}) {
+		var have *logger.ExecutionResult
+		if err := json.Unmarshal(result.(json.RawMessage), &have); err != nil {
+			t.Fatalf("%s: failed to unmarshal result: %v", name, err)
+		}
+		if balance := new(big.Int).SetBytes(have.ReturnValue); balance.Cmp(want) != 0 {
+			t.Errorf("%s: L1 fee vault balance mismatch: have %v, want %v", name, balance, want)
+		}
+	}
+	api := NewAPI(backend)
+	result, err := api.TraceTransaction(context.Background(), target, nil)
+	if err != nil {
+		t.Fatalf("failed to trace transaction: %v", err)
+	}
+	check("transaction", result)
+
+	results, err := api.TraceBlockByNumber(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("trace count mismatch: have %d, want 3", len(results))
+	}
+	check("block", results[2].Result)
+}
+
+// Tests that a panicking tracer is reported as an internal error carrying the
+// truncated panic message, instead of crashing the RPC handler.
+func TestTraceTransactionPanic(t *testing.T) {
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	var target common.Hash
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &accounts[1].addr,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		}), types.HomesteadSigner{}, accounts[0].key)
+		b.AddTx(tx)
+		target = tx.Hash()
+	})
+	defer backend.chain.Stop()
+
+	reason := "nil tracer state: " + strings.Repeat("x", 2*maxTracerPanicSize)
+	DefaultDirectory.Register("panicTracer", func(*Context, json.RawMessage, *params.ChainConfig) (*Tracer, error) {
+		return &Tracer{
+			Hooks: &tracing.Hooks{
+				OnTxStart: func(*tracing.VMContext, *types.Transaction, common.Address) { panic(reason) },
+			},
+			GetResult: func() (json.RawMessage, error) { return nil, nil },
+			Stop:      func(error) {},
+		}, nil
+	}, false)
+
+	server := rpc.NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("debug", NewAPI(backend)); err != nil {
+		t.Fatalf("failed to register API: %v", err)
+	}
+	client := rpc.DialInProc(server)
+	defer client.Close()
+
+	var result json.RawMessage
+	err := client.Call(&result, "debug_traceTransaction", target, map[string]string{"tracer": "panicTracer"})
+	if err == nil {
+		t.Fatal("panicking tracer succeeded")
+	}
+	if code := err.(rpc.Error).ErrorCode(); code != -32603 {
+		t.Errorf("error code mismatch: have %d, want %d", code, -32603)
+	}
+	if data := err.(rpc.DataError).ErrorData(); data != reason[:maxTracerPanicSize] {
+		t.Errorf("error data mismatch: have %v, want %v", data, reason[:maxTracerPanicSize])
+	}
+}
+
+// Tests that once a tracer panicked on a transaction of a block, the remaining
+// transactions of the block are failed instead of traced.
+func TestTraceBlockPanic(t *testing.T) {
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		for nonce := uint64(0); nonce < 3; nonce++ {
+			tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+				Nonce:    nonce,
+				To:       &accounts[1].addr,
+				Value:    big.NewInt(1000),
+				Gas:      params.TxGas,
+				GasPrice: b.BaseFee(),
+			}), types.HomesteadSigner{}, accounts[0].key)
+			b.AddTx(tx)
+		}
+	})
+	defer backend.chain.Stop()
+
+	DefaultDirectory.Register("panicSecondTracer", func(ctx *Context, _ json.RawMessage, _ *params.ChainConfig) (*Tracer, error) {
+		return &Tracer{
+			Hooks: &tracing.Hooks{
+				OnTxStart: func(*tracing.VMContext, *types.Transaction, common.Address) {
+					if ctx.TxIndex == 1 {
+						panic("tracer failure")
+					}
+				},
+			},
+			GetResult: func() (json.RawMessage, error) { return json.RawMessage(`{}`), nil },
+			Stop:      func(error) {},
+		}, nil
+	}, false)
+
+	var (
+		api    = NewAPI(backend)
+		tracer = "panicSecondTracer"
+		config = &TraceConfig{Tracer: &tracer}
+	)
+	check := func(name string, results []*txTraceResult) {
+		if len(results) != 3 {
+			t.Fatalf("%s: result count mismatch: have %d, want 3", name, len(results))
+		}
+		if results[0].Error != "" {
+			t.Errorf("%s: first transaction failed: %v", name, results[0].Error)
+		}
+		if !strings.Contains(results[1].Error, "tracer failure") {
+			t.Errorf("%s: second transaction error mismatch: have %q, want the panic", name, results[1].Error)
+		}
+		if have, want := results[2].Error, errTraceSkipped.Error(); have != want {
+			t.Errorf("%s: third transaction error mismatch: have %q, want %q", name, have, want)
+		}
+	}
+	genesisBlock, _ := api.blockByNumber(context.Background(), 0)
+	block, _ := api.blockByNumber(context.Background(), 1)
+
+	statedb, release, err := backend.StateAtBlock(context.Background(), genesisBlock, defaultTraceReexec, nil, true, false)
+	if err != nil {
+		t.Fatalf("failed to retrieve parent state: %v", err)
+	}
+	results, err := api.traceBlockParallel(context.Background(), block, statedb, config)
+	release()
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	check("parallel", results)
+
+	var traced int
+	for result := range api.traceChain(genesisBlock, block, config, nil) {
+		check("chain", result.Traces)
+		traced++
+	}
+	if traced != 1 {
+		t.Errorf("traced block count mismatch: have %d, want 1", traced)
+	}
+}
+
+func TestTraceTransactionWithOverrides(t *testing.T) {
+	t.Parallel()
+
+	// The guard reverts unless the balance of the caller is at least 1000 ether.
+	//
+	//   PUSH9 1000e18 CALLER BALANCE LT PUSH1 0x11 JUMPI STOP
+	//   JUMPDEST PUSH1 0 DUP1 REVERT
+	var (
+		accounts = newAccounts(2)
+		guard    = common.HexToAddress("0x000000000000000000000000000000000000a5a5")
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				accounts[1].addr: {Balance: big.NewInt(2 * params.Ether)},
+				guard:            {Code: common.FromHex("0x683635c9adc5dea00000333110601157005b600080fd")},
+			},
+		}
+		target common.Hash
+		signer = types.HomesteadSigner{}
+	)
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		// The first transaction funds the caller of the guard
+		tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+			To:       &accounts[0].addr,
+			Value:    big.NewInt(params.Ether),
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		}), signer, accounts[1].key)
+		b.AddTx(tx)
+
+		tx, _ = types.SignTx(types.NewTx(&types.LegacyTx{
+			To:       &guard,
+			Gas:      100_000,
+			GasPrice: b.BaseFee(),
+		}), signer, accounts[0].key)
+		b.AddTx(tx)
+		target = tx.Hash()
+	})
+	defer backend.chain.Stop()
+	api := NewAPI(backend)
+
+	trace := func(overrides *override.StateOverride) *logger.ExecutionResult {
+		t.Helper()
+		result, err := api.TraceTransactionWithOverrides(context.Background(), target, overrides, nil)
+		if err != nil {
+			t.Fatalf("failed to trace transaction: %v", err)
+		}
+		var have *logger.ExecutionResult
+		if err := json.Unmarshal(result.(json.RawMessage), &have); err != nil {
+			t.Fatalf("failed to unmarshal result: %v", err)
+		}
+		return have
+	}
+	if !trace(nil).Failed {
+		t.Error("transaction succeeded without overrides")
+	}
+	// The funds received from the preceding transaction are needed on top of
+	// the overridden balance.
+	balance := new(big.Int).Mul(big.NewInt(9995), big.NewInt(params.Ether/10))
+	if trace(&override.StateOverride{accounts[0].addr: override.OverrideAccount{Balance: newRPCBalance(balance)}}).Failed {
+		t.Error("transaction failed with balance override")
+	}
+	// Test non-existent transaction
+	_, err := api.TraceTransactionWithOverrides(context.Background(), common.Hash{42}, nil, nil)
+	if !errors.Is(err, errTxNotFound) {
+		t.Fatalf("want %v, have %v", errTxNotFound, err)
+	}
+}
+
+func TestTraceTransactionCache(t *testing.T) {
+	t.Parallel()
+
+	// Initialize test accounts
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			accounts[1].addr: {Balance: big.NewInt(params.Ether)},
+		},
+	}
+	signer := types.HomesteadSigner{}
+	tx, _ := types.SignTx(types.NewTx(&types.LegacyTx{
+		Nonce:    0,
+		To:       &accounts[1].addr,
+		Value:    big.NewInt(1000),
+		Gas:      params.TxGas,
+		GasPrice: big.NewInt(params.InitialBaseFee),
+	}), signer, accounts[0].key)
+
+	backend := newTestBackend(t, 1, genesis, func(i int, b *core.BlockGen) {
+		b.AddTx(tx)
+	})
+	defer backend.chain.Stop()
+
+	var executed int
+	backend.traceCacheSize = 16
+	backend.refHook = func() { executed++ }
+	api := NewAPI(backend)
+
+	trace := func(config *TraceConfig) interface{} {
+		t.Helper()
+		result, err := api.TraceTransaction(context.Background(), tx.Hash(), config)
+		if err != nil {
+			t.Fatalf("failed to trace transaction: %v", err)
+		}
+		return result
+	}
+	// The second trace with the same config must be served from the cache
+	first := trace(nil)
+	if second := trace(nil); !reflect.DeepEqual(first, second) {
+		t.Fatalf("cached trace mismatch: have %s, want %s", second, first)
+	}
+	if executed != 1 {
+		t.Fatalf("executions mismatch: have %d, want 1", executed)
+	}
+	// A trace with a different config is not served the cached one
+	config := &TraceConfig{Config: &logger.Config{EnableMemory: true}}
+	trace(config)
+	trace(config)
+	if executed != 2 {
+		t.Fatalf("executions mismatch: have %d, want 2", executed)
+	}
+	// Reorg the transaction into a different block, the cached trace must not
+	// be served for it anymore
+	_, fork, _ := core.GenerateChainWithGenesis(genesis, backend.engine, 2, func(i int, b *core.BlockGen) {
+		if i == 1 {
+			b.AddTx(tx)
+		}
+	})
+	if _, err := backend.chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert fork: %v", err)
+	}
+	if _, _, blockHash, _, _ := backend.GetTransaction(tx.Hash()); blockHash != fork[1].Hash() {
+		t.Fatalf("transaction not reorged: have block %x, want %x", blockHash, fork[1].Hash())
+	}
+	trace(nil)
+	if executed != 3 {
+		t.Fatalf("executions mismatch: have %d, want 3", executed)
+	}
+}
+
 func TestTraceBlock(t *testing.T) {
 	t.Parallel()
 
@@ -643,6 +1037,67 @@ func TestTraceBlock(t *testing.T) {
 	}
 }
 
+// Tests that RLP encoded blocks are traced against the state of their parent,
+// whether they're part of the local chain or not.
+func TestTraceBlockRLP(t *testing.T) {
+	t.Parallel()
+
+	accounts := newAccounts(2)
+	genesis := &core.Genesis{
+		Config: params.TestChainConfig,
+		Alloc:  types.GenesisAlloc{accounts[0].addr: {Balance: big.NewInt(params.Ether)}},
+	}
+	transfer := func(value int64) func(i int, b *core.BlockGen) {
+		return func(i int, b *core.BlockGen) {
+			b.AddTx(types.MustSignNewTx(accounts[0].key, types.HomesteadSigner{}, &types.LegacyTx{
+				Nonce:    uint64(i),
+				To:       &accounts[1].addr,
+				Value:    big.NewInt(value),
+				Gas:      params.TxGas,
+				GasPrice: b.BaseFee(),
+			}))
+		}
+	}
+	backend := newTestBackend(t, 5, genesis, transfer(1000))
+	defer backend.chain.Stop()
+	api := NewAPI(backend)
+
+	// A block of the local chain is traced like by hash
+	block := backend.chain.GetBlockByNumber(3)
+	blob, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		t.Fatalf("failed to encode block: %v", err)
+	}
+	have, err := api.TraceBlock(context.Background(), blob, nil)
+	if err != nil {
+		t.Fatalf("failed to trace encoded block: %v", err)
+	}
+	want, err := api.TraceBlockByHash(context.Background(), block.Hash(), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block by hash: %v", err)
+	}
+	haveJSON, _ := json.Marshal(have)
+	wantJSON, _ := json.Marshal(want)
+	if string(haveJSON) != string(wantJSON) {
+		t.Errorf("trace mismatch: have %s, want %s", haveJSON, wantJSON)
+	}
+	// A block of another fork is traced against its known parent
+	_, fork, _ := core.GenerateChainWithGenesis(genesis, ethash.NewFaker(), 2, transfer(2000))
+	blob, _ = rlp.EncodeToBytes(fork[0])
+	have, err = api.TraceBlock(context.Background(), blob, nil)
+	if err != nil {
+		t.Fatalf("failed to trace fork block: %v", err)
+	}
+	if len(have) != 1 || have[0].TxHash != fork[0].Transactions()[0].Hash() {
+		t.Errorf("fork block trace mismatch: have %+v", have)
+	}
+	// The blocks on top of unknown parents are rejected
+	blob, _ = rlp.EncodeToBytes(fork[1])
+	if _, err := api.TraceBlock(context.Background(), blob, nil); !errors.Is(err, ErrParentStateNotAvailable) {
+		t.Errorf("error mismatch: have %v, want %v", err, ErrParentStateNotAvailable)
+	}
+}
+
 func TestTracingWithOverrides(t *testing.T) {
 	t.Parallel()
 	// Initialize test accounts
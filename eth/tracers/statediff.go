@@ -0,0 +1,219 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// BlockTrace is the result of debug_traceBlockWithStateDiff: the traces of the
+// transactions of a block along with the state modified by all of them.
+type BlockTrace struct {
+	Transactions []*txTraceResult `json:"transactions"`
+	StateDiff    *StateDiff       `json:"stateDiff"`
+}
+
+// StateDiff is the state modified by a sequence of transactions, in the format
+// of the prestateTracer diff mode: the accounts modified along with their state
+// before the first transaction, and the fields modified by the transactions.
+type StateDiff struct {
+	Pre  map[common.Address]*DiffAccount `json:"pre"`
+	Post map[common.Address]*DiffAccount `json:"post"`
+}
+
+// DiffAccount is the state of an account in a StateDiff.
+type DiffAccount struct {
+	Balance *hexutil.Big                `json:"balance,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Nonce   uint64                      `json:"nonce,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// TraceBlockWithStateDiff returns the traces of the transactions of a block
+// produced by the given tracer, along with the state diff of the whole block,
+// merged from the prestateTracer diffs of its transactions. The changes made
+// outside of the transactions, e.g. block rewards, are not included.
+func (api *API) TraceBlockWithStateDiff(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash, config *TraceConfig) (*BlockTrace, error) {
+	block, err := api.blockByNumberOrHash(ctx, blockNrOrHash)
+	if err != nil {
+		return nil, err
+	}
+	traces, err := api.traceBlock(ctx, block, config)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		tracer     = "prestateTracer"
+		diffConfig = &TraceConfig{Tracer: &tracer, TracerConfig: json.RawMessage(`{"diffMode": true}`)}
+	)
+	if config != nil {
+		diffConfig.Timeout, diffConfig.Reexec = config.Timeout, config.Reexec
+	}
+	diffs, err := api.traceBlock(ctx, block, diffConfig)
+	if err != nil {
+		return nil, err
+	}
+	result := &BlockTrace{Transactions: traces, StateDiff: newStateDiff()}
+	for _, res := range diffs {
+		if res.Error != "" {
+			return nil, fmt.Errorf("tx %#x: %s", res.TxHash, res.Error)
+		}
+		blob, err := json.Marshal(res.Result)
+		if err != nil {
+			return nil, err
+		}
+		diff := newStateDiff()
+		if err := json.Unmarshal(blob, diff); err != nil {
+			return nil, fmt.Errorf("tx %#x: invalid state diff: %v", res.TxHash, err)
+		}
+		result.StateDiff.merge(diff)
+	}
+	result.StateDiff.normalize()
+	return result, nil
+}
+
+// blockByNumberOrHash is the wrapper of the chain access function offered by
+// the backend. It will return an error if the block is not found.
+func (api *API) blockByNumberOrHash(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*types.Block, error) {
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		return api.blockByHash(ctx, hash)
+	}
+	if number, ok := blockNrOrHash.Number(); ok {
+		return api.blockByNumber(ctx, number)
+	}
+	return nil, errors.New("invalid arguments; neither block nor hash specified")
+}
+
+func newStateDiff() *StateDiff {
+	return &StateDiff{
+		Pre:  make(map[common.Address]*DiffAccount),
+		Post: make(map[common.Address]*DiffAccount),
+	}
+}
+
+// merge applies the diff of the next transaction. The state before the first
+// transaction modifying an account or a slot is retained, the post state of
+// the later transactions overriding the earlier ones.
+func (d *StateDiff) merge(next *StateDiff) {
+	for addr, pre := range next.Pre {
+		acc, ok := d.Pre[addr]
+		if !ok {
+			// Accounts created by an earlier transaction didn't exist before
+			if _, created := d.Post[addr]; created {
+				acc = &DiffAccount{}
+			} else {
+				acc = &DiffAccount{Balance: pre.Balance, Code: pre.Code, Nonce: pre.Nonce}
+			}
+			d.Pre[addr] = acc
+		}
+		post := d.Post[addr]
+		for slot, val := range pre.Storage {
+			// The slots modified by an earlier transaction are tracked already
+			if _, ok := acc.Storage[slot]; ok {
+				continue
+			}
+			if post != nil {
+				if _, ok := post.Storage[slot]; ok {
+					continue
+				}
+			}
+			if acc.Storage == nil {
+				acc.Storage = make(map[common.Hash]common.Hash)
+			}
+			acc.Storage[slot] = val
+		}
+		// The accounts missing from the post state were deleted
+		if _, ok := next.Post[addr]; !ok {
+			delete(d.Post, addr)
+			continue
+		}
+		// The slots missing from the post state were cleared
+		if post != nil {
+			for slot := range pre.Storage {
+				if _, ok := next.Post[addr].Storage[slot]; !ok {
+					delete(post.Storage, slot)
+				}
+			}
+		}
+	}
+	for addr, next := range next.Post {
+		acc, ok := d.Post[addr]
+		if !ok {
+			acc = &DiffAccount{}
+			d.Post[addr] = acc
+		}
+		if next.Balance != nil {
+			acc.Balance = next.Balance
+		}
+		if next.Code != nil {
+			acc.Code = next.Code
+		}
+		if next.Nonce != 0 {
+			acc.Nonce = next.Nonce
+		}
+		for slot, val := range next.Storage {
+			if acc.Storage == nil {
+				acc.Storage = make(map[common.Hash]common.Hash)
+			}
+			acc.Storage[slot] = val
+		}
+	}
+}
+
+// normalize drops the fields reverted to their state before the first
+// transaction, and the accounts left unmodified altogether.
+func (d *StateDiff) normalize() {
+	for addr, post := range d.Post {
+		pre := d.Pre[addr]
+		if pre == nil {
+			pre = new(DiffAccount)
+		}
+		if post.Balance != nil && pre.Balance != nil && post.Balance.ToInt().Cmp(pre.Balance.ToInt()) == 0 {
+			post.Balance = nil
+		}
+		if post.Code != nil && bytes.Equal(post.Code, pre.Code) {
+			post.Code = nil
+		}
+		if post.Nonce == pre.Nonce {
+			post.Nonce = 0
+		}
+		for slot, val := range pre.Storage {
+			if post.Storage[slot] == val {
+				delete(pre.Storage, slot)
+				delete(post.Storage, slot)
+			}
+		}
+		for slot, val := range post.Storage {
+			if _, ok := pre.Storage[slot]; !ok && val == (common.Hash{}) {
+				delete(post.Storage, slot)
+			}
+		}
+		if post.Balance == nil && post.Code == nil && post.Nonce == 0 && len(post.Storage) == 0 && len(pre.Storage) == 0 {
+			delete(d.Pre, addr)
+			delete(d.Post, addr)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package tracers_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/program"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Tests that the state diff of a block accumulates the changes of all of its
+// transactions.
+func TestTraceBlockWithStateDiff(t *testing.T) {
+	var (
+		key, _    = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender    = crypto.PubkeyToAddress(key.PublicKey)
+		recipient = common.HexToAddress("0xbb")
+		token     = common.HexToAddress("0xcc")
+		funds     = big.NewInt(params.Ether)
+
+		// transfer(address to, uint256 amount) of a token keeping the balance
+		// of every holder in the slot of its address
+		code = program.New().
+			Push(36).Op(vm.CALLDATALOAD).Op(vm.CALLER, vm.SLOAD, vm.SUB).Op(vm.CALLER, vm.SSTORE).
+			Push(36).Op(vm.CALLDATALOAD).Push(4).Op(vm.CALLDATALOAD, vm.SLOAD, vm.ADD).Push(4).Op(vm.CALLDATALOAD, vm.SSTORE).
+			Op(vm.STOP).Bytes()
+		senderSlot    = common.BytesToHash(sender.Bytes())
+		recipientSlot = common.BytesToHash(recipient.Bytes())
+	)
+	genesis := &core.Genesis{
+		Config: params.AllEthashProtocolChanges,
+		Alloc: types.GenesisAlloc{
+			sender: {Balance: funds},
+			token:  {Code: code, Storage: map[common.Hash]common.Hash{senderSlot: common.BigToHash(big.NewInt(1000))}},
+		},
+	}
+	signer := types.LatestSigner(genesis.Config)
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, ethash.NewFaker(), 1, func(i int, b *core.BlockGen) {
+		b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    0,
+			To:       &recipient,
+			Value:    big.NewInt(1000),
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		}))
+		input := append(make([]byte, 4), append(recipientSlot.Bytes(), common.BigToHash(big.NewInt(100)).Bytes()...)...)
+		b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    1,
+			To:       &token,
+			Gas:      100000,
+			GasPrice: b.BaseFee(),
+			Data:     input,
+		}))
+	})
+	stack, err := node.New(new(node.Config))
+	if err != nil {
+		t.Fatalf("failed to create node: %v", err)
+	}
+	defer stack.Close()
+
+	ethservice, err := eth.New(stack, &ethconfig.Config{Genesis: genesis})
+	if err != nil {
+		t.Fatalf("failed to create ethereum service: %v", err)
+	}
+	if err := stack.Start(); err != nil {
+		t.Fatalf("failed to start node: %v", err)
+	}
+	if _, err := ethservice.BlockChain().InsertChain(blocks); err != nil {
+		t.Fatalf("failed to import blocks: %v", err)
+	}
+	api := tracers.NewAPI(ethservice.APIBackend)
+	trace, err := api.TraceBlockWithStateDiff(context.Background(), rpc.BlockNumberOrHashWithNumber(1), nil)
+	if err != nil {
+		t.Fatalf("failed to trace block: %v", err)
+	}
+	if len(trace.Transactions) != 2 {
+		t.Fatalf("transaction traces mismatch: have %d, want %d", len(trace.Transactions), 2)
+	}
+	var (
+		pre  = trace.StateDiff.Pre
+		post = trace.StateDiff.Post
+	)
+	// The ether transfer and the fees of both transactions
+	receipts := ethservice.BlockChain().GetReceiptsByHash(blocks[0].Hash())
+	fees := new(big.Int)
+	for _, receipt := range receipts {
+		fees.Add(fees, new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice))
+	}
+	if pre[sender] == nil || pre[sender].Balance.ToInt().Cmp(funds) != 0 {
+		t.Errorf("sender pre balance mismatch: have %v, want %v", pre[sender], funds)
+	}
+	want := new(big.Int).Sub(funds, new(big.Int).Add(fees, big.NewInt(1000)))
+	if post[sender] == nil || post[sender].Balance.ToInt().Cmp(want) != 0 || post[sender].Nonce != 2 {
+		t.Errorf("sender post state mismatch: have %v, want balance %v nonce %d", post[sender], want, 2)
+	}
+	if post[recipient] == nil || post[recipient].Balance.ToInt().Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("recipient post balance mismatch: have %v, want %v", post[recipient], 1000)
+	}
+	// The token balances
+	if have := pre[token].Storage[senderSlot]; have != common.BigToHash(big.NewInt(1000)) {
+		t.Errorf("sender token pre balance mismatch: have %x, want %x", have, 1000)
+	}
+	if _, ok := pre[token].Storage[recipientSlot]; ok {
+		t.Errorf("unexpected recipient token pre balance")
+	}
+	if have := post[token].Storage[senderSlot]; have != common.BigToHash(big.NewInt(900)) {
+		t.Errorf("sender token post balance mismatch: have %x, want %x", have, 900)
+	}
+	if have := post[token].Storage[recipientSlot]; have != common.BigToHash(big.NewInt(100)) {
+		t.Errorf("recipient token post balance mismatch: have %x, want %x", have, 100)
+	}
+	if post[token].Balance != nil || post[token].Nonce != 0 || post[token].Code != nil {
+		t.Errorf("unexpected token account changes: %+v", post[token])
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/locals"
+)
+
+// TxPoolHealthHandler returns an HTTP handler serving the health summary of the
+// transaction pool as JSON.
+func (s *Ethereum) TxPoolHealthHandler() http.Handler {
+	return newTxPoolHealthHandler(s.txPool, s.localTxTracker)
+}
+
+// newTxPoolHealthHandler creates the health handler of the pool, counting the
+// local transactions of the tracker if there is one.
+func newTxPoolHealthHandler(pool *txpool.TxPool, tracker *locals.TxTracker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		health := pool.Health()
+		if tracker != nil {
+			health.LocalCount = tracker.Count()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(health)
+	})
+}
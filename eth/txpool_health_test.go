@@ -0,0 +1,106 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package eth
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/txpool"
+	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Tests that the health endpoint of the transaction pool reports it degraded
+// once it holds too many pending transactions.
+func TestTxPoolHealth(t *testing.T) {
+	var (
+		key, _ = crypto.GenerateKey()
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		gspec  = &core.Genesis{
+			Config:  params.TestChainConfig,
+			Alloc:   types.GenesisAlloc{addr: {Balance: new(big.Int).Mul(big.NewInt(1_000_000), big.NewInt(params.Ether))}},
+			BaseFee: big.NewInt(params.InitialBaseFee),
+		}
+	)
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, ethash.NewFaker(), nil)
+	if err != nil {
+		t.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	config := legacypool.DefaultConfig
+	config.Journal = ""
+	config.GlobalSlots = 60_000
+	pool, err := txpool.New(config.PriceLimit, chain, []txpool.SubPool{legacypool.New(config, chain)})
+	if err != nil {
+		t.Fatalf("failed to create tx pool: %v", err)
+	}
+	defer pool.Close()
+
+	var (
+		signer  = types.LatestSigner(params.TestChainConfig)
+		handler = newTxPoolHealthHandler(pool, nil)
+		nonce   uint64
+	)
+	fill := func(n int) {
+		txs := make([]*types.Transaction, n)
+		for i := range txs {
+			txs[i] = types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+				ChainID:   params.TestChainConfig.ChainID,
+				Nonce:     nonce,
+				GasTipCap: big.NewInt(params.GWei),
+				GasFeeCap: big.NewInt(2 * params.GWei),
+				Gas:       params.TxGas,
+				To:        &common.Address{0x01},
+				Value:     new(big.Int),
+			})
+			nonce++
+		}
+		for i, err := range pool.Add(txs, true) {
+			if err != nil {
+				t.Fatalf("failed to add transaction %d: %v", i, err)
+			}
+		}
+	}
+	query := func() *txpool.Health {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest("GET", "/txpool/health", nil))
+
+		health := new(txpool.Health)
+		if err := json.Unmarshal(rec.Body.Bytes(), health); err != nil {
+			t.Fatalf("failed to decode health: %v", err)
+		}
+		return health
+	}
+	fill(1000)
+	if health := query(); health.PendingCount != 1000 || health.BaseFee != "1 gwei" || health.Status != txpool.HealthStatusHealthy {
+		t.Fatalf("health mismatch: have %+v, want 1000 pending at 1 gwei, %s", health, txpool.HealthStatusHealthy)
+	}
+	fill(50_000)
+	if health := query(); health.PendingCount != 51_000 || health.Status != txpool.HealthStatusDegraded {
+		t.Fatalf("health mismatch: have %+v, want 51000 pending, %s", health, txpool.HealthStatusDegraded)
+	}
+}
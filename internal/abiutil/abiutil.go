@@ -0,0 +1,180 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package abiutil converts between the Go values of the ABI encoder and values
+// decoded from JSON or user input.
+package abiutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ToGoValue converts a decoded JSON value into the Go type that the ABI encoder
+// expects for the given type. Numbers may be given as JSON numbers, decimal or
+// hex strings, and binary values as hex strings. Tuples may be given as a list
+// of values or an object keyed by the names of the elements.
+func ToGoValue(typ abi.Type, v interface{}) (interface{}, error) {
+	val := reflect.New(typ.GetType()).Elem()
+	if err := setValue(typ, val, v); err != nil {
+		return nil, err
+	}
+	return val.Interface(), nil
+}
+
+func setValue(typ abi.Type, dst reflect.Value, v interface{}) error {
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		var n *big.Int
+		switch v := v.(type) {
+		case json.Number:
+			n, _ = new(big.Int).SetString(string(v), 10)
+		case string:
+			n, _ = new(big.Int).SetString(v, 0)
+		}
+		if n == nil {
+			return fmt.Errorf("invalid %s: %v", typ, v)
+		}
+		switch dst.Kind() {
+		case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if !n.IsInt64() || dst.OverflowInt(n.Int64()) {
+				return fmt.Errorf("%s overflow: %v", typ, n)
+			}
+			dst.SetInt(n.Int64())
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if !n.IsUint64() || dst.OverflowUint(n.Uint64()) {
+				return fmt.Errorf("%s overflow: %v", typ, n)
+			}
+			dst.SetUint(n.Uint64())
+		default:
+			dst.Set(reflect.ValueOf(n))
+		}
+	case abi.BoolTy:
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("invalid bool: %v", v)
+		}
+		dst.SetBool(b)
+	case abi.StringTy:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("invalid string: %v", v)
+		}
+		dst.SetString(s)
+	case abi.AddressTy:
+		s, ok := v.(string)
+		if !ok || !common.IsHexAddress(s) {
+			return fmt.Errorf("invalid address: %v", v)
+		}
+		dst.Set(reflect.ValueOf(common.HexToAddress(s)))
+	case abi.BytesTy, abi.FixedBytesTy:
+		s, _ := v.(string)
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %v", typ, v)
+		}
+		if typ.T == abi.BytesTy {
+			dst.SetBytes(b)
+			return nil
+		}
+		if len(b) != typ.Size {
+			return fmt.Errorf("invalid %s length: %d", typ, len(b))
+		}
+		reflect.Copy(dst, reflect.ValueOf(b))
+	case abi.SliceTy, abi.ArrayTy:
+		list, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("invalid %s: %v", typ, v)
+		}
+		if typ.T == abi.ArrayTy && len(list) != typ.Size {
+			return fmt.Errorf("invalid %s length: %d", typ, len(list))
+		}
+		if typ.T == abi.SliceTy {
+			dst.Set(reflect.MakeSlice(dst.Type(), len(list), len(list)))
+		}
+		for i, item := range list {
+			if err := setValue(*typ.Elem, dst.Index(i), item); err != nil {
+				return err
+			}
+		}
+	case abi.TupleTy:
+		// Tuples may be given as a list of values or an object keyed by names
+		list, isList := v.([]interface{})
+		fields, isObject := v.(map[string]interface{})
+		if (!isList || len(list) != len(typ.TupleElems)) && !isObject {
+			return fmt.Errorf("invalid %s: %v", typ, v)
+		}
+		for i, elem := range typ.TupleElems {
+			var item interface{}
+			if isList {
+				item = list[i]
+			} else {
+				item = fields[typ.TupleRawNames[i]]
+			}
+			if err := setValue(*elem, dst.Field(i), item); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported type %s", typ)
+	}
+	return nil
+}
+
+// FromGoValue converts a value decoded by the ABI decoder into a JSON friendly
+// one, encoding the numbers as decimal strings and the binary values as hex
+// strings. Tuples are converted into objects keyed by the names of the elements.
+func FromGoValue(typ abi.Type, v interface{}) interface{} {
+	if hash, ok := v.(common.Hash); ok {
+		return hash.Hex() // dynamic indexed value
+	}
+	val := reflect.ValueOf(v)
+	switch typ.T {
+	case abi.IntTy, abi.UintTy:
+		if n, ok := v.(*big.Int); ok {
+			return n.String()
+		}
+		return fmt.Sprint(v)
+	case abi.AddressTy:
+		return v.(common.Address).Hex()
+	case abi.BytesTy:
+		return hexutil.Encode(v.([]byte))
+	case abi.FixedBytesTy, abi.FunctionTy:
+		b := make([]byte, val.Len())
+		reflect.Copy(reflect.ValueOf(b), val)
+		return hexutil.Encode(b)
+	case abi.SliceTy, abi.ArrayTy:
+		list := make([]interface{}, val.Len())
+		for i := range list {
+			list[i] = FromGoValue(*typ.Elem, val.Index(i).Interface())
+		}
+		return list
+	case abi.TupleTy:
+		fields := make(map[string]interface{})
+		for i, elem := range typ.TupleElems {
+			fields[typ.TupleRawNames[i]] = FromGoValue(*elem, val.Field(i).Interface())
+		}
+		return fields
+	default:
+		return v
+	}
+}
@@ -23,15 +23,22 @@ import (
 	"fmt"
 	gomath "math"
 	"math/big"
+	"runtime"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/lru"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip1559"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -40,19 +47,27 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/gasestimator"
 	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	"github.com/ethereum/go-ethereum/internal/ethapi/override"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/fourbyte"
 	"github.com/ethereum/go-ethereum/trie"
+	"golang.org/x/sync/errgroup"
 )
 
 // estimateGasErrorRatio is the amount of overestimation eth_estimateGas is
 // allowed to produce in order to speed up calculations.
 const estimateGasErrorRatio = 0.015
 
+// maxAccountsByBalance is the maximum number of accounts returned by
+// eth_accountsByBalance.
+const maxAccountsByBalance = 100
+
 var errBlobTxNotSupported = errors.New("signing blob transactions not supported")
 
 // EthereumAPI provides an API to access Ethereum related information.
@@ -137,6 +152,27 @@ func (api *EthereumAPI) BlobBaseFee(ctx context.Context) *hexutil.Big {
 	return (*hexutil.Big)(api.b.BlobBaseFee(ctx))
 }
 
+// Mining returns whether the node produces blocks. Blocks are not mined since
+// the merge, only the sequencer of a rollup chain builds them itself.
+func (api *EthereumAPI) Mining() bool {
+	return api.b.IsSequencer()
+}
+
+// Hashrate returns the hashrate of the node, which is always zero since the merge.
+func (api *EthereumAPI) Hashrate() hexutil.Uint64 {
+	return 0
+}
+
+// Coinbase returns the recipient of the fees of the blocks built locally. On
+// rollup chains it's the sequencer fee vault, otherwise the fee recipient is
+// chosen by the consensus client and the zero address is returned.
+func (api *EthereumAPI) Coinbase() common.Address {
+	if api.b.ChainConfig().IsOptimism() {
+		return params.SequencerFeeVaultAddress
+	}
+	return common.Address{}
+}
+
 // Syncing returns false in case the node is currently not syncing with the network. It can be up-to-date or has not
 // yet received the latest block headers from its peers. In case it is synchronizing:
 // - startingBlock: block number this node started to synchronize from
@@ -183,27 +219,71 @@ func NewTxPoolAPI(b Backend) *TxPoolAPI {
 	return &TxPoolAPI{b}
 }
 
+// RPCPoolTransaction is a pool transaction annotated with the name of the
+// called function, if its selector is known.
+type RPCPoolTransaction struct {
+	*RPCTransaction
+	FunctionName *string `json:"functionName"`
+}
+
+var (
+	fourbyteOnce sync.Once
+	fourbyteDB   *fourbyte.Database
+)
+
+// loadFourbyteDB returns the 4byte database, or nil if it failed to load. The
+// database is large, it's only loaded once it's needed.
+func loadFourbyteDB() *fourbyte.Database {
+	fourbyteOnce.Do(func() {
+		db, err := fourbyte.New()
+		if err != nil {
+			log.Warn("Failed to load the 4byte database", "err", err)
+			return
+		}
+		fourbyteDB = db
+	})
+	return fourbyteDB
+}
+
+// newRPCPoolTransaction returns a pool transaction that will serialize to the
+// RPC representation, with the called function looked up in the 4byte database.
+func newRPCPoolTransaction(tx *types.Transaction, current *types.Header, config *params.ChainConfig) *RPCPoolTransaction {
+	result := &RPCPoolTransaction{RPCTransaction: NewRPCPendingTransaction(tx, current, config)}
+	if tx.To() == nil || len(tx.Data()) < 4 {
+		return result
+	}
+	db := loadFourbyteDB()
+	if db == nil {
+		return result
+	}
+	if selector, err := db.Selector(tx.Data()); err == nil {
+		name, _, _ := strings.Cut(selector, "(")
+		result.FunctionName = &name
+	}
+	return result
+}
+
 // Content returns the transactions contained within the transaction pool.
-func (api *TxPoolAPI) Content() map[string]map[string]map[string]*RPCTransaction {
-	content := map[string]map[string]map[string]*RPCTransaction{
-		"pending": make(map[string]map[string]*RPCTransaction),
-		"queued":  make(map[string]map[string]*RPCTransaction),
+func (api *TxPoolAPI) Content() map[string]map[string]map[string]*RPCPoolTransaction {
+	content := map[string]map[string]map[string]*RPCPoolTransaction{
+		"pending": make(map[string]map[string]*RPCPoolTransaction),
+		"queued":  make(map[string]map[string]*RPCPoolTransaction),
 	}
 	pending, queue := api.b.TxPoolContent()
 	curHeader := api.b.CurrentHeader()
 	// Flatten the pending transactions
 	for account, txs := range pending {
-		dump := make(map[string]*RPCTransaction)
+		dump := make(map[string]*RPCPoolTransaction)
 		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = NewRPCPendingTransaction(tx, curHeader, api.b.ChainConfig())
+			dump[fmt.Sprintf("%d", tx.Nonce())] = newRPCPoolTransaction(tx, curHeader, api.b.ChainConfig())
 		}
 		content["pending"][account.Hex()] = dump
 	}
 	// Flatten the queued transactions
 	for account, txs := range queue {
-		dump := make(map[string]*RPCTransaction)
+		dump := make(map[string]*RPCPoolTransaction)
 		for _, tx := range txs {
-			dump[fmt.Sprintf("%d", tx.Nonce())] = NewRPCPendingTransaction(tx, curHeader, api.b.ChainConfig())
+			dump[fmt.Sprintf("%d", tx.Nonce())] = newRPCPoolTransaction(tx, curHeader, api.b.ChainConfig())
 		}
 		content["queued"][account.Hex()] = dump
 	}
@@ -211,22 +291,22 @@ func (api *TxPoolAPI) Content() map[string]map[string]map[string]*RPCTransaction
 }
 
 // ContentFrom returns the transactions contained within the transaction pool.
-func (api *TxPoolAPI) ContentFrom(addr common.Address) map[string]map[string]*RPCTransaction {
-	content := make(map[string]map[string]*RPCTransaction, 2)
+func (api *TxPoolAPI) ContentFrom(addr common.Address) map[string]map[string]*RPCPoolTransaction {
+	content := make(map[string]map[string]*RPCPoolTransaction, 2)
 	pending, queue := api.b.TxPoolContentFrom(addr)
 	curHeader := api.b.CurrentHeader()
 
 	// Build the pending transactions
-	dump := make(map[string]*RPCTransaction, len(pending))
+	dump := make(map[string]*RPCPoolTransaction, len(pending))
 	for _, tx := range pending {
-		dump[fmt.Sprintf("%d", tx.Nonce())] = NewRPCPendingTransaction(tx, curHeader, api.b.ChainConfig())
+		dump[fmt.Sprintf("%d", tx.Nonce())] = newRPCPoolTransaction(tx, curHeader, api.b.ChainConfig())
 	}
 	content["pending"] = dump
 
 	// Build the queued transactions
-	dump = make(map[string]*RPCTransaction, len(queue))
+	dump = make(map[string]*RPCPoolTransaction, len(queue))
 	for _, tx := range queue {
-		dump[fmt.Sprintf("%d", tx.Nonce())] = NewRPCPendingTransaction(tx, curHeader, api.b.ChainConfig())
+		dump[fmt.Sprintf("%d", tx.Nonce())] = newRPCPoolTransaction(tx, curHeader, api.b.ChainConfig())
 	}
 	content["queued"] = dump
 
@@ -293,6 +373,40 @@ func (api *EthereumAccountAPI) Accounts() []common.Address {
 	return api.am.Accounts()
 }
 
+// PersonalAPI provides an API to verify personal messages. It doesn't access
+// any account managed by this node.
+type PersonalAPI struct{}
+
+// NewPersonalAPI creates a new PersonalAPI.
+func NewPersonalAPI() *PersonalAPI {
+	return &PersonalAPI{}
+}
+
+// EcRecover returns the address for the account that was used to create the signature.
+// Note, this function is compatible with personal_sign. As such it recovers
+// the address of:
+// hash = keccak256("\x19Ethereum Signed Message:\n"${message length}${message})
+// addr = ecrecover(hash, signature)
+//
+// Note, the signature must conform to the secp256k1 curve R, S and V values, where
+// the V value must be 27 or 28 for legacy reasons.
+func (api *PersonalAPI) EcRecover(data, sig hexutil.Bytes) (common.Address, error) {
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("signature must be %d bytes long", crypto.SignatureLength)
+	}
+	if sig[crypto.RecoveryIDOffset] != 27 && sig[crypto.RecoveryIDOffset] != 28 {
+		return common.Address{}, errors.New("invalid Ethereum signature (V is not 27 or 28)")
+	}
+	sig = common.CopyBytes(sig)
+	sig[crypto.RecoveryIDOffset] -= 27 // Transform yellow paper V from 27/28 to 0/1
+
+	rpk, err := crypto.SigToPub(accounts.TextHash(data), sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*rpk), nil
+}
+
 // BlockChainAPI provides an API to access Ethereum blockchain data.
 type BlockChainAPI struct {
 	b Backend
@@ -331,6 +445,24 @@ func (api *BlockChainAPI) GetBalance(ctx context.Context, address common.Address
 	return (*hexutil.Big)(b), state.Error()
 }
 
+// AccountsByBalance returns the accounts this node manages, sorted by their
+// balance at the given block in descending order. At most maxAccountsByBalance
+// accounts are returned.
+func (api *BlockChainAPI) AccountsByBalance(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]common.Address, error) {
+	state, _, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	addrs := api.b.AccountManager().Accounts()
+	slices.SortStableFunc(addrs, func(a, b common.Address) int {
+		return state.GetBalance(b).Cmp(state.GetBalance(a))
+	})
+	if len(addrs) > maxAccountsByBalance {
+		addrs = addrs[:maxAccountsByBalance]
+	}
+	return addrs, state.Error()
+}
+
 // AccountResult structs for GetProof
 type AccountResult struct {
 	Address      common.Address  `json:"address"`
@@ -362,6 +494,7 @@ func (n *proofList) Delete(key []byte) error {
 }
 
 // GetProof returns the Merkle-proof for a given account and optionally some storage keys.
+// The storage trie of the account is only opened if storage keys are requested.
 func (api *BlockChainAPI) GetProof(ctx context.Context, address common.Address, storageKeys []string, blockNrOrHash rpc.BlockNumberOrHash) (*AccountResult, error) {
 	var (
 		keys         = make([]common.Hash, len(storageKeys))
@@ -438,6 +571,12 @@ func (api *BlockChainAPI) GetProof(ctx context.Context, address common.Address,
 	}, statedb.Error()
 }
 
+// GetAccountProof returns the Merkle-proof for a given account, without any
+// storage proofs.
+func (api *BlockChainAPI) GetAccountProof(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*AccountResult, error) {
+	return api.GetProof(ctx, address, nil, blockNrOrHash)
+}
+
 // decodeHash parses a hex-encoded 32-byte hash. The input may optionally
 // be prefixed by 0x and can have a byte length up to 32.
 func decodeHash(s string) (h common.Hash, inputLength int, err error) {
@@ -568,6 +707,72 @@ func (api *BlockChainAPI) GetUncleCountByBlockHash(ctx context.Context, blockHas
 	return nil, err
 }
 
+// UncleReward is the mining reward credited to the coinbase of an uncle.
+type UncleReward struct {
+	Hash     common.Hash    `json:"hash"`
+	Number   hexutil.Uint64 `json:"number"`
+	Coinbase common.Address `json:"coinbase"`
+	Reward   *hexutil.Big   `json:"reward"`
+}
+
+// BlockRewardInfo is the result of eth_getBlockReward.
+type BlockRewardInfo struct {
+	MiningReward         *hexutil.Big   `json:"miningReward"`         // Static block reward
+	UncleInclusionReward *hexutil.Big   `json:"uncleInclusionReward"` // Reward for including the uncles
+	UncleRewards         []*UncleReward `json:"uncleRewards"`
+	TotalFees            *hexutil.Big   `json:"totalFees"` // Fees credited to the coinbase, excluding the burnt base fee
+}
+
+// GetBlockReward returns the rewards credited by the given block. Only proof-of-
+// work blocks of ethash chains pay mining and uncle rewards, they are zero for
+// any other block.
+func (api *BlockChainAPI) GetBlockReward(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) (*BlockRewardInfo, error) {
+	block, err := api.b.BlockByNumberOrHash(ctx, blockNrOrHash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	receipts, err := api.b.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	fees := new(big.Int)
+	for _, receipt := range receipts {
+		// Deposits don't pay any fee on L2, they were paid for on L1
+		if receipt.Type == types.OptimismDepositTxType || receipt.EffectiveGasPrice == nil {
+			continue
+		}
+		tip := new(big.Int).Set(receipt.EffectiveGasPrice)
+		if baseFee := block.BaseFee(); baseFee != nil {
+			tip.Sub(tip, baseFee)
+		}
+		if tip.Sign() <= 0 {
+			continue
+		}
+		fees.Add(fees, tip.Mul(tip, new(big.Int).SetUint64(receipt.GasUsed)))
+	}
+	info := &BlockRewardInfo{
+		MiningReward:         new(hexutil.Big),
+		UncleInclusionReward: new(hexutil.Big),
+		UncleRewards:         []*UncleReward{},
+		TotalFees:            (*hexutil.Big)(fees),
+	}
+	if api.b.ChainConfig().Ethash == nil || block.Difficulty().Sign() == 0 {
+		return info, nil
+	}
+	blockReward, inclusionReward, uncleRewards := ethash.BlockRewards(api.b.ChainConfig(), block.Header(), block.Uncles())
+	info.MiningReward = (*hexutil.Big)(blockReward.ToBig())
+	info.UncleInclusionReward = (*hexutil.Big)(inclusionReward.ToBig())
+	for i, uncle := range block.Uncles() {
+		info.UncleRewards = append(info.UncleRewards, &UncleReward{
+			Hash:     uncle.Hash(),
+			Number:   hexutil.Uint64(uncle.Number.Uint64()),
+			Coinbase: uncle.Coinbase,
+			Reward:   (*hexutil.Big)(uncleRewards[i].ToBig()),
+		})
+	}
+	return info, nil
+}
+
 // GetCode returns the code stored at the given address in the state for the given block number.
 func (api *BlockChainAPI) GetCode(ctx context.Context, address common.Address, blockNrOrHash rpc.BlockNumberOrHash) (hexutil.Bytes, error) {
 	state, _, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
@@ -578,6 +783,83 @@ func (api *BlockChainAPI) GetCode(ctx context.Context, address common.Address, b
 	return code, state.Error()
 }
 
+// ContractCreationInfo describes the transaction that deployed a contract.
+type ContractCreationInfo struct {
+	TxHash      common.Hash    `json:"transactionHash"`
+	BlockHash   common.Hash    `json:"blockHash"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	Deployer    common.Address `json:"deployer"`
+}
+
+// GetContractCreationTransaction returns the transaction that deployed the contract
+// at the given address, or nil if there is no code at the address or if the code
+// was allocated in the genesis.
+//
+// Contract creations are not indexed. The block of the deployment is located by a
+// binary search over the historical states instead, assuming the code was never
+// removed since, and only its receipts are scanned. ErrNotIndexed is returned if
+// the historical states are not available, or if the contract was deployed by
+// another contract, as receipts only record the creations done by transactions.
+func (api *BlockChainAPI) GetContractCreationTransaction(ctx context.Context, address common.Address) (*ContractCreationInfo, error) {
+	hasCode := func(number uint64) (bool, error) {
+		state, _, err := api.b.StateAndHeaderByNumber(ctx, rpc.BlockNumber(number))
+		if state == nil || err != nil {
+			return false, ErrNotIndexed
+		}
+		return state.GetCodeSize(address) > 0, nil
+	}
+	head := api.b.CurrentHeader().Number.Uint64()
+	if ok, err := hasCode(head); err != nil || !ok {
+		return nil, err
+	}
+	// Find the first block with the contract code
+	lo, hi := uint64(0), head
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		ok, err := hasCode(mid)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	if lo == 0 {
+		return nil, nil
+	}
+	block, err := api.b.BlockByNumber(ctx, rpc.BlockNumber(lo))
+	if block == nil || err != nil {
+		return nil, err
+	}
+	receipts, err := api.b.GetReceipts(ctx, block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	txs := block.Transactions()
+	if len(txs) != len(receipts) {
+		return nil, fmt.Errorf("receipts length mismatch: %d vs %d", len(txs), len(receipts))
+	}
+	for i, receipt := range receipts {
+		if txs[i].To() != nil || receipt.ContractAddress != address {
+			continue
+		}
+		signer := types.MakeSigner(api.b.ChainConfig(), block.Number(), block.Time())
+		deployer, err := types.Sender(signer, txs[i])
+		if err != nil {
+			return nil, err
+		}
+		return &ContractCreationInfo{
+			TxHash:      txs[i].Hash(),
+			BlockHash:   block.Hash(),
+			BlockNumber: hexutil.Uint64(lo),
+			Deployer:    deployer,
+		}, nil
+	}
+	return nil, ErrNotIndexed
+}
+
 // GetStorageAt returns the storage from the state at the given address, key and
 // block number. The rpc.LatestBlockNumber and rpc.PendingBlockNumber meta block
 // numbers are also allowed.
@@ -594,6 +876,37 @@ func (api *BlockChainAPI) GetStorageAt(ctx context.Context, address common.Addre
 	return res[:], state.Error()
 }
 
+// erc20ABI is the subset of the ERC-20 token interface used by GetTokenBalance.
+var erc20ABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]}]`))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// GetTokenBalance returns the balance of an owner in an ERC-20 token at the
+// given block, calling the balanceOf method of the token contract.
+func (api *BlockChainAPI) GetTokenBalance(ctx context.Context, token common.Address, owner common.Address, blockNrOrHash rpc.BlockNumberOrHash) (*hexutil.Big, error) {
+	input, err := erc20ABI.Pack("balanceOf", owner)
+	if err != nil {
+		return nil, err
+	}
+	args := TransactionArgs{To: &token, Input: (*hexutil.Bytes)(&input)}
+	result, err := DoCall(ctx, api.b, args, blockNrOrHash, nil, nil, api.b.RPCEVMTimeout(), api.b.RPCGasCap())
+	if err != nil {
+		return nil, err
+	}
+	if result.Failed() || len(result.Return()) < 32 {
+		return nil, ErrNotERC20
+	}
+	values, err := erc20ABI.Unpack("balanceOf", result.Return()[:32])
+	if err != nil {
+		return nil, ErrNotERC20
+	}
+	return (*hexutil.Big)(values[0].(*big.Int)), nil
+}
+
 // GetBlockReceipts returns the block receipts for the given block hash or number or tag.
 func (api *BlockChainAPI) GetBlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]map[string]interface{}, error) {
 	block, err := api.b.BlockByNumberOrHash(ctx, blockNrOrHash)
@@ -753,7 +1066,9 @@ func DoCall(ctx context.Context, b Backend, args TransactionArgs, blockNrOrHash
 // Additionally, the caller can specify a batch of contract for fields overriding.
 //
 // Note, this function doesn't make and changes in the state/blockchain and is
-// useful to execute and retrieve values.
+// useful to execute and retrieve values. Blob transactions are executed with
+// their versioned hashes and blob fee cap, the blobs, commitments and proofs
+// are not verified.
 func (api *BlockChainAPI) Call(ctx context.Context, args TransactionArgs, blockNrOrHash *rpc.BlockNumberOrHash, overrides *override.StateOverride, blockOverrides *override.BlockOverrides) (hexutil.Bytes, error) {
 	if blockNrOrHash == nil {
 		latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
@@ -966,6 +1281,11 @@ type RPCTransaction struct {
 	R                   *hexutil.Big                 `json:"r"`
 	S                   *hexutil.Big                 `json:"s"`
 	YParity             *hexutil.Uint64              `json:"yParity,omitempty"`
+
+	// deposit transaction fields
+	SourceHash *common.Hash `json:"sourceHash,omitempty"`
+	Mint       *hexutil.Big `json:"mint,omitempty"`
+	IsSystemTx *bool        `json:"isSystemTx,omitempty"`
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
@@ -1056,6 +1376,15 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 			result.GasPrice = (*hexutil.Big)(tx.GasFeeCap())
 		}
 		result.AuthorizationList = tx.SetCodeAuthorizations()
+
+	case types.OptimismDepositTxType:
+		// deposits are unsigned, the sender is carried by the transaction itself
+		srcHash := tx.SourceHash()
+		isSystemTx := tx.IsSystemTx()
+		result.From = tx.From()
+		result.SourceHash = &srcHash
+		result.Mint = (*hexutil.Big)(tx.Mint())
+		result.IsSystemTx = &isSystemTx
 	}
 	return result
 }
@@ -1080,7 +1409,7 @@ func NewRPCPendingTransaction(tx *types.Transaction, current *types.Header, conf
 		blockTime   = uint64(0)
 	)
 	if current != nil {
-		baseFee = eip1559.CalcBaseFee(config, current)
+		baseFee = eip1559.CalcBaseFee(config, current, current.Time+1)
 		blockNumber = current.Number.Uint64()
 		blockTime = current.Time
 	}
@@ -1247,6 +1576,7 @@ type TransactionAPI struct {
 	b         Backend
 	nonceLock *AddrLocker
 	signer    types.Signer
+	txCache   *finalizedTxCache
 }
 
 // NewTransactionAPI creates a new RPC service with methods for interacting with transactions.
@@ -1254,7 +1584,49 @@ func NewTransactionAPI(b Backend, nonceLock *AddrLocker) *TransactionAPI {
 	// The signer used by the API should always be the 'latest' known one because we expect
 	// signers to be backwards-compatible with old transactions.
 	signer := types.LatestSigner(b.ChainConfig())
-	return &TransactionAPI{b, nonceLock, signer}
+	return &TransactionAPI{b, nonceLock, signer, newFinalizedTxCache(finalizedTxCacheSize)}
+}
+
+// finalizedTxCacheSize is the number of finalized transactions whose lookups
+// are cached by eth_getTransactionByHash.
+const finalizedTxCacheSize = 4096
+
+// finalizedTxCache caches the RPC representation of the transactions included
+// in finalized blocks. Those can't be reorged out, so the entries only need to
+// be dropped when the chain is rewound below the finalized block they were
+// cached under.
+type finalizedTxCache struct {
+	txs       *lru.Cache[common.Hash, *RPCTransaction]
+	finalized uint64 // Number of the finalized block the entries were cached under
+	lock      sync.Mutex
+}
+
+func newFinalizedTxCache(size int) *finalizedTxCache {
+	return &finalizedTxCache{txs: lru.NewCache[common.Hash, *RPCTransaction](size)}
+}
+
+// get returns the cached transaction, given the current finalized block.
+func (c *finalizedTxCache) get(hash common.Hash, finalized uint64) (*RPCTransaction, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if finalized < c.finalized {
+		c.txs.Purge()
+	}
+	c.finalized = finalized
+	return c.txs.Get(hash)
+}
+
+// add caches a transaction included at or below the given finalized block.
+func (c *finalizedTxCache) add(hash common.Hash, tx *RPCTransaction, finalized uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Drop the transactions resolved while the chain was being rewound
+	if finalized != c.finalized {
+		return
+	}
+	c.txs.Add(hash, tx)
 }
 
 // GetBlockTransactionCountByNumber returns the number of transactions in the block with the given block number.
@@ -1332,6 +1704,14 @@ func (api *TransactionAPI) GetTransactionCount(ctx context.Context, address comm
 
 // GetTransactionByHash returns the transaction for the given hash
 func (api *TransactionAPI) GetTransactionByHash(ctx context.Context, hash common.Hash) (*RPCTransaction, error) {
+	// Serve the transactions of finalized blocks from the cache if possible
+	var finalized *types.Header
+	if header, err := api.b.HeaderByNumber(ctx, rpc.FinalizedBlockNumber); err == nil && header != nil {
+		finalized = header
+		if tx, ok := api.txCache.get(hash, finalized.Number.Uint64()); ok {
+			return tx, nil
+		}
+	}
 	// Try to return an already finalized transaction
 	found, tx, blockHash, blockNumber, index := api.b.GetTransaction(hash)
 	if !found {
@@ -1350,7 +1730,11 @@ func (api *TransactionAPI) GetTransactionByHash(ctx context.Context, hash common
 	if err != nil {
 		return nil, err
 	}
-	return newRPCTransaction(tx, blockHash, blockNumber, header.Time, index, header.BaseFee, api.b.ChainConfig()), nil
+	result := newRPCTransaction(tx, blockHash, blockNumber, header.Time, index, header.BaseFee, api.b.ChainConfig())
+	if finalized != nil && blockNumber <= finalized.Number.Uint64() {
+		api.txCache.add(hash, result, finalized.Number.Uint64())
+	}
+	return result, nil
 }
 
 // GetRawTransactionByHash returns the bytes of the transaction for the given hash.
@@ -1400,6 +1784,23 @@ func (api *TransactionAPI) GetTransactionReceipt(ctx context.Context, hash commo
 	return marshalReceipt(receipt, blockHash, blockNumber, signer, tx, int(index)), nil
 }
 
+// GetDecodedReceipt returns the transaction receipt for the given transaction
+// hash, its logs annotated with the name and the arguments of the emitted events
+// whose signature is known.
+func (api *TransactionAPI) GetDecodedReceipt(ctx context.Context, hash common.Hash) (map[string]interface{}, error) {
+	fields, err := api.GetTransactionReceipt(ctx, hash)
+	if fields == nil || err != nil {
+		return fields, err
+	}
+	logs := fields["logs"].([]*types.Log)
+	decoded := make([]*RPCDecodedLog, len(logs))
+	for i, log := range logs {
+		decoded[i] = newRPCDecodedLog(log)
+	}
+	fields["logs"] = decoded
+	return fields, nil
+}
+
 // marshalReceipt marshals a transaction receipt into a JSON object.
 func marshalReceipt(receipt *types.Receipt, blockHash common.Hash, blockNumber uint64, signer types.Signer, tx *types.Transaction, txIndex int) map[string]interface{} {
 	from, _ := types.Sender(signer, tx)
@@ -1543,6 +1944,9 @@ func (api *TransactionAPI) FillTransaction(ctx context.Context, args Transaction
 // SendRawTransaction will add the signed transaction to the transaction pool.
 // The sender is responsible for signing the transaction and using the correct nonce.
 func (api *TransactionAPI) SendRawTransaction(ctx context.Context, input hexutil.Bytes) (common.Hash, error) {
+	if err := validateRawTransaction(input); err != nil {
+		return common.Hash{}, err
+	}
 	tx := new(types.Transaction)
 	if err := tx.UnmarshalBinary(input); err != nil {
 		return common.Hash{}, err
@@ -1550,6 +1954,36 @@ func (api *TransactionAPI) SendRawTransaction(ctx context.Context, input hexutil
 	return SubmitTransaction(ctx, api.b, tx)
 }
 
+// validateRawTransaction runs the cheap checks on an encoded transaction ahead
+// of decoding it, rejecting the inputs which can't be valid transactions. Blob
+// transactions carry their sidecar and are not bound by MaxTransactionSize.
+func validateRawTransaction(input []byte) error {
+	if len(input) == 0 {
+		return &invalidParamsError{message: "empty transaction"}
+	}
+	switch kind := input[0]; {
+	case kind > 0x7f:
+		// Legacy transactions are a single RLP list
+		if k, _, rest, err := rlp.Split(input); err != nil || k != rlp.List || len(rest) != 0 {
+			return &invalidParamsError{message: "invalid legacy transaction encoding"}
+		}
+	case kind == types.LegacyTxType:
+		// Legacy transactions are not enveloped, a zero type prefix is invalid
+		return &invalidTxError{Message: types.ErrInvalidTxType.Error() + ": legacy transactions have no type prefix", Code: errCodeInvalidParams}
+	case kind == types.AccessListTxType, kind == types.DynamicFeeTxType, kind == types.SetCodeTxType:
+	case kind == types.BlobTxType:
+		return nil
+	case kind == types.OptimismDepositTxType:
+		return &invalidTxError{Message: "deposit transactions can't be submitted: " + types.ErrTxTypeNotSupported.Error(), Code: errCodeInvalidParams}
+	default:
+		return &invalidTxError{Message: types.ErrTxTypeNotSupported.Error(), Code: errCodeInvalidParams}
+	}
+	if len(input) > params.MaxTransactionSize {
+		return &invalidTxError{Message: fmt.Sprintf("oversized transaction: size %d, limit %d", len(input), params.MaxTransactionSize), Code: errCodeInvalidParams}
+	}
+	return nil
+}
+
 // Sign calculates an ECDSA signature for:
 // keccak256("\x19Ethereum Signed Message:\n" + len(message) + message).
 //
@@ -1830,19 +2264,132 @@ func (api *DebugAPI) ChaindbCompact() error {
 	return nil
 }
 
-// SetHead rewinds the head of the blockchain to a previous block.
-func (api *DebugAPI) SetHead(number hexutil.Uint64) error {
+// SetHead rewinds the head of the blockchain to a previous block. The target
+// block given by hash must be canonical.
+func (api *DebugAPI) SetHead(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) error {
 	header := api.b.CurrentHeader()
 	if header == nil {
 		return errors.New("current header is not available")
 	}
-	if header.Number.Uint64() <= uint64(number) {
+	var number uint64
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		target, err := api.b.HeaderByHash(ctx, hash)
+		if err != nil {
+			return err
+		}
+		if target == nil {
+			return fmt.Errorf("block %#x not found", hash)
+		}
+		canonical, err := api.b.HeaderByNumber(ctx, rpc.BlockNumber(target.Number.Int64()))
+		if err != nil {
+			return err
+		}
+		if canonical == nil || canonical.Hash() != hash {
+			return fmt.Errorf("block %#x is not canonical", hash)
+		}
+		number = target.Number.Uint64()
+	} else {
+		blockNr, _ := blockNrOrHash.Number()
+		if blockNr < 0 {
+			return fmt.Errorf("invalid block number %v", blockNr)
+		}
+		number = uint64(blockNr)
+	}
+	if header.Number.Uint64() <= number {
 		return errors.New("not allowed to rewind to a future block")
 	}
-	api.b.SetHead(uint64(number))
+	log.Warn("Rewinding the chain, this is a dangerous operation", "number", number, "head", header.Number)
+	api.b.SetHead(number)
 	return nil
 }
 
+// ProofVerifyRequest is the Merkle proof of an account, and optionally of one of
+// its storage slots, as returned by eth_getProof.
+type ProofVerifyRequest struct {
+	StateRoot    common.Hash     `json:"stateRoot"`
+	Address      common.Address  `json:"address"`
+	StorageKey   *common.Hash    `json:"storageKey"` // Only the account is proven if nil
+	AccountProof []hexutil.Bytes `json:"accountProof"`
+	StorageProof []hexutil.Bytes `json:"storageProof"`
+}
+
+// verify checks the account proof against the state root and, if a storage key
+// is given, the storage proof against the storage root of the proven account.
+func (req *ProofVerifyRequest) verify() bool {
+	value, err := trie.VerifyProof(req.StateRoot, crypto.Keccak256(req.Address.Bytes()), newProofDB(req.AccountProof))
+	if err != nil {
+		return false
+	}
+	if req.StorageKey == nil {
+		return true
+	}
+	root := types.EmptyRootHash
+	if value != nil {
+		var account types.StateAccount
+		if err := rlp.DecodeBytes(value, &account); err != nil {
+			return false
+		}
+		root = account.Root
+	}
+	// The storage of empty accounts is proven by an empty proof
+	if root == types.EmptyRootHash {
+		return len(req.StorageProof) == 0
+	}
+	_, err = trie.VerifyProof(root, crypto.Keccak256(req.StorageKey.Bytes()), newProofDB(req.StorageProof))
+	return err == nil
+}
+
+// newProofDB indexes the nodes of a proof by their hashes.
+func newProofDB(proof []hexutil.Bytes) ethdb.KeyValueReader {
+	db := memorydb.New()
+	for _, node := range proof {
+		db.Put(crypto.Keccak256(node), node)
+	}
+	return db
+}
+
+// VerifyProofBatch verifies the given account and storage proofs in parallel,
+// returning whether each of them is valid, in the order of the requests. If
+// stopOnFirst is set, the verification stops at the first invalid proof and the
+// results up to and including it are returned.
+func (api *DebugAPI) VerifyProofBatch(requests []ProofVerifyRequest, stopOnFirst *bool) ([]bool, error) {
+	var (
+		results = make([]bool, len(requests))
+		next    atomic.Int64 // index of the next request to verify
+		failed  atomic.Int64 // lowest index of the invalid proofs
+		workers errgroup.Group
+	)
+	failed.Store(int64(len(requests)))
+	stop := stopOnFirst != nil && *stopOnFirst
+
+	for i := 0; i < min(runtime.NumCPU(), len(requests)); i++ {
+		workers.Go(func() error {
+			for {
+				// Requests are picked in order, so all the ones before the first
+				// invalid proof are verified even when stopping on it.
+				index := next.Add(1) - 1
+				if index >= int64(len(requests)) || (stop && index > failed.Load()) {
+					return nil
+				}
+				if results[index] = requests[index].verify(); !results[index] {
+					for {
+						lowest := failed.Load()
+						if index >= lowest || failed.CompareAndSwap(lowest, index) {
+							break
+						}
+					}
+				}
+			}
+		})
+	}
+	workers.Wait()
+
+	if stop && failed.Load() < int64(len(requests)) {
+		return results[:failed.Load()+1], nil
+	}
+	return results, nil
+}
+
 // NetAPI offers network related RPC methods
 type NetAPI struct {
 	net            *p2p.Server
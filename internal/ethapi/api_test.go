@@ -51,12 +51,14 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/program"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/internal/blocktest"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
@@ -415,7 +417,7 @@ func allBlobTxs(addr common.Address, config *params.ChainConfig) []txData {
 	}
 }
 
-func newTestAccountManager(t *testing.T) (*accounts.Manager, accounts.Account) {
+func newTestAccountManager(t testing.TB) (*accounts.Manager, accounts.Account) {
 	var (
 		dir        = t.TempDir()
 		am         = accounts.NewManager(nil)
@@ -439,9 +441,12 @@ type testBackend struct {
 	pending *types.Block
 	accman  *accounts.Manager
 	acc     accounts.Account
+
+	sequencer   bool
+	poolContent map[common.Address][]*types.Transaction // pending transactions of the pool
 }
 
-func newTestBackend(t *testing.T, n int, gspec *core.Genesis, engine consensus.Engine, generator func(i int, b *core.BlockGen)) *testBackend {
+func newTestBackend(t testing.TB, n int, gspec *core.Genesis, engine consensus.Engine, generator func(i int, b *core.BlockGen)) *testBackend {
 	options := core.DefaultConfig().WithArchive(true)
 	options.TxLookupLimit = 0 // index all txs
 
@@ -484,7 +489,8 @@ func (b testBackend) RPCGasCap() uint64                        { return 10000000
 func (b testBackend) RPCEVMTimeout() time.Duration             { return time.Second }
 func (b testBackend) RPCTxFeeCap() float64                     { return 0 }
 func (b testBackend) UnprotectedAllowed() bool                 { return false }
-func (b testBackend) SetHead(number uint64)                    {}
+func (b testBackend) IsSequencer() bool                        { return b.sequencer }
+func (b testBackend) SetHead(number uint64)                    { b.chain.SetHead(number) }
 func (b testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
 	if number == rpc.LatestBlockNumber {
 		return b.chain.CurrentBlock(), nil
@@ -492,6 +498,9 @@ func (b testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber)
 	if number == rpc.PendingBlockNumber && b.pending != nil {
 		return b.pending.Header(), nil
 	}
+	if number == rpc.FinalizedBlockNumber {
+		return b.chain.CurrentFinalBlock(), nil
+	}
 	return b.chain.GetHeaderByNumber(uint64(number)), nil
 }
 func (b testBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
@@ -600,10 +609,10 @@ func (b testBackend) GetPoolNonce(ctx context.Context, addr common.Address) (uin
 }
 func (b testBackend) Stats() (pending int, queued int) { panic("implement me") }
 func (b testBackend) TxPoolContent() (map[common.Address][]*types.Transaction, map[common.Address][]*types.Transaction) {
-	panic("implement me")
+	return b.poolContent, nil
 }
 func (b testBackend) TxPoolContentFrom(addr common.Address) ([]*types.Transaction, []*types.Transaction) {
-	panic("implement me")
+	return b.poolContent[addr], nil
 }
 func (b testBackend) SubscribeNewTxsEvent(events chan<- core.NewTxsEvent) event.Subscription {
 	panic("implement me")
@@ -920,6 +929,55 @@ func TestEstimateGas(t *testing.T) {
 	}
 }
 
+// TestEstimateGasDeployment tests that the gas estimated for a contract deployment
+// accounts for the initcode and the storage initialized by the constructor, by
+// deploying the contract with the estimated gas.
+func TestEstimateGasDeployment(t *testing.T) {
+	t.Parallel()
+
+	// The constructor writes the slots 1 to 100, followed by an unused
+	// constructor argument.
+	//
+	//   PUSH1 100 JUMPDEST DUP1 DUP1 SSTORE PUSH1 1 SWAP1 SUB DUP1 PUSH1 2 JUMPI POP STOP
+	var (
+		accounts = newAccounts(1)
+		genesis  = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc:  types.GenesisAlloc{accounts[0].addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		engine   = beacon.New(ethash.NewFaker())
+		initcode = append(common.FromHex("0x60645b80805560019003806002575000"), common.LeftPadBytes([]byte{0x2a}, 32)...)
+	)
+	api := NewBlockChainAPI(newTestBackend(t, 0, genesis, engine, nil))
+
+	input := hexutil.Bytes(initcode)
+	estimate, err := api.EstimateGas(context.Background(), TransactionArgs{From: &accounts[0].addr, Input: &input}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to estimate gas: %v", err)
+	}
+	if min := uint64(100 * params.SstoreSetGasEIP2200); uint64(estimate) < min {
+		t.Fatalf("estimate too low: have %d, want at least %d", estimate, min)
+	}
+	// Deploy the contract with the estimated gas
+	signer := types.LatestSigner(params.MergedTestChainConfig)
+	_, _, receipts := core.GenerateChainWithGenesis(genesis, engine, 1, func(i int, b *core.BlockGen) {
+		b.SetPoS()
+		b.AddTx(types.MustSignNewTx(accounts[0].key, signer, &types.DynamicFeeTx{
+			ChainID:   params.MergedTestChainConfig.ChainID,
+			Gas:       uint64(estimate),
+			GasFeeCap: b.BaseFee(),
+			Data:      initcode,
+		}))
+	})
+	receipt := receipts[0][0]
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("deployment failed with estimated gas %d", estimate)
+	}
+	if receipt.ContractAddress != crypto.CreateAddress(accounts[0].addr, 0) {
+		t.Errorf("contract address mismatch: have %v, want %v", receipt.ContractAddress, crypto.CreateAddress(accounts[0].addr, 0))
+	}
+}
+
 func TestCall(t *testing.T) {
 	t.Parallel()
 
@@ -954,6 +1012,7 @@ func TestCall(t *testing.T) {
 		b.SetPoS()
 	}))
 	randomAccounts := newAccounts(3)
+	blobTxType := uint64(types.BlobTxType)
 	var testSuite = []struct {
 		name           string
 		blockNumber    rpc.BlockNumber
@@ -1170,6 +1229,58 @@ func TestCall(t *testing.T) {
 			},
 			want: "0x0122000000000000000000000000000000000000000000000000000000000000",
 		},
+		// BLOBBASEFEE opcode in a blob transaction
+		{
+			name:        "blobbasefee-opcode",
+			blockNumber: rpc.LatestBlockNumber,
+			call: TransactionArgs{
+				From:       &accounts[1].addr,
+				To:         &randomAccounts[2].addr,
+				Type:       (*hexutil.Uint64)(&blobTxType),
+				BlobHashes: []common.Hash{{0x01, 0x22}},
+				BlobFeeCap: (*hexutil.Big)(big.NewInt(1)),
+			},
+			overrides: override.StateOverride{
+				randomAccounts[2].addr: {
+					Code: hex2Bytes("4a60005260206000f3"),
+				},
+			},
+			want: "0x0000000000000000000000000000000000000000000000000000000000000001",
+		},
+		{
+			name:        "blobbasefee-opcode-override",
+			blockNumber: rpc.LatestBlockNumber,
+			call: TransactionArgs{
+				From:       &accounts[1].addr,
+				To:         &randomAccounts[2].addr,
+				Type:       (*hexutil.Uint64)(&blobTxType),
+				BlobHashes: []common.Hash{{0x01, 0x22}},
+				BlobFeeCap: (*hexutil.Big)(big.NewInt(10)),
+			},
+			overrides: override.StateOverride{
+				randomAccounts[2].addr: {
+					Code: hex2Bytes("4a60005260206000f3"),
+				},
+			},
+			blockOverrides: override.BlockOverrides{
+				BlobBaseFee: (*hexutil.Big)(big.NewInt(7)),
+			},
+			want: "0x0000000000000000000000000000000000000000000000000000000000000007",
+		},
+		{
+			name:        "blobbasefee-opcode-low-feecap",
+			blockNumber: rpc.LatestBlockNumber,
+			call: TransactionArgs{
+				From:       &accounts[1].addr,
+				To:         &randomAccounts[2].addr,
+				BlobHashes: []common.Hash{{0x01, 0x22}},
+				BlobFeeCap: (*hexutil.Big)(big.NewInt(1)),
+			},
+			blockOverrides: override.BlockOverrides{
+				BlobBaseFee: (*hexutil.Big)(big.NewInt(7)),
+			},
+			expectErr: core.ErrBlobFeeCapTooLow,
+		},
 		// Clear the entire storage set
 		{
 			blockNumber: rpc.LatestBlockNumber,
@@ -1196,6 +1307,45 @@ func TestCall(t *testing.T) {
 			},
 			want: "0x0000000000000000000000000000000000000000000000000000000000000000",
 		},
+		// Deposit minting ether to an empty sender before calling a contract
+		// which returns the balance of its caller.
+		{
+			name:        "deposit-mint",
+			blockNumber: rpc.LatestBlockNumber,
+			call: TransactionArgs{
+				Type: newUint64(types.OptimismDepositTxType),
+				From: &randomAccounts[0].addr,
+				To:   &randomAccounts[2].addr,
+				Mint: (*hexutil.Big)(big.NewInt(params.Ether)),
+			},
+			overrides: override.StateOverride{
+				randomAccounts[2].addr: {
+					Code: hex2Bytes("333160005260206000f3"), // return balance(caller)
+				},
+			},
+			want: "0x0000000000000000000000000000000000000000000000000de0b6b3a7640000",
+		},
+		{
+			name:        "deposit-with-gas-price",
+			blockNumber: rpc.LatestBlockNumber,
+			call: TransactionArgs{
+				Type:     newUint64(types.OptimismDepositTxType),
+				From:     &randomAccounts[0].addr,
+				To:       &randomAccounts[2].addr,
+				GasPrice: (*hexutil.Big)(big.NewInt(1)),
+			},
+			expectErr: errors.New("gas price fields specified for deposit transaction"),
+		},
+		{
+			name:        "mint-without-deposit",
+			blockNumber: rpc.LatestBlockNumber,
+			call: TransactionArgs{
+				From: &randomAccounts[0].addr,
+				To:   &randomAccounts[2].addr,
+				Mint: (*hexutil.Big)(big.NewInt(params.Ether)),
+			},
+			expectErr: errors.New("mint specified for non-deposit transaction"),
+		},
 		{
 			name:        "unsupported block override beaconRoot",
 			blockNumber: rpc.LatestBlockNumber,
@@ -1240,6 +1390,241 @@ func TestCall(t *testing.T) {
 	}
 }
 
+func TestCallL1Fee(t *testing.T) {
+	t.Parallel()
+
+	var (
+		config   = *params.MergedTestChainConfig
+		accounts = newAccounts(1)
+		oracle   = common.HexToAddress("0x000000000000000000000000000000000000fee0")
+		caller   = common.HexToAddress("0x000000000000000000000000000000000000ca11")
+	)
+	config.Optimism = &params.OptimismConfig{}
+	config.L1FeeOracleAddress = &oracle
+
+	genesis := &core.Genesis{
+		Config: &config,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			oracle: {
+				Code: []byte{byte(vm.STOP)},
+				Storage: map[common.Hash]common.Hash{
+					types.L1BaseFeeSlot: common.BigToHash(big.NewInt(params.GWei)),
+					types.OverheadSlot:  common.BigToHash(big.NewInt(188)),
+					types.ScalarSlot:    common.BigToHash(big.NewInt(1_500_000)),
+				},
+			},
+			caller: {Code: common.FromHex("333160005260206000f3")}, // return balance(caller)
+		},
+	}
+	api := NewBlockChainAPI(newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+	}))
+	var (
+		gas      = uint64(100_000)
+		gasPrice = big.NewInt(params.GWei)
+		latest   = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	res, err := api.Call(context.Background(), TransactionArgs{
+		From:     &accounts[0].addr,
+		To:       &caller,
+		Gas:      (*hexutil.Uint64)(&gas),
+		GasPrice: (*hexutil.Big)(gasPrice),
+		Input:    hex2Bytes("0000ff01"),
+	}, &latest, nil, nil)
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	// (2 zero bytes * 4 + 2 non-zero bytes * 16 + 188 overhead) * 1 gwei * 1.5
+	l1Fee := big.NewInt(228 * params.GWei * 3 / 2)
+
+	want := new(big.Int).Sub(big.NewInt(params.Ether), new(big.Int).Mul(new(big.Int).SetUint64(gas), gasPrice))
+	want.Sub(want, l1Fee)
+	if have := new(big.Int).SetBytes(res); have.Cmp(want) != 0 {
+		t.Fatalf("caller balance mismatch: have %v, want %v (L1 fee %v)", have, want, l1Fee)
+	}
+	// Gasless calls are not charged for L1 data
+	res, err = api.Call(context.Background(), TransactionArgs{
+		From:  &accounts[0].addr,
+		To:    &caller,
+		Input: hex2Bytes("0000ff01"),
+	}, &latest, nil, nil)
+	if err != nil {
+		t.Fatalf("gasless call failed: %v", err)
+	}
+	if have := new(big.Int).SetBytes(res); have.Cmp(big.NewInt(params.Ether)) != 0 {
+		t.Fatalf("gasless caller balance mismatch: have %v, want %v", have, params.Ether)
+	}
+}
+
+// TestRPCGetDepositTransactionByBlockAndIndex tests that deposit transactions
+// looked up by block and index carry their sender and deposit specific fields.
+func TestRPCGetDepositTransactionByBlockAndIndex(t *testing.T) {
+	t.Parallel()
+
+	var (
+		config    = *params.MergedTestChainConfig
+		sender    = common.HexToAddress("0x5e1de7")
+		recipient = common.HexToAddress("0xdead")
+		source    = common.HexToHash("0x50c3ce")
+		mint      = big.NewInt(params.GWei)
+	)
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+
+	genesis := &core.Genesis{Config: &config, Alloc: types.GenesisAlloc{}}
+	backend := newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+		b.AddTx(types.NewTx(&types.OptimismDepositTx{
+			SourceHash: source,
+			From:       sender,
+			To:         &recipient,
+			Mint:       mint,
+			Value:      new(big.Int),
+			Gas:        100_000,
+		}))
+	})
+	var (
+		api   = NewTransactionAPI(backend, new(AddrLocker))
+		block = backend.chain.GetBlockByNumber(1)
+	)
+	byNumber, err := api.GetTransactionByBlockNumberAndIndex(context.Background(), 1, 0)
+	if err != nil {
+		t.Fatalf("failed to get deposit by number: %v", err)
+	}
+	byHash, err := api.GetTransactionByBlockHashAndIndex(context.Background(), block.Hash(), 0)
+	if err != nil {
+		t.Fatalf("failed to get deposit by hash: %v", err)
+	}
+	for name, tx := range map[string]*RPCTransaction{"number": byNumber, "hash": byHash} {
+		if tx == nil {
+			t.Fatalf("by %s: deposit not found", name)
+		}
+		if tx.Type != types.OptimismDepositTxType {
+			t.Errorf("by %s: type mismatch: have %d, want %d", name, tx.Type, types.OptimismDepositTxType)
+		}
+		if tx.From != sender {
+			t.Errorf("by %s: sender mismatch: have %v, want %v", name, tx.From, sender)
+		}
+		if tx.SourceHash == nil || *tx.SourceHash != source {
+			t.Errorf("by %s: source hash mismatch: have %v, want %v", name, tx.SourceHash, source)
+		}
+		if tx.Mint == nil || tx.Mint.ToInt().Cmp(mint) != 0 {
+			t.Errorf("by %s: mint mismatch: have %v, want %v", name, tx.Mint, mint)
+		}
+		if tx.IsSystemTx == nil || *tx.IsSystemTx {
+			t.Errorf("by %s: system flag mismatch: have %v, want false", name, tx.IsSystemTx)
+		}
+	}
+	// The deposit fields must also survive the JSON encoding
+	enc, err := json.Marshal(byNumber)
+	if err != nil {
+		t.Fatalf("failed to encode deposit: %v", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(enc, &fields); err != nil {
+		t.Fatalf("failed to decode deposit: %v", err)
+	}
+	for _, field := range []string{"sourceHash", "mint", "isSystemTx", "from"} {
+		if _, ok := fields[field]; !ok {
+			t.Errorf("missing %q in encoded deposit: %s", field, enc)
+		}
+	}
+}
+
+// TestTxPoolContentFunctionName tests that the pool content is annotated with
+// the names of the called functions.
+func TestTxPoolContentFunctionName(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		token    = common.HexToAddress("0x000000000000000000000000000000000000d0c5")
+		genesis  = &core.Genesis{Config: params.MergedTestChainConfig, Alloc: types.GenesisAlloc{}}
+		backend  = newTestBackend(t, 0, genesis, beacon.New(ethash.NewFaker()), nil)
+		signer   = types.LatestSigner(params.MergedTestChainConfig)
+	)
+	calls := [][]byte{
+		common.FromHex("0xa9059cbb000000000000000000000000000000000000000000000000000000000000dead0000000000000000000000000000000000000000000000000000000000000001"), // transfer(address,uint256)
+		common.FromHex("0x7e1f7e1f"), // unknown selector
+	}
+	for nonce, data := range calls {
+		tx := types.MustSignNewTx(accounts[0].key, signer, &types.LegacyTx{
+			Nonce:    uint64(nonce),
+			To:       &token,
+			Gas:      100_000,
+			GasPrice: big.NewInt(params.GWei),
+			Data:     data,
+		})
+		backend.poolContent = map[common.Address][]*types.Transaction{
+			accounts[0].addr: append(backend.poolContent[accounts[0].addr], tx),
+		}
+	}
+	content := NewTxPoolAPI(backend).Content()
+	txs := content["pending"][accounts[0].addr.Hex()]
+	if len(txs) != 2 {
+		t.Fatalf("pending transaction count mismatch: have %d, want 2", len(txs))
+	}
+	if name := txs["0"].FunctionName; name == nil || *name != "transfer" {
+		t.Errorf("function name mismatch: have %v, want transfer", name)
+	}
+	if name := txs["1"].FunctionName; name != nil {
+		t.Errorf("function name of unknown selector: have %v, want nil", *name)
+	}
+	// The function name must be encoded along the transaction fields
+	enc, err := json.Marshal(txs["0"])
+	if err != nil {
+		t.Fatalf("failed to encode transaction: %v", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(enc, &fields); err != nil {
+		t.Fatalf("failed to decode transaction: %v", err)
+	}
+	if fields["functionName"] != "transfer" || fields["hash"] != txs["0"].Hash.Hex() {
+		t.Errorf("encoded transaction mismatch: %s", enc)
+	}
+	// The annotation also applies to the content of a single account
+	from := NewTxPoolAPI(backend).ContentFrom(accounts[0].addr)
+	if name := from["pending"]["0"].FunctionName; name == nil || *name != "transfer" {
+		t.Errorf("function name mismatch in account content: have %v, want transfer", name)
+	}
+}
+
+// TestMiningStatus tests the mining status reported for L1 and rollup nodes.
+func TestMiningStatus(t *testing.T) {
+	t.Parallel()
+
+	rollup := *params.MergedTestChainConfig
+	rollup.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+
+	tests := []struct {
+		name      string
+		config    *params.ChainConfig
+		sequencer bool
+		mining    bool
+		coinbase  common.Address
+	}{
+		{name: "l1", config: params.MergedTestChainConfig},
+		{name: "rollup", config: &rollup, coinbase: params.SequencerFeeVaultAddress},
+		{name: "sequencer", config: &rollup, sequencer: true, mining: true, coinbase: params.SequencerFeeVaultAddress},
+	}
+	for _, tt := range tests {
+		genesis := &core.Genesis{Config: tt.config, Alloc: types.GenesisAlloc{}}
+		backend := newTestBackend(t, 0, genesis, beacon.New(ethash.NewFaker()), nil)
+		backend.sequencer = tt.sequencer
+
+		api := NewEthereumAPI(backend)
+		if have := api.Mining(); have != tt.mining {
+			t.Errorf("%s: mining mismatch: have %v, want %v", tt.name, have, tt.mining)
+		}
+		if have := api.Hashrate(); have != 0 {
+			t.Errorf("%s: hashrate mismatch: have %v, want 0", tt.name, have)
+		}
+		if have := api.Coinbase(); have != tt.coinbase {
+			t.Errorf("%s: coinbase mismatch: have %v, want %v", tt.name, have, tt.coinbase)
+		}
+	}
+}
+
 func TestSimulateV1(t *testing.T) {
 	t.Parallel()
 	// Initialize test accounts
@@ -3735,3 +4120,956 @@ func TestCreateAccessListWithStateOverrides(t *testing.T) {
 	}}
 	require.Equal(t, expected, result.Accesslist)
 }
+
+// newProofBackend creates a backend with a contract holding the given number of
+// storage slots, for testing and benchmarking proof retrieval.
+func newProofBackend(t testing.TB, slots int) (*testBackend, common.Address) {
+	contract := common.HexToAddress("0x000000000000000000000000000000000000c0de")
+	storage := make(map[common.Hash]common.Hash, slots)
+	for i := 0; i < slots; i++ {
+		storage[common.BigToHash(big.NewInt(int64(i)))] = common.BigToHash(big.NewInt(int64(i + 1)))
+	}
+	genesis := &core.Genesis{
+		Config: params.MergedTestChainConfig,
+		Alloc: types.GenesisAlloc{
+			contract: {Balance: big.NewInt(params.Ether), Code: []byte{byte(vm.STOP)}, Storage: storage},
+		},
+	}
+	backend := newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+	})
+	return backend, contract
+}
+
+// TestGetAccountProof tests that account proofs match the account part of the
+// full proofs, without any storage proofs.
+func TestGetAccountProof(t *testing.T) {
+	t.Parallel()
+
+	backend, contract := newProofBackend(t, 16)
+	var (
+		api    = NewBlockChainAPI(backend)
+		latest = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	have, err := api.GetAccountProof(context.Background(), contract, latest)
+	if err != nil {
+		t.Fatalf("failed to get account proof: %v", err)
+	}
+	want, err := api.GetProof(context.Background(), contract, []string{"0x0"}, latest)
+	if err != nil {
+		t.Fatalf("failed to get proof: %v", err)
+	}
+	if len(have.StorageProof) != 0 {
+		t.Errorf("account proof has storage proofs: %v", have.StorageProof)
+	}
+	want.StorageProof = []StorageResult{}
+	require.Equal(t, want, have)
+}
+
+// TestVerifyProofBatch tests that a batch of proofs is verified in order, and that
+// the verification can stop at the first invalid proof.
+func TestVerifyProofBatch(t *testing.T) {
+	t.Parallel()
+
+	backend, contract := newProofBackend(t, 16)
+	var (
+		api    = NewBlockChainAPI(backend)
+		latest = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		root   = backend.chain.CurrentBlock().Root
+	)
+	toBytes := func(proof []string) []hexutil.Bytes {
+		nodes := make([]hexutil.Bytes, len(proof))
+		for i, node := range proof {
+			nodes[i] = hexutil.MustDecode(node)
+		}
+		return nodes
+	}
+	var requests []ProofVerifyRequest
+	for i := 0; i < 11; i++ {
+		key := common.BigToHash(big.NewInt(int64(i)))
+		result, err := api.GetProof(context.Background(), contract, []string{key.Hex()}, latest)
+		if err != nil {
+			t.Fatalf("failed to get proof: %v", err)
+		}
+		requests = append(requests, ProofVerifyRequest{
+			StateRoot:    root,
+			Address:      contract,
+			StorageKey:   &key,
+			AccountProof: toBytes(result.AccountProof),
+			StorageProof: toBytes(result.StorageProof[0].Proof),
+		})
+	}
+	// Prove a storage slot against the proof of another one
+	requests[7].StorageProof = requests[3].StorageProof
+
+	results, err := NewDebugAPI(backend).VerifyProofBatch(requests, nil)
+	if err != nil {
+		t.Fatalf("failed to verify proofs: %v", err)
+	}
+	want := slices.Repeat([]bool{true}, 11)
+	want[7] = false
+	if !slices.Equal(results, want) {
+		t.Fatalf("results mismatch: have %v, want %v", results, want)
+	}
+	stop := true
+	results, err = NewDebugAPI(backend).VerifyProofBatch(requests, &stop)
+	if err != nil {
+		t.Fatalf("failed to verify proofs: %v", err)
+	}
+	if !slices.Equal(results, want[:8]) {
+		t.Fatalf("results mismatch when stopping on the first failure: have %v, want %v", results, want[:8])
+	}
+}
+
+func BenchmarkGetProof(b *testing.B) {
+	backend, contract := newProofBackend(b, 10_000)
+	var (
+		api    = NewBlockChainAPI(backend)
+		latest = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	b.Run("account", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := api.GetAccountProof(context.Background(), contract, latest); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("empty-keys", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := api.GetProof(context.Background(), contract, []string{}, latest); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("one-key", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := api.GetProof(context.Background(), contract, []string{"0x0"}, latest); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+// TestPersonalEcRecover tests that the signer of a personal message is recovered,
+// and that malformed signatures are rejected.
+func TestPersonalEcRecover(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr   = crypto.PubkeyToAddress(key.PublicKey)
+		msg    = []byte("Hello, world!")
+		api    = NewPersonalAPI()
+	)
+	sig, err := crypto.Sign(accounts.TextHash(msg), key)
+	if err != nil {
+		t.Fatalf("failed to sign message: %v", err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+
+	have, err := api.EcRecover(msg, sig)
+	if err != nil {
+		t.Fatalf("failed to recover signer: %v", err)
+	}
+	if have != addr {
+		t.Errorf("signer mismatch: have %v, want %v", have, addr)
+	}
+	if sig[crypto.RecoveryIDOffset] < 27 {
+		t.Errorf("signature modified: v %d", sig[crypto.RecoveryIDOffset])
+	}
+	// A different message recovers a different signer
+	if have, err := api.EcRecover([]byte("Hello, mars!"), sig); err == nil && have == addr {
+		t.Error("signer recovered from a different message")
+	}
+	// Malformed signatures are rejected
+	invalidV := common.CopyBytes(sig)
+	invalidV[crypto.RecoveryIDOffset] = 1
+	for i, sig := range []hexutil.Bytes{sig[:crypto.SignatureLength-1], append(common.CopyBytes(sig), 0), invalidV} {
+		if _, err := api.EcRecover(msg, sig); err == nil {
+			t.Errorf("malformed signature %d accepted", i)
+		}
+	}
+}
+
+// TestGetContractCreationTransaction tests that the transaction deploying a
+// contract is located.
+func TestGetContractCreationTransaction(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		genesisC = common.HexToAddress("0x000000000000000000000000000000000000c0de")
+		genesis  = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				genesisC:         {Code: []byte{0x00}},
+			},
+		}
+		signer   = types.LatestSigner(params.MergedTestChainConfig)
+		deployed common.Address
+		deployTx common.Hash
+	)
+	backend := newTestBackend(t, 6, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+		if i != 2 {
+			return
+		}
+		// Deploys a contract with a single STOP instruction as code
+		tx := types.MustSignNewTx(accounts[0].key, signer, &types.LegacyTx{
+			Nonce:    b.TxNonce(accounts[0].addr),
+			Gas:      100_000,
+			GasPrice: b.BaseFee(),
+			Data:     common.FromHex("0x6001600c60003960016000f300"),
+		})
+		b.AddTx(tx)
+		deployed = crypto.CreateAddress(accounts[0].addr, tx.Nonce())
+		deployTx = tx.Hash()
+	})
+	api := NewBlockChainAPI(backend)
+
+	info, err := api.GetContractCreationTransaction(context.Background(), deployed)
+	if err != nil {
+		t.Fatalf("failed to locate contract creation: %v", err)
+	}
+	if info == nil {
+		t.Fatal("contract creation not found")
+	}
+	if info.TxHash != deployTx {
+		t.Errorf("transaction hash mismatch: have %v, want %v", info.TxHash, deployTx)
+	}
+	if info.BlockNumber != 3 {
+		t.Errorf("block number mismatch: have %d, want 3", info.BlockNumber)
+	}
+	if info.Deployer != accounts[0].addr {
+		t.Errorf("deployer mismatch: have %v, want %v", info.Deployer, accounts[0].addr)
+	}
+	// Accounts without code and genesis contracts have no creation transaction
+	for _, addr := range []common.Address{accounts[0].addr, genesisC} {
+		info, err := api.GetContractCreationTransaction(context.Background(), addr)
+		if info != nil || err != nil {
+			t.Errorf("%v: unexpected contract creation: have %v, %v", addr, info, err)
+		}
+	}
+}
+
+// TestAccountsByBalance tests that the managed accounts are sorted by their
+// balance at the requested block.
+func TestAccountsByBalance(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ks      = keystore.NewKeyStore(t.TempDir(), 2, 1)
+		am      = accounts.NewManager(nil, ks)
+		genesis = &core.Genesis{Config: params.MergedTestChainConfig, Alloc: types.GenesisAlloc{}}
+		addrs   []common.Address
+	)
+	for _, balance := range []int64{3, 1, 5, 2, 4} {
+		acc, err := ks.NewAccount("")
+		if err != nil {
+			t.Fatalf("failed to create account: %v", err)
+		}
+		addrs = append(addrs, acc.Address)
+		genesis.Alloc[acc.Address] = types.Account{Balance: new(big.Int).Mul(big.NewInt(balance), big.NewInt(params.Ether))}
+	}
+	// Make the poorest account the richest one in the first block
+	backend := newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+		b.AddWithdrawal(&types.Withdrawal{Address: addrs[1], Amount: 10 * params.GWei})
+	})
+	backend.accman = am
+	api := NewBlockChainAPI(backend)
+
+	tests := []struct {
+		block rpc.BlockNumber
+		want  []common.Address
+	}{
+		{0, []common.Address{addrs[2], addrs[4], addrs[0], addrs[3], addrs[1]}},
+		{rpc.LatestBlockNumber, []common.Address{addrs[1], addrs[2], addrs[4], addrs[0], addrs[3]}},
+	}
+	for _, tt := range tests {
+		have, err := api.AccountsByBalance(context.Background(), rpc.BlockNumberOrHashWithNumber(tt.block))
+		if err != nil {
+			t.Fatalf("block %d: failed to get accounts: %v", tt.block, err)
+		}
+		if !slices.Equal(have, tt.want) {
+			t.Errorf("block %d: accounts mismatch: have %v, want %v", tt.block, have, tt.want)
+		}
+	}
+}
+
+// TestCallRevertFields tests that the revert data of calls is decoded into the
+// additional members of the error object.
+func TestCallRevertFields(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		reverter = common.HexToAddress("0x000000000000000000000000000000000000dead")
+		genesis  = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				// CALLDATASIZE PUSH1 0 PUSH1 0 CALLDATACOPY CALLDATASIZE PUSH1 0 REVERT
+				reverter: {Code: common.FromHex("0x366000600037366000fd")},
+			},
+		}
+	)
+	backend := newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+	})
+	var (
+		api       = NewBlockChainAPI(backend)
+		latest    = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		reason, _ = abi.Arguments{{Type: abi.Type{T: abi.StringTy}}}.Pack("custom message")
+		custom    = append(crypto.Keccak256([]byte("Unauthorized(address)"))[:4], common.LeftPadBytes(accounts[0].addr.Bytes(), 32)...)
+	)
+	tests := []struct {
+		name   string
+		revert []byte
+		want   map[string]interface{}
+	}{
+		{"reason", append(common.FromHex("0x08c379a0"), reason...), map[string]interface{}{"revertReason": "custom message"}},
+		{"custom", custom, map[string]interface{}{"customError": hexutil.Encode(custom)}},
+		{"empty", nil, nil},
+	}
+	for _, tt := range tests {
+		input := hexutil.Bytes(tt.revert)
+		_, err := api.Call(context.Background(), TransactionArgs{From: &accounts[0].addr, To: &reverter, Input: &input}, &latest, nil, nil)
+		if err == nil || !strings.HasPrefix(err.Error(), vm.ErrExecutionReverted.Error()) {
+			t.Fatalf("%s: error mismatch: have %v, want %v", tt.name, err, vm.ErrExecutionReverted)
+		}
+		if have := err.(rpc.DataError).ErrorData(); have != hexutil.Encode(tt.revert) {
+			t.Errorf("%s: error data mismatch: have %v, want %v", tt.name, have, hexutil.Encode(tt.revert))
+		}
+		if have := err.(rpc.FieldsError).ErrorFields(); !reflect.DeepEqual(have, tt.want) {
+			t.Errorf("%s: error fields mismatch: have %v, want %v", tt.name, have, tt.want)
+		}
+	}
+}
+
+// TestGetBlockReward tests the mining and uncle rewards of proof-of-work blocks,
+// and the fees credited to the coinbase.
+func TestGetBlockReward(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{accounts[0].addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer    = types.LatestSigner(params.TestChainConfig)
+		gasPrice  = big.NewInt(10 * params.GWei)
+		uncleBase = common.HexToAddress("0xc0ffee")
+	)
+	backend := newTestBackend(t, 3, genesis, ethash.NewFaker(), func(i int, b *core.BlockGen) {
+		if i == 2 {
+			uncle := b.PrevBlock(1).Header()
+			uncle.Extra = []byte("uncle")
+			uncle.Coinbase = uncleBase
+			b.AddUncle(uncle)
+
+			tx, _ := types.SignTx(types.NewTransaction(0, common.Address{0x01}, big.NewInt(1), params.TxGas, gasPrice, nil), signer, accounts[0].key)
+			b.AddTx(tx)
+		}
+	})
+	api := NewBlockChainAPI(backend)
+
+	info, err := api.GetBlockReward(context.Background(), rpc.BlockNumberOrHashWithNumber(3))
+	if err != nil {
+		t.Fatalf("failed to get block reward: %v", err)
+	}
+	// Constantinople rewards 2 ether per block, the uncle at distance 1 earns
+	// 7/8 of it and its inclusion 1/32 of it.
+	var (
+		ether = big.NewInt(params.Ether)
+		tip   = new(big.Int).Sub(gasPrice, backend.chain.GetBlockByNumber(3).BaseFee())
+		fees  = new(big.Int).Mul(tip, big.NewInt(int64(params.TxGas)))
+	)
+	if have, want := info.MiningReward.ToInt(), new(big.Int).Mul(ether, big.NewInt(2)); have.Cmp(want) != 0 {
+		t.Errorf("mining reward mismatch: have %v, want %v", have, want)
+	}
+	if have, want := info.UncleInclusionReward.ToInt(), new(big.Int).Div(ether, big.NewInt(16)); have.Cmp(want) != 0 {
+		t.Errorf("uncle inclusion reward mismatch: have %v, want %v", have, want)
+	}
+	if len(info.UncleRewards) != 1 {
+		t.Fatalf("uncle reward count mismatch: have %d, want 1", len(info.UncleRewards))
+	}
+	if have, want := info.UncleRewards[0].Reward.ToInt(), new(big.Int).Div(new(big.Int).Mul(ether, big.NewInt(7)), big.NewInt(4)); have.Cmp(want) != 0 {
+		t.Errorf("uncle reward mismatch: have %v, want %v", have, want)
+	}
+	if have := info.UncleRewards[0].Coinbase; have != uncleBase {
+		t.Errorf("uncle coinbase mismatch: have %v, want %v", have, uncleBase)
+	}
+	if have := info.TotalFees.ToInt(); have.Cmp(fees) != 0 {
+		t.Errorf("fees mismatch: have %v, want %v", have, fees)
+	}
+	// Blocks without uncles only pay the block reward
+	info, err = api.GetBlockReward(context.Background(), rpc.BlockNumberOrHashWithNumber(2))
+	if err != nil {
+		t.Fatalf("failed to get block reward: %v", err)
+	}
+	if len(info.UncleRewards) != 0 || info.UncleInclusionReward.ToInt().Sign() != 0 || info.TotalFees.ToInt().Sign() != 0 {
+		t.Errorf("unexpected rewards for block without uncles: %+v", info)
+	}
+}
+
+// TestGetBlockRewardPoS tests that proof-of-stake blocks pay no mining reward.
+func TestGetBlockRewardPoS(t *testing.T) {
+	t.Parallel()
+
+	genesis := &core.Genesis{Config: params.MergedTestChainConfig, Alloc: types.GenesisAlloc{}}
+	backend := newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+	})
+	info, err := NewBlockChainAPI(backend).GetBlockReward(context.Background(), rpc.BlockNumberOrHashWithNumber(1))
+	if err != nil {
+		t.Fatalf("failed to get block reward: %v", err)
+	}
+	if have := info.MiningReward.ToInt(); have.Sign() != 0 {
+		t.Errorf("mining reward mismatch: have %v, want 0", have)
+	}
+}
+
+// TestGetBlockRewardDeposit tests that the deposits of OP-Stack blocks don't
+// count towards the fees paid to the block producer.
+func TestGetBlockRewardDeposit(t *testing.T) {
+	t.Parallel()
+
+	var (
+		config   = *params.MergedTestChainConfig
+		accounts = newAccounts(1)
+		tip      = big.NewInt(2 * params.GWei)
+		tx       *types.Transaction
+	)
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+
+	genesis := &core.Genesis{Config: &config, Alloc: types.GenesisAlloc{accounts[0].addr: {Balance: big.NewInt(params.Ether)}}}
+	backend := newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+		b.AddTx(types.NewTx(&types.OptimismDepositTx{
+			SourceHash: common.HexToHash("0x50c3ce"),
+			From:       common.HexToAddress("0x5e1de7"),
+			To:         &common.Address{0xde, 0xad},
+			Mint:       big.NewInt(params.GWei),
+			Value:      new(big.Int),
+			Gas:        100_000,
+		}))
+		tx, _ = types.SignNewTx(accounts[0].key, types.LatestSigner(&config), &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			To:        &common.Address{0xde, 0xad},
+			Gas:       params.TxGas,
+			GasFeeCap: new(big.Int).Add(b.BaseFee(), tip),
+			GasTipCap: tip,
+		})
+		b.AddTx(tx)
+	})
+	info, err := NewBlockChainAPI(backend).GetBlockReward(context.Background(), rpc.BlockNumberOrHashWithNumber(1))
+	if err != nil {
+		t.Fatalf("failed to get block reward: %v", err)
+	}
+	want := new(big.Int).Mul(tip, new(big.Int).SetUint64(params.TxGas))
+	if have := info.TotalFees.ToInt(); have.Cmp(want) != 0 {
+		t.Errorf("total fees mismatch: have %v, want %v", have, want)
+	}
+}
+
+// TestGetRawTransaction tests that the raw transactions are returned in the
+// encoding accepted by eth_sendRawTransaction, with the type prefix.
+func TestGetRawTransaction(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		genesis  = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc:  types.GenesisAlloc{accounts[0].addr: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(params.MergedTestChainConfig)
+		txs    []*types.Transaction
+	)
+	backend := newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+		legacy, _ := types.SignTx(types.NewTransaction(0, common.Address{0x01}, big.NewInt(1), params.TxGas, b.BaseFee(), nil), signer, accounts[0].key)
+		dynamic, _ := types.SignNewTx(accounts[0].key, signer, &types.DynamicFeeTx{
+			ChainID:   params.MergedTestChainConfig.ChainID,
+			Nonce:     1,
+			GasTipCap: big.NewInt(1),
+			GasFeeCap: b.BaseFee(),
+			Gas:       params.TxGas,
+			To:        &common.Address{0x01},
+			Value:     big.NewInt(1),
+		})
+		b.AddTx(legacy)
+		b.AddTx(dynamic)
+		txs = append(txs, legacy, dynamic)
+	})
+	var (
+		api       = NewTransactionAPI(backend, new(AddrLocker))
+		blockHash = backend.chain.GetBlockByNumber(1).Hash()
+	)
+	for i, want := range txs {
+		byHash, err := api.GetRawTransactionByHash(context.Background(), want.Hash())
+		if err != nil {
+			t.Fatalf("tx %d: failed to get raw transaction: %v", i, err)
+		}
+		byIndex := api.GetRawTransactionByBlockHashAndIndex(context.Background(), blockHash, hexutil.Uint(i))
+		if !bytes.Equal(byHash, byIndex) {
+			t.Fatalf("tx %d: raw transaction mismatch: by hash %x, by index %x", i, byHash, byIndex)
+		}
+		if want.Type() != types.LegacyTxType && byHash[0] != want.Type() {
+			t.Errorf("tx %d: type prefix mismatch: have %d, want %d", i, byHash[0], want.Type())
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(byHash); err != nil {
+			t.Fatalf("tx %d: failed to decode raw transaction: %v", i, err)
+		}
+		if tx.Hash() != want.Hash() {
+			t.Errorf("tx %d: decoded transaction mismatch: have %x, want %x", i, tx.Hash(), want.Hash())
+		}
+	}
+}
+
+// Tests that the known events of the receipt logs are decoded.
+func TestGetDecodedReceipt(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		token    = common.HexToAddress("0x7070")
+		to       = common.HexToAddress("0xbeef")
+		transfer = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+		unknown  = crypto.Keccak256Hash([]byte("Unknown(uint256)"))
+
+		// Emits a transfer of 42 tokens from the caller, followed by an unknown event
+		code = program.New().
+			Mstore(common.LeftPadBytes([]byte{42}, 32), 0).
+			Push(to).Op(vm.CALLER).Push(transfer).Push(32).Push(0).Op(vm.LOG3).
+			Push(unknown).Push(32).Push(0).Op(vm.LOG1).
+			Bytes()
+		genesis = &core.Genesis{
+			Config: params.MergedTestChainConfig,
+			Alloc: types.GenesisAlloc{
+				accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+				token:            {Code: code},
+			},
+		}
+		signer = types.LatestSigner(params.MergedTestChainConfig)
+		tx     *types.Transaction
+	)
+	backend := newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+		tx, _ = types.SignTx(types.NewTransaction(0, token, nil, 100_000, b.BaseFee(), nil), signer, accounts[0].key)
+		b.AddTx(tx)
+	})
+	api := NewTransactionAPI(backend, new(AddrLocker))
+
+	receipt, err := api.GetDecodedReceipt(context.Background(), tx.Hash())
+	if err != nil {
+		t.Fatalf("failed to get decoded receipt: %v", err)
+	}
+	enc, err := json.Marshal(receipt)
+	if err != nil {
+		t.Fatalf("failed to encode receipt: %v", err)
+	}
+	var result struct {
+		Logs []struct {
+			Topics      []common.Hash          `json:"topics"`
+			EventName   *string                `json:"eventName"`
+			DecodedArgs map[string]interface{} `json:"decodedArgs"`
+		} `json:"logs"`
+	}
+	if err := json.Unmarshal(enc, &result); err != nil {
+		t.Fatalf("failed to decode receipt: %v", err)
+	}
+	if len(result.Logs) != 2 {
+		t.Fatalf("log count mismatch: have %d, want 2", len(result.Logs))
+	}
+	transferLog := result.Logs[0]
+	if transferLog.EventName == nil || *transferLog.EventName != "Transfer" {
+		t.Fatalf("event name mismatch: have %v, want Transfer", transferLog.EventName)
+	}
+	want := map[string]interface{}{
+		"0": accounts[0].addr.Hex(),
+		"1": to.Hex(),
+		"2": "42",
+	}
+	if !reflect.DeepEqual(transferLog.DecodedArgs, want) {
+		t.Errorf("decoded args mismatch: have %v, want %v", transferLog.DecodedArgs, want)
+	}
+	// Unknown events are left undecoded
+	if unknownLog := result.Logs[1]; unknownLog.EventName != nil || unknownLog.DecodedArgs != nil {
+		t.Errorf("unknown event decoded: %v %v", unknownLog.EventName, unknownLog.DecodedArgs)
+	}
+	if result.Logs[1].Topics[0] != unknown {
+		t.Errorf("topic mismatch: have %x, want %x", result.Logs[1].Topics[0], unknown)
+	}
+}
+
+func TestDebugSetHead(t *testing.T) {
+	t.Parallel()
+
+	var (
+		genesis = &core.Genesis{Config: params.TestChainConfig, Alloc: types.GenesisAlloc{}}
+		engine  = ethash.NewFaker()
+		backend = newTestBackend(t, 10, genesis, engine, func(i int, b *core.BlockGen) {})
+		api     = NewDebugAPI(backend)
+	)
+	// Import a shorter fork, kept as a side chain
+	head := backend.chain.GetBlockByNumber(10)
+	_, fork, _ := core.GenerateChainWithGenesis(genesis, engine, 5, func(i int, b *core.BlockGen) {
+		b.SetCoinbase(common.Address{0xff})
+	})
+	if _, err := backend.chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to import fork: %v", err)
+	}
+	if _, err := backend.chain.SetCanonical(head); err != nil {
+		t.Fatalf("failed to restore canonical chain: %v", err)
+	}
+	if head := backend.chain.CurrentBlock().Number.Uint64(); head != 10 {
+		t.Fatalf("head mismatch: have %d, want %d", head, 10)
+	}
+	forked := rpc.BlockNumberOrHashWithHash(fork[2].Hash(), false)
+	if err := api.SetHead(context.Background(), forked); err == nil || !strings.Contains(err.Error(), "not canonical") {
+		t.Fatalf("rewind to non-canonical block: have %v, want not canonical error", err)
+	}
+	if head := backend.chain.CurrentBlock().Number.Uint64(); head != 10 {
+		t.Fatalf("head mismatch after rejected rewind: have %d, want %d", head, 10)
+	}
+	// Rewinds to canonical blocks are allowed, by hash or by number
+	canonical := backend.chain.GetHeaderByNumber(8).Hash()
+	if err := api.SetHead(context.Background(), rpc.BlockNumberOrHashWithHash(canonical, false)); err != nil {
+		t.Fatalf("failed to rewind to canonical block: %v", err)
+	}
+	if head := backend.chain.CurrentBlock(); head.Hash() != canonical {
+		t.Fatalf("head mismatch: have %d, want %d", head.Number, 8)
+	}
+	if err := api.SetHead(context.Background(), rpc.BlockNumberOrHashWithNumber(5)); err != nil {
+		t.Fatalf("failed to rewind to block number: %v", err)
+	}
+	if head := backend.chain.CurrentBlock().Number.Uint64(); head != 5 {
+		t.Fatalf("head mismatch: have %d, want %d", head, 5)
+	}
+	if err := api.SetHead(context.Background(), rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)); err == nil {
+		t.Fatal("rewind to a block tag unexpectedly succeeded")
+	}
+}
+
+// Tests that raw transactions which can't be valid are rejected with the right
+// error code before being decoded.
+func TestSendRawTransactionValidation(t *testing.T) {
+	t.Parallel()
+
+	genesis := &core.Genesis{Config: params.MergedTestChainConfig, Alloc: types.GenesisAlloc{}}
+	api := NewTransactionAPI(newTestBackend(t, 0, genesis, beacon.New(ethash.NewFaker()), nil), nil)
+
+	legacy, _ := rlp.EncodeToBytes([]interface{}{make([]byte, params.MaxTransactionSize)})
+
+	tests := []struct {
+		name  string
+		input []byte
+		code  int
+		want  string
+	}{
+		{"empty", nil, errCodeInvalidParams, "empty transaction"},
+		{"oversized", append([]byte{types.DynamicFeeTxType}, make([]byte, params.MaxTransactionSize)...), errCodeInvalidParams, "oversized transaction"},
+		{"oversized legacy", legacy, errCodeInvalidParams, "oversized transaction"},
+		{"invalid legacy", []byte{0xc2, 0x01}, errCodeInvalidParams, "invalid legacy transaction encoding"},
+		{"trailing legacy", []byte{0xc0, 0x00}, errCodeInvalidParams, "invalid legacy transaction encoding"},
+		{"prefixed legacy", []byte{types.LegacyTxType, 0xc0}, errCodeInvalidParams, types.ErrInvalidTxType.Error()},
+		{"deposit", []byte{types.OptimismDepositTxType, 0xc0}, errCodeInvalidParams, types.ErrTxTypeNotSupported.Error()},
+		{"unknown type", []byte{0x05, 0xc0}, errCodeInvalidParams, types.ErrTxTypeNotSupported.Error()},
+	}
+	for _, tt := range tests {
+		_, err := api.SendRawTransaction(context.Background(), tt.input)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error mismatch: have %v, want %q", tt.name, err, tt.want)
+			continue
+		}
+		var rpcErr rpc.Error
+		if !errors.As(err, &rpcErr) || rpcErr.ErrorCode() != tt.code {
+			t.Errorf("%s: error code mismatch: have %v, want %d", tt.name, err, tt.code)
+		}
+	}
+}
+
+// FuzzSendRawTransaction feeds arbitrary inputs through the validation and the
+// decoding of eth_sendRawTransaction, checking that only well formed envelopes
+// are accepted.
+func FuzzSendRawTransaction(f *testing.F) {
+	key, _ := crypto.GenerateKey()
+	signer := types.LatestSigner(params.MergedTestChainConfig)
+	for _, inner := range []types.TxData{
+		&types.LegacyTx{Gas: params.TxGas, GasPrice: big.NewInt(1)},
+		&types.AccessListTx{ChainID: params.MergedTestChainConfig.ChainID, Gas: params.TxGas, GasPrice: big.NewInt(1)},
+		&types.DynamicFeeTx{ChainID: params.MergedTestChainConfig.ChainID, Gas: params.TxGas, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1)},
+	} {
+		enc, _ := types.MustSignNewTx(key, signer, inner).MarshalBinary()
+		f.Add(enc)
+		f.Add(append([]byte{types.LegacyTxType}, enc...))
+	}
+	f.Add([]byte{})
+	f.Add([]byte{0xc0, 0x00})
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		if err := validateRawTransaction(input); err != nil {
+			return
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(input); err != nil {
+			return
+		}
+		switch kind := input[0]; {
+		case kind >= 0xc0:
+			if tx.Type() != types.LegacyTxType {
+				t.Fatalf("legacy encoding decoded as type %d", tx.Type())
+			}
+		case kind == types.AccessListTxType, kind == types.DynamicFeeTxType, kind == types.BlobTxType, kind == types.SetCodeTxType:
+			if tx.Type() != kind {
+				t.Fatalf("type %d envelope decoded as type %d", kind, tx.Type())
+			}
+		default:
+			t.Fatalf("transaction prefixed with %#x accepted", kind)
+		}
+	})
+}
+
+// Tests that eth_getCode serves the code of historical blocks an archive node
+// already moved into the ancient store.
+func TestGetCodeAncient(t *testing.T) {
+	t.Parallel()
+
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender  = crypto.PubkeyToAddress(key.PublicKey)
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}},
+		}
+		code     = []byte{byte(vm.PUSH1), 0x2a, byte(vm.STOP)}
+		contract = crypto.CreateAddress(sender, 0)
+		signer   = types.LatestSigner(genesis.Config)
+		engine   = beacon.New(ethash.NewFaker())
+	)
+	// Deploy the contract in block 10 and keep mining on top
+	_, blocks, _ := core.GenerateChainWithGenesis(genesis, engine, 1000, func(i int, b *core.BlockGen) {
+		if i == 9 {
+			b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+				Gas:      100000,
+				GasPrice: b.BaseFee(),
+				Data:     program.New().ReturnViaCodeCopy(code).Bytes(),
+			}))
+		}
+	})
+	db, err := rawdb.Open(rawdb.NewMemoryDatabase(), rawdb.OpenOptions{Ancient: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer db.Close()
+
+	chain, err := core.NewBlockChain(db, genesis, engine, core.DefaultConfig().WithArchive(true))
+	if err != nil {
+		t.Fatalf("failed to create tester chain: %v", err)
+	}
+	defer chain.Stop()
+	if n, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("block %d: failed to insert into chain: %v", n, err)
+	}
+	// Finalize most of the chain and move it into the ancient store
+	chain.SetFinalized(blocks[len(blocks)-100].Header())
+	db.(interface{ Freeze() error }).Freeze()
+	if frozen, _ := db.Ancients(); frozen <= 10 {
+		t.Fatalf("block 10 not frozen: %d ancients", frozen)
+	}
+	api := NewBlockChainAPI(&testBackend{db: db, chain: chain})
+	have, err := api.GetCode(context.Background(), contract, rpc.BlockNumberOrHashWithNumber(10))
+	if err != nil {
+		t.Fatalf("failed to get code: %v", err)
+	}
+	if !bytes.Equal(have, code) {
+		t.Errorf("code mismatch: have %x, want %x", have, code)
+	}
+	// The code didn't exist before its deployment
+	have, err = api.GetCode(context.Background(), contract, rpc.BlockNumberOrHashWithNumber(9))
+	if err != nil {
+		t.Fatalf("failed to get code: %v", err)
+	}
+	if len(have) != 0 {
+		t.Errorf("code before deployment: have %x, want empty", have)
+	}
+}
+
+// Tests that eth_getStorageAt rejects storage keys which don't decode into a
+// 32 byte slot, rather than truncating them.
+func TestGetStorageAtInvalidKey(t *testing.T) {
+	t.Parallel()
+
+	var (
+		contract = common.HexToAddress("0xc0de")
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				contract: {Storage: map[common.Hash]common.Hash{{0x01}: {0x02}}},
+			},
+		}
+		api    = NewBlockChainAPI(newTestBackend(t, 0, genesis, ethash.NewFaker(), nil))
+		latest = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	have, err := api.GetStorageAt(context.Background(), contract, "0x01"+strings.Repeat("00", 31), latest)
+	if err != nil {
+		t.Fatalf("failed to get storage: %v", err)
+	}
+	if want := (common.Hash{0x02}); common.BytesToHash(have) != want {
+		t.Errorf("storage mismatch: have %x, want %x", have, want)
+	}
+	for _, key := range []string{
+		"0x" + strings.Repeat("00", 32) + "01", // 33 bytes, truncating into slot 1
+		" 0x01",
+		"0x01 ",
+		"0xzz",
+	} {
+		if _, err := api.GetStorageAt(context.Background(), contract, key, latest); err == nil {
+			t.Errorf("key %q: storage returned, want error", key)
+		}
+	}
+	_, err = api.GetStorageAt(context.Background(), contract, "0x"+strings.Repeat("00", 33), latest)
+	if want := "hex string too long, want at most 32 bytes"; err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("error mismatch: have %v, want %q", err, want)
+	}
+}
+
+// Tests that eth_getTokenBalance returns the balanceOf result of ERC-20 tokens
+// and rejects the contracts not answering it.
+func TestGetTokenBalance(t *testing.T) {
+	t.Parallel()
+
+	var (
+		token    = common.HexToAddress("0x20")
+		reverter = common.HexToAddress("0xdead")
+		owner    = common.HexToAddress("0xa11ce")
+		balance  = big.NewInt(123456789)
+		genesis  = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc: types.GenesisAlloc{
+				// The balances are stored at the slot of the owner address
+				token: {
+					Code:    program.New().Push(4).Op(vm.CALLDATALOAD, vm.SLOAD).Push(0).Op(vm.MSTORE).Return(0, 32).Bytes(),
+					Storage: map[common.Hash]common.Hash{common.BytesToHash(owner.Bytes()): common.BigToHash(balance)},
+				},
+				reverter: {Code: program.New().Push(0).Push(0).Op(vm.REVERT).Bytes()},
+			},
+		}
+		api    = NewBlockChainAPI(newTestBackend(t, 0, genesis, ethash.NewFaker(), nil))
+		latest = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	)
+	have, err := api.GetTokenBalance(context.Background(), token, owner, latest)
+	if err != nil {
+		t.Fatalf("failed to get token balance: %v", err)
+	}
+	if have.ToInt().Cmp(balance) != 0 {
+		t.Errorf("balance mismatch: have %v, want %v", have.ToInt(), balance)
+	}
+	have, err = api.GetTokenBalance(context.Background(), token, common.HexToAddress("0x01"), latest)
+	if err != nil || have.ToInt().Sign() != 0 {
+		t.Errorf("unknown owner balance mismatch: have %v, %v, want 0", have, err)
+	}
+	for _, addr := range []common.Address{reverter, common.HexToAddress("0xe0a")} {
+		if _, err := api.GetTokenBalance(context.Background(), addr, owner, latest); !errors.Is(err, ErrNotERC20) {
+			t.Errorf("token %v: error mismatch: have %v, want %v", addr, err, ErrNotERC20)
+		}
+	}
+}
+
+// newTransferBackend creates a test backend with a chain of n blocks holding a
+// single transfer each.
+func newTransferBackend(t testing.TB, n int) (*testBackend, []*types.Block) {
+	var (
+		key, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender  = crypto.PubkeyToAddress(key.PublicKey)
+		genesis = &core.Genesis{
+			Config: params.TestChainConfig,
+			Alloc:  types.GenesisAlloc{sender: {Balance: big.NewInt(params.Ether)}},
+		}
+		signer = types.LatestSigner(params.TestChainConfig)
+	)
+	backend := newTestBackend(t, n, genesis, ethash.NewFaker(), func(i int, b *core.BlockGen) {
+		b.AddTx(types.MustSignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    uint64(i),
+			To:       &common.Address{0x01},
+			Value:    big.NewInt(1),
+			Gas:      params.TxGas,
+			GasPrice: b.BaseFee(),
+		}))
+	})
+	blocks := make([]*types.Block, n+1)
+	for i := range blocks {
+		blocks[i] = backend.chain.GetBlockByNumber(uint64(i))
+	}
+	return backend, blocks
+}
+
+// Tests that eth_getTransactionByHash caches the transactions of the finalized
+// blocks only, and drops them if the finalized block is rewound.
+func TestGetTransactionByHashCache(t *testing.T) {
+	t.Parallel()
+
+	backend, blocks := newTransferBackend(t, 10)
+	api := NewTransactionAPI(backend, nil)
+
+	lookup := func(block int) *RPCTransaction {
+		t.Helper()
+		hash := blocks[block].Transactions()[0].Hash()
+		tx, err := api.GetTransactionByHash(context.Background(), hash)
+		if err != nil || tx == nil || tx.Hash != hash {
+			t.Fatalf("block %d: failed to get transaction: %v, %v", block, tx, err)
+		}
+		return tx
+	}
+	// Nothing is cached without a finalized block
+	if lookup(3) == lookup(3) {
+		t.Fatal("transaction cached without finalized block")
+	}
+	// Only the finalized transactions are cached
+	backend.chain.SetFinalized(blocks[5].Header())
+	if lookup(3) != lookup(3) {
+		t.Error("finalized transaction not cached")
+	}
+	if lookup(5) != lookup(5) {
+		t.Error("transaction of the finalized block not cached")
+	}
+	if lookup(8) == lookup(8) {
+		t.Error("non-finalized transaction cached")
+	}
+	// Rewinding the finalized block drops the cached transactions
+	cached := lookup(3)
+	backend.chain.SetFinalized(blocks[2].Header())
+	if lookup(3) == cached {
+		t.Error("transaction still cached after rewinding the finalized block")
+	}
+}
+
+// BenchmarkGetTransactionByHash measures the lookups of transactions polled over
+// and over, with and without the finalized transaction cache.
+func BenchmarkGetTransactionByHash(b *testing.B) {
+	backend, blocks := newTransferBackend(b, 1000)
+	backend.chain.SetFinalized(blocks[len(blocks)-1].Header())
+
+	hashes := make([]common.Hash, 0, len(blocks))
+	for _, block := range blocks[1:] {
+		hashes = append(hashes, block.Transactions()[0].Hash())
+	}
+	run := func(b *testing.B, cache *finalizedTxCache) {
+		api := NewTransactionAPI(backend, nil)
+		api.txCache = cache
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if tx, _ := api.GetTransactionByHash(context.Background(), hashes[i%len(hashes)]); tx == nil {
+				b.Fatal("missing transaction")
+			}
+		}
+	}
+	b.Run("nocache", func(b *testing.B) { run(b, newFinalizedTxCache(0)) })
+	b.Run("cache", func(b *testing.B) { run(b, newFinalizedTxCache(finalizedTxCacheSize)) })
+}
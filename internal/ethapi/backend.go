@@ -53,6 +53,7 @@ type Backend interface {
 	RPCEVMTimeout() time.Duration // global timeout for eth_call over rpc: DoS protection
 	RPCTxFeeCap() float64         // global tx fee cap for all transaction related APIs
 	UnprotectedAllowed() bool     // allows only for EIP155 transactions.
+	IsSequencer() bool            // whether the node is the sequencer of the rollup chain
 
 	// Blockchain API
 	SetHead(number uint64)
@@ -118,9 +119,15 @@ func GetAPIs(apiBackend Backend) []rpc.API {
 		}, {
 			Namespace: "debug",
 			Service:   NewDebugAPI(apiBackend),
+		}, {
+			Namespace: "bundle",
+			Service:   NewBundleAPI(apiBackend),
 		}, {
 			Namespace: "eth",
 			Service:   NewEthereumAccountAPI(apiBackend.AccountManager()),
+		}, {
+			Namespace: "personal",
+			Service:   NewPersonalAPI(),
 		},
 	}
 }
@@ -0,0 +1,146 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	gomath "math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// maxSimulateBundles is the maximum number of bundles simulated in one call.
+const maxSimulateBundles = 256
+
+// BundleAPI provides an API to simulate bundles of transactions, as submitted
+// by searchers to block builders.
+type BundleAPI struct {
+	b Backend
+}
+
+// NewBundleAPI creates a new bundle simulation API.
+func NewBundleAPI(b Backend) *BundleAPI {
+	return &BundleAPI{b}
+}
+
+// BundleTxResult is the outcome of a single transaction of a simulated bundle.
+type BundleTxResult struct {
+	GasUsed    hexutil.Uint64 `json:"gasUsed"`
+	ReturnData hexutil.Bytes  `json:"returnData"`
+	Revert     string         `json:"revert,omitempty"`
+}
+
+// BundleResult is the outcome of a simulated bundle.
+type BundleResult struct {
+	TotalGasUsed      hexutil.Uint64    `json:"totalGasUsed"`
+	CoinbaseDiff      *hexutil.Big      `json:"coinbaseDiff"`      // Balance change of the coinbase
+	EthSentToCoinbase *hexutil.Big      `json:"ethSentToCoinbase"` // Balance change of the coinbase, excluding fees
+	Results           []*BundleTxResult `json:"results"`
+}
+
+// DebugBundles simulates each bundle on top of the state of the given block.
+// The transactions of a bundle are executed in order, on a state shared by the
+// bundle only: the state changes of one bundle are not visible to the others.
+//
+// Note, this function doesn't make any changes in the state/blockchain and is
+// useful to evaluate bundles before submitting them.
+func (api *BundleAPI) DebugBundles(ctx context.Context, bundles [][]TransactionArgs, blockNrOrHash rpc.BlockNumberOrHash) ([]*BundleResult, error) {
+	if len(bundles) == 0 {
+		return nil, &invalidParamsError{message: "empty input"}
+	} else if len(bundles) > maxSimulateBundles {
+		return nil, &clientLimitExceededError{message: "too many bundles"}
+	}
+	base, header, err := api.b.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if base == nil || err != nil {
+		return nil, err
+	}
+	// Setup context so it may be cancelled once the simulation has completed
+	// or, in case of unmetered gas, setup a context with a timeout.
+	timeout := api.b.RPCEVMTimeout()
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	results := make([]*BundleResult, len(bundles))
+	for i, bundle := range bundles {
+		var (
+			state    = base.Copy()
+			blockCtx = core.NewEVMBlockContext(header, NewChainContext(ctx, api.b), nil)
+			gp       = new(core.GasPool)
+			before   = state.GetBalance(blockCtx.Coinbase).ToBig()
+			fees     = new(big.Int)
+			result   = &BundleResult{Results: make([]*BundleTxResult, 0, len(bundle))}
+		)
+		if gasCap := api.b.RPCGasCap(); gasCap == 0 {
+			gp.AddGas(gomath.MaxUint64)
+		} else {
+			gp.AddGas(gasCap)
+		}
+		for j, args := range bundle {
+			if err := args.CallDefaults(gp.Gas(), blockCtx.BaseFee, api.b.ChainConfig().ChainID); err != nil {
+				return nil, fmt.Errorf("bundle %d, tx %d: %w", i, j, err)
+			}
+			var (
+				msg    = args.ToMessage(header.BaseFee, true, true)
+				txCtx  = blockCtx
+				tipCap = new(big.Int).Set(msg.GasPrice)
+			)
+			// Lower the basefee to 0 to avoid breaking EVM invariants (basefee < feecap)
+			if msg.GasPrice.Sign() == 0 {
+				txCtx.BaseFee = new(big.Int)
+			}
+			if msg.BlobGasFeeCap != nil && msg.BlobGasFeeCap.BitLen() == 0 {
+				txCtx.BlobBaseFee = new(big.Int)
+			}
+			if txCtx.BaseFee != nil {
+				tipCap.Sub(tipCap, txCtx.BaseFee)
+			}
+			evm := api.b.GetEVM(ctx, state, header, &vm.Config{NoBaseFee: true}, &txCtx)
+			res, err := applyMessageWithEVM(ctx, evm, msg, timeout, gp)
+			if err := state.Error(); err != nil {
+				return nil, err
+			}
+			if err != nil {
+				return nil, fmt.Errorf("bundle %d, tx %d: %w", i, j, err)
+			}
+			txResult := &BundleTxResult{GasUsed: hexutil.Uint64(res.UsedGas), ReturnData: res.Return()}
+			if errors.Is(res.Err, vm.ErrExecutionReverted) {
+				txResult.Revert = newRevertError(res.Revert()).Error()
+			} else if res.Err != nil {
+				txResult.Revert = res.Err.Error()
+			}
+			result.Results = append(result.Results, txResult)
+			result.TotalGasUsed += hexutil.Uint64(res.UsedGas)
+			fees.Add(fees, new(big.Int).Mul(tipCap, new(big.Int).SetUint64(res.UsedGas)))
+		}
+		diff := new(big.Int).Sub(state.GetBalance(blockCtx.Coinbase).ToBig(), before)
+		result.CoinbaseDiff = (*hexutil.Big)(diff)
+		result.EthSentToCoinbase = (*hexutil.Big)(new(big.Int).Sub(diff, fees))
+		results[i] = result
+	}
+	return results, nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// TestDebugBundles tests that bundles are simulated on independent states, with
+// the payments to the coinbase accounted apart from the transaction fees.
+func TestDebugBundles(t *testing.T) {
+	t.Parallel()
+
+	var (
+		accounts = newAccounts(1)
+		oracle   = common.HexToAddress("0x000000000000000000000000000000000000a11c")
+	)
+	// The oracle stores the price passed as calldata, or pays the stored price
+	// to the coinbase when called without any.
+	//
+	//   CALLDATASIZE PUSH1 0x14 JUMPI
+	//   PUSH1 0 PUSH1 0 PUSH1 0 PUSH1 0 PUSH1 0 SLOAD COINBASE GAS CALL POP STOP
+	//   JUMPDEST PUSH1 0 CALLDATALOAD PUSH1 0 SSTORE STOP
+	genesis := &core.Genesis{
+		Config: params.MergedTestChainConfig,
+		Alloc: types.GenesisAlloc{
+			accounts[0].addr: {Balance: big.NewInt(params.Ether)},
+			oracle: {
+				Balance: big.NewInt(params.Ether),
+				Code:    common.FromHex("0x366014576000600060006000600054415af150005b60003560005500"),
+				Storage: map[common.Hash]common.Hash{{}: common.BigToHash(big.NewInt(100))},
+			},
+		},
+	}
+	backend := newTestBackend(t, 1, genesis, beacon.New(ethash.NewFaker()), func(i int, b *core.BlockGen) {
+		b.SetPoS()
+	})
+	var (
+		api    = NewBundleAPI(backend)
+		latest = rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+		price  = hexutil.Bytes(common.BigToHash(big.NewInt(1000)).Bytes())
+		tip    = big.NewInt(params.GWei)
+		gas    = hexutil.Uint64(100_000)
+	)
+	update := TransactionArgs{From: &accounts[0].addr, To: &oracle, Input: &price}
+	pay := TransactionArgs{From: &accounts[0].addr, To: &oracle}
+	pricedPay := TransactionArgs{
+		From:                 &accounts[0].addr,
+		To:                   &oracle,
+		Gas:                  &gas,
+		MaxFeePerGas:         (*hexutil.Big)(big.NewInt(10 * params.GWei)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(tip),
+	}
+	results, err := api.DebugBundles(context.Background(), [][]TransactionArgs{
+		{pay},            // pays the original price
+		{update, pay},    // pays the price updated within the bundle
+		{pay},            // doesn't see the update of the previous bundle
+		{pricedPay, pay}, // pays fees on top of the price
+	}, latest)
+	if err != nil {
+		t.Fatalf("failed to simulate bundles: %v", err)
+	}
+	if len(results) != 4 {
+		t.Fatalf("result count mismatch: have %d, want 4", len(results))
+	}
+	for i, want := range []int64{100, 1000, 100, 200} {
+		if have := results[i].EthSentToCoinbase.ToInt(); have.Int64() != want {
+			t.Errorf("bundle %d: eth sent to coinbase mismatch: have %v, want %v", i, have, want)
+		}
+	}
+	// Unpriced bundles don't pay any fees
+	for i := 0; i < 3; i++ {
+		if have, want := results[i].CoinbaseDiff.ToInt(), results[i].EthSentToCoinbase.ToInt(); have.Cmp(want) != 0 {
+			t.Errorf("bundle %d: coinbase diff mismatch: have %v, want %v", i, have, want)
+		}
+	}
+	// The priced transaction pays its tip for the gas used on top
+	priced := results[3]
+	if len(priced.Results) != 2 {
+		t.Fatalf("priced bundle result count mismatch: have %d, want 2", len(priced.Results))
+	}
+	fees := new(big.Int).Mul(tip, new(big.Int).SetUint64(uint64(priced.Results[0].GasUsed)))
+	if have, want := priced.CoinbaseDiff.ToInt(), new(big.Int).Add(fees, big.NewInt(200)); have.Cmp(want) != 0 {
+		t.Errorf("priced bundle coinbase diff mismatch: have %v, want %v", have, want)
+	}
+	if have, want := priced.TotalGasUsed, priced.Results[0].GasUsed+priced.Results[1].GasUsed; have != want {
+		t.Errorf("total gas used mismatch: have %d, want %d", have, want)
+	}
+}
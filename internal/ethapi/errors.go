@@ -30,7 +30,8 @@ import (
 // code and a binary data blob.
 type revertError struct {
 	error
-	reason string // revert reason hex encoded
+	reason string                 // revert reason hex encoded
+	fields map[string]interface{} // decoded revert reason or custom error
 }
 
 // ErrorCode returns the JSON error code for a revert.
@@ -44,20 +45,40 @@ func (e *revertError) ErrorData() interface{} {
 	return e.reason
 }
 
+// ErrorFields returns the revert reason if the revert data is an Error(string)
+// or a Panic(uint256), or the hex encoded custom error otherwise.
+func (e *revertError) ErrorFields() map[string]interface{} {
+	return e.fields
+}
+
 // newRevertError creates a revertError instance with the provided revert data.
 func newRevertError(revert []byte) *revertError {
 	err := vm.ErrExecutionReverted
 
+	var fields map[string]interface{}
+
 	reason, errUnpack := abi.UnpackRevert(revert)
 	if errUnpack == nil {
 		err = fmt.Errorf("%w: %v", vm.ErrExecutionReverted, reason)
+		fields = map[string]interface{}{"revertReason": reason}
+	} else if len(revert) >= 4 {
+		fields = map[string]interface{}{"customError": hexutil.Encode(revert)}
 	}
 	return &revertError{
 		error:  err,
 		reason: hexutil.Encode(revert),
+		fields: fields,
 	}
 }
 
+// ErrNotIndexed is returned if the requested data can't be retrieved without an
+// index, which the node doesn't maintain.
+var ErrNotIndexed = errors.New("not indexed")
+
+// ErrNotERC20 is returned if a token contract doesn't answer a balanceOf call
+// like an ERC-20 token.
+var ErrNotERC20 = errors.New("not an ERC-20 token")
+
 // TxIndexingError is an API error that indicates the transaction indexing is not
 // fully finished yet with JSON error code and a binary data blob.
 type TxIndexingError struct{}
@@ -0,0 +1,111 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package ethapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/internal/abiutil"
+)
+
+// RPCDecodedLog is a log annotated with the name and the arguments of the
+// emitted event, if its signature is known.
+type RPCDecodedLog struct {
+	*types.Log
+	EventName   string
+	DecodedArgs map[string]interface{}
+}
+
+// MarshalJSON encodes the log along with the decoded event, if any.
+func (l *RPCDecodedLog) MarshalJSON() ([]byte, error) {
+	enc, err := json.Marshal(l.Log)
+	if err != nil || l.EventName == "" {
+		return enc, err
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(enc, &fields); err != nil {
+		return nil, err
+	}
+	fields["eventName"] = l.EventName
+	fields["decodedArgs"] = l.DecodedArgs
+	return json.Marshal(fields)
+}
+
+// newRPCDecodedLog decodes the event of a log from the 4byte database. The event
+// is left out if it's unknown or if the log doesn't match its signature.
+func newRPCDecodedLog(log *types.Log) *RPCDecodedLog {
+	result := &RPCDecodedLog{Log: log}
+	if len(log.Topics) == 0 {
+		return result
+	}
+	db := loadFourbyteDB()
+	if db == nil {
+		return result
+	}
+	signature, err := db.Event(log.Topics[0].Bytes())
+	if err != nil {
+		return result
+	}
+	selector, err := abi.ParseSelector(signature)
+	if err != nil || len(log.Topics)-1 > len(selector.Inputs) {
+		return result
+	}
+	// The signatures don't tell which parameters are indexed, the known events
+	// have them first. The arguments are keyed by position as they're unnamed.
+	var (
+		args    = make(abi.Arguments, len(selector.Inputs))
+		indexed abi.Arguments
+		data    abi.Arguments
+	)
+	for i, input := range selector.Inputs {
+		typ, err := abi.NewType(input.Type, "", input.Components)
+		if err != nil {
+			return result
+		}
+		args[i] = abi.Argument{Name: strconv.Itoa(i), Type: typ, Indexed: i < len(log.Topics)-1}
+		if args[i].Indexed {
+			indexed = append(indexed, args[i])
+		} else {
+			data = append(data, args[i])
+		}
+	}
+	decoded := make(map[string]interface{})
+	if err := abi.ParseTopicsIntoMap(decoded, indexed, log.Topics[1:]); err != nil {
+		return result
+	}
+	values, err := data.Unpack(log.Data)
+	if err != nil {
+		return result
+	}
+	// Reject the logs carrying more data than the event, they don't match it
+	if packed, err := data.Pack(values...); err != nil || !bytes.Equal(packed, log.Data) {
+		return result
+	}
+	for i, value := range values {
+		decoded[data[i].Name] = value
+	}
+	result.EventName = selector.Name
+	result.DecodedArgs = make(map[string]interface{}, len(args))
+	for _, arg := range args {
+		result.DecodedArgs[arg.Name] = abiutil.FromGoValue(arg.Type, decoded[arg.Name])
+	}
+	return result
+}
@@ -71,6 +71,11 @@ type TransactionArgs struct {
 	// For SetCodeTxType
 	AuthorizationList []types.SetCodeAuthorization `json:"authorizationList"`
 
+	// For OptimismDepositTxType. The type is only consulted to tell deposits
+	// apart, all other transaction types are inferred from the given fields.
+	Type *hexutil.Uint64 `json:"type"`
+	Mint *hexutil.Big    `json:"mint"`
+
 	// This configures whether blobs are allowed to be passed.
 	blobSidecarAllowed bool
 }
@@ -83,6 +88,11 @@ func (args *TransactionArgs) from() common.Address {
 	return *args.From
 }
 
+// isDeposit reports whether the arguments describe an OP-Stack deposit.
+func (args *TransactionArgs) isDeposit() bool {
+	return args.Type != nil && *args.Type == types.OptimismDepositTxType
+}
+
 // data retrieves the transaction calldata. Input field is preferred.
 func (args *TransactionArgs) data() []byte {
 	if args.Input != nil {
@@ -96,6 +106,9 @@ func (args *TransactionArgs) data() []byte {
 
 // setDefaults fills in default values for unspecified tx fields.
 func (args *TransactionArgs) setDefaults(ctx context.Context, b Backend, skipGasEstimation bool) error {
+	if args.isDeposit() {
+		return errors.New("deposit transactions can only be simulated")
+	}
 	if err := args.setBlobTxSidecar(ctx); err != nil {
 		return err
 	}
@@ -364,6 +377,14 @@ func (args *TransactionArgs) CallDefaults(globalGasCap uint64, baseFee *big.Int,
 	if args.GasPrice != nil && (args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil) {
 		return errors.New("both gasPrice and (maxFeePerGas or maxPriorityFeePerGas) specified")
 	}
+	// Deposits are paid for on L1, they neither specify nor pay L2 gas prices
+	if args.isDeposit() {
+		if args.GasPrice != nil || args.MaxFeePerGas != nil || args.MaxPriorityFeePerGas != nil {
+			return errors.New("gas price fields specified for deposit transaction")
+		}
+	} else if args.Mint != nil {
+		return errors.New("mint specified for non-deposit transaction")
+	}
 	if args.ChainID == nil {
 		args.ChainID = (*hexutil.Big)(chainID)
 	} else {
@@ -447,6 +468,8 @@ func (args *TransactionArgs) ToMessage(baseFee *big.Int, skipNonceCheck, skipEoA
 	if args.AccessList != nil {
 		accessList = *args.AccessList
 	}
+	// The L1 data fee of a call is estimated from its calldata only, since the
+	// envelope and signature of the final transaction are not known yet.
 	return &core.Message{
 		From:                  args.from(),
 		To:                    args.To,
@@ -461,7 +484,10 @@ func (args *TransactionArgs) ToMessage(baseFee *big.Int, skipNonceCheck, skipEoA
 		BlobGasFeeCap:         (*big.Int)(args.BlobFeeCap),
 		BlobHashes:            args.BlobHashes,
 		SetCodeAuthorizations: args.AuthorizationList,
-		SkipNonceChecks:       skipNonceCheck,
+		IsDepositTx:           args.isDeposit(),
+		Mint:                  (*big.Int)(args.Mint),
+		RollupCostData:        types.NewRollupCostData(args.data()),
+		SkipNonceChecks:       skipNonceCheck || args.isDeposit(),
 		SkipFromEOACheck:      skipEoACheck,
 	}
 }
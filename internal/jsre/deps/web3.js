@@ -4035,11 +4035,12 @@ var SolidityFunction = function (eth, json, address) {
     this._inputTypes = json.inputs.map(function (i) {
         return i.type;
     });
-    this._outputTypes = json.outputs.map(function (i) {
+    this._outputTypes = (json.outputs || []).map(function (i) {
         return i.type;
     });
-    this._constant = json.constant;
-    this._payable = json.payable;
+    // Newer compilers only report the state mutability of the functions
+    this._constant = json.constant || json.stateMutability === 'view' || json.stateMutability === 'pure';
+    this._payable = json.payable || json.stateMutability === 'payable';
     this._name = utils.transformToFullName(json);
     this._address = address;
 };
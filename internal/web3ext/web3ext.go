@@ -18,15 +18,17 @@
 package web3ext
 
 var Modules = map[string]string{
-	"admin":  AdminJs,
-	"clique": CliqueJs,
-	"debug":  DebugJs,
-	"eth":    EthJs,
-	"miner":  MinerJs,
-	"net":    NetJs,
-	"rpc":    RpcJs,
-	"txpool": TxpoolJs,
-	"dev":    DevJs,
+	"admin":    AdminJs,
+	"clique":   CliqueJs,
+	"debug":    DebugJs,
+	"eth":      EthJs,
+	"miner":    MinerJs,
+	"net":      NetJs,
+	"rpc":      RpcJs,
+	"txpool":   TxpoolJs,
+	"dev":      DevJs,
+	"optimism": OptimismJs,
+	"bundle":   BundleJs,
 }
 
 const CliqueJs = `
@@ -121,6 +123,11 @@ web3._extend({
 			call: 'admin_importChain',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'importTrustedBlocks',
+			call: 'admin_importTrustedBlocks',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'sleepBlocks',
 			call: 'admin_sleepBlocks',
@@ -183,8 +190,8 @@ web3._extend({
 		new web3._extend.Method({
 			name: 'accountRange',
 			call: 'debug_accountRange',
-			params: 6,
-			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter, null, null, null, null, null],
+			params: 7,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter, null, null, null, null, null, null],
 		}),
 		new web3._extend.Method({
 			name: 'printBlock',
@@ -217,6 +224,12 @@ web3._extend({
 			call: 'debug_setHead',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'verifyProofBatch',
+			call: 'debug_verifyProofBatch',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
 		new web3._extend.Method({
 			name: 'seedHash',
 			call: 'debug_seedHash',
@@ -225,8 +238,8 @@ web3._extend({
 		new web3._extend.Method({
 			name: 'dumpBlock',
 			call: 'debug_dumpBlock',
-			params: 1,
-			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
 		}),
 		new web3._extend.Method({
 			name: 'chaindbProperty',
@@ -380,6 +393,18 @@ web3._extend({
 			params: 2,
 			inputFormatter: [null, null]
 		}),
+		new web3._extend.Method({
+			name: 'exportStorageSnapshot',
+			call: 'debug_exportStorageSnapshot',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'traceBlockWithStateDiff',
+			call: 'debug_traceBlockWithStateDiff',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, null]
+		}),
 		new web3._extend.Method({
 			name: 'traceBlockByNumber',
 			call: 'debug_traceBlockByNumber',
@@ -398,6 +423,12 @@ web3._extend({
 			params: 2,
 			inputFormatter: [null, null]
 		}),
+		new web3._extend.Method({
+			name: 'traceTransactionWithOverrides',
+			call: 'debug_traceTransactionWithOverrides',
+			params: 3,
+			inputFormatter: [null, null, null]
+		}),
 		new web3._extend.Method({
 			name: 'traceCall',
 			call: 'debug_traceCall',
@@ -555,12 +586,23 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter, web3._extend.utils.toHex]
 		}),
+		new web3._extend.Method({
+			name: 'getDecodedReceipt',
+			call: 'eth_getDecodedReceipt',
+			params: 1
+		}),
 		new web3._extend.Method({
 			name: 'getProof',
 			call: 'eth_getProof',
 			params: 3,
 			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null, web3._extend.formatters.inputBlockNumberFormatter]
 		}),
+		new web3._extend.Method({
+			name: 'getAccountProof',
+			call: 'eth_getAccountProof',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'createAccessList',
 			call: 'eth_createAccessList',
@@ -595,6 +637,23 @@ web3._extend({
 			call: 'eth_getBlockReceipts',
 			params: 1,
 		}),
+		new web3._extend.Method({
+			name: 'getContractCreationTransaction',
+			call: 'eth_getContractCreationTransaction',
+			params: 1,
+		}),
+		new web3._extend.Method({
+			name: 'accountsByBalance',
+			call: 'eth_accountsByBalance',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getBlockReward',
+			call: 'eth_getBlockReward',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 	],
 	properties: [
 		new web3._extend.Property({
@@ -639,6 +698,20 @@ web3._extend({
 			params: 1,
 			inputFormatter: [web3._extend.utils.fromDecimal]
 		}),
+		new web3._extend.Method({
+			name: 'pendingBlock',
+			call: 'miner_pendingBlock',
+			params: 2,
+			inputFormatter: [web3._extend.utils.fromDecimal, web3._extend.formatters.inputAddressFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'pause',
+			call: 'miner_pause',
+		}),
+		new web3._extend.Method({
+			name: 'resume',
+			call: 'miner_resume',
+		}),
 	],
 	properties: []
 });
@@ -720,3 +793,54 @@ web3._extend({
 	],
 });
 `
+
+const OptimismJs = `
+web3._extend({
+	property: 'optimism',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'safeHeadAtL1Block',
+			call: 'optimism_safeHeadAtL1Block',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'sequencerHealthz',
+			call: 'optimism_sequencerHealthz',
+		}),
+		new web3._extend.Method({
+			name: 'depositStatus',
+			call: 'optimism_depositStatus',
+			params: 2,
+			inputFormatter: [null, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'gasPrice',
+			call: 'optimism_gasPrice',
+			outputFormatter: web3._extend.utils.toBigNumber
+		}),
+		new web3._extend.Method({
+			name: 'getL1GasUsed',
+			call: 'optimism_getL1GasUsed',
+			params: 1,
+			outputFormatter: web3._extend.utils.toDecimal
+		}),
+	],
+});
+`
+
+const BundleJs = `
+web3._extend({
+	property: 'bundle',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'debugBundles',
+			call: 'bundle_debugBundles',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+	],
+});
+`
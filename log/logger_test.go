@@ -2,6 +2,7 @@ package log
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -68,6 +69,46 @@ func TestJSONHandler(t *testing.T) {
 	}
 }
 
+// Tests that every line of the json handler is a standalone json object holding
+// the time, level, message and context of the record.
+func TestJSONHandlerLines(t *testing.T) {
+	out := new(bytes.Buffer)
+	logger := NewLogger(JSONHandler(out))
+	logger.Info("first", "number", 1, "hash", "0x01")
+	logger.Warn("second", "err", errors.New("failure"))
+	logger.Error("third", "big", big.NewInt(100))
+
+	want := []struct {
+		lvl, msg, key string
+	}{
+		{"info", "first", "number"},
+		{"warn", "second", "err"},
+		{"error", "third", "big"},
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("line count mismatch: have %d, want %d", len(lines), len(want))
+	}
+	for i, line := range lines {
+		var fields map[string]any
+		if err := json.Unmarshal([]byte(line), &fields); err != nil {
+			t.Fatalf("line %d: invalid json %q: %v", i, line, err)
+		}
+		if _, ok := fields["t"]; !ok {
+			t.Errorf("line %d: missing time: %s", i, line)
+		}
+		if fields["lvl"] != want[i].lvl {
+			t.Errorf("line %d: level mismatch: have %v, want %v", i, fields["lvl"], want[i].lvl)
+		}
+		if fields["msg"] != want[i].msg {
+			t.Errorf("line %d: message mismatch: have %v, want %v", i, fields["msg"], want[i].msg)
+		}
+		if _, ok := fields[want[i].key]; !ok {
+			t.Errorf("line %d: missing field %q: %s", i, want[i].key, line)
+		}
+	}
+}
+
 func BenchmarkTraceLogging(b *testing.B) {
 	SetDefault(NewLogger(NewTerminalHandler(io.Discard, true)))
 	b.ResetTimer()
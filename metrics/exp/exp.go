@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"sync"
+	"sync/atomic"
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
@@ -54,12 +55,46 @@ func ExpHandler(r metrics.Registry) http.Handler {
 	return http.HandlerFunc(e.expHandler)
 }
 
+var (
+	handlers     = http.NewServeMux()            // Additional endpoints of the dedicated metrics server
+	handlerSlots = make(map[string]*handlerSlot) // Registered endpoints, to swap their handler on re-registration
+	handlersLock sync.Mutex                      // Lock protecting the registration of endpoints
+)
+
+// handlerSlot is an endpoint of the metrics server whose handler can be replaced,
+// since a ServeMux panics if the same pattern is registered twice.
+type handlerSlot struct {
+	handler atomic.Pointer[http.Handler]
+}
+
+func (s *handlerSlot) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	(*s.handler.Load()).ServeHTTP(w, r)
+}
+
+// Handle registers an additional endpoint on the dedicated metrics server. It
+// can be called before or after the server is started. Registering the same
+// pattern again replaces the previous handler.
+func Handle(pattern string, handler http.Handler) {
+	handlersLock.Lock()
+	defer handlersLock.Unlock()
+
+	if slot, ok := handlerSlots[pattern]; ok {
+		slot.handler.Store(&handler)
+		return
+	}
+	slot := new(handlerSlot)
+	slot.handler.Store(&handler)
+	handlerSlots[pattern] = slot
+	handlers.Handle(pattern, slot)
+}
+
 // Setup starts a dedicated metrics server at the given address.
 // This function enables metrics reporting separate from pprof.
 func Setup(address string) {
 	m := http.NewServeMux()
 	m.Handle("/debug/metrics", ExpHandler(metrics.DefaultRegistry))
 	m.Handle("/debug/metrics/prometheus", prometheus.Handler(metrics.DefaultRegistry))
+	m.Handle("/", handlers)
 	log.Info("Starting metrics server", "addr", fmt.Sprintf("http://%s/debug/metrics", address))
 	go func() {
 		if err := http.ListenAndServe(address, m); err != nil {
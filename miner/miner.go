@@ -18,9 +18,11 @@
 package miner
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -62,6 +64,9 @@ var DefaultConfig = Config{
 	Recommit: 2 * time.Second,
 }
 
+// ErrPaused is returned when building a payload while block production is paused.
+var ErrPaused = errors.New("block production is paused")
+
 // Miner is the main object which takes care of submitting new work to consensus
 // engine and gathering the sealing result.
 type Miner struct {
@@ -73,7 +78,8 @@ type Miner struct {
 	prio        []common.Address // A list of senders to prioritize
 	chain       *core.BlockChain
 	pending     *pending
-	pendingMu   sync.Mutex // Lock protects the pending block
+	pendingMu   sync.Mutex  // Lock protects the pending block
+	paused      atomic.Bool // Whether building new payloads is refused
 }
 
 // New creates a new miner with provided config.
@@ -99,6 +105,18 @@ func (miner *Miner) Pending() (*types.Block, types.Receipts, *state.StateDB) {
 	return pending.block, pending.receipts, pending.stateDB.Copy()
 }
 
+// PendingWithOverrides builds a pending block on top of the current head with
+// the given timestamp and coinbase, returning it along with its receipts and
+// statedb. Unlike Pending, the block is built on every call and not cached.
+// Note, the consensus engine may still adjust the header when preparing it.
+func (miner *Miner) PendingWithOverrides(timestamp uint64, coinbase common.Address) (*types.Block, types.Receipts, *state.StateDB, error) {
+	ret := miner.generateWork(miner.pendingParams(miner.chain.CurrentHeader(), timestamp, true, coinbase), false)
+	if ret.err != nil {
+		return nil, nil, nil, ret.err
+	}
+	return ret.block, ret.receipts, ret.stateDB, nil
+}
+
 // SetExtra sets the content used to initialize the block extra field.
 func (miner *Miner) SetExtra(extra []byte) error {
 	if uint64(len(extra)) > params.MaximumExtraDataSize {
@@ -133,8 +151,28 @@ func (miner *Miner) SetGasTip(tip *big.Int) error {
 	return nil
 }
 
+// Pause stops the miner from building new payloads until Resume is called. The
+// payloads being built are not interrupted, and the pending block is still
+// maintained.
+func (miner *Miner) Pause() {
+	miner.paused.Store(true)
+}
+
+// Resume lets the miner build payloads again after a Pause.
+func (miner *Miner) Resume() {
+	miner.paused.Store(false)
+}
+
+// Paused returns whether building new payloads is paused.
+func (miner *Miner) Paused() bool {
+	return miner.paused.Load()
+}
+
 // BuildPayload builds the payload according to the provided parameters.
 func (miner *Miner) BuildPayload(args *BuildPayloadArgs, witness bool) (*Payload, error) {
+	if miner.paused.Load() {
+		return nil, ErrPaused
+	}
 	return miner.buildPayload(args, witness)
 }
 
@@ -148,26 +186,30 @@ func (miner *Miner) getPending() *newPayloadResult {
 		return cached
 	}
 
-	var (
-		timestamp  = uint64(time.Now().Unix())
-		withdrawal types.Withdrawals
-	)
+	params := miner.pendingParams(header, uint64(time.Now().Unix()), false, miner.config.PendingFeeRecipient)
+	ret := miner.generateWork(params, false) // we will never make a witness for a pending block
+	if ret.err != nil {
+		return nil
+	}
+	miner.pending.update(header.Hash(), ret)
+	return ret
+}
+
+// pendingParams returns the parameters to build a pending block on top of the
+// given header.
+func (miner *Miner) pendingParams(header *types.Header, timestamp uint64, forceTime bool, coinbase common.Address) *generateParams {
+	var withdrawal types.Withdrawals
 	if miner.chainConfig.IsShanghai(new(big.Int).Add(header.Number, big.NewInt(1)), timestamp) {
 		withdrawal = []*types.Withdrawal{}
 	}
-	ret := miner.generateWork(&generateParams{
+	return &generateParams{
 		timestamp:   timestamp,
-		forceTime:   false,
+		forceTime:   forceTime,
 		parentHash:  header.Hash(),
-		coinbase:    miner.config.PendingFeeRecipient,
+		coinbase:    coinbase,
 		random:      common.Hash{},
 		withdrawals: withdrawal,
 		beaconRoot:  nil,
 		noTxs:       false,
-	}, false) // we will never make a witness for a pending block
-	if ret.err != nil {
-		return nil
 	}
-	miner.pending.update(header.Hash(), ret)
-	return ret
 }
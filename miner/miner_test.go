@@ -21,9 +21,11 @@ import (
 	"math/big"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/clique"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -106,6 +108,42 @@ func TestBuildPendingBlocks(t *testing.T) {
 	wg.Wait()
 }
 
+func TestPendingWithOverrides(t *testing.T) {
+	var (
+		db       = rawdb.NewMemoryDatabase()
+		engine   = ethash.NewFaker()
+		genesis  = &core.Genesis{Config: params.TestChainConfig, BaseFee: big.NewInt(params.InitialBaseFee)}
+		coinbase = common.HexToAddress("0xc0ffee")
+	)
+	bc, err := core.NewBlockChain(db, genesis, engine, nil)
+	if err != nil {
+		t.Fatalf("can't create new chain %v", err)
+	}
+	defer bc.Stop()
+
+	pool := legacypool.New(testTxPoolConfig, bc)
+	txpool, _ := txpool.New(testTxPoolConfig.PriceLimit, bc, []txpool.SubPool{pool})
+	defer txpool.Close()
+
+	miner := New(NewMockBackend(bc, txpool), Config{PendingFeeRecipient: common.HexToAddress("123456789")}, engine)
+
+	timestamp := uint64(time.Now().Unix()) + 3600
+	block, _, _, err := miner.PendingWithOverrides(timestamp, coinbase)
+	if err != nil {
+		t.Fatalf("failed to build pending block: %v", err)
+	}
+	if block.Time() != timestamp {
+		t.Errorf("timestamp mismatch: have %d, want %d", block.Time(), timestamp)
+	}
+	if block.Coinbase() != coinbase {
+		t.Errorf("coinbase mismatch: have %x, want %x", block.Coinbase(), coinbase)
+	}
+	// Timestamps not after the parent are rejected
+	if _, _, _, err := miner.PendingWithOverrides(bc.CurrentHeader().Time, coinbase); err == nil {
+		t.Error("pending block built with the timestamp of its parent")
+	}
+}
+
 func minerTestGenesisBlock(period uint64, gasLimit uint64, faucet common.Address) *core.Genesis {
 	config := *params.AllCliqueProtocolChanges
 	config.Clique = &params.CliqueConfig{
@@ -17,6 +17,8 @@
 package miner
 
 import (
+	"crypto/ecdsa"
+	"errors"
 	"math/big"
 	"reflect"
 	"testing"
@@ -193,6 +195,32 @@ func TestBuildPayload(t *testing.T) {
 	}
 }
 
+// Tests that no payload is built while the miner is paused, the pending block
+// still being available.
+func TestBuildPayloadPaused(t *testing.T) {
+	w, b := newTestWorker(t, params.TestChainConfig, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+	args := &BuildPayloadArgs{
+		Parent:       b.chain.CurrentBlock().Hash(),
+		Timestamp:    uint64(time.Now().Unix()),
+		FeeRecipient: common.HexToAddress("0xdeadbeef"),
+	}
+	w.Pause()
+	if _, err := w.BuildPayload(args, false); !errors.Is(err, ErrPaused) {
+		t.Fatalf("payload built while paused: have %v, want %v", err, ErrPaused)
+	}
+	if block, _, _ := w.Pending(); block == nil || len(block.Transactions()) != len(pendingTxs) {
+		t.Fatal("pending block unavailable while paused")
+	}
+	w.Resume()
+	payload, err := w.BuildPayload(args, false)
+	if err != nil {
+		t.Fatalf("failed to build payload after resume: %v", err)
+	}
+	if txs := len(payload.ResolveFull().ExecutionPayload.Transactions); txs != len(pendingTxs) {
+		t.Errorf("transaction count mismatch: have %d, want %d", txs, len(pendingTxs))
+	}
+}
+
 func TestPayloadId(t *testing.T) {
 	t.Parallel()
 	ids := make(map[string]int)
@@ -269,3 +297,109 @@ func TestPayloadId(t *testing.T) {
 		ids[id] = i
 	}
 }
+
+// BenchmarkGenerateWork measures the time needed to build a block of 500 value
+// transfers from 50 senders.
+func BenchmarkGenerateWork(b *testing.B) {
+	var (
+		signer = types.LatestSigner(params.TestChainConfig)
+		keys   = make([]*ecdsa.PrivateKey, 50)
+		gspec  = &core.Genesis{
+			Config:   params.TestChainConfig,
+			GasLimit: 30_000_000,
+			Alloc:    types.GenesisAlloc{},
+		}
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		gspec.Alloc[crypto.PubkeyToAddress(keys[i].PublicKey)] = types.Account{Balance: testBankFunds}
+	}
+	engine := ethash.NewFaker()
+	chain, err := core.NewBlockChain(rawdb.NewMemoryDatabase(), gspec, engine, nil)
+	if err != nil {
+		b.Fatalf("failed to create chain: %v", err)
+	}
+	defer chain.Stop()
+
+	pool := legacypool.New(testTxPoolConfig, chain)
+	txpool, _ := txpool.New(testTxPoolConfig.PriceLimit, chain, []txpool.SubPool{pool})
+	defer txpool.Close()
+
+	var txs []*types.Transaction
+	for nonce := uint64(0); nonce < 10; nonce++ {
+		for _, key := range keys {
+			txs = append(txs, types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+				ChainID:   signer.ChainID(),
+				Nonce:     nonce,
+				To:        &testUserAddress,
+				Value:     big.NewInt(1),
+				Gas:       params.TxGas,
+				GasFeeCap: big.NewInt(10 * params.InitialBaseFee),
+				GasTipCap: big.NewInt(params.GWei),
+			}))
+		}
+	}
+	for _, err := range txpool.Add(txs, true) {
+		if err != nil {
+			b.Fatalf("failed to add transaction: %v", err)
+		}
+	}
+	config := testConfig
+	config.GasCeil = gspec.GasLimit
+	miner := New(&testWorkerBackend{chain: chain, txPool: txpool}, config, engine)
+
+	params := &generateParams{
+		parentHash: chain.CurrentBlock().Hash(),
+		timestamp:  chain.CurrentBlock().Time + 1,
+		forceTime:  true,
+		coinbase:   testBankAddress,
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		res := miner.generateWork(params, false)
+		if res.err != nil {
+			b.Fatalf("failed to generate work: %v", res.err)
+		}
+		if len(res.block.Transactions()) != len(txs) {
+			b.Fatalf("transaction count mismatch: have %d, want %d", len(res.block.Transactions()), len(txs))
+		}
+	}
+}
+
+// Tests that the transactions not fitting into the body size limit of the chain
+// are left out of the built blocks.
+func TestBuildPayloadBodySizeLimit(t *testing.T) {
+	limit := uint64(2048)
+	config := *params.TestChainConfig
+	config.MaxBlockBodySize = &limit
+
+	w, b := newTestWorker(t, &config, ethash.NewFaker(), rawdb.NewMemoryDatabase(), 0)
+	// Each transaction takes more than half of the body size limit
+	signer := types.LatestSigner(&config)
+	var txs []*types.Transaction
+	for nonce := uint64(1); nonce <= 2; nonce++ {
+		txs = append(txs, types.MustSignNewTx(testBankKey, signer, &types.LegacyTx{
+			Nonce:    nonce,
+			To:       &testUserAddress,
+			Gas:      100_000,
+			GasPrice: big.NewInt(params.InitialBaseFee),
+			Data:     make([]byte, limit/2),
+		}))
+	}
+	b.txPool.Add(txs, true)
+
+	res := w.generateWork(&generateParams{
+		parentHash: b.chain.CurrentBlock().Hash(),
+		timestamp:  uint64(time.Now().Unix()),
+		coinbase:   testBankAddress,
+	}, false)
+	if res.err != nil {
+		t.Fatalf("failed to build block: %v", res.err)
+	}
+	if have, want := len(res.block.Transactions()), len(pendingTxs)+1; have != want {
+		t.Errorf("transaction count mismatch: have %d, want %d", have, want)
+	}
+	if size := res.block.Body().Size(); size > limit {
+		t.Errorf("body size above the limit: have %d, want at most %d", size, limit)
+	}
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/holiman/uint256"
 )
 
@@ -59,10 +60,15 @@ type environment struct {
 	receipts []*types.Receipt
 	sidecars []*types.BlobTxSidecar
 	blobs    int
+	size     uint64 // encoded size of the block body, with room for its list headers to grow
 
 	witness *stateless.Witness
 }
 
+// bodyHeaderGrowth is the space reserved in a block body for the headers of the
+// body and transaction lists to grow, as transactions are added.
+const bodyHeaderGrowth = 2 * 8
+
 const (
 	commitInterruptNone int32 = iota
 	commitInterruptNewHead
@@ -199,7 +205,7 @@ func (miner *Miner) prepareWork(genParams *generateParams, witness bool) (*envir
 	}
 	// Set baseFee and GasLimit if we are on an EIP-1559 chain
 	if miner.chainConfig.IsLondon(header.Number) {
-		header.BaseFee = eip1559.CalcBaseFee(miner.chainConfig, parent)
+		header.BaseFee = eip1559.CalcBaseFee(miner.chainConfig, parent, header.Time)
 		if !miner.chainConfig.IsLondon(parent.Number) {
 			parentGasLimit := parent.GasLimit * miner.chainConfig.ElasticityMultiplier()
 			header.GasLimit = core.CalcGasLimit(parentGasLimit, miner.config.GasCeil)
@@ -229,6 +235,7 @@ func (miner *Miner) prepareWork(genParams *generateParams, witness bool) (*envir
 		log.Error("Failed to create sealing context", "err", err)
 		return nil, err
 	}
+	env.size = (&types.Body{Withdrawals: genParams.withdrawals}).Size() + bodyHeaderGrowth
 	if header.ParentBeaconRoot != nil {
 		core.ProcessBeaconBlockRoot(*header.ParentBeaconRoot, env.evm)
 	}
@@ -391,6 +398,14 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 			continue
 		}
 
+		// If the transaction doesn't fit into the body size limit, skip the account.
+		size := bodySize(tx)
+		if limit, ok := miner.chainConfig.BlockBodySizeLimit(); ok && env.size+size > limit {
+			log.Trace("Not enough body space left for transaction", "hash", ltx.Hash, "used", env.size, "limit", limit, "needed", size)
+			txs.Pop()
+			continue
+		}
+
 		// Make sure all transactions after osaka have cell proofs
 		if miner.chainConfig.IsOsaka(env.header.Number, env.header.Time) {
 			if sidecar := tx.BlobTxSidecar(); sidecar != nil {
@@ -431,6 +446,7 @@ func (miner *Miner) commitTransactions(env *environment, plainTxs, blobTxs *tran
 
 		case errors.Is(err, nil):
 			// Everything ok, collect the logs and shift in the next transaction from the same account
+			env.size += size
 			txs.Shift()
 
 		default:
@@ -527,3 +543,14 @@ func signalToErr(signal int32) error {
 		panic(fmt.Errorf("undefined signal %d", signal))
 	}
 }
+
+// bodySize returns the encoded size of a transaction within a block body, which
+// carries blob transactions without their sidecars.
+func bodySize(tx *types.Transaction) uint64 {
+	tx = tx.WithoutBlobTxSidecar()
+	if tx.Type() == types.LegacyTxType {
+		return tx.Size()
+	}
+	// Typed transactions are wrapped into an RLP string
+	return rlp.ListSize(tx.Size())
+}
@@ -102,6 +102,11 @@ type Config struct {
 	// relative), then that specific path is enforced. An empty path disables IPC.
 	IPCPath string
 
+	// IPCAllowedUIDs restricts the IPC endpoint to the processes running under
+	// the given user IDs. An empty list allows all users with access to the
+	// socket. Only supported on Linux.
+	IPCAllowedUIDs []uint32 `toml:",omitempty"`
+
 	// HTTPHost is the host interface on which to start the HTTP RPC server. If this
 	// field is empty, no HTTP API endpoint will be started.
 	HTTPHost string
@@ -585,16 +585,17 @@ func newGzipHandler(next http.Handler) http.Handler {
 }
 
 type ipcServer struct {
-	log      log.Logger
-	endpoint string
+	log         log.Logger
+	endpoint    string
+	allowedUIDs []uint32
 
 	mu       sync.Mutex
 	listener net.Listener
 	srv      *rpc.Server
 }
 
-func newIPCServer(log log.Logger, endpoint string) *ipcServer {
-	return &ipcServer{log: log, endpoint: endpoint}
+func newIPCServer(log log.Logger, endpoint string, allowedUIDs []uint32) *ipcServer {
+	return &ipcServer{log: log, endpoint: endpoint, allowedUIDs: allowedUIDs}
 }
 
 // start starts the httpServer's http.Server
@@ -605,7 +606,7 @@ func (is *ipcServer) start(apis []rpc.API) error {
 	if is.listener != nil {
 		return nil // already running
 	}
-	listener, srv, err := rpc.StartIPCEndpoint(is.endpoint, apis)
+	listener, srv, err := rpc.StartIPCEndpointWithUIDs(is.endpoint, apis, is.allowedUIDs)
 	if err != nil {
 		is.log.Warn("IPC opening failed", "url", is.endpoint, "error", err)
 		return err
@@ -19,8 +19,10 @@ package params
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"math/big"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params/forks"
@@ -431,10 +433,28 @@ type ChainConfig struct {
 	// those cases.
 	EnableVerkleAtGenesis bool `json:"enableVerkleAtGenesis,omitempty"`
 
+	// DifficultyBombDelaySchedule overrides the difficulty bomb delays of the
+	// ethash forks, mapping block numbers to the delay in effect from them on.
+	// Blocks before the first entry use the delays of the configured forks.
+	DifficultyBombDelaySchedule map[uint64]*big.Int `json:"difficultyBombDelaySchedule,omitempty"`
+
 	// Various consensus engines
 	Ethash             *EthashConfig       `json:"ethash,omitempty"`
 	Clique             *CliqueConfig       `json:"clique,omitempty"`
 	BlobScheduleConfig *BlobScheduleConfig `json:"blobSchedule,omitempty"`
+
+	// OP-Stack specific configuration, nil for non OP-Stack chains
+	Optimism           *OptimismConfig `json:"optimism,omitempty"`
+	L1FeeOracleAddress *common.Address `json:"l1FeeOracleAddress,omitempty"` // L1 fee oracle override (nil = standard predeploy)
+	MaxBlockBodySize   *uint64         `json:"maxBlockBodySize,omitempty"`   // Block body size limit (nil = unlimited)
+
+	RegolithTime *uint64 `json:"regolithTime,omitempty"` // Regolith switch time (nil = no fork, 0 = already on regolith)
+	CanyonTime   *uint64 `json:"canyonTime,omitempty"`   // Canyon switch time (nil = no fork, 0 = already on canyon)
+	DeltaTime    *uint64 `json:"deltaTime,omitempty"`    // Delta switch time (nil = no fork, 0 = already on delta)
+	EcotoneTime  *uint64 `json:"ecotoneTime,omitempty"`  // Ecotone switch time (nil = no fork, 0 = already on ecotone)
+	FjordTime    *uint64 `json:"fjordTime,omitempty"`    // Fjord switch time (nil = no fork, 0 = already on fjord)
+	HoloceneTime *uint64 `json:"holoceneTime,omitempty"` // Holocene switch time (nil = no fork, 0 = already on holocene)
+	IsthmusTime  *uint64 `json:"isthmusTime,omitempty"`  // Isthmus switch time (nil = no fork, 0 = already on isthmus)
 }
 
 // EthashConfig is the consensus engine configs for proof-of-work based sealing.
@@ -456,6 +476,28 @@ func (c CliqueConfig) String() string {
 	return fmt.Sprintf("clique(period: %d, epoch: %d)", c.Period, c.Epoch)
 }
 
+// OptimismConfig is the OP-Stack specific configuration of a rollup chain.
+type OptimismConfig struct {
+	EIP1559Elasticity  uint64 `json:"eip1559Elasticity"`  // Elasticity multiplier of the L2 base fee market
+	EIP1559Denominator uint64 `json:"eip1559Denominator"` // Base fee change denominator of the L2 base fee market
+
+	EIP1559DenominatorCanyon uint64 `json:"eip1559DenominatorCanyon,omitempty"` // Base fee change denominator after Canyon (0 = unchanged)
+
+	UsePermissionlessGame bool `json:"usePermissionlessGame,omitempty"` // Whether withdrawals are proven against permissionless dispute games
+
+	DepositGasStipend bool `json:"depositGasStipend,omitempty"` // Whether deposits sending ether to a contract get the DepositGasStipend
+
+	BlockTime           uint64 `json:"blockTime,omitempty"`     // Seconds between the L2 blocks
+	SequencerWindowSize uint64 `json:"seqWindowSize,omitempty"` // Number of L1 blocks the batches of an L1 origin may be included in
+
+	GenesisHash common.Hash `json:"l2GenesisHash,omitempty"` // Expected hash of the L2 genesis block (zero = unchecked)
+}
+
+// String implements the stringer interface, returning the rollup details.
+func (c OptimismConfig) String() string {
+	return fmt.Sprintf("optimism(eip1559Elasticity: %d, eip1559Denominator: %d)", c.EIP1559Elasticity, c.EIP1559Denominator)
+}
+
 // Description returns a human-readable description of ChainConfig.
 func (c *ChainConfig) Description() string {
 	var banner string
@@ -531,6 +573,31 @@ func (c *ChainConfig) Description() string {
 	if c.VerkleTime != nil {
 		banner += fmt.Sprintf(" - Verkle:                      @%-10v\n", *c.VerkleTime)
 	}
+	if c.Optimism != nil {
+		banner += "\n"
+		banner += "OP-Stack hard forks (timestamp based):\n"
+		if c.RegolithTime != nil {
+			banner += fmt.Sprintf(" - Regolith:                    @%-10v\n", *c.RegolithTime)
+		}
+		if c.CanyonTime != nil {
+			banner += fmt.Sprintf(" - Canyon:                      @%-10v\n", *c.CanyonTime)
+		}
+		if c.DeltaTime != nil {
+			banner += fmt.Sprintf(" - Delta:                       @%-10v\n", *c.DeltaTime)
+		}
+		if c.EcotoneTime != nil {
+			banner += fmt.Sprintf(" - Ecotone:                     @%-10v\n", *c.EcotoneTime)
+		}
+		if c.FjordTime != nil {
+			banner += fmt.Sprintf(" - Fjord:                       @%-10v\n", *c.FjordTime)
+		}
+		if c.HoloceneTime != nil {
+			banner += fmt.Sprintf(" - Holocene:                    @%-10v\n", *c.HoloceneTime)
+		}
+		if c.IsthmusTime != nil {
+			banner += fmt.Sprintf(" - Isthmus:                     @%-10v\n", *c.IsthmusTime)
+		}
+	}
 	return banner
 }
 
@@ -765,6 +832,9 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 		}
 	}
 
+	if err := ValidateDifficultyBombSchedule(c); err != nil {
+		return err
+	}
 	// Check that all forks with blobs explicitly define the blob schedule configuration.
 	bsc := c.BlobScheduleConfig
 	if bsc == nil {
@@ -794,6 +864,41 @@ func (c *ChainConfig) CheckConfigForkOrder() error {
 	return nil
 }
 
+// ValidateDifficultyBombSchedule checks that the delays of the difficulty bomb
+// schedule are defined and don't decrease over the block numbers.
+func ValidateDifficultyBombSchedule(cfg *ChainConfig) error {
+	var (
+		blocks = slices.Sorted(maps.Keys(cfg.DifficultyBombDelaySchedule))
+		last   *big.Int
+	)
+	for _, number := range blocks {
+		delay := cfg.DifficultyBombDelaySchedule[number]
+		switch {
+		case delay == nil || delay.Sign() < 0:
+			return fmt.Errorf("invalid difficulty bomb delay at block %d: %v", number, delay)
+		case last != nil && delay.Cmp(last) < 0:
+			return fmt.Errorf("unsupported difficulty bomb schedule: delay %v at block %d lower than the previous delay %v", delay, number, last)
+		}
+		last = delay
+	}
+	return nil
+}
+
+// DifficultyBombDelay returns the difficulty bomb delay configured by the
+// schedule for the given block, or nil if the schedule doesn't cover it.
+func (c *ChainConfig) DifficultyBombDelay(num *big.Int) *big.Int {
+	var (
+		delay *big.Int
+		from  uint64
+	)
+	for number, d := range c.DifficultyBombDelaySchedule {
+		if isBlockForked(new(big.Int).SetUint64(number), num) && (delay == nil || number >= from) {
+			delay, from = d, number
+		}
+	}
+	return delay
+}
+
 func (bc *BlobConfig) validate() error {
 	if bc.Max < 0 {
 		return errors.New("max < 0")
@@ -863,6 +968,9 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, headNumber *big.Int,
 	if isForkBlockIncompatible(c.MergeNetsplitBlock, newcfg.MergeNetsplitBlock, headNumber) {
 		return newBlockCompatError("Merge netsplit fork block", c.MergeNetsplitBlock, newcfg.MergeNetsplitBlock)
 	}
+	if stored, updated, ok := difficultyBombScheduleConflict(c.DifficultyBombDelaySchedule, newcfg.DifficultyBombDelaySchedule, headNumber); ok {
+		return newBlockCompatError("Difficulty bomb delay schedule", stored, updated)
+	}
 	if isForkTimestampIncompatible(c.ShanghaiTime, newcfg.ShanghaiTime, headTimestamp) {
 		return newTimestampCompatError("Shanghai fork timestamp", c.ShanghaiTime, newcfg.ShanghaiTime)
 	}
@@ -878,19 +986,116 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, headNumber *big.Int,
 	if isForkTimestampIncompatible(c.VerkleTime, newcfg.VerkleTime, headTimestamp) {
 		return newTimestampCompatError("Verkle fork timestamp", c.VerkleTime, newcfg.VerkleTime)
 	}
+	if isForkTimestampIncompatible(c.RegolithTime, newcfg.RegolithTime, headTimestamp) {
+		return newTimestampCompatError("Regolith fork timestamp", c.RegolithTime, newcfg.RegolithTime)
+	}
+	if isForkTimestampIncompatible(c.CanyonTime, newcfg.CanyonTime, headTimestamp) {
+		return newTimestampCompatError("Canyon fork timestamp", c.CanyonTime, newcfg.CanyonTime)
+	}
+	if isForkTimestampIncompatible(c.DeltaTime, newcfg.DeltaTime, headTimestamp) {
+		return newTimestampCompatError("Delta fork timestamp", c.DeltaTime, newcfg.DeltaTime)
+	}
+	if isForkTimestampIncompatible(c.EcotoneTime, newcfg.EcotoneTime, headTimestamp) {
+		return newTimestampCompatError("Ecotone fork timestamp", c.EcotoneTime, newcfg.EcotoneTime)
+	}
+	if isForkTimestampIncompatible(c.FjordTime, newcfg.FjordTime, headTimestamp) {
+		return newTimestampCompatError("Fjord fork timestamp", c.FjordTime, newcfg.FjordTime)
+	}
+	if isForkTimestampIncompatible(c.HoloceneTime, newcfg.HoloceneTime, headTimestamp) {
+		return newTimestampCompatError("Holocene fork timestamp", c.HoloceneTime, newcfg.HoloceneTime)
+	}
+	if isForkTimestampIncompatible(c.IsthmusTime, newcfg.IsthmusTime, headTimestamp) {
+		return newTimestampCompatError("Isthmus fork timestamp", c.IsthmusTime, newcfg.IsthmusTime)
+	}
 	return nil
 }
 
-// BaseFeeChangeDenominator bounds the amount the base fee can change between blocks.
-func (c *ChainConfig) BaseFeeChangeDenominator() uint64 {
+// BaseFeeChangeDenominator bounds the amount the base fee can change between
+// blocks, for a block at the given time.
+func (c *ChainConfig) BaseFeeChangeDenominator(time uint64) uint64 {
+	if c.IsCanyon(time) && c.Optimism.EIP1559DenominatorCanyon != 0 {
+		return c.Optimism.EIP1559DenominatorCanyon
+	}
+	if c.Optimism != nil && c.Optimism.EIP1559Denominator != 0 {
+		return c.Optimism.EIP1559Denominator
+	}
 	return DefaultBaseFeeChangeDenominator
 }
 
 // ElasticityMultiplier bounds the maximum gas limit an EIP-1559 block may have.
 func (c *ChainConfig) ElasticityMultiplier() uint64 {
+	if c.Optimism != nil && c.Optimism.EIP1559Elasticity != 0 {
+		return c.Optimism.EIP1559Elasticity
+	}
 	return DefaultElasticityMultiplier
 }
 
+// IsOptimism returns whether the chain is an OP-Stack rollup.
+func (c *ChainConfig) IsOptimism() bool {
+	return c.Optimism != nil
+}
+
+// IsRegolith returns whether time is either equal to the Regolith fork time or
+// greater on an OP-Stack chain.
+func (c *ChainConfig) IsRegolith(time uint64) bool {
+	return c.IsOptimism() && isTimestampForked(c.RegolithTime, time)
+}
+
+// IsCanyon returns whether time is either equal to the Canyon fork time or
+// greater on an OP-Stack chain.
+func (c *ChainConfig) IsCanyon(time uint64) bool {
+	return c.IsOptimism() && isTimestampForked(c.CanyonTime, time)
+}
+
+// IsDelta returns whether time is either equal to the Delta fork time or
+// greater on an OP-Stack chain.
+func (c *ChainConfig) IsDelta(time uint64) bool {
+	return c.IsOptimism() && isTimestampForked(c.DeltaTime, time)
+}
+
+// IsEcotone returns whether time is either equal to the Ecotone fork time or
+// greater on an OP-Stack chain.
+func (c *ChainConfig) IsEcotone(time uint64) bool {
+	return c.IsOptimism() && isTimestampForked(c.EcotoneTime, time)
+}
+
+// IsFjord returns whether time is either equal to the Fjord fork time or
+// greater on an OP-Stack chain.
+func (c *ChainConfig) IsFjord(time uint64) bool {
+	return c.IsOptimism() && isTimestampForked(c.FjordTime, time)
+}
+
+// IsHolocene returns whether time is either equal to the Holocene fork time or
+// greater on an OP-Stack chain.
+func (c *ChainConfig) IsHolocene(time uint64) bool {
+	return c.IsOptimism() && isTimestampForked(c.HoloceneTime, time)
+}
+
+// IsIsthmus returns whether time is either equal to the Isthmus fork time or
+// greater on an OP-Stack chain.
+func (c *ChainConfig) IsIsthmus(time uint64) bool {
+	return c.IsOptimism() && isTimestampForked(c.IsthmusTime, time)
+}
+
+// L1FeeOracle returns the address of the contract holding the L1 fee parameters
+// on OP-Stack chains.
+func (c *ChainConfig) L1FeeOracle() common.Address {
+	if c.L1FeeOracleAddress != nil {
+		return *c.L1FeeOracleAddress
+	}
+	return DefaultL1FeeOracleAddress
+}
+
+// BlockBodySizeLimit returns the maximum encoded size of a block body, and whether
+// the size is limited at all. Only the chains setting a limit in their config are,
+// as enforcing one on a live chain would reject its existing blocks.
+func (c *ChainConfig) BlockBodySizeLimit() (uint64, bool) {
+	if c.MaxBlockBodySize != nil {
+		return *c.MaxBlockBodySize, true
+	}
+	return 0, false
+}
+
 // LatestFork returns the latest time-based fork that would be active for the given time.
 func (c *ChainConfig) LatestFork(time uint64) forks.Fork {
 	// Assume last non-time-based fork has passed.
@@ -933,6 +1138,39 @@ func isForkBlockIncompatible(s1, s2, head *big.Int) bool {
 	return (isBlockForked(s1, head) || isBlockForked(s2, head)) && !configBlockEqual(s1, s2)
 }
 
+// difficultyBombScheduleConflict returns the first entry at or before the head
+// block that differs between the two difficulty bomb schedules. The block of the
+// entry is returned for each schedule, or nil if the schedule lacks the entry.
+func difficultyBombScheduleConflict(s1, s2 map[uint64]*big.Int, head *big.Int) (*big.Int, *big.Int, bool) {
+	numbers := make(map[uint64]struct{}, len(s1)+len(s2))
+	for number := range s1 {
+		numbers[number] = struct{}{}
+	}
+	for number := range s2 {
+		numbers[number] = struct{}{}
+	}
+	for _, number := range slices.Sorted(maps.Keys(numbers)) {
+		block := new(big.Int).SetUint64(number)
+		if !isBlockForked(block, head) {
+			break
+		}
+		d1, ok1 := s1[number]
+		d2, ok2 := s2[number]
+		if ok1 == ok2 && configBlockEqual(d1, d2) {
+			continue
+		}
+		var stored, updated *big.Int
+		if ok1 {
+			stored = block
+		}
+		if ok2 {
+			updated = block
+		}
+		return stored, updated, true
+	}
+	return nil, nil, false
+}
+
 // isBlockForked returns whether a fork scheduled at block s is active at the
 // given head block. Whilst this method is the same as isTimestampForked, they
 // are explicitly separate for clearer reading.
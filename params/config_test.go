@@ -93,6 +93,34 @@ func TestCheckCompatible(t *testing.T) {
 				RewindToBlock: 30,
 			},
 		},
+		{
+			stored:    &ChainConfig{DifficultyBombDelaySchedule: map[uint64]*big.Int{10: big.NewInt(1000)}},
+			new:       &ChainConfig{DifficultyBombDelaySchedule: map[uint64]*big.Int{10: big.NewInt(1000), 30: big.NewInt(2000)}},
+			headBlock: 20,
+			wantErr:   nil,
+		},
+		{
+			stored:    &ChainConfig{DifficultyBombDelaySchedule: map[uint64]*big.Int{10: big.NewInt(1000), 20: big.NewInt(2000)}},
+			new:       &ChainConfig{DifficultyBombDelaySchedule: map[uint64]*big.Int{10: big.NewInt(1000), 20: big.NewInt(3000)}},
+			headBlock: 25,
+			wantErr: &ConfigCompatError{
+				What:          "Difficulty bomb delay schedule",
+				StoredBlock:   big.NewInt(20),
+				NewBlock:      big.NewInt(20),
+				RewindToBlock: 19,
+			},
+		},
+		{
+			stored:    &ChainConfig{DifficultyBombDelaySchedule: map[uint64]*big.Int{20: big.NewInt(2000)}},
+			new:       &ChainConfig{DifficultyBombDelaySchedule: map[uint64]*big.Int{15: big.NewInt(1000), 20: big.NewInt(2000)}},
+			headBlock: 15,
+			wantErr: &ConfigCompatError{
+				What:          "Difficulty bomb delay schedule",
+				StoredBlock:   nil,
+				NewBlock:      big.NewInt(15),
+				RewindToBlock: 14,
+			},
+		},
 		{
 			stored:        &ChainConfig{ShanghaiTime: newUint64(10)},
 			new:           &ChainConfig{ShanghaiTime: newUint64(20)},
@@ -155,3 +183,57 @@ func TestTimestampCompatError(t *testing.T) {
 	require.Equal(t, newTimestampCompatError(errWhat, newUint64(0), newUint64(1681338455)).Error(),
 		"mismatching Shanghai fork timestamp in database (have timestamp 0, want timestamp 1681338455, rewindto timestamp 0)")
 }
+
+func TestValidateDifficultyBombSchedule(t *testing.T) {
+	tests := []struct {
+		schedule map[uint64]*big.Int
+		valid    bool
+	}{
+		{nil, true},
+		{map[uint64]*big.Int{0: big.NewInt(0)}, true},
+		{map[uint64]*big.Int{100: big.NewInt(1000), 200: big.NewInt(1000), 300: big.NewInt(2000)}, true},
+		{map[uint64]*big.Int{100: big.NewInt(2000), 200: big.NewInt(1000)}, false},
+		{map[uint64]*big.Int{100: nil}, false},
+		{map[uint64]*big.Int{100: big.NewInt(-1)}, false},
+	}
+	for i, tt := range tests {
+		err := ValidateDifficultyBombSchedule(&ChainConfig{DifficultyBombDelaySchedule: tt.schedule})
+		if (err == nil) != tt.valid {
+			t.Errorf("test %d: validation mismatch: have %v, want valid %v", i, err, tt.valid)
+		}
+	}
+	config := &ChainConfig{DifficultyBombDelaySchedule: map[uint64]*big.Int{100: big.NewInt(1000), 200: big.NewInt(2000)}}
+	for number, want := range map[int64]*big.Int{99: nil, 100: big.NewInt(1000), 199: big.NewInt(1000), 200: big.NewInt(2000)} {
+		if have := config.DifficultyBombDelay(big.NewInt(number)); (have == nil) != (want == nil) || (want != nil && have.Cmp(want) != 0) {
+			t.Errorf("block %d: delay mismatch: have %v, want %v", number, have, want)
+		}
+	}
+}
+
+func TestBlockBodySizeLimit(t *testing.T) {
+	limit := uint64(1024)
+	op := *TestChainConfig
+	op.Optimism = &OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+	capped := op
+	capped.MaxBlockBodySize = &limit
+	l1 := *TestChainConfig
+	l1.MaxBlockBodySize = &limit
+
+	tests := []struct {
+		name   string
+		config *ChainConfig
+		limit  uint64
+		ok     bool
+	}{
+		{"mainnet", MainnetChainConfig, 0, false},
+		{"merged", MergedTestChainConfig, 0, false},
+		{"op default", &op, 0, false},
+		{"op configured", &capped, limit, true},
+		{"configured", &l1, limit, true},
+	}
+	for _, tt := range tests {
+		if limit, ok := tt.config.BlockBodySizeLimit(); limit != tt.limit || ok != tt.ok {
+			t.Errorf("%s: limit mismatch: have %d, %v, want %d, %v", tt.name, limit, ok, tt.limit, tt.ok)
+		}
+	}
+}
@@ -39,6 +39,7 @@ const (
 	QuadCoeffDiv          uint64 = 512   // Divisor for the quadratic particle of the memory cost equation.
 	LogDataGas            uint64 = 8     // Per byte in a LOG* operation's data.
 	CallStipend           uint64 = 2300  // Free gas given at beginning of call.
+	DepositGasStipend     uint64 = 2300  // Free gas given to OP-Stack deposits sending ether to a contract, if enabled.
 
 	Keccak256Gas     uint64 = 30 // Once per KECCAK256 operation.
 	Keccak256WordGas uint64 = 6  // Once per word of the KECCAK256 operation's data.
@@ -135,6 +136,9 @@ const (
 	MaxCodeSize     = 24576           // Maximum bytecode to permit for a contract
 	MaxInitCodeSize = 2 * MaxCodeSize // Maximum initcode to permit in a creation transaction and create instructions
 
+	MaxTransactionsPerBlock = 65536      // Maximum transactions a block is expected to hold, bounding the decoded transactions
+	MaxTransactionSize      = 128 * 1024 // Maximum encoded size of a non-blob transaction accepted over RPC
+
 	// Precompiled contract gas prices
 
 	EcrecoverGas        uint64 = 3000 // Elliptic curve sender recovery gas price
@@ -211,4 +215,18 @@ var (
 	// EIP-7251 - Increase the MAX_EFFECTIVE_BALANCE
 	ConsolidationQueueAddress = common.HexToAddress("0x0000BBdDc7CE488642fb579F8B00f3a590007251")
 	ConsolidationQueueCode    = common.FromHex("3373fffffffffffffffffffffffffffffffffffffffe1460d35760115f54807fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1461019a57600182026001905f5b5f82111560685781019083028483029004916001019190604d565b9093900492505050366060146088573661019a573461019a575f5260205ff35b341061019a57600154600101600155600354806004026004013381556001015f358155600101602035815560010160403590553360601b5f5260605f60143760745fa0600101600355005b6003546002548082038060021160e7575060025b5f5b8181146101295782810160040260040181607402815460601b815260140181600101548152602001816002015481526020019060030154905260010160e9565b910180921461013b5790600255610146565b90505f6002555f6003555b5f54807fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff141561017357505f5b6001546001828201116101885750505f61018e565b01600190035b5f555f6001556074025ff35b5f5ffd")
+
+	// DefaultL1FeeOracleAddress is the OP-Stack predeploy holding the L1 fee parameters.
+	DefaultL1FeeOracleAddress = common.HexToAddress("0x420000000000000000000000000000000000000F")
+
+	// L1InfoDepositerAddress is the sender of the L1 info deposit opening every
+	// OP-Stack block.
+	L1InfoDepositerAddress = common.HexToAddress("0xDeaDDEaDDeAdDeAdDEAdDEaddeAddEAdDEAd0001")
+
+	// L1FeeVaultAddress is the OP-Stack predeploy collecting the L1 data fees.
+	L1FeeVaultAddress = common.HexToAddress("0x420000000000000000000000000000000000001A")
+
+	// SequencerFeeVaultAddress is the OP-Stack predeploy collecting the priority
+	// fees, set as the coinbase of the blocks built by the sequencer.
+	SequencerFeeVaultAddress = common.HexToAddress("0x4200000000000000000000000000000000000011")
 )
@@ -619,6 +619,16 @@ func NewStream(r io.Reader, inputLimit uint64) *Stream {
 	return s
 }
 
+// SetMaxInputSize caps the remaining input of the stream to the given size, if
+// it is unlimited or larger. Values exceeding it return ErrValueTooLarge. The
+// cap applies until the next call to Reset.
+func (s *Stream) SetMaxInputSize(size uint64) {
+	if !s.limited || s.remaining > size {
+		s.remaining = size
+		s.limited = true
+	}
+}
+
 // NewListStream creates a new stream that pretends to be positioned
 // at an encoded list of the given length.
 func NewListStream(r io.Reader, len uint64) *Stream {
@@ -288,6 +288,44 @@ func TestStreamRaw(t *testing.T) {
 	}
 }
 
+func TestStreamMaxInputSize(t *testing.T) {
+	input := unhex("8401010101") // 5 bytes
+	tests := []struct {
+		name  string
+		input io.Reader
+		limit uint64
+	}{
+		{"unlimited reader", newPlainReader(input), 0},
+		{"byte reader", bytes.NewReader(input), 0},
+		{"larger limit", newPlainReader(input), 100},
+	}
+	for _, tt := range tests {
+		s := NewStream(tt.input, tt.limit)
+		s.SetMaxInputSize(4)
+		if _, err := s.Bytes(); err != ErrValueTooLarge {
+			t.Errorf("%s: error mismatch: got %v, want %v", tt.name, err, ErrValueTooLarge)
+		}
+	}
+	// Smaller limits are kept
+	s := NewStream(bytes.NewReader(input), 4)
+	s.SetMaxInputSize(100)
+	if _, err := s.Bytes(); err != ErrValueTooLarge {
+		t.Errorf("smaller limit: error mismatch: got %v, want %v", err, ErrValueTooLarge)
+	}
+	s = NewStream(newPlainReader(input), 0)
+	s.SetMaxInputSize(5)
+	if b, err := s.Bytes(); err != nil || !bytes.Equal(b, input[1:]) {
+		t.Errorf("input within limit: got %x, %v", b, err)
+	}
+	// Reset drops the cap
+	s.Reset(newPlainReader(input), 0)
+	s.SetMaxInputSize(4)
+	s.Reset(newPlainReader(input), 0)
+	if b, err := s.Bytes(); err != nil || !bytes.Equal(b, input[1:]) {
+		t.Errorf("input after reset: got %x, %v", b, err)
+	}
+}
+
 func TestStreamReadBytes(t *testing.T) {
 	tests := []struct {
 		input string
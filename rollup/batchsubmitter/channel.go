@@ -0,0 +1,85 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package batchsubmitter
+
+import (
+	"errors"
+
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rollup/derive"
+)
+
+var (
+	compressionRatioGauge  = metrics.NewRegisteredGaugeFloat64("rollup/batcher/compression_ratio", nil)
+	uncompressedBytesCount = metrics.NewRegisteredCounter("rollup/batcher/bytes_uncompressed_total", nil)
+	compressedBytesCount   = metrics.NewRegisteredCounter("rollup/batcher/bytes_compressed_total", nil)
+	channelFullCount       = metrics.NewRegisteredCounter("rollup/batcher/channel_full_events_total", nil)
+)
+
+// ErrChannelFull is returned when adding a batch to a channel would exceed the
+// amount of batch data a channel may carry.
+var ErrChannelFull = errors.New("channel full")
+
+// Channel gathers the batches to be posted to L1 together, compressed into a
+// single channel.
+type Channel struct {
+	batches []derive.Batch
+	size    int // Uncompressed size of the batches, as RLP strings
+	closed  bool
+}
+
+// AddBatch appends a batch to the channel, unless the channel would exceed the
+// maximum amount of batch data, in which case ErrChannelFull is returned and the
+// batch should go into a new channel.
+func (c *Channel) AddBatch(batch derive.Batch) error {
+	if c.closed {
+		return errors.New("channel closed")
+	}
+	enc, err := derive.EncodeBatch(batch)
+	if err != nil {
+		return err
+	}
+	size := int(rlp.StringSize(string(enc)))
+	if c.size+size > derive.MaxRLPBytesPerChannel {
+		channelFullCount.Inc(1)
+		return ErrChannelFull
+	}
+	c.batches = append(c.batches, batch)
+	c.size += size
+	return nil
+}
+
+// Close compresses the batches into the channel data, recording the compression
+// achieved.
+func (c *Channel) Close() ([]byte, error) {
+	if c.closed {
+		return nil, errors.New("channel closed")
+	}
+	data, err := derive.EncodeChannel(c.batches)
+	if err != nil {
+		return nil, err
+	}
+	c.closed = true
+
+	uncompressedBytesCount.Inc(int64(c.size))
+	compressedBytesCount.Inc(int64(len(data)))
+	if c.size > 0 {
+		compressionRatioGauge.Update(float64(len(data)) / float64(c.size))
+	}
+	return data, nil
+}
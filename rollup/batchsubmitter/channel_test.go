@@ -0,0 +1,90 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package batchsubmitter
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rollup/derive"
+)
+
+// Tests that closing a channel of highly compressible batches records the
+// compression achieved.
+func TestChannelCompressionMetrics(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	tx := types.MustSignNewTx(key, types.LatestSignerForChainID(params.TestChainConfig.ChainID), &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		GasTipCap: big.NewInt(params.GWei),
+		GasFeeCap: big.NewInt(params.GWei),
+		Gas:       params.TxGas,
+		To:        &common.Address{0x01},
+		Value:     big.NewInt(1),
+	})
+	raw, _ := tx.MarshalBinary()
+
+	batch := &derive.SingularBatch{Timestamp: 2}
+	for i := 0; i < 100; i++ {
+		batch.Transactions = append(batch.Transactions, hexutil.Bytes(raw))
+	}
+	var (
+		uncompressed = uncompressedBytesCount.Snapshot().Count()
+		compressed   = compressedBytesCount.Snapshot().Count()
+		channel      = new(Channel)
+	)
+	if err := channel.AddBatch(batch); err != nil {
+		t.Fatalf("failed to add batch: %v", err)
+	}
+	data, err := channel.Close()
+	if err != nil {
+		t.Fatalf("failed to close channel: %v", err)
+	}
+	if have := compressedBytesCount.Snapshot().Count() - compressed; have != int64(len(data)) {
+		t.Errorf("compressed bytes mismatch: have %d, want %d", have, len(data))
+	}
+	if have := uncompressedBytesCount.Snapshot().Count() - uncompressed; have <= 100*int64(len(raw)) {
+		t.Errorf("uncompressed bytes too low: have %d, want above %d", have, 100*len(raw))
+	}
+	if ratio := compressionRatioGauge.Snapshot().Value(); ratio >= 0.1 {
+		t.Errorf("compression ratio too high: have %v, want below 0.1", ratio)
+	}
+}
+
+// Tests that a channel refuses the batches exceeding the channel size limit.
+func TestChannelFull(t *testing.T) {
+	var (
+		full    = channelFullCount.Snapshot().Count()
+		channel = new(Channel)
+		batch   = &derive.SingularBatch{Transactions: []hexutil.Bytes{bytes.Repeat([]byte{0x01}, derive.MaxRLPBytesPerChannel/2)}}
+	)
+	if err := channel.AddBatch(batch); err != nil {
+		t.Fatalf("failed to add batch: %v", err)
+	}
+	if err := channel.AddBatch(batch); !errors.Is(err, ErrChannelFull) {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrChannelFull)
+	}
+	if have := channelFullCount.Snapshot().Count() - full; have != 1 {
+		t.Errorf("channel full events mismatch: have %d, want 1", have)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package batchsubmitter implements the submission of L2 batch data to L1 by
+// the OP-Stack batcher.
+package batchsubmitter
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rollup/derive"
+)
+
+// EstimateBatchSubmissionCost estimates the L1 cost in wei of submitting the
+// channel data to the batch inbox.
+//
+// Calldata submissions pay for the intrinsic gas and every byte of channel data
+// at the EIP-2028 rates at the L1 base fee. Blob submissions pay for the
+// intrinsic gas at the L1 base fee, plus the blob gas of every blob needed to
+// carry the channel data at the blob base fee.
+func EstimateBatchSubmissionCost(channelData []byte, l1BaseFee, blobBaseFee *big.Int, blobEnabled bool) *big.Int {
+	cost := new(big.Int).SetUint64(params.TxGas)
+	if !blobEnabled {
+		rcd := types.NewRollupCostData(channelData)
+		cost.SetUint64(params.TxGas + rcd.Zeroes*params.TxDataZeroGas + rcd.Ones*params.TxDataNonZeroGasEIP2028)
+		return cost.Mul(cost, l1BaseFee)
+	}
+	cost.Mul(cost, l1BaseFee)
+
+	// A blob transaction carries at least one blob
+	blobs := max(1, (len(channelData)+derive.MaxBlobDataSize-1)/derive.MaxBlobDataSize)
+	blobCost := new(big.Int).SetUint64(uint64(blobs) * params.BlobTxBlobGasPerBlob)
+	return cost.Add(cost, blobCost.Mul(blobCost, blobBaseFee))
+}
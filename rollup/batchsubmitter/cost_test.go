@@ -0,0 +1,69 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package batchsubmitter
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rollup/derive"
+)
+
+func TestEstimateCalldataCost(t *testing.T) {
+	data := []byte{0x00, 0x01, 0x00, 0xff, 0x02}
+	cost := EstimateBatchSubmissionCost(data, big.NewInt(10), big.NewInt(1), false)
+	if want := big.NewInt(10 * (21000 + 2*4 + 3*16)); cost.Cmp(want) != 0 {
+		t.Fatalf("calldata cost mismatch: have %v, want %v", cost, want)
+	}
+}
+
+func TestEstimateBlobCost(t *testing.T) {
+	tests := []struct {
+		size  int
+		blobs uint64
+	}{
+		{0, 1},
+		{1, 1},
+		{derive.MaxBlobDataSize, 1},
+		{derive.MaxBlobDataSize + 1, 2},
+		{5 * derive.MaxBlobDataSize, 5},
+	}
+	for _, tt := range tests {
+		cost := EstimateBatchSubmissionCost(make([]byte, tt.size), big.NewInt(10), big.NewInt(3), true)
+		want := new(big.Int).SetUint64(10*params.TxGas + 3*tt.blobs*params.BlobTxBlobGasPerBlob)
+		if cost.Cmp(want) != 0 {
+			t.Errorf("size %d: blob cost mismatch: have %v, want %v", tt.size, cost, want)
+		}
+	}
+}
+
+func TestBlobCheaperBelowBreakEven(t *testing.T) {
+	// Compressed channel data is mostly non-zero
+	data := make([]byte, 4*derive.MaxBlobDataSize)
+	for i := range data {
+		data[i] = byte(i%255) + 1
+	}
+	l1BaseFee := big.NewInt(30_000_000_000)
+	for _, blobBaseFee := range []*big.Int{big.NewInt(1), new(big.Int).Div(l1BaseFee, big.NewInt(17))} {
+		calldata := EstimateBatchSubmissionCost(data, l1BaseFee, blobBaseFee, false)
+		blob := EstimateBatchSubmissionCost(data, l1BaseFee, blobBaseFee, true)
+		if blob.Cmp(calldata) >= 0 {
+			t.Errorf("blob base fee %v: blob submission not cheaper: blob %v, calldata %v", blobBaseFee, blob, calldata)
+		}
+	}
+}
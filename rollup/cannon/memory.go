@@ -0,0 +1,169 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package cannon
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// leafSize is the number of memory bytes covered by a Merkle leaf.
+	leafSize = 32
+
+	// memoryDepth is the depth of the memory Merkle tree, whose leaves cover
+	// the whole 32-bit address space.
+	memoryDepth = 32 - 5
+)
+
+var errInvalidMemoryProof = errors.New("invalid memory proof")
+
+// MemoryProof is the Merkle proof of a memory leaf: the leaf content followed
+// by the sibling subtree roots from the bottom up.
+type MemoryProof [memoryDepth + 1]common.Hash
+
+// zeroHashes are the roots of the empty memory subtrees at every height.
+var zeroHashes = func() [memoryDepth + 1]common.Hash {
+	var hashes [memoryDepth + 1]common.Hash
+	for i := 1; i <= memoryDepth; i++ {
+		hashes[i] = crypto.Keccak256Hash(hashes[i-1][:], hashes[i-1][:])
+	}
+	return hashes
+}()
+
+// Memory is the sparse 32-bit address space of the MIPS VM. It is merkleized
+// as a binary tree of 32 byte leaves, every node hashing the concatenation of
+// its children with keccak256, like the reference Cannon implementation.
+type Memory struct {
+	leaves map[uint32]*[leafSize]byte // leaf index -> leaf content
+}
+
+// NewMemory creates an empty memory.
+func NewMemory() *Memory {
+	return &Memory{leaves: make(map[uint32]*[leafSize]byte)}
+}
+
+// Copy returns a deep copy of the memory.
+func (m *Memory) Copy() *Memory {
+	cpy := NewMemory()
+	for idx, leaf := range m.leaves {
+		content := *leaf
+		cpy.leaves[idx] = &content
+	}
+	return cpy
+}
+
+// GetWord returns the 32-bit word at the given address, which must be word
+// aligned.
+func (m *Memory) GetWord(addr uint32) (uint32, error) {
+	if addr%4 != 0 {
+		return 0, fmt.Errorf("%w: %#x", errUnalignedAccess, addr)
+	}
+	leaf, ok := m.leaves[addr/leafSize]
+	if !ok {
+		return 0, nil
+	}
+	offset := addr % leafSize
+	return binary.BigEndian.Uint32(leaf[offset : offset+4]), nil
+}
+
+// SetWord stores the 32-bit word at the given address, which must be word
+// aligned.
+func (m *Memory) SetWord(addr uint32, value uint32) error {
+	if addr%4 != 0 {
+		return fmt.Errorf("%w: %#x", errUnalignedAccess, addr)
+	}
+	leaf, ok := m.leaves[addr/leafSize]
+	if !ok {
+		leaf = new([leafSize]byte)
+		m.leaves[addr/leafSize] = leaf
+	}
+	offset := addr % leafSize
+	binary.BigEndian.PutUint32(leaf[offset:offset+4], value)
+	return nil
+}
+
+// MerkleRoot returns the root of the memory Merkle tree.
+func (m *Memory) MerkleRoot() common.Hash {
+	indices := slices.Sorted(maps.Keys(m.leaves))
+	return m.merkleize(memoryDepth, 0, indices)
+}
+
+// merkleize hashes the subtree of the given height rooted at the prefix, the
+// indices being the sorted indices of the non-empty leaves below it.
+func (m *Memory) merkleize(height int, prefix uint32, indices []uint32) common.Hash {
+	if len(indices) == 0 {
+		return zeroHashes[height]
+	}
+	if height == 0 {
+		return common.Hash(*m.leaves[prefix])
+	}
+	// Split the leaves between the left and right subtrees
+	right := prefix<<1 | 1
+	split, _ := slices.BinarySearch(indices, right<<(height-1))
+	left := m.merkleize(height-1, prefix<<1, indices[:split])
+	return crypto.Keccak256Hash(left[:], m.merkleize(height-1, right, indices[split:]).Bytes())
+}
+
+// MerkleProof returns the proof of the leaf holding the given address.
+func (m *Memory) MerkleProof(addr uint32) MemoryProof {
+	var (
+		proof   MemoryProof
+		index   = addr / leafSize
+		indices = slices.Sorted(maps.Keys(m.leaves))
+	)
+	if leaf, ok := m.leaves[index]; ok {
+		proof[0] = common.Hash(*leaf)
+	}
+	for height := 0; height < memoryDepth; height++ {
+		sibling := (index >> height) ^ 1
+		start, _ := slices.BinarySearch(indices, sibling<<height)
+		end, _ := slices.BinarySearch(indices, (sibling+1)<<height)
+		proof[height+1] = m.merkleize(height, sibling, indices[start:end])
+	}
+	return proof
+}
+
+// VerifyMemoryProof checks the proof of the leaf holding the given address
+// against the memory root, returning the word stored at the address.
+func VerifyMemoryProof(root common.Hash, addr uint32, proof MemoryProof) (uint32, error) {
+	if addr%4 != 0 {
+		return 0, fmt.Errorf("%w: %#x", errUnalignedAccess, addr)
+	}
+	var (
+		index = addr / leafSize
+		node  = proof[0]
+	)
+	for height := 0; height < memoryDepth; height++ {
+		if (index>>height)&1 == 1 {
+			node = crypto.Keccak256Hash(proof[height+1][:], node[:])
+		} else {
+			node = crypto.Keccak256Hash(node[:], proof[height+1][:])
+		}
+	}
+	if node != root {
+		return 0, fmt.Errorf("%w: have root %x, want %x", errInvalidMemoryProof, node, root)
+	}
+	offset := addr % leafSize
+	return binary.BigEndian.Uint32(proof[0][offset : offset+4]), nil
+}
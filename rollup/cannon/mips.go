@@ -0,0 +1,219 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package cannon
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	errUnsupportedInstruction = errors.New("unsupported instruction")
+	errUnalignedAccess        = errors.New("unaligned memory access")
+)
+
+// instruction is a decoded MIPS32 instruction.
+type instruction struct {
+	opcode, rs, rt, rd, shamt, funct uint32
+
+	imm  uint32 // zero extended immediate
+	simm uint32 // sign extended immediate
+}
+
+func decode(insn uint32) instruction {
+	return instruction{
+		opcode: insn >> 26,
+		rs:     (insn >> 21) & 0x1f,
+		rt:     (insn >> 16) & 0x1f,
+		rd:     (insn >> 11) & 0x1f,
+		shamt:  (insn >> 6) & 0x1f,
+		funct:  insn & 0x3f,
+		imm:    insn & 0xffff,
+		simm:   uint32(int32(int16(insn))),
+	}
+}
+
+// isLoad reports whether the instruction reads a word from memory.
+func (i instruction) isLoad() bool { return i.opcode == 0x23 }
+
+// isStore reports whether the instruction writes a word to memory.
+func (i instruction) isStore() bool { return i.opcode == 0x2b }
+
+// memoryAddress returns the address accessed by a load or store.
+func (i instruction) memoryAddress(regs *[32]uint32) (uint32, error) {
+	addr := regs[i.rs] + i.simm
+	if addr%4 != 0 {
+		return 0, fmt.Errorf("%w: %#x", errUnalignedAccess, addr)
+	}
+	return addr, nil
+}
+
+// execute applies the register effects of a non memory instruction, returning
+// the destination register and its new value. Writes to the zero register are
+// discarded by the caller.
+func (i instruction) execute(state *CannonStep) (dst uint32, value uint32, err error) {
+	regs := &state.Registers
+	switch i.opcode {
+	case 0x00: // SPECIAL
+		s, t := regs[i.rs], regs[i.rt]
+		switch i.funct {
+		case 0x00: // sll
+			return i.rd, t << i.shamt, nil
+		case 0x02: // srl
+			return i.rd, t >> i.shamt, nil
+		case 0x03: // sra
+			return i.rd, uint32(int32(t) >> i.shamt), nil
+		case 0x10: // mfhi
+			return i.rd, state.HI, nil
+		case 0x12: // mflo
+			return i.rd, state.LO, nil
+		case 0x18: // mult
+			prod := uint64(int64(int32(s)) * int64(int32(t)))
+			state.HI, state.LO = uint32(prod>>32), uint32(prod)
+			return 0, 0, nil
+		case 0x19: // multu
+			prod := uint64(s) * uint64(t)
+			state.HI, state.LO = uint32(prod>>32), uint32(prod)
+			return 0, 0, nil
+		case 0x20, 0x21: // add, addu (overflows are not trapped, like in Cannon)
+			return i.rd, s + t, nil
+		case 0x22, 0x23: // sub, subu
+			return i.rd, s - t, nil
+		case 0x24: // and
+			return i.rd, s & t, nil
+		case 0x25: // or
+			return i.rd, s | t, nil
+		case 0x26: // xor
+			return i.rd, s ^ t, nil
+		case 0x27: // nor
+			return i.rd, ^(s | t), nil
+		case 0x2a: // slt
+			return i.rd, boolToWord(int32(s) < int32(t)), nil
+		case 0x2b: // sltu
+			return i.rd, boolToWord(s < t), nil
+		}
+	case 0x08, 0x09: // addi, addiu
+		return i.rt, regs[i.rs] + i.simm, nil
+	case 0x0a: // slti
+		return i.rt, boolToWord(int32(regs[i.rs]) < int32(i.simm)), nil
+	case 0x0b: // sltiu
+		return i.rt, boolToWord(regs[i.rs] < i.simm), nil
+	case 0x0c: // andi
+		return i.rt, regs[i.rs] & i.imm, nil
+	case 0x0d: // ori
+		return i.rt, regs[i.rs] | i.imm, nil
+	case 0x0e: // xori
+		return i.rt, regs[i.rs] ^ i.imm, nil
+	case 0x0f: // lui
+		return i.rt, i.imm << 16, nil
+	}
+	return 0, 0, fmt.Errorf("%w: opcode %#x, funct %#x", errUnsupportedInstruction, i.opcode, i.funct)
+}
+
+// step executes the instruction at the program counter of the state, updating
+// the state and the memory in place.
+func step(state *CannonStep, mem *Memory) error {
+	word, err := mem.GetWord(state.PC)
+	if err != nil {
+		return fmt.Errorf("invalid program counter: %w", err)
+	}
+	insn := decode(word)
+	switch {
+	case insn.isLoad():
+		addr, err := insn.memoryAddress(&state.Registers)
+		if err != nil {
+			return err
+		}
+		if state.Registers[insn.rt], err = mem.GetWord(addr); err != nil {
+			return err
+		}
+	case insn.isStore():
+		addr, err := insn.memoryAddress(&state.Registers)
+		if err != nil {
+			return err
+		}
+		if err := mem.SetWord(addr, state.Registers[insn.rt]); err != nil {
+			return err
+		}
+		state.MemRoot = mem.MerkleRoot()
+	default:
+		dst, value, err := insn.execute(state)
+		if err != nil {
+			return err
+		}
+		state.Registers[dst] = value
+	}
+	state.Registers[0] = 0
+	state.PC, state.NextPC = state.NextPC, state.NextPC+4
+	return nil
+}
+
+// VerifyCannonStep checks that the post state results from executing the
+// instruction on the pre state. Memory content is not part of the states, so
+// the word loaded by a load and the memory root updated by a store are taken
+// from the post state, their correctness being established by memory proofs.
+func VerifyCannonStep(preState, postState CannonStep, instruction uint32) error {
+	if preState.PC%4 != 0 {
+		return fmt.Errorf("invalid program counter: %w: %#x", errUnalignedAccess, preState.PC)
+	}
+	var (
+		insn = decode(instruction)
+		want = preState
+	)
+	switch {
+	case insn.isLoad():
+		if _, err := insn.memoryAddress(&want.Registers); err != nil {
+			return err
+		}
+		want.Registers[insn.rt] = postState.Registers[insn.rt]
+	case insn.isStore():
+		if _, err := insn.memoryAddress(&want.Registers); err != nil {
+			return err
+		}
+		want.MemRoot = postState.MemRoot
+	default:
+		dst, value, err := insn.execute(&want)
+		if err != nil {
+			return err
+		}
+		want.Registers[dst] = value
+	}
+	want.Registers[0] = 0
+	want.PC, want.NextPC = want.NextPC, want.NextPC+4
+
+	switch {
+	case want.PC != postState.PC || want.NextPC != postState.NextPC:
+		return fmt.Errorf("program counter mismatch: have %#x/%#x, want %#x/%#x", postState.PC, postState.NextPC, want.PC, want.NextPC)
+	case want.LO != postState.LO || want.HI != postState.HI:
+		return fmt.Errorf("LO/HI mismatch: have %#x/%#x, want %#x/%#x", postState.LO, postState.HI, want.LO, want.HI)
+	case want.MemRoot != postState.MemRoot:
+		return fmt.Errorf("memory root mismatch: have %x, want %x", postState.MemRoot, want.MemRoot)
+	}
+	for i := range want.Registers {
+		if want.Registers[i] != postState.Registers[i] {
+			return fmt.Errorf("register %d mismatch: have %#x, want %#x", i, postState.Registers[i], want.Registers[i])
+		}
+	}
+	return nil
+}
+
+func boolToWord(b bool) uint32 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package cannon implements the execution trace of the Cannon MIPS VM running
+// the OP-Stack fault proof program, as bisected by the dispute game.
+package cannon
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var errTraceOutOfRange = errors.New("trace position out of range")
+
+// CannonStep is the state of the MIPS VM in between two instructions.
+type CannonStep struct {
+	PC, NextPC, LO, HI uint32
+	Registers          [32]uint32
+	MemRoot            common.Hash
+}
+
+// StateHash returns the commitment to the VM state claimed in dispute games:
+// the keccak256 hash of the memory root followed by the big endian encoding of
+// the program counters, LO, HI and the registers.
+func (s *CannonStep) StateHash() common.Hash {
+	enc := make([]byte, 0, common.HashLength+4*(4+len(s.Registers)))
+	enc = append(enc, s.MemRoot[:]...)
+	for _, word := range [4]uint32{s.PC, s.NextPC, s.LO, s.HI} {
+		enc = binary.BigEndian.AppendUint32(enc, word)
+	}
+	for _, reg := range s.Registers {
+		enc = binary.BigEndian.AppendUint32(enc, reg)
+	}
+	return crypto.Keccak256Hash(enc)
+}
+
+// CannonTrace is the execution trace of a program, holding the VM state and
+// memory before every instruction and after the last one.
+type CannonTrace struct {
+	states   []CannonStep
+	memories []*Memory
+}
+
+// RunCannon executes the given number of instructions of the program loaded in
+// memory, starting at the entry point.
+func RunCannon(mem *Memory, entry uint32, steps uint64) (*CannonTrace, error) {
+	var (
+		state = CannonStep{PC: entry, NextPC: entry + 4, MemRoot: mem.MerkleRoot()}
+		trace = &CannonTrace{
+			states:   []CannonStep{state},
+			memories: []*Memory{mem.Copy()},
+		}
+	)
+	mem = mem.Copy()
+	for i := uint64(0); i < steps; i++ {
+		if err := step(&state, mem); err != nil {
+			return nil, fmt.Errorf("step %d at pc %#x: %w", i, state.PC, err)
+		}
+		trace.states = append(trace.states, state)
+		trace.memories = append(trace.memories, mem.Copy())
+	}
+	return trace, nil
+}
+
+// Len returns the number of states in the trace.
+func (t *CannonTrace) Len() uint64 {
+	return uint64(len(t.states))
+}
+
+// State returns the VM state at the given trace position.
+func (t *CannonTrace) State(pos uint64) (CannonStep, error) {
+	if pos >= t.Len() {
+		return CannonStep{}, fmt.Errorf("%w: %d >= %d", errTraceOutOfRange, pos, t.Len())
+	}
+	return t.states[pos], nil
+}
+
+// Get returns the state hash at the given trace position.
+func (t *CannonTrace) Get(pos uint64) (common.Hash, error) {
+	state, err := t.State(pos)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return state.StateHash(), nil
+}
+
+// Instruction returns the instruction executed at the given trace position.
+func (t *CannonTrace) Instruction(pos uint64) (uint32, error) {
+	if pos+1 >= t.Len() {
+		return 0, fmt.Errorf("%w: %d >= %d", errTraceOutOfRange, pos+1, t.Len())
+	}
+	return t.memories[pos].GetWord(t.states[pos].PC)
+}
@@ -0,0 +1,193 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package cannon
+
+import (
+	"errors"
+	"testing"
+)
+
+const testDataBase = 0x10000
+
+func rtype(funct, rs, rt, rd uint32) uint32 { return rs<<21 | rt<<16 | rd<<11 | funct }
+func itype(op, rs, rt, imm uint32) uint32   { return op<<26 | rs<<21 | rt<<16 | imm&0xffff }
+
+// testProgram returns a program of 100 instructions storing an arithmetic
+// progression into memory, loading every element back before computing the
+// next one.
+func testProgram() []uint32 {
+	program := []uint32{
+		itype(0x09, 0, 1, 7), // addiu $1, $0, 7
+		itype(0x0f, 0, 2, 1), // lui   $2, 1
+		itype(0x09, 0, 3, 1), // addiu $3, $0, 1
+	}
+	for i := 0; i < 24; i++ {
+		program = append(program,
+			itype(0x2b, 2, 3, 0), // sw    $3, 0($2)
+			itype(0x23, 2, 4, 0), // lw    $4, 0($2)
+			rtype(0x21, 4, 1, 3), // addu  $3, $4, $1
+			itype(0x09, 2, 2, 4), // addiu $2, $2, 4
+		)
+	}
+	return append(program, rtype(0x23, 3, 1, 6)) // subu $6, $3, $1
+}
+
+func loadProgram(program []uint32) *Memory {
+	mem := NewMemory()
+	for i, insn := range program {
+		mem.SetWord(uint32(4*i), insn)
+	}
+	return mem
+}
+
+func TestCannonTrace(t *testing.T) {
+	program := testProgram()
+	if len(program) != 100 {
+		t.Fatalf("program length mismatch: have %d, want 100", len(program))
+	}
+	mem := loadProgram(program)
+	trace, err := RunCannon(mem, 0, uint64(len(program)))
+	if err != nil {
+		t.Fatalf("failed to run program: %v", err)
+	}
+	if trace.Len() != 101 {
+		t.Fatalf("trace length mismatch: have %d, want 101", trace.Len())
+	}
+	// Every step must be verifiable and committed to by its state hash
+	for pos := uint64(0); pos+1 < trace.Len(); pos++ {
+		pre, _ := trace.State(pos)
+		post, _ := trace.State(pos + 1)
+		insn, err := trace.Instruction(pos)
+		if err != nil {
+			t.Fatalf("step %d: failed to get instruction: %v", pos, err)
+		}
+		if insn != program[pos] {
+			t.Fatalf("step %d: instruction mismatch: have %#x, want %#x", pos, insn, program[pos])
+		}
+		if err := VerifyCannonStep(pre, post, insn); err != nil {
+			t.Fatalf("step %d: failed to verify: %v", pos, err)
+		}
+		hash, err := trace.Get(pos + 1)
+		if err != nil {
+			t.Fatalf("step %d: failed to get state hash: %v", pos, err)
+		}
+		if hash != post.StateHash() {
+			t.Fatalf("step %d: state hash mismatch", pos)
+		}
+		if prev, _ := trace.Get(pos); prev == hash {
+			t.Fatalf("step %d: state hash unchanged", pos)
+		}
+	}
+	if _, err := trace.Get(trace.Len()); err == nil {
+		t.Fatal("expected position beyond the trace to fail")
+	}
+	// Check the final state against the expected progression
+	final, _ := trace.State(trace.Len() - 1)
+	want := map[int]uint32{1: 7, 2: testDataBase + 96, 3: 1 + 7*24, 4: 1 + 7*23, 6: 1 + 7*23}
+	for reg, value := range want {
+		if final.Registers[reg] != value {
+			t.Errorf("register %d mismatch: have %d, want %d", reg, final.Registers[reg], value)
+		}
+	}
+	expected := loadProgram(program)
+	for i := uint32(0); i < 24; i++ {
+		expected.SetWord(testDataBase+4*i, 1+7*i)
+	}
+	if final.MemRoot != expected.MerkleRoot() {
+		t.Fatalf("memory root mismatch: have %x, want %x", final.MemRoot, expected.MerkleRoot())
+	}
+	if final.PC != 400 || final.NextPC != 404 {
+		t.Fatalf("program counter mismatch: have %d/%d, want 400/404", final.PC, final.NextPC)
+	}
+}
+
+func TestVerifyCannonStepRejects(t *testing.T) {
+	program := testProgram()
+	trace, err := RunCannon(loadProgram(program), 0, uint64(len(program)))
+	if err != nil {
+		t.Fatalf("failed to run program: %v", err)
+	}
+	check := func(pos uint64, tamper func(*CannonStep)) {
+		t.Helper()
+		pre, _ := trace.State(pos)
+		post, _ := trace.State(pos + 1)
+		tamper(&post)
+		if err := VerifyCannonStep(pre, post, program[pos]); err == nil {
+			t.Errorf("step %d: expected tampered post state to be rejected", pos)
+		}
+	}
+	check(5, func(s *CannonStep) { s.Registers[3]++ })   // wrong addu result
+	check(6, func(s *CannonStep) { s.Registers[2] = 0 }) // wrong addiu result
+	check(3, func(s *CannonStep) { s.Registers[7] = 1 }) // store touching a register
+	check(4, func(s *CannonStep) { s.MemRoot[0] ^= 1 })  // load touching memory
+	check(0, func(s *CannonStep) { s.NextPC += 4 })      // wrong program counter
+
+	if err := VerifyCannonStep(CannonStep{}, CannonStep{}, 0xfc000000); err == nil {
+		t.Fatal("expected unsupported instruction to be rejected")
+	}
+	if err := VerifyCannonStep(CannonStep{PC: 2}, CannonStep{PC: 6, NextPC: 10}, program[0]); !errors.Is(err, errUnalignedAccess) {
+		t.Fatalf("unaligned program counter error mismatch: have %v, want %v", err, errUnalignedAccess)
+	}
+}
+
+// Tests that unaligned program counters and memory accesses fail the step
+// instead of crashing the VM, even near the end of a memory leaf.
+func TestCannonUnaligned(t *testing.T) {
+	mem := NewMemory()
+	if _, err := mem.GetWord(leafSize - 2); !errors.Is(err, errUnalignedAccess) {
+		t.Fatalf("unaligned read error mismatch: have %v, want %v", err, errUnalignedAccess)
+	}
+	if err := mem.SetWord(leafSize-1, 1); !errors.Is(err, errUnalignedAccess) {
+		t.Fatalf("unaligned write error mismatch: have %v, want %v", err, errUnalignedAccess)
+	}
+	if _, err := VerifyMemoryProof(mem.MerkleRoot(), leafSize-3, mem.MerkleProof(0)); !errors.Is(err, errUnalignedAccess) {
+		t.Fatalf("unaligned proof error mismatch: have %v, want %v", err, errUnalignedAccess)
+	}
+	// Jumping to an unaligned entry point fails the first step
+	if _, err := RunCannon(loadProgram(testProgram()), leafSize-2, 1); !errors.Is(err, errUnalignedAccess) {
+		t.Fatalf("unaligned program counter error mismatch: have %v, want %v", err, errUnalignedAccess)
+	}
+	// Loads and stores off word boundaries fail as well
+	program := []uint32{
+		itype(0x09, 0, 1, leafSize-2), // addiu $1, $0, 30
+		itype(0x23, 1, 2, 0),          // lw    $2, 0($1)
+	}
+	if _, err := RunCannon(loadProgram(program), 0, 2); !errors.Is(err, errUnalignedAccess) {
+		t.Fatalf("unaligned load error mismatch: have %v, want %v", err, errUnalignedAccess)
+	}
+	program[1] = itype(0x2b, 1, 2, 0) // sw $2, 0($1)
+	if _, err := RunCannon(loadProgram(program), 0, 2); !errors.Is(err, errUnalignedAccess) {
+		t.Fatalf("unaligned store error mismatch: have %v, want %v", err, errUnalignedAccess)
+	}
+}
+
+func TestMemoryMerkleRoot(t *testing.T) {
+	mem := NewMemory()
+	if mem.MerkleRoot() != zeroHashes[memoryDepth] {
+		t.Fatal("empty memory root mismatch")
+	}
+	mem.SetWord(0xfffffffc, 1)
+	root := mem.MerkleRoot()
+	mem.SetWord(0xfffffffc, 0)
+	if mem.MerkleRoot() != zeroHashes[memoryDepth] {
+		t.Fatal("zeroed memory root mismatch")
+	}
+	mem.SetWord(0xfffffffc, 1)
+	if mem.MerkleRoot() != root {
+		t.Fatal("memory root not deterministic")
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package cannon
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StateWitness is the data needed to verify a single step of the trace on L1:
+// the pre state, the proof of the memory word accessed by the step and the
+// claimed post state hash.
+type StateWitness struct {
+	PreState      CannonStep
+	PreStateHash  common.Hash
+	MemAddr       uint32
+	MemProof      MemoryProof
+	PostStateHash common.Hash
+}
+
+// GenerateStateWitness creates the witness of the given step of the trace,
+// proving the memory word at the given address in the pre state memory.
+func GenerateStateWitness(trace *CannonTrace, step uint64, memAddr uint32) (*StateWitness, error) {
+	pre, err := trace.State(step)
+	if err != nil {
+		return nil, err
+	}
+	post, err := trace.Get(step + 1)
+	if err != nil {
+		return nil, err
+	}
+	if memAddr%4 != 0 {
+		return nil, fmt.Errorf("%w: %#x", errUnalignedAccess, memAddr)
+	}
+	return &StateWitness{
+		PreState:      pre,
+		PreStateHash:  pre.StateHash(),
+		MemAddr:       memAddr,
+		MemProof:      trace.memories[step].MerkleProof(memAddr),
+		PostStateHash: post,
+	}, nil
+}
+
+// Verify checks the witness against the pre state hash it claims, returning
+// the proven memory word.
+func (w *StateWitness) Verify() (uint32, error) {
+	if hash := w.PreState.StateHash(); hash != w.PreStateHash {
+		return 0, fmt.Errorf("pre state hash mismatch: have %x, want %x", hash, w.PreStateHash)
+	}
+	return VerifyMemoryProof(w.PreState.MemRoot, w.MemAddr, w.MemProof)
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package cannon
+
+import "testing"
+
+func TestStateWitness(t *testing.T) {
+	program := testProgram()
+	trace, err := RunCannon(loadProgram(program), 0, uint64(len(program)))
+	if err != nil {
+		t.Fatalf("failed to run program: %v", err)
+	}
+	// Step 8 loads the second element of the progression stored by step 7
+	const step, addr = 8, testDataBase + 4
+	witness, err := GenerateStateWitness(trace, step, addr)
+	if err != nil {
+		t.Fatalf("failed to generate witness: %v", err)
+	}
+	if want, _ := trace.Get(step + 1); witness.PostStateHash != want {
+		t.Fatalf("post state hash mismatch: have %x, want %x", witness.PostStateHash, want)
+	}
+	word, err := witness.Verify()
+	if err != nil {
+		t.Fatalf("failed to verify witness: %v", err)
+	}
+	if word != 8 {
+		t.Fatalf("proven word mismatch: have %d, want 8", word)
+	}
+	post, _ := trace.State(step + 1)
+	if post.Registers[4] != word {
+		t.Fatalf("loaded word mismatch: have %d, want %d", post.Registers[4], word)
+	}
+	// A proof of a mutated memory cell must not verify against the pre state
+	mem := trace.memories[step].Copy()
+	mem.SetWord(addr, 9)
+	tampered := *witness
+	tampered.MemProof = mem.MerkleProof(addr)
+	if _, err := tampered.Verify(); err == nil {
+		t.Fatal("expected tampered memory proof to be rejected")
+	}
+	// Neither must a pre state committing to the mutated memory
+	tampered.PreState.MemRoot = mem.MerkleRoot()
+	if _, err := tampered.Verify(); err == nil {
+		t.Fatal("expected tampered pre state to be rejected")
+	}
+	// Proofs of untouched memory must verify as well
+	witness, err = GenerateStateWitness(trace, step, 0x80000000)
+	if err != nil {
+		t.Fatalf("failed to generate witness: %v", err)
+	}
+	if word, err := witness.Verify(); err != nil || word != 0 {
+		t.Fatalf("empty memory proof mismatch: have %d (%v), want 0", word, err)
+	}
+	if _, err := GenerateStateWitness(trace, trace.Len()-1, addr); err == nil {
+		t.Fatal("expected witness of the final state to fail")
+	}
+	if _, err := GenerateStateWitness(trace, step, addr+1); err == nil {
+		t.Fatal("expected unaligned witness to fail")
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package derive
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	// SingularBatchType is the version of batches carrying a single L2 block.
+	SingularBatchType = 0
+
+	// SpanBatchType is the version of batches carrying a span of L2 blocks,
+	// accepted from the Delta fork on.
+	SpanBatchType = 1
+
+	// MaxRLPBytesPerChannel is the maximum amount of decompressed batch data a
+	// channel may carry.
+	MaxRLPBytesPerChannel = 10_000_000
+)
+
+var (
+	// ErrNotSupported is returned when decoding a batch of a type the rollup
+	// does not accept yet.
+	ErrNotSupported = errors.New("batch type not supported")
+
+	errEmptyBatch         = errors.New("empty batch")
+	errUnknownBatchType   = errors.New("unknown batch type")
+	errChannelCompression = errors.New("invalid channel compression")
+)
+
+// Batch is a batch of L2 blocks posted to L1 by the batcher.
+type Batch interface {
+	// BatchType returns the version of the batch encoding.
+	BatchType() int
+}
+
+// SingularBatch is a batch carrying the transactions of a single L2 block.
+type SingularBatch struct {
+	ParentHash   common.Hash
+	EpochNum     uint64
+	EpochHash    common.Hash
+	Timestamp    uint64
+	Transactions []hexutil.Bytes
+}
+
+// BatchType implements Batch.
+func (b *SingularBatch) BatchType() int { return SingularBatchType }
+
+// EncodeBatch encodes the batch prefixed with its version.
+func EncodeBatch(batch Batch) ([]byte, error) {
+	switch b := batch.(type) {
+	case *SingularBatch:
+		enc, err := rlp.EncodeToBytes(b)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte{SingularBatchType}, enc...), nil
+	case *SpanBatch:
+		return append([]byte{SpanBatchType}, b.encode()...), nil
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnknownBatchType, batch)
+	}
+}
+
+// DecodeBatch decodes a versioned batch included in L1 at the given time. Span
+// batches are only accepted from the Delta fork on.
+func DecodeBatch(config *params.ChainConfig, l1Time uint64, data []byte) (Batch, error) {
+	if len(data) == 0 {
+		return nil, errEmptyBatch
+	}
+	switch data[0] {
+	case SingularBatchType:
+		batch := new(SingularBatch)
+		if err := rlp.DecodeBytes(data[1:], batch); err != nil {
+			return nil, fmt.Errorf("invalid singular batch: %w", err)
+		}
+		return batch, nil
+	case SpanBatchType:
+		if !config.IsDelta(l1Time) {
+			return nil, fmt.Errorf("%w: span batch included at %d before Delta", ErrNotSupported, l1Time)
+		}
+		batch := new(SpanBatch)
+		if err := batch.decode(data[1:]); err != nil {
+			return nil, fmt.Errorf("invalid span batch: %w", err)
+		}
+		return batch, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", errUnknownBatchType, data[0])
+	}
+}
+
+// EncodeChannel packs the batches into channel data: the batches are encoded
+// as a sequence of RLP strings, compressed with zlib.
+func EncodeChannel(batches []Batch) ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		w   = zlib.NewWriter(&buf)
+	)
+	for i, batch := range batches {
+		enc, err := EncodeBatch(batch)
+		if err != nil {
+			return nil, fmt.Errorf("batch %d: %w", i, err)
+		}
+		if err := rlp.Encode(w, enc); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ReadChannelBatches decodes the batches of a channel whose data was fully
+// included in L1 at the given time.
+func ReadChannelBatches(config *params.ChainConfig, l1Time uint64, channel []byte) ([]Batch, error) {
+	zr, err := zlib.NewReader(bytes.NewReader(channel))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errChannelCompression, err)
+	}
+	var (
+		stream  = rlp.NewStream(zr, MaxRLPBytesPerChannel)
+		batches []Batch
+	)
+	for {
+		data, err := stream.Bytes()
+		if err == io.EOF {
+			return batches, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("batch %d: %w", len(batches), err)
+		}
+		batch, err := DecodeBatch(config, l1Time, data)
+		if err != nil {
+			return nil, fmt.Errorf("batch %d: %w", len(batches), err)
+		}
+		batches = append(batches, batch)
+	}
+}
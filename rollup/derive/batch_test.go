@@ -0,0 +1,219 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package derive
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var testL2ChainID = big.NewInt(10)
+
+// testSpanBatchTxs signs a transaction of every type supported by span batches.
+func testSpanBatchTxs(t *testing.T) []*types.Transaction {
+	var (
+		signer = types.NewLondonSigner(testL2ChainID)
+		to     = common.HexToAddress("0xdead")
+		txs    []*types.Transaction
+	)
+	for i, data := range []types.TxData{
+		&types.LegacyTx{Nonce: 0, GasPrice: big.NewInt(1e9), Gas: 21000, To: &to, Value: big.NewInt(1)},
+		&types.AccessListTx{ChainID: testL2ChainID, Nonce: 1, GasPrice: big.NewInt(2e9), Gas: 30000, To: &to,
+			AccessList: types.AccessList{{Address: to, StorageKeys: []common.Hash{{0x01}}}}},
+		&types.DynamicFeeTx{ChainID: testL2ChainID, Nonce: 2, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(3e9), Gas: 100000,
+			Value: new(big.Int), Data: []byte{0x60, 0x00}},
+		&types.DynamicFeeTx{ChainID: testL2ChainID, Nonce: 3, GasTipCap: big.NewInt(2), GasFeeCap: big.NewInt(3e9), Gas: 50000,
+			To: &to, Value: big.NewInt(5), Data: []byte{0xca, 0xfe}},
+	} {
+		tx, err := types.SignNewTx(batcherKey, signer, data)
+		if err != nil {
+			t.Fatalf("tx %d: failed to sign: %v", i, err)
+		}
+		txs = append(txs, tx)
+	}
+	// Include an unprotected legacy transaction too
+	tx, err := types.SignNewTx(batcherKey, types.HomesteadSigner{}, &types.LegacyTx{Nonce: 4, GasPrice: big.NewInt(1e9), Gas: 21000, To: &to})
+	if err != nil {
+		t.Fatalf("failed to sign unprotected tx: %v", err)
+	}
+	return append(txs, tx)
+}
+
+func testSpanBatch(t *testing.T, txs []*types.Transaction) *SpanBatch {
+	batch := &SpanBatch{
+		RelTimestamp:  1_000_000,
+		L1OriginNum:   19_000_000,
+		ParentCheck:   [20]byte{0x01},
+		L1OriginCheck: [20]byte{0x02},
+		OriginBits:    []bool{true, false, false, true, false, false, false, false, true},
+		BlockTxCounts: []uint64{2, 0, 3, 0, 0, 0, 0, 0, 0},
+	}
+	for _, tx := range txs {
+		stx, err := NewSpanBatchTx(tx)
+		if err != nil {
+			t.Fatalf("failed to convert tx: %v", err)
+		}
+		batch.Txs = append(batch.Txs, stx)
+	}
+	return batch
+}
+
+func TestSpanBatchDeltaActivation(t *testing.T) {
+	deltaTime := uint64(1000)
+	config := *params.TestChainConfig
+	config.Optimism = &params.OptimismConfig{}
+	config.DeltaTime = &deltaTime
+
+	var (
+		txs      = testSpanBatchTxs(t)
+		span     = testSpanBatch(t, txs)
+		singular = &SingularBatch{ParentHash: common.Hash{0x03}, EpochNum: 7, EpochHash: common.Hash{0x04}, Timestamp: 42,
+			Transactions: []hexutil.Bytes{{0x01, 0x02}}}
+	)
+	channel, err := EncodeChannel([]Batch{singular, span})
+	if err != nil {
+		t.Fatalf("failed to encode channel: %v", err)
+	}
+	// Before Delta the span batch must be rejected, unlike the singular one
+	if _, err := ReadChannelBatches(&config, deltaTime-1, channel); !errors.Is(err, ErrNotSupported) {
+		t.Fatalf("pre-delta error mismatch: have %v, want %v", err, ErrNotSupported)
+	}
+	if _, err := ReadChannelBatches(&config, deltaTime-1, mustEncodeChannel(t, singular)); err != nil {
+		t.Fatalf("failed to read singular batch before delta: %v", err)
+	}
+	// After Delta both batch types must decode
+	batches, err := ReadChannelBatches(&config, deltaTime, channel)
+	if err != nil {
+		t.Fatalf("failed to read channel after delta: %v", err)
+	}
+	if len(batches) != 2 {
+		t.Fatalf("batch count mismatch: have %d, want 2", len(batches))
+	}
+	if !reflect.DeepEqual(batches[0], singular) {
+		t.Fatalf("singular batch mismatch: have %+v, want %+v", batches[0], singular)
+	}
+	decoded, ok := batches[1].(*SpanBatch)
+	if !ok {
+		t.Fatalf("batch type mismatch: have %T, want *SpanBatch", batches[1])
+	}
+	if decoded.RelTimestamp != span.RelTimestamp || decoded.L1OriginNum != span.L1OriginNum ||
+		decoded.ParentCheck != span.ParentCheck || decoded.L1OriginCheck != span.L1OriginCheck {
+		t.Fatalf("span batch prefix mismatch: have %+v", decoded)
+	}
+	if !reflect.DeepEqual(decoded.OriginBits, span.OriginBits) || !reflect.DeepEqual(decoded.BlockTxCounts, span.BlockTxCounts) {
+		t.Fatalf("span batch blocks mismatch: have %v/%v", decoded.OriginBits, decoded.BlockTxCounts)
+	}
+	blocks, err := decoded.Transactions(testL2ChainID)
+	if err != nil {
+		t.Fatalf("failed to restore transactions: %v", err)
+	}
+	if len(blocks[0]) != 2 || len(blocks[1]) != 0 || len(blocks[2]) != 3 {
+		t.Fatalf("block transaction counts mismatch")
+	}
+	restored := append(blocks[0], blocks[2]...)
+	for i, tx := range restored {
+		if tx.Hash() != txs[i].Hash() {
+			t.Errorf("tx %d: hash mismatch: have %x, want %x", i, tx.Hash(), txs[i].Hash())
+		}
+	}
+}
+
+func mustEncodeChannel(t *testing.T, batches ...Batch) []byte {
+	channel, err := EncodeChannel(batches)
+	if err != nil {
+		t.Fatalf("failed to encode channel: %v", err)
+	}
+	return channel
+}
+
+func TestSpanBatchInvalid(t *testing.T) {
+	valid := testSpanBatch(t, testSpanBatchTxs(t)).encode()
+	if err := new(SpanBatch).decode(valid); err != nil {
+		t.Fatalf("failed to decode valid span batch: %v", err)
+	}
+	tests := map[string][]byte{
+		"truncated":     valid[:len(valid)-1],
+		"trailing data": append(append([]byte{}, valid...), 0x00),
+		"no blocks":     append(append([]byte{0x01, 0x01}, make([]byte, 40)...), 0x00),
+		// The single origin bit of a one block span may not exceed the list length
+		"invalid bits": append(append([]byte{0x01, 0x01}, make([]byte, 40)...), 0x01, 0x02, 0x00),
+	}
+	for name, data := range tests {
+		if err := new(SpanBatch).decode(data); err == nil {
+			t.Errorf("%s: expected span batch to be rejected", name)
+		}
+	}
+}
+
+// Tests that the span batches are accepted up to the boundaries of the window
+// around the L2 head, and rejected past them.
+func TestValidateBatchTimestamp(t *testing.T) {
+	config := *params.TestChainConfig
+	config.Optimism = &params.OptimismConfig{BlockTime: 2, SequencerWindowSize: 3600}
+
+	var (
+		genesis = uint64(1_000_000)
+		head    = &L2BlockRef{Number: 50_000, Time: genesis + 100_000}
+	)
+	cfg, err := DefaultBatchValidationConfig(&config, genesis)
+	if err != nil {
+		t.Fatalf("failed to create validation config: %v", err)
+	}
+	if cfg.MaxFutureSeconds != 4 || cfg.MaxPastSeconds != 3600*12 {
+		t.Fatalf("default window mismatch: have %+v", cfg)
+	}
+	tests := []struct {
+		name string
+		rel  uint64
+		want error
+	}{
+		{"head", 100_000, nil},
+		{"max future", 100_000 + 4, nil},
+		{"over max future", 100_000 + 5, errBatchFuture},
+		{"max past", 100_000 - 3600*12, nil},
+		{"over max past", 100_000 - 3600*12 - 1, errBatchPast},
+	}
+	for _, tt := range tests {
+		err := ValidateBatchTimestamp(&SpanBatch{RelTimestamp: tt.rel}, head, cfg)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: error mismatch: have %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+// Tests that no validation window is derived from an incomplete chain config.
+func TestDefaultBatchValidationConfigIncomplete(t *testing.T) {
+	tests := map[string]*params.OptimismConfig{
+		"no optimism":       nil,
+		"no block time":     {SequencerWindowSize: 3600},
+		"no sequencer size": {BlockTime: 2},
+	}
+	for name, optimism := range tests {
+		config := *params.TestChainConfig
+		config.Optimism = optimism
+		if _, err := DefaultBatchValidationConfig(&config, 0); !errors.Is(err, errValidationWindow) {
+			t.Errorf("%s: error mismatch: have %v, want %v", name, err, errValidationWindow)
+		}
+	}
+}
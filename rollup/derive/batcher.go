@@ -0,0 +1,71 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package derive implements the derivation of OP-Stack L2 blocks from the
+// batches posted to L1.
+package derive
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var errMissingBlobSidecar = errors.New("blob batcher transaction without sidecar")
+
+// IsBatcherTransaction reports whether the L1 transaction carries batch data:
+// it must be sent by the batcher to the batch inbox, as a legacy, dynamic fee
+// or blob transaction. Anyone may send transactions to the inbox, these are
+// simply ignored by the derivation.
+func IsBatcherTransaction(tx *types.Transaction, batcherAddr common.Address, inboxAddr common.Address, signer types.Signer) bool {
+	switch tx.Type() {
+	case types.LegacyTxType, types.DynamicFeeTxType, types.BlobTxType:
+	default:
+		return false
+	}
+	if to := tx.To(); to == nil || *to != inboxAddr {
+		return false
+	}
+	sender, err := types.Sender(signer, tx)
+	if err != nil {
+		return false
+	}
+	return sender == batcherAddr
+}
+
+// BatcherTransactionData returns the batch data carried by a batcher transaction.
+// Calldata transactions carry it in their input, while blob transactions carry
+// one piece of data per blob and leave the input empty.
+func BatcherTransactionData(tx *types.Transaction) ([][]byte, error) {
+	if tx.Type() != types.BlobTxType {
+		return [][]byte{tx.Data()}, nil
+	}
+	sidecar := tx.BlobTxSidecar()
+	if sidecar == nil {
+		return nil, errMissingBlobSidecar
+	}
+	data := make([][]byte, 0, len(sidecar.Blobs))
+	for i := range sidecar.Blobs {
+		blob, err := DecodeBlob(&sidecar.Blobs[i])
+		if err != nil {
+			return nil, fmt.Errorf("blob %d: %w", i, err)
+		}
+		data = append(data, blob)
+	}
+	return data, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package derive
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/holiman/uint256"
+)
+
+var (
+	batcherKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	otherKey, _   = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+	batcherAddr   = crypto.PubkeyToAddress(batcherKey.PublicKey)
+	inboxAddr     = common.HexToAddress("0xff00000000000000000000000000000000000010")
+)
+
+func TestIsBatcherTransaction(t *testing.T) {
+	var (
+		signer = types.NewCancunSigner(big.NewInt(1))
+		other  = common.HexToAddress("0x1234")
+		data   = []byte{0x00, 0xde, 0xad, 0xbe, 0xef}
+	)
+	blob, err := EncodeBlob([]byte("blob batch"))
+	if err != nil {
+		t.Fatalf("failed to encode blob: %v", err)
+	}
+	tests := []struct {
+		name string
+		key  bool // whether the batcher key signs the transaction
+		tx   types.TxData
+		want bool
+		data [][]byte
+	}{
+		{
+			name: "legacy",
+			key:  true,
+			tx:   &types.LegacyTx{To: &inboxAddr, Gas: 50000, GasPrice: big.NewInt(1), Data: data},
+			want: true,
+			data: [][]byte{data},
+		},
+		{
+			name: "dynamic fee",
+			key:  true,
+			tx:   &types.DynamicFeeTx{To: &inboxAddr, Gas: 50000, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1), Data: data},
+			want: true,
+			data: [][]byte{data},
+		},
+		{
+			name: "blob",
+			key:  true,
+			tx: &types.BlobTx{
+				To: inboxAddr, Gas: 50000, GasFeeCap: uint256.NewInt(1), GasTipCap: uint256.NewInt(1), BlobFeeCap: uint256.NewInt(1),
+				Sidecar: &types.BlobTxSidecar{Blobs: []kzg4844.Blob{*blob}},
+			},
+			want: true,
+			data: [][]byte{[]byte("blob batch")},
+		},
+		{
+			name: "access list",
+			key:  true,
+			tx:   &types.AccessListTx{To: &inboxAddr, Gas: 50000, GasPrice: big.NewInt(1), Data: data},
+		},
+		{
+			name: "wrong sender",
+			tx:   &types.DynamicFeeTx{To: &inboxAddr, Gas: 50000, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1), Data: data},
+		},
+		{
+			name: "wrong recipient",
+			key:  true,
+			tx:   &types.DynamicFeeTx{To: &other, Gas: 50000, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1), Data: data},
+		},
+		{
+			name: "blob wrong recipient",
+			key:  true,
+			tx: &types.BlobTx{
+				To: other, Gas: 50000, GasFeeCap: uint256.NewInt(1), GasTipCap: uint256.NewInt(1), BlobFeeCap: uint256.NewInt(1),
+				Sidecar: &types.BlobTxSidecar{Blobs: []kzg4844.Blob{*blob}},
+			},
+		},
+		{
+			name: "contract creation",
+			key:  true,
+			tx:   &types.LegacyTx{Gas: 50000, GasPrice: big.NewInt(1), Data: data},
+		},
+	}
+	for _, tt := range tests {
+		key := otherKey
+		if tt.key {
+			key = batcherKey
+		}
+		tx, err := types.SignNewTx(key, signer, tt.tx)
+		if err != nil {
+			t.Fatalf("%s: failed to sign transaction: %v", tt.name, err)
+		}
+		if have := IsBatcherTransaction(tx, batcherAddr, inboxAddr, signer); have != tt.want {
+			t.Errorf("%s: batcher transaction mismatch: have %v, want %v", tt.name, have, tt.want)
+		}
+		if !tt.want {
+			continue
+		}
+		have, err := BatcherTransactionData(tx)
+		if err != nil {
+			t.Fatalf("%s: failed to extract batch data: %v", tt.name, err)
+		}
+		if len(have) != len(tt.data) {
+			t.Fatalf("%s: batch data count mismatch: have %d, want %d", tt.name, len(have), len(tt.data))
+		}
+		for i := range have {
+			if !bytes.Equal(have[i], tt.data[i]) {
+				t.Errorf("%s: batch data %d mismatch: have %x, want %x", tt.name, i, have[i], tt.data[i])
+			}
+		}
+	}
+}
+
+func TestBlobDataWithoutSidecar(t *testing.T) {
+	tx := types.NewTx(&types.BlobTx{To: inboxAddr, BlobHashes: []common.Hash{{0x01}}})
+	if _, err := BatcherTransactionData(tx); err == nil {
+		t.Fatal("expected blob transaction without sidecar to be rejected")
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package derive
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+const (
+	// blobEncodingVersion is the version of the batch data encoding into blobs.
+	blobEncodingVersion = 0
+
+	// blobRounds is the number of encoding rounds of a blob, every round packing
+	// 127 bytes of data into four field elements.
+	blobRounds = 1024
+
+	// MaxBlobDataSize is the maximum amount of batch data a blob can carry: the
+	// first round loses four bytes to the version and length prefix.
+	MaxBlobDataSize = (4*31+3)*blobRounds - 4
+)
+
+var (
+	errBlobDataTooLarge   = errors.New("blob data too large")
+	errBlobInvalidVersion = errors.New("invalid blob encoding version")
+	errBlobInvalidLength  = errors.New("invalid blob data length")
+	errBlobInvalidElement = errors.New("invalid blob field element")
+	errBlobTrailingData   = errors.New("non-zero blob data after the encoded length")
+)
+
+// EncodeBlob packs the data into a blob. Each field element carries 31 bytes
+// of data in its lower bytes and 6 bits in its first byte, keeping the element
+// below the BLS modulus, so that four elements carry 127 bytes. The first
+// element is prefixed with the encoding version and the 3-byte data length.
+func EncodeBlob(data []byte) (*kzg4844.Blob, error) {
+	if len(data) > MaxBlobDataSize {
+		return nil, fmt.Errorf("%w: %d > %d", errBlobDataTooLarge, len(data), MaxBlobDataSize)
+	}
+	var (
+		blob  = new(kzg4844.Blob)
+		input = make([]byte, 4+len(data))
+	)
+	input[0] = blobEncodingVersion
+	input[1], input[2], input[3] = byte(len(data)>>16), byte(len(data)>>8), byte(len(data))
+	copy(input[4:], data)
+
+	// read returns the next n bytes of the input, zero padding past its end
+	var pos int
+	read := func(n int) []byte {
+		out := make([]byte, n)
+		if pos < len(input) {
+			copy(out, input[pos:])
+		}
+		pos += n
+		return out
+	}
+	for round := 0; round < blobRounds && pos < len(input); round++ {
+		var (
+			elems [4][]byte
+			extra [3]byte
+		)
+		for i := range elems {
+			elems[i] = read(31)
+			if i < 3 {
+				extra[i] = read(1)[0]
+			}
+		}
+		// Spread the three extra bytes over the 6 free bits of every element
+		x, y, z := extra[0], extra[1], extra[2]
+		heads := [4]byte{
+			x & 0b0011_1111,
+			(y & 0b0000_1111) | ((x & 0b1100_0000) >> 2),
+			z & 0b0011_1111,
+			((z & 0b1100_0000) >> 2) | ((y & 0b1111_0000) >> 4),
+		}
+		for i := range elems {
+			offset := (round*4 + i) * 32
+			blob[offset] = heads[i]
+			copy(blob[offset+1:offset+32], elems[i])
+		}
+	}
+	return blob, nil
+}
+
+// DecodeBlob extracts the data packed into a blob by EncodeBlob.
+func DecodeBlob(blob *kzg4844.Blob) ([]byte, error) {
+	output := make([]byte, 0, blobRounds*127)
+	for round := 0; round < blobRounds; round++ {
+		var heads [4]byte
+		for i := range heads {
+			offset := (round*4 + i) * 32
+			if blob[offset]&0b1100_0000 != 0 {
+				return nil, fmt.Errorf("%w: %d", errBlobInvalidElement, round*4+i)
+			}
+			heads[i] = blob[offset]
+			output = append(output, blob[offset+1:offset+32]...)
+			if i < 3 {
+				output = append(output, 0) // placeholder for the reassembled byte
+			}
+		}
+		base := round * 127
+		output[base+31] = (heads[0] & 0b0011_1111) | ((heads[1] & 0b0011_0000) << 2)
+		output[base+63] = (heads[1] & 0b0000_1111) | ((heads[3] & 0b0000_1111) << 4)
+		output[base+95] = (heads[2] & 0b0011_1111) | ((heads[3] & 0b0011_0000) << 2)
+	}
+	if output[0] != blobEncodingVersion {
+		return nil, fmt.Errorf("%w: %d", errBlobInvalidVersion, output[0])
+	}
+	size := int(output[1])<<16 | int(output[2])<<8 | int(output[3])
+	if size > MaxBlobDataSize {
+		return nil, fmt.Errorf("%w: %d", errBlobInvalidLength, size)
+	}
+	for _, b := range output[4+size:] {
+		if b != 0 {
+			return nil, errBlobTrailingData
+		}
+	}
+	return output[4 : 4+size], nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package derive
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBlobRoundTrip(t *testing.T) {
+	for _, size := range []int{0, 1, 27, 28, 127, 128, 1000, MaxBlobDataSize} {
+		data := make([]byte, size)
+		for i := range data {
+			data[i] = byte(i*7 + 1)
+		}
+		blob, err := EncodeBlob(data)
+		if err != nil {
+			t.Fatalf("size %d: failed to encode: %v", size, err)
+		}
+		for i := 0; i < len(blob); i += 32 {
+			if blob[i]&0b1100_0000 != 0 {
+				t.Fatalf("size %d: field element %d exceeds the modulus", size, i/32)
+			}
+		}
+		have, err := DecodeBlob(blob)
+		if err != nil {
+			t.Fatalf("size %d: failed to decode: %v", size, err)
+		}
+		if !bytes.Equal(have, data) {
+			t.Fatalf("size %d: round trip mismatch", size)
+		}
+	}
+	if _, err := EncodeBlob(make([]byte, MaxBlobDataSize+1)); err == nil {
+		t.Fatal("expected oversized data to be rejected")
+	}
+	// Corrupt blobs must be rejected
+	blob, _ := EncodeBlob([]byte("batch"))
+	blob[1] = 1
+	if _, err := DecodeBlob(blob); err == nil {
+		t.Fatal("expected unknown encoding version to be rejected")
+	}
+	blob, _ = EncodeBlob([]byte("batch"))
+	blob[64] = 0xff
+	if _, err := DecodeBlob(blob); err == nil {
+		t.Fatal("expected out of range field element to be rejected")
+	}
+	blob, _ = EncodeBlob([]byte("batch"))
+	blob[len(blob)-1] = 1
+	if _, err := DecodeBlob(blob); err == nil {
+		t.Fatal("expected data past the encoded length to be rejected")
+	}
+}
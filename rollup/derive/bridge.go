@@ -0,0 +1,139 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package derive
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// standardBridgeABI is the subset of the standard bridge ABI used to derive
+// ERC-20 deposits: the L1 deposit event and the matching L2 finalization.
+const standardBridgeABI = `[
+	{"type":"event","name":"ERC20DepositInitiated","anonymous":false,"inputs":[
+		{"name":"l1Token","type":"address","indexed":true},
+		{"name":"l2Token","type":"address","indexed":true},
+		{"name":"from","type":"address","indexed":true},
+		{"name":"to","type":"address","indexed":false},
+		{"name":"amount","type":"uint256","indexed":false},
+		{"name":"extraData","type":"bytes","indexed":false}
+	]},
+	{"type":"function","name":"finalizeDeposit","stateMutability":"payable","inputs":[
+		{"name":"_l1Token","type":"address"},
+		{"name":"_l2Token","type":"address"},
+		{"name":"_from","type":"address"},
+		{"name":"_to","type":"address"},
+		{"name":"_amount","type":"uint256"},
+		{"name":"_extraData","type":"bytes"}
+	],"outputs":[]}
+]`
+
+var (
+	errNotBridgeLog      = errors.New("log not emitted by the bridge")
+	errNotERC20Deposit   = errors.New("log is not an ERC20DepositInitiated event")
+	errInvalidDepositLog = errors.New("invalid ERC20DepositInitiated event")
+)
+
+var bridgeABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(standardBridgeABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// ERC20DepositEventTopic is the topic of ERC20DepositInitiated events.
+var ERC20DepositEventTopic = bridgeABI.Events["ERC20DepositInitiated"].ID
+
+// ERC20DepositEvent is an ERC-20 deposit initiated through the L1 standard
+// bridge, along with the position of its log on L1.
+type ERC20DepositEvent struct {
+	L1Token   common.Address
+	L2Token   common.Address
+	From      common.Address
+	To        common.Address
+	Amount    *big.Int
+	ExtraData []byte
+
+	L1BlockHash common.Hash // Hash of the L1 block including the deposit
+	L1TxHash    common.Hash // Hash of the L1 transaction initiating the deposit
+	LogIndex    uint        // Index of the deposit log in the L1 block
+}
+
+// FinalizeDepositData returns the calldata of the L2 standard bridge call
+// crediting the deposit, relayed to L2 by the cross domain messenger.
+func (ev *ERC20DepositEvent) FinalizeDepositData() ([]byte, error) {
+	return bridgeABI.Pack("finalizeDeposit", ev.L1Token, ev.L2Token, ev.From, ev.To, ev.Amount, ev.ExtraData)
+}
+
+// ParseERC20DepositEvent decodes an ERC20DepositInitiated log emitted by the
+// L1 standard bridge at the given address.
+func ParseERC20DepositEvent(log *types.Log, bridgeAddr common.Address) (*ERC20DepositEvent, error) {
+	if log.Address != bridgeAddr {
+		return nil, fmt.Errorf("%w: have %v, want %v", errNotBridgeLog, log.Address, bridgeAddr)
+	}
+	if len(log.Topics) == 0 || log.Topics[0] != ERC20DepositEventTopic {
+		return nil, errNotERC20Deposit
+	}
+	if len(log.Topics) != 4 {
+		return nil, fmt.Errorf("%w: have %d topics, want 4", errInvalidDepositLog, len(log.Topics))
+	}
+	values, err := bridgeABI.Events["ERC20DepositInitiated"].Inputs.NonIndexed().Unpack(log.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidDepositLog, err)
+	}
+	return &ERC20DepositEvent{
+		L1Token:     common.BytesToAddress(log.Topics[1][:]),
+		L2Token:     common.BytesToAddress(log.Topics[2][:]),
+		From:        common.BytesToAddress(log.Topics[3][:]),
+		To:          values[0].(common.Address),
+		Amount:      values[1].(*big.Int),
+		ExtraData:   values[2].([]byte),
+		L1BlockHash: log.BlockHash,
+		L1TxHash:    log.TxHash,
+		LogIndex:    log.Index,
+	}, nil
+}
+
+// DeriveERC20Deposits returns the ERC-20 deposits initiated through the bridge
+// in the receipts of an L1 block. Logs of failed transactions and unrelated
+// logs are skipped, while malformed deposit events are reported as errors.
+func DeriveERC20Deposits(receipts []*types.Receipt, bridgeAddr common.Address) ([]*ERC20DepositEvent, error) {
+	var deposits []*ERC20DepositEvent
+	for i, receipt := range receipts {
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		for j, log := range receipt.Logs {
+			if log.Address != bridgeAddr || len(log.Topics) == 0 || log.Topics[0] != ERC20DepositEventTopic {
+				continue
+			}
+			deposit, err := ParseERC20DepositEvent(log, bridgeAddr)
+			if err != nil {
+				return nil, fmt.Errorf("receipt %d, log %d: %w", i, j, err)
+			}
+			deposits = append(deposits, deposit)
+		}
+	}
+	return deposits, nil
+}
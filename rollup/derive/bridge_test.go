@@ -0,0 +1,135 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package derive
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	bridgeAddr = common.HexToAddress("0x3154Cf16ccdb4C6d922629664174b904d80F2C35")
+	l1Token    = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	l2Token    = common.HexToAddress("0x0b2C639c533813f4Aa9D7837CAf62653d097Ff85")
+	depositor  = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	recipient  = common.HexToAddress("0x2000000000000000000000000000000000000002")
+)
+
+// testDepositLog assembles an ERC20DepositInitiated log by hand.
+func testDepositLog(amount *big.Int, extraData []byte) *types.Log {
+	data := append(common.LeftPadBytes(recipient[:], 32), common.BigToHash(amount).Bytes()...)
+	data = append(data, common.BigToHash(big.NewInt(3*32)).Bytes()...)
+	data = append(data, common.BigToHash(big.NewInt(int64(len(extraData)))).Bytes()...)
+	data = append(data, common.RightPadBytes(extraData, (len(extraData)+31)/32*32)...)
+
+	return &types.Log{
+		Address: bridgeAddr,
+		Topics: []common.Hash{
+			crypto.Keccak256Hash([]byte("ERC20DepositInitiated(address,address,address,address,uint256,bytes)")),
+			common.BytesToHash(l1Token[:]),
+			common.BytesToHash(l2Token[:]),
+			common.BytesToHash(depositor[:]),
+		},
+		Data:      data,
+		BlockHash: common.Hash{0xbb},
+		TxHash:    common.Hash{0xcc},
+		Index:     5,
+	}
+}
+
+func TestParseERC20DepositEvent(t *testing.T) {
+	amount := new(big.Int).Mul(big.NewInt(1500), big.NewInt(1_000_000))
+	deposit, err := ParseERC20DepositEvent(testDepositLog(amount, []byte("memo")), bridgeAddr)
+	if err != nil {
+		t.Fatalf("failed to parse deposit: %v", err)
+	}
+	if deposit.L1Token != l1Token || deposit.L2Token != l2Token || deposit.From != depositor || deposit.To != recipient {
+		t.Fatalf("address mismatch: have %v/%v/%v/%v", deposit.L1Token, deposit.L2Token, deposit.From, deposit.To)
+	}
+	if deposit.Amount.Cmp(amount) != 0 {
+		t.Fatalf("amount mismatch: have %v, want %v", deposit.Amount, amount)
+	}
+	if !bytes.Equal(deposit.ExtraData, []byte("memo")) {
+		t.Fatalf("extra data mismatch: have %x", deposit.ExtraData)
+	}
+	if deposit.L1BlockHash != (common.Hash{0xbb}) || deposit.L1TxHash != (common.Hash{0xcc}) || deposit.LogIndex != 5 {
+		t.Fatalf("log position mismatch: have %x/%x/%d", deposit.L1BlockHash, deposit.L1TxHash, deposit.LogIndex)
+	}
+	// The L2 finalization takes the event arguments in the same order
+	calldata, err := deposit.FinalizeDepositData()
+	if err != nil {
+		t.Fatalf("failed to encode finalization: %v", err)
+	}
+	selector := crypto.Keccak256([]byte("finalizeDeposit(address,address,address,address,uint256,bytes)"))[:4]
+	if !bytes.Equal(calldata[:4], selector) {
+		t.Fatalf("selector mismatch: have %x, want %x", calldata[:4], selector)
+	}
+	log := testDepositLog(amount, []byte("memo"))
+	want := bytes.Join([][]byte{
+		log.Topics[1][:], log.Topics[2][:], log.Topics[3][:],
+		log.Data[:64],
+		common.BigToHash(big.NewInt(6 * 32)).Bytes(), // extra data offset past all six heads
+		log.Data[96:],
+	}, nil)
+	if !bytes.Equal(calldata[4:], want) {
+		t.Fatalf("finalization calldata mismatch:\nhave %x\nwant %x", calldata[4:], want)
+	}
+}
+
+func TestParseERC20DepositEventInvalid(t *testing.T) {
+	tests := map[string]func(*types.Log){
+		"wrong emitter": func(l *types.Log) { l.Address = common.Address{0x01} },
+		"wrong topic":   func(l *types.Log) { l.Topics[0] = common.Hash{0x01} },
+		"no topics":     func(l *types.Log) { l.Topics = nil },
+		"missing topic": func(l *types.Log) { l.Topics = l.Topics[:3] },
+		"short data":    func(l *types.Log) { l.Data = l.Data[:64] },
+	}
+	for name, tamper := range tests {
+		log := testDepositLog(big.NewInt(1), nil)
+		tamper(log)
+		if _, err := ParseERC20DepositEvent(log, bridgeAddr); err == nil {
+			t.Errorf("%s: expected log to be rejected", name)
+		}
+	}
+}
+
+func TestDeriveERC20Deposits(t *testing.T) {
+	other := testDepositLog(big.NewInt(1), nil)
+	other.Address = common.Address{0x01}
+
+	receipts := []*types.Receipt{
+		{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{other, testDepositLog(big.NewInt(1), nil)}},
+		{Status: types.ReceiptStatusFailed, Logs: []*types.Log{testDepositLog(big.NewInt(2), nil)}},
+		{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{testDepositLog(big.NewInt(3), nil)}},
+	}
+	deposits, err := DeriveERC20Deposits(receipts, bridgeAddr)
+	if err != nil {
+		t.Fatalf("failed to derive deposits: %v", err)
+	}
+	if len(deposits) != 2 || deposits[0].Amount.Int64() != 1 || deposits[1].Amount.Int64() != 3 {
+		t.Fatalf("deposits mismatch: have %d deposits", len(deposits))
+	}
+	receipts[2].Logs[0].Data = nil
+	if _, err := DeriveERC20Deposits(receipts, bridgeAddr); err == nil {
+		t.Fatal("expected malformed deposit event to fail")
+	}
+}
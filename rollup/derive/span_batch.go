@@ -0,0 +1,452 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package derive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// MaxSpanBatchElementCount is the maximum number of blocks, and of transactions
+// across all blocks, a span batch may carry.
+const MaxSpanBatchElementCount = 10_000_000
+
+var (
+	errSpanBatchNoBlocks      = errors.New("span batch without blocks")
+	errSpanBatchTooLarge      = errors.New("span batch element count too large")
+	errSpanBatchInvalidBits   = errors.New("invalid span batch bitlist")
+	errSpanBatchTrailingData  = errors.New("trailing data after span batch")
+	errSpanBatchMismatch      = errors.New("span batch block fields mismatch")
+	errSpanBatchUnsupportedTx = errors.New("unsupported span batch transaction type")
+)
+
+// SpanBatch is a batch carrying a span of consecutive L2 blocks. The blocks
+// share the batch prefix, while their transactions are encoded column by
+// column to compress better.
+type SpanBatch struct {
+	RelTimestamp  uint64   // Timestamp of the first block, relative to the L2 genesis
+	L1OriginNum   uint64   // L1 origin number of the last block
+	ParentCheck   [20]byte // First 20 bytes of the parent hash of the first block
+	L1OriginCheck [20]byte // First 20 bytes of the L1 origin hash of the last block
+
+	OriginBits    []bool   // Whether every block advances the L1 origin
+	BlockTxCounts []uint64 // Number of transactions in every block
+	Txs           []*SpanBatchTx
+}
+
+// BatchType implements Batch.
+func (b *SpanBatch) BatchType() int { return SpanBatchType }
+
+// Transactions returns the signed transactions of every block of the span.
+func (b *SpanBatch) Transactions(chainID *big.Int) ([][]*types.Transaction, error) {
+	blocks := make([][]*types.Transaction, len(b.BlockTxCounts))
+	next := 0
+	for i, count := range b.BlockTxCounts {
+		if uint64(len(b.Txs)-next) < count {
+			return nil, fmt.Errorf("%w: block %d has %d transactions, %d left", errSpanBatchMismatch, i, count, len(b.Txs)-next)
+		}
+		for _, stx := range b.Txs[next : next+int(count)] {
+			tx, err := stx.Transaction(chainID)
+			if err != nil {
+				return nil, fmt.Errorf("block %d: %w", i, err)
+			}
+			blocks[i] = append(blocks[i], tx)
+		}
+		next += int(count)
+	}
+	return blocks, nil
+}
+
+// SpanBatchTx is a transaction of a span batch, stripped of the chain id which
+// is shared by all transactions.
+type SpanBatchTx struct {
+	Type       byte
+	To         *common.Address // nil means contract creation
+	Nonce      uint64
+	Gas        uint64
+	Value      *big.Int
+	GasPrice   *big.Int // legacy and access list transactions
+	GasTipCap  *big.Int // dynamic fee transactions
+	GasFeeCap  *big.Int // dynamic fee transactions
+	Data       []byte
+	AccessList types.AccessList
+
+	YParity   bool
+	R, S      *big.Int
+	Protected bool // whether legacy transactions are replay protected
+}
+
+// The typed payloads of the span batch transactions, stripped from the fields
+// encoded in their own columns.
+type spanLegacyTxData struct {
+	Value    *big.Int
+	GasPrice *big.Int
+	Data     []byte
+}
+
+type spanAccessListTxData struct {
+	Value      *big.Int
+	GasPrice   *big.Int
+	Data       []byte
+	AccessList types.AccessList
+}
+
+type spanDynamicFeeTxData struct {
+	Value      *big.Int
+	GasTipCap  *big.Int
+	GasFeeCap  *big.Int
+	Data       []byte
+	AccessList types.AccessList
+}
+
+// NewSpanBatchTx strips a signed transaction down to its span batch fields.
+func NewSpanBatchTx(tx *types.Transaction) (*SpanBatchTx, error) {
+	switch tx.Type() {
+	case types.LegacyTxType, types.AccessListTxType, types.DynamicFeeTxType:
+	default:
+		return nil, fmt.Errorf("%w: %d", errSpanBatchUnsupportedTx, tx.Type())
+	}
+	v, r, s := tx.RawSignatureValues()
+	stx := &SpanBatchTx{
+		Type:       tx.Type(),
+		To:         tx.To(),
+		Nonce:      tx.Nonce(),
+		Gas:        tx.Gas(),
+		Value:      tx.Value(),
+		Data:       tx.Data(),
+		AccessList: tx.AccessList(),
+		R:          r,
+		S:          s,
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		// Both 27 + yParity and chainId * 2 + 35 + yParity are odd for a zero parity
+		stx.GasPrice = tx.GasPrice()
+		stx.YParity = v.Bit(0) == 0
+		stx.Protected = tx.Protected()
+	case types.AccessListTxType:
+		stx.GasPrice = tx.GasPrice()
+		stx.YParity = v.Sign() != 0
+	case types.DynamicFeeTxType:
+		stx.GasTipCap = tx.GasTipCap()
+		stx.GasFeeCap = tx.GasFeeCap()
+		stx.YParity = v.Sign() != 0
+	}
+	return stx, nil
+}
+
+// Transaction restores the signed transaction on the chain with the given id.
+func (stx *SpanBatchTx) Transaction(chainID *big.Int) (*types.Transaction, error) {
+	v := new(big.Int)
+	if stx.YParity {
+		v.SetUint64(1)
+	}
+	switch stx.Type {
+	case types.LegacyTxType:
+		if stx.Protected {
+			v.Add(v, new(big.Int).Add(new(big.Int).Lsh(chainID, 1), big.NewInt(35)))
+		} else {
+			v.Add(v, big.NewInt(27))
+		}
+		return types.NewTx(&types.LegacyTx{
+			Nonce: stx.Nonce, GasPrice: stx.GasPrice, Gas: stx.Gas, To: stx.To, Value: stx.Value, Data: stx.Data,
+			V: v, R: stx.R, S: stx.S,
+		}), nil
+	case types.AccessListTxType:
+		return types.NewTx(&types.AccessListTx{
+			ChainID: chainID, Nonce: stx.Nonce, GasPrice: stx.GasPrice, Gas: stx.Gas, To: stx.To, Value: stx.Value,
+			Data: stx.Data, AccessList: stx.AccessList, V: v, R: stx.R, S: stx.S,
+		}), nil
+	case types.DynamicFeeTxType:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID: chainID, Nonce: stx.Nonce, GasTipCap: stx.GasTipCap, GasFeeCap: stx.GasFeeCap, Gas: stx.Gas,
+			To: stx.To, Value: stx.Value, Data: stx.Data, AccessList: stx.AccessList, V: v, R: stx.R, S: stx.S,
+		}), nil
+	default:
+		return nil, fmt.Errorf("%w: %d", errSpanBatchUnsupportedTx, stx.Type)
+	}
+}
+
+// encode serializes the span batch as the prefix followed by the payload:
+//
+//	prefix  = rel_timestamp ++ l1_origin_num ++ parent_check ++ l1_origin_check
+//	payload = block_count ++ origin_bits ++ block_tx_counts ++ txs
+//	txs     = contract_creation_bits ++ y_parity_bits ++ tx_sigs ++ tx_tos ++
+//	          tx_datas ++ tx_nonces ++ tx_gases ++ protected_bits
+func (b *SpanBatch) encode() []byte {
+	enc := binary.AppendUvarint(nil, b.RelTimestamp)
+	enc = binary.AppendUvarint(enc, b.L1OriginNum)
+	enc = append(enc, b.ParentCheck[:]...)
+	enc = append(enc, b.L1OriginCheck[:]...)
+
+	enc = binary.AppendUvarint(enc, uint64(len(b.BlockTxCounts)))
+	enc = appendBitlist(enc, b.OriginBits)
+	for _, count := range b.BlockTxCounts {
+		enc = binary.AppendUvarint(enc, count)
+	}
+	var (
+		creations = make([]bool, len(b.Txs))
+		parities  = make([]bool, len(b.Txs))
+		protected []bool
+	)
+	for i, tx := range b.Txs {
+		creations[i], parities[i] = tx.To == nil, tx.YParity
+		if tx.Type == types.LegacyTxType {
+			protected = append(protected, tx.Protected)
+		}
+	}
+	enc = appendBitlist(enc, creations)
+	enc = appendBitlist(enc, parities)
+	for _, tx := range b.Txs {
+		enc = append(enc, common.BigToHash(tx.R).Bytes()...)
+		enc = append(enc, common.BigToHash(tx.S).Bytes()...)
+	}
+	for _, tx := range b.Txs {
+		if tx.To != nil {
+			enc = append(enc, tx.To[:]...)
+		}
+	}
+	for _, tx := range b.Txs {
+		enc = append(enc, tx.encodeData()...)
+	}
+	for _, tx := range b.Txs {
+		enc = binary.AppendUvarint(enc, tx.Nonce)
+	}
+	for _, tx := range b.Txs {
+		enc = binary.AppendUvarint(enc, tx.Gas)
+	}
+	return appendBitlist(enc, protected)
+}
+
+// encodeData serializes the typed payload of the transaction.
+func (stx *SpanBatchTx) encodeData() []byte {
+	var data any
+	switch stx.Type {
+	case types.LegacyTxType:
+		data = &spanLegacyTxData{stx.Value, stx.GasPrice, stx.Data}
+	case types.AccessListTxType:
+		data = &spanAccessListTxData{stx.Value, stx.GasPrice, stx.Data, stx.AccessList}
+	default:
+		data = &spanDynamicFeeTxData{stx.Value, stx.GasTipCap, stx.GasFeeCap, stx.Data, stx.AccessList}
+	}
+	enc, _ := rlp.EncodeToBytes(data)
+	if stx.Type == types.LegacyTxType {
+		return enc
+	}
+	return append([]byte{stx.Type}, enc...)
+}
+
+// decode parses the span batch from its serialization.
+func (b *SpanBatch) decode(data []byte) error {
+	r := bytes.NewReader(data)
+
+	var err error
+	if b.RelTimestamp, err = binary.ReadUvarint(r); err != nil {
+		return fmt.Errorf("invalid relative timestamp: %w", err)
+	}
+	if b.L1OriginNum, err = binary.ReadUvarint(r); err != nil {
+		return fmt.Errorf("invalid L1 origin number: %w", err)
+	}
+	if _, err := io.ReadFull(r, b.ParentCheck[:]); err != nil {
+		return fmt.Errorf("invalid parent check: %w", err)
+	}
+	if _, err := io.ReadFull(r, b.L1OriginCheck[:]); err != nil {
+		return fmt.Errorf("invalid L1 origin check: %w", err)
+	}
+	blocks, err := readElementCount(r)
+	if err != nil {
+		return fmt.Errorf("invalid block count: %w", err)
+	}
+	if blocks == 0 {
+		return errSpanBatchNoBlocks
+	}
+	if b.OriginBits, err = readBitlist(r, blocks); err != nil {
+		return fmt.Errorf("invalid origin bits: %w", err)
+	}
+	var txs uint64
+	b.BlockTxCounts = make([]uint64, blocks)
+	for i := range b.BlockTxCounts {
+		if b.BlockTxCounts[i], err = readElementCount(r); err != nil {
+			return fmt.Errorf("invalid block %d tx count: %w", i, err)
+		}
+		if txs += b.BlockTxCounts[i]; txs > MaxSpanBatchElementCount {
+			return fmt.Errorf("%w: %d transactions", errSpanBatchTooLarge, txs)
+		}
+	}
+	if err := b.decodeTxs(r, txs); err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("%w: %d bytes", errSpanBatchTrailingData, r.Len())
+	}
+	return nil
+}
+
+// decodeTxs parses the transaction columns of the span batch.
+func (b *SpanBatch) decodeTxs(r *bytes.Reader, count uint64) error {
+	creations, err := readBitlist(r, count)
+	if err != nil {
+		return fmt.Errorf("invalid contract creation bits: %w", err)
+	}
+	parities, err := readBitlist(r, count)
+	if err != nil {
+		return fmt.Errorf("invalid y parity bits: %w", err)
+	}
+	// Every transaction carries at least its signature, bound the allocation
+	if count > uint64(r.Len())/64 {
+		return fmt.Errorf("%w: %d transactions in %d bytes", errSpanBatchTooLarge, count, r.Len())
+	}
+	b.Txs = make([]*SpanBatchTx, count)
+	for i := range b.Txs {
+		var sig [64]byte
+		if _, err := io.ReadFull(r, sig[:]); err != nil {
+			return fmt.Errorf("invalid tx %d signature: %w", i, err)
+		}
+		b.Txs[i] = &SpanBatchTx{
+			YParity: parities[i],
+			R:       new(big.Int).SetBytes(sig[:32]),
+			S:       new(big.Int).SetBytes(sig[32:]),
+		}
+	}
+	for i, tx := range b.Txs {
+		if creations[i] {
+			continue
+		}
+		tx.To = new(common.Address)
+		if _, err := io.ReadFull(r, tx.To[:]); err != nil {
+			return fmt.Errorf("invalid tx %d recipient: %w", i, err)
+		}
+	}
+	var legacy uint64
+	for i, tx := range b.Txs {
+		if err := tx.decodeData(r); err != nil {
+			return fmt.Errorf("invalid tx %d data: %w", i, err)
+		}
+		if tx.Type == types.LegacyTxType {
+			legacy++
+		}
+	}
+	for i, tx := range b.Txs {
+		if tx.Nonce, err = binary.ReadUvarint(r); err != nil {
+			return fmt.Errorf("invalid tx %d nonce: %w", i, err)
+		}
+	}
+	for i, tx := range b.Txs {
+		if tx.Gas, err = binary.ReadUvarint(r); err != nil {
+			return fmt.Errorf("invalid tx %d gas: %w", i, err)
+		}
+	}
+	protected, err := readBitlist(r, legacy)
+	if err != nil {
+		return fmt.Errorf("invalid protected bits: %w", err)
+	}
+	for _, tx := range b.Txs {
+		if tx.Type == types.LegacyTxType {
+			tx.Protected, protected = protected[0], protected[1:]
+		}
+	}
+	return nil
+}
+
+// decodeData parses the typed payload of the transaction.
+func (stx *SpanBatchTx) decodeData(r *bytes.Reader) error {
+	kind, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if kind >= 0xc0 {
+		// Legacy payloads are bare RLP lists
+		r.UnreadByte()
+		kind = types.LegacyTxType
+	}
+	stream := rlp.NewStream(r, uint64(r.Len()))
+	stx.Type = kind
+
+	switch kind {
+	case types.LegacyTxType:
+		var data spanLegacyTxData
+		if err := stream.Decode(&data); err != nil {
+			return err
+		}
+		stx.Value, stx.GasPrice, stx.Data = data.Value, data.GasPrice, data.Data
+	case types.AccessListTxType:
+		var data spanAccessListTxData
+		if err := stream.Decode(&data); err != nil {
+			return err
+		}
+		stx.Value, stx.GasPrice, stx.Data, stx.AccessList = data.Value, data.GasPrice, data.Data, data.AccessList
+	case types.DynamicFeeTxType:
+		var data spanDynamicFeeTxData
+		if err := stream.Decode(&data); err != nil {
+			return err
+		}
+		stx.Value, stx.GasTipCap, stx.GasFeeCap = data.Value, data.GasTipCap, data.GasFeeCap
+		stx.Data, stx.AccessList = data.Data, data.AccessList
+	default:
+		return fmt.Errorf("%w: %d", errSpanBatchUnsupportedTx, kind)
+	}
+	return nil
+}
+
+// readElementCount reads a uvarint counting span batch elements.
+func readElementCount(r *bytes.Reader) (uint64, error) {
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, err
+	}
+	if count > MaxSpanBatchElementCount {
+		return 0, fmt.Errorf("%w: %d", errSpanBatchTooLarge, count)
+	}
+	return count, nil
+}
+
+// appendBitlist appends the bits as a big endian integer whose i-th least
+// significant bit is the i-th element, padded to a whole number of bytes.
+func appendBitlist(enc []byte, bits []bool) []byte {
+	n := new(big.Int)
+	for i, bit := range bits {
+		if bit {
+			n.SetBit(n, i, 1)
+		}
+	}
+	return append(enc, n.FillBytes(make([]byte, (len(bits)+7)/8))...)
+}
+
+// readBitlist reads a bitlist of the given length.
+func readBitlist(r *bytes.Reader, length uint64) ([]bool, error) {
+	buf := make([]byte, (length+7)/8)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	n := new(big.Int).SetBytes(buf)
+	if uint64(n.BitLen()) > length {
+		return nil, fmt.Errorf("%w: %d bits set beyond length %d", errSpanBatchInvalidBits, n.BitLen(), length)
+	}
+	bits := make([]bool, length)
+	for i := range bits {
+		bits[i] = n.Bit(i) == 1
+	}
+	return bits, nil
+}
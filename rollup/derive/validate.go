@@ -0,0 +1,84 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package derive
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// l1BlockTime is the number of seconds between the L1 blocks.
+const l1BlockTime = 12
+
+var (
+	errBatchFuture = errors.New("batch too far in the future")
+	errBatchPast   = errors.New("batch too far in the past")
+
+	errValidationWindow = errors.New("batch validation window undefined")
+)
+
+// L2BlockRef identifies an L2 block along with its timestamp.
+type L2BlockRef struct {
+	Hash   common.Hash
+	Number uint64
+	Time   uint64
+}
+
+// BatchValidationConfig is the window around the L2 head the timestamps of the
+// batches must fall into.
+type BatchValidationConfig struct {
+	GenesisTime      uint64 // Timestamp of the L2 genesis, span batch timestamps are relative to it
+	MaxFutureSeconds uint64 // Seconds a batch may be ahead of the L2 head
+	MaxPastSeconds   uint64 // Seconds a batch may be behind the L2 head
+}
+
+// DefaultBatchValidationConfig returns the validation window of the rollup: the
+// batches may be up to two blocks ahead of the L2 head, and behind it by up to
+// the duration of the sequencer window. An error is returned if the chain config
+// lacks either of them, as the window would not admit any batch but at the head.
+func DefaultBatchValidationConfig(config *params.ChainConfig, genesisTime uint64) (BatchValidationConfig, error) {
+	if config.Optimism == nil {
+		return BatchValidationConfig{}, fmt.Errorf("%w: not an optimism chain", errValidationWindow)
+	}
+	if config.Optimism.BlockTime == 0 {
+		return BatchValidationConfig{}, fmt.Errorf("%w: missing block time", errValidationWindow)
+	}
+	if config.Optimism.SequencerWindowSize == 0 {
+		return BatchValidationConfig{}, fmt.Errorf("%w: missing sequencer window size", errValidationWindow)
+	}
+	return BatchValidationConfig{
+		GenesisTime:      genesisTime,
+		MaxFutureSeconds: config.Optimism.BlockTime * 2,
+		MaxPastSeconds:   config.Optimism.SequencerWindowSize * l1BlockTime,
+	}, nil
+}
+
+// ValidateBatchTimestamp checks that the first block of the span batch is within
+// the validation window around the L2 head. The boundaries are inclusive.
+func ValidateBatchTimestamp(batch *SpanBatch, l2Head *L2BlockRef, cfg BatchValidationConfig) error {
+	timestamp := cfg.GenesisTime + batch.RelTimestamp
+	if timestamp > l2Head.Time+cfg.MaxFutureSeconds {
+		return fmt.Errorf("%w: timestamp %d, head %d, max %d seconds ahead", errBatchFuture, timestamp, l2Head.Time, cfg.MaxFutureSeconds)
+	}
+	if timestamp+cfg.MaxPastSeconds < l2Head.Time {
+		return fmt.Errorf("%w: timestamp %d, head %d, max %d seconds behind", errBatchPast, timestamp, l2Head.Time, cfg.MaxPastSeconds)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package dispute
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// DisputeGameType identifies the kind of dispute game backing an output root,
+// following the game type numbering of the dispute game factory on L1.
+type DisputeGameType uint32
+
+const (
+	// FaultGameType is the permissionless fault dispute game, in which anyone
+	// can dispute a claim by bisecting the trace down to a single instruction.
+	FaultGameType DisputeGameType = 0
+
+	// PermissionedGameType is the fault dispute game restricted to the trusted
+	// proposer and challenger.
+	PermissionedGameType DisputeGameType = 1
+)
+
+// String implements the stringer interface.
+func (t DisputeGameType) String() string {
+	switch t {
+	case FaultGameType:
+		return "fault"
+	case PermissionedGameType:
+		return "permissioned"
+	default:
+		return fmt.Sprintf("DisputeGameType(%d)", uint32(t))
+	}
+}
+
+// DisputeGame is a dispute game created by a GameFactory.
+type DisputeGame struct {
+	GameType  DisputeGameType
+	RootClaim common.Hash
+	ExtraData []byte
+	Game      *FaultDisputeGame
+}
+
+// GameFactory creates dispute games over proposed output roots.
+type GameFactory interface {
+	// Create creates a new dispute game of the given type over the root claim,
+	// the extra data carrying the game type specific parameters.
+	Create(gameType DisputeGameType, rootClaim common.Hash, extraData []byte) (*DisputeGame, error)
+}
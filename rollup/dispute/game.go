@@ -0,0 +1,159 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package dispute implements the OP-Stack fault dispute game, in which the
+// output root proposed for an L2 block is challenged by bisecting the execution
+// trace of its derivation.
+package dispute
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MaxGameDepth is the maximum depth of the game tree. Claims at the deepest
+// level commit to single instructions of the trace, their positions still fit
+// into a uint64.
+const MaxGameDepth = 62
+
+// rootParentIndex is the parent index of the root claim.
+const rootParentIndex = math.MaxUint64
+
+var (
+	errGameNotInProgress     = errors.New("game not in progress")
+	errInvalidParent         = errors.New("invalid parent claim")
+	errGameDepthExceeded     = errors.New("game depth exceeded")
+	errCannotDefendRoot      = errors.New("cannot defend the root claim")
+	errClaimAlreadyCountered = errors.New("claim already countered")
+)
+
+// GameStatus is the resolution status of a dispute game.
+type GameStatus uint8
+
+const (
+	InProgress GameStatus = iota
+	ChallengerWins
+	DefenderWins
+)
+
+// String implements the stringer interface.
+func (s GameStatus) String() string {
+	switch s {
+	case InProgress:
+		return "InProgress"
+	case ChallengerWins:
+		return "ChallengerWins"
+	case DefenderWins:
+		return "DefenderWins"
+	default:
+		return fmt.Sprintf("GameStatus(%d)", s)
+	}
+}
+
+// Claim is a commitment to the state of the trace at a position of the game.
+type Claim struct {
+	ParentIndex uint64 // index of the claim disputed by this one
+	Value       common.Hash
+	Position    Position
+	Countered   bool // whether the claim has already been disputed
+}
+
+// IsRoot reports whether the claim is the root claim of the game.
+func (c *Claim) IsRoot() bool {
+	return c.ParentIndex == rootParentIndex
+}
+
+// FaultDisputeGame is a bisection game over the output root proposed for an L2
+// block, derived from the L1 chain up to the given L1 head.
+type FaultDisputeGame struct {
+	RootClaim     common.Hash
+	L1Head        common.Hash
+	L2BlockNumber uint64
+	Status        GameStatus
+	Claims        []Claim
+}
+
+// NewFaultDisputeGame creates a dispute game over the given root claim.
+func NewFaultDisputeGame(rootClaim common.Hash, l1Head common.Hash, l2BlockNumber uint64) *FaultDisputeGame {
+	return &FaultDisputeGame{
+		RootClaim:     rootClaim,
+		L1Head:        l1Head,
+		L2BlockNumber: l2BlockNumber,
+		Status:        InProgress,
+		Claims: []Claim{{
+			ParentIndex: rootParentIndex,
+			Value:       rootClaim,
+			Position:    RootPosition,
+		}},
+	}
+}
+
+// Move disputes the parent claim with a new claim, either attacking it or
+// defending it against its own parent. Every claim can only be countered once
+// and counters cannot be placed below the maximum game depth.
+func (g *FaultDisputeGame) Move(parent uint64, claim common.Hash, isAttack bool) error {
+	if g.Status != InProgress {
+		return fmt.Errorf("%w: %v", errGameNotInProgress, g.Status)
+	}
+	if parent >= uint64(len(g.Claims)) {
+		return fmt.Errorf("%w: index %d, have %d claims", errInvalidParent, parent, len(g.Claims))
+	}
+	disputed := &g.Claims[parent]
+	if disputed.Countered {
+		return fmt.Errorf("%w: index %d", errClaimAlreadyCountered, parent)
+	}
+	if disputed.Position.Depth() >= MaxGameDepth {
+		return fmt.Errorf("%w: parent at depth %d", errGameDepthExceeded, disputed.Position.Depth())
+	}
+	position := disputed.Position.Attack()
+	if !isAttack {
+		if disputed.IsRoot() {
+			return errCannotDefendRoot
+		}
+		position = disputed.Position.Defend()
+	}
+	disputed.Countered = true
+	g.Claims = append(g.Claims, Claim{
+		ParentIndex: parent,
+		Value:       claim,
+		Position:    position,
+	})
+	return nil
+}
+
+// Resolve settles the game: a claim stands if none of its counters stand, the
+// defender wins if the root claim stands.
+func (g *FaultDisputeGame) Resolve() (GameStatus, error) {
+	if g.Status != InProgress {
+		return g.Status, fmt.Errorf("%w: %v", errGameNotInProgress, g.Status)
+	}
+	// Children are always appended after their parents, so walking the claims
+	// backwards settles every counter before the claim it disputes.
+	defeated := make([]bool, len(g.Claims))
+	for i := len(g.Claims) - 1; i > 0; i-- {
+		if !defeated[i] {
+			defeated[g.Claims[i].ParentIndex] = true
+		}
+	}
+	g.Status = DefenderWins
+	if defeated[0] {
+		g.Status = ChallengerWins
+	}
+	return g.Status, nil
+}
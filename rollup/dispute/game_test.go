@@ -0,0 +1,135 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package dispute
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testTrace is an execution trace, diverging from the honest one at the given
+// instruction index.
+type testTrace struct {
+	diverge uint64
+}
+
+func (t testTrace) state(index uint64) common.Hash {
+	var blob [9]byte
+	binary.BigEndian.PutUint64(blob[1:], index)
+	if index >= t.diverge {
+		blob[0] = 1
+	}
+	return crypto.Keccak256Hash(blob[:])
+}
+
+func (t testTrace) claim(pos Position) common.Hash {
+	return t.state(pos.TraceIndex(MaxGameDepth))
+}
+
+func TestPosition(t *testing.T) {
+	if d := RootPosition.Depth(); d != 0 {
+		t.Fatalf("root depth mismatch: have %d, want 0", d)
+	}
+	if idx := RootPosition.TraceIndex(MaxGameDepth); idx != 1<<MaxGameDepth-1 {
+		t.Fatalf("root trace index mismatch: have %d, want %d", idx, uint64(1<<MaxGameDepth-1))
+	}
+	attack := RootPosition.Attack()
+	if attack.Depth() != 1 || attack.IndexAtDepth() != 0 || attack.TraceIndex(MaxGameDepth) != 1<<(MaxGameDepth-1)-1 {
+		t.Fatalf("attack position mismatch: %d", attack)
+	}
+	defend := attack.Attack().Defend()
+	if defend.Depth() != 3 || defend.IndexAtDepth() != 2 {
+		t.Fatalf("defend position mismatch: depth %d, index %d", defend.Depth(), defend.IndexAtDepth())
+	}
+}
+
+// TestBisection plays a game between an honest defender and a challenger with
+// a faulty trace, both bisecting down to the disagreeing instruction.
+func TestBisection(t *testing.T) {
+	var (
+		honest = testTrace{diverge: 1 << MaxGameDepth}
+		faulty = testTrace{diverge: 123456789}
+		game   = NewFaultDisputeGame(honest.claim(RootPosition), common.Hash{0x01}, 100)
+	)
+	for moves, trace := 0, faulty; ; moves++ {
+		parent := uint64(len(game.Claims) - 1)
+		claim := game.Claims[parent]
+		// Attack claims disagreeing with the local trace, defend the others
+		isAttack := claim.Value != trace.claim(claim.Position)
+		pos := claim.Position.Attack()
+		if !isAttack {
+			pos = claim.Position.Defend()
+		}
+		err := game.Move(parent, trace.claim(pos), isAttack)
+		if claim.Position.Depth() == MaxGameDepth {
+			if !errors.Is(err, errGameDepthExceeded) {
+				t.Fatalf("move below the maximum depth: have %v, want %v", err, errGameDepthExceeded)
+			}
+			if moves != MaxGameDepth {
+				t.Fatalf("move count mismatch: have %d, want %d", moves, MaxGameDepth)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatalf("move %d failed: %v", moves, err)
+		}
+		if trace == faulty {
+			trace = honest
+		} else {
+			trace = faulty
+		}
+	}
+	// The bisection must have narrowed down to the first faulty instruction
+	leaf := game.Claims[len(game.Claims)-1].Position
+	if idx := leaf.TraceIndex(MaxGameDepth); idx != faulty.diverge && idx+1 != faulty.diverge {
+		t.Fatalf("bisection ended at instruction %d, want next to %d", idx, faulty.diverge)
+	}
+	status, err := game.Resolve()
+	if err != nil {
+		t.Fatalf("failed to resolve game: %v", err)
+	}
+	if status != DefenderWins {
+		t.Fatalf("game status mismatch: have %v, want %v", status, DefenderWins)
+	}
+	if err := game.Move(0, common.Hash{}, true); !errors.Is(err, errGameNotInProgress) {
+		t.Fatalf("move after resolution: have %v, want %v", err, errGameNotInProgress)
+	}
+}
+
+func TestMoveInvariants(t *testing.T) {
+	game := NewFaultDisputeGame(common.Hash{0x01}, common.Hash{}, 1)
+	if err := game.Move(0, common.Hash{0x02}, false); !errors.Is(err, errCannotDefendRoot) {
+		t.Fatalf("defending the root: have %v, want %v", err, errCannotDefendRoot)
+	}
+	if err := game.Move(1, common.Hash{0x02}, true); !errors.Is(err, errInvalidParent) {
+		t.Fatalf("unknown parent: have %v, want %v", err, errInvalidParent)
+	}
+	if err := game.Move(0, common.Hash{0x02}, true); err != nil {
+		t.Fatalf("failed to attack the root: %v", err)
+	}
+	if err := game.Move(0, common.Hash{0x03}, true); !errors.Is(err, errClaimAlreadyCountered) {
+		t.Fatalf("countering twice: have %v, want %v", err, errClaimAlreadyCountered)
+	}
+	// The uncountered attack stands, defeating the root claim
+	if status, err := game.Resolve(); err != nil || status != ChallengerWins {
+		t.Fatalf("game status mismatch: have %v (%v), want %v", status, err, ChallengerWins)
+	}
+}
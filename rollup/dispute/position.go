@@ -0,0 +1,55 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package dispute
+
+import "math/bits"
+
+// Position is the generalized index of a claim in the game tree: the root is
+// at 1 and the children of position p are at 2p and 2p+1.
+type Position uint64
+
+// RootPosition is the position of the root claim.
+const RootPosition Position = 1
+
+// Depth returns the depth of the position in the game tree.
+func (p Position) Depth() uint64 {
+	return uint64(bits.Len64(uint64(p)) - 1)
+}
+
+// IndexAtDepth returns the index of the position among the positions at the
+// same depth, counting from the left.
+func (p Position) IndexAtDepth() uint64 {
+	return uint64(p) - 1<<p.Depth()
+}
+
+// Attack returns the position disputing the claim at p, committing to the
+// midpoint of the left half of the trace range of p.
+func (p Position) Attack() Position {
+	return p * 2
+}
+
+// Defend returns the position agreeing with the claim at p but disputing its
+// parent, committing to the midpoint of the right half of the parent range.
+func (p Position) Defend() Position {
+	return (p | 1) * 2
+}
+
+// TraceIndex returns the index of the trace instruction whose post state the
+// claim at p commits to, in a game of the given maximum depth.
+func (p Position) TraceIndex(maxDepth uint64) uint64 {
+	return (p.IndexAtDepth()+1)<<(maxDepth-p.Depth()) - 1
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package rollup contains the checks shared by the OP-Stack components.
+package rollup
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	errNotOptimism         = errors.New("chain is not an OP-Stack rollup")
+	errGenesisHashMismatch = errors.New("L2 genesis hash mismatch")
+)
+
+// predeploy is a contract an OP-Stack chain expects in its genesis.
+type predeploy struct {
+	name string
+	addr common.Address
+}
+
+// predeploys returns the contracts required in the genesis of the chain.
+func predeploys(config *params.ChainConfig) []predeploy {
+	return []predeploy{
+		{"L2CrossDomainMessenger", common.HexToAddress("0x4200000000000000000000000000000000000007")},
+		{"L2StandardBridge", common.HexToAddress("0x4200000000000000000000000000000000000010")},
+		{"OptimismMintableERC20Factory", common.HexToAddress("0x4200000000000000000000000000000000000012")},
+		{"L2ERC721Bridge", common.HexToAddress("0x4200000000000000000000000000000000000014")},
+		{"L1Block", common.HexToAddress("0x4200000000000000000000000000000000000015")},
+		{"L2ToL1MessagePasser", common.HexToAddress("0x4200000000000000000000000000000000000016")},
+		{"BaseFeeVault", common.HexToAddress("0x4200000000000000000000000000000000000019")},
+		{"L1FeeOracle", config.L1FeeOracle()},
+		{"SequencerFeeVault", params.SequencerFeeVaultAddress},
+		{"L1FeeVault", params.L1FeeVaultAddress},
+	}
+}
+
+// ValidateOPGenesis checks that the genesis of an OP-Stack chain deploys all the
+// predeploys the rollup needs. Without them the chain starts fine, but can't
+// bridge assets or relay messages.
+func ValidateOPGenesis(genesis *core.Genesis) error {
+	if genesis.Config == nil || genesis.Config.Optimism == nil {
+		return errNotOptimism
+	}
+	for _, p := range predeploys(genesis.Config) {
+		account, ok := genesis.Alloc[p.addr]
+		if !ok {
+			return fmt.Errorf("missing %s predeploy at %v", p.name, p.addr)
+		}
+		if len(account.Code) == 0 {
+			return fmt.Errorf("%s predeploy at %v has no code", p.name, p.addr)
+		}
+	}
+	return nil
+}
+
+// ValidateGenesisHash checks that the genesis block stored in the database is
+// the one of the network the rollup config expects. A node initialised with the
+// genesis of another network would otherwise sync a chain nobody else follows.
+func ValidateGenesisHash(config *params.ChainConfig, stored common.Hash) error {
+	if config.Optimism == nil || config.Optimism.GenesisHash == (common.Hash{}) {
+		return nil
+	}
+	if want := config.Optimism.GenesisHash; stored != want {
+		return fmt.Errorf("%w: database has %v, rollup config expects %v", errGenesisHashMismatch, stored, want)
+	}
+	return nil
+}
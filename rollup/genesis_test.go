@@ -0,0 +1,85 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rollup
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestValidateOPGenesis(t *testing.T) {
+	config := *params.TestChainConfig
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+
+	newGenesis := func() *core.Genesis {
+		genesis := &core.Genesis{Config: &config, Alloc: make(types.GenesisAlloc)}
+		for _, p := range predeploys(&config) {
+			genesis.Alloc[p.addr] = types.Account{Code: []byte{0x00}}
+		}
+		return genesis
+	}
+	if err := ValidateOPGenesis(newGenesis()); err != nil {
+		t.Fatalf("complete genesis rejected: %v", err)
+	}
+	passer := common.HexToAddress("0x4200000000000000000000000000000000000016")
+
+	missing := newGenesis()
+	delete(missing.Alloc, passer)
+
+	empty := newGenesis()
+	empty.Alloc[passer] = types.Account{}
+
+	tests := []struct {
+		name    string
+		genesis *core.Genesis
+		want    string
+	}{
+		{"missing predeploy", missing, "missing L2ToL1MessagePasser predeploy"},
+		{"no code", empty, "L2ToL1MessagePasser predeploy at 0x4200000000000000000000000000000000000016 has no code"},
+		{"not optimism", &core.Genesis{Config: params.TestChainConfig}, errNotOptimism.Error()},
+	}
+	for _, tt := range tests {
+		if err := ValidateOPGenesis(tt.genesis); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: error mismatch: have %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestValidateGenesisHash(t *testing.T) {
+	config := *params.TestChainConfig
+	config.Optimism = &params.OptimismConfig{EIP1559Elasticity: 6, EIP1559Denominator: 50}
+
+	if err := ValidateGenesisHash(&config, common.Hash{0x01}); err != nil {
+		t.Fatalf("unchecked genesis rejected: %v", err)
+	}
+	config.Optimism.GenesisHash = common.Hash{0x01}
+	if err := ValidateGenesisHash(&config, common.Hash{0x01}); err != nil {
+		t.Fatalf("expected genesis rejected: %v", err)
+	}
+	if err := ValidateGenesisHash(&config, common.Hash{0x02}); !errors.Is(err, errGenesisHashMismatch) {
+		t.Fatalf("error mismatch: have %v, want %v", err, errGenesisHashMismatch)
+	}
+	if err := ValidateGenesisHash(params.TestChainConfig, common.Hash{0x02}); err != nil {
+		t.Fatalf("non-rollup genesis rejected: %v", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package p2p implements the messages gossiped between OP-Stack nodes to
+// propagate unsafe L2 blocks ahead of their submission to L1.
+package p2p
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/beacon/engine/ssz"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BlockSigningDomain is the signing domain of gossiped blocks, the all-zero
+// V1 domain of the OP-Stack specification. Signatures are bound to a chain by
+// its chain ID, committed to next to the domain.
+var BlockSigningDomain = [32]byte{}
+
+var errMissingPayload = errors.New("missing execution payload")
+
+// ExecutionPayloadEnvelope is an unsafe L2 block gossiped by the sequencer,
+// wrapping the execution payload along with the parent beacon block root.
+type ExecutionPayloadEnvelope struct {
+	ParentBeaconBlockRoot common.Hash
+	ExecutionPayload      *engine.ExecutableData
+}
+
+// SigningHash returns the hash signed by the sequencer of the given chain over
+// the envelope:
+//
+//	keccak256(domain ++ uint256(chainID) ++ keccak256(ssz(envelope)))
+//
+// Envelopes which cannot be encoded, as well as invalid chain IDs, yield the
+// zero hash, which no valid signature commits to.
+func (e *ExecutionPayloadEnvelope) SigningHash(domain [32]byte, chainID *big.Int) common.Hash {
+	if chainID == nil || chainID.Sign() < 0 || chainID.BitLen() > 256 {
+		return common.Hash{}
+	}
+	enc, err := MarshalSSZ(e)
+	if err != nil {
+		return common.Hash{}
+	}
+	var id [32]byte
+	chainID.FillBytes(id[:])
+	return crypto.Keccak256Hash(domain[:], id[:], crypto.Keccak256(enc))
+}
+
+// VerifySignature reports whether the signature over the envelope was made by
+// the expected signer of the given chain, within the block signing domain.
+func VerifySignature(envelope *ExecutionPayloadEnvelope, chainID *big.Int, sig [65]byte, expectedSigner common.Address) bool {
+	hash := envelope.SigningHash(BlockSigningDomain, chainID)
+	if hash == (common.Hash{}) {
+		return false
+	}
+	pub, err := crypto.SigToPub(hash[:], sig[:])
+	if err != nil {
+		return false
+	}
+	return crypto.PubkeyToAddress(*pub) == expectedSigner
+}
+
+// MarshalSSZ encodes the envelope as gossiped: the parent beacon block root
+// followed by the SSZ encoding of the Ecotone execution payload.
+func MarshalSSZ(envelope *ExecutionPayloadEnvelope) ([]byte, error) {
+	if envelope.ExecutionPayload == nil {
+		return nil, errMissingPayload
+	}
+	payload, err := ssz.EncodeExecutionPayloadV3(envelope.ExecutionPayload)
+	if err != nil {
+		return nil, err
+	}
+	return append(envelope.ParentBeaconBlockRoot[:], payload...), nil
+}
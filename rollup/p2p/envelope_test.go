@@ -0,0 +1,138 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package p2p
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/beacon/engine"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func testEnvelope() *ExecutionPayloadEnvelope {
+	blobGasUsed, excessBlobGas := uint64(0), uint64(0)
+	return &ExecutionPayloadEnvelope{
+		ParentBeaconBlockRoot: common.Hash{0xbe},
+		ExecutionPayload: &engine.ExecutableData{
+			ParentHash:    common.Hash{0x01},
+			FeeRecipient:  common.Address{0x02},
+			StateRoot:     common.Hash{0x03},
+			ReceiptsRoot:  common.Hash{0x04},
+			LogsBloom:     make([]byte, types.BloomByteLength),
+			Random:        common.Hash{0x05},
+			Number:        100,
+			GasLimit:      30_000_000,
+			GasUsed:       21_000,
+			Timestamp:     1700000000,
+			ExtraData:     []byte("op"),
+			BaseFeePerGas: big.NewInt(1_000_000_000),
+			BlockHash:     common.Hash{0x06},
+			Transactions:  [][]byte{{0x7e, 0x01}, {0x02, 0x03, 0x04}},
+			Withdrawals:   []*types.Withdrawal{},
+			BlobGasUsed:   &blobGasUsed,
+			ExcessBlobGas: &excessBlobGas,
+		},
+	}
+}
+
+// payloadFixedSize is the size of the fixed part of the SSZ encoded payload,
+// variable size fields being replaced by offsets.
+const payloadFixedSize = 32 + 20 + 32 + 32 + types.BloomByteLength + 32 + 8*4 + 4 + 32 + 32 + 4 + 4 + 8 + 8
+
+func TestMarshalSSZ(t *testing.T) {
+	envelope := testEnvelope()
+	enc, err := MarshalSSZ(envelope)
+	if err != nil {
+		t.Fatalf("failed to encode envelope: %v", err)
+	}
+	if want := 32 + payloadFixedSize + 2 + 2*4 + 5; len(enc) != want {
+		t.Fatalf("encoding length mismatch: have %d, want %d", len(enc), want)
+	}
+	if !bytes.Equal(enc[:32], envelope.ParentBeaconBlockRoot[:]) {
+		t.Fatalf("parent beacon block root mismatch: %x", enc[:32])
+	}
+	payload := enc[32:]
+	if number := binary.LittleEndian.Uint64(payload[32+20+32+32+256+32:]); number != 100 {
+		t.Fatalf("block number mismatch: have %d, want 100", number)
+	}
+	extraOffset := binary.LittleEndian.Uint32(payload[32+20+32+32+256+32+8*4:])
+	if extraOffset != payloadFixedSize || !bytes.Equal(payload[extraOffset:extraOffset+2], []byte("op")) {
+		t.Fatalf("extra data mismatch: offset %d", extraOffset)
+	}
+	txs := payload[payloadFixedSize+2:]
+	if first := binary.LittleEndian.Uint32(txs); first != 8 || !bytes.Equal(txs[first:first+2], []byte{0x7e, 0x01}) {
+		t.Fatalf("first transaction mismatch: offset %d", first)
+	}
+	// Pre-Ecotone payloads cannot be encoded in the Ecotone layout
+	envelope.ExecutionPayload.BlobGasUsed = nil
+	if _, err := MarshalSSZ(envelope); err == nil {
+		t.Fatal("expected payload without blob gas fields to be rejected")
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := crypto.PubkeyToAddress(key.PublicKey)
+
+	envelope := testEnvelope()
+	chainID := big.NewInt(10)
+	hash := envelope.SigningHash(BlockSigningDomain, chainID)
+	sig, err := crypto.Sign(hash[:], key)
+	if err != nil {
+		t.Fatalf("failed to sign envelope: %v", err)
+	}
+	var signature [65]byte
+	copy(signature[:], sig)
+
+	if !VerifySignature(envelope, chainID, signature, signer) {
+		t.Fatal("valid signature rejected")
+	}
+	if VerifySignature(envelope, chainID, signature, common.Address{0x01}) {
+		t.Fatal("signature accepted for the wrong signer")
+	}
+	if other := envelope.SigningHash([32]byte{0x01}, chainID); other == hash {
+		t.Fatal("signing hash does not commit to the domain")
+	}
+	// Signatures of another chain must not verify, even by the same signer
+	if VerifySignature(envelope, big.NewInt(8453), signature, signer) {
+		t.Fatal("signature accepted for another chain")
+	}
+	if VerifySignature(envelope, nil, signature, signer) {
+		t.Fatal("signature accepted without a chain ID")
+	}
+	// Tampering with the payload or the beacon root must invalidate the signature
+	tampered := testEnvelope()
+	tampered.ExecutionPayload.GasUsed++
+	if VerifySignature(tampered, chainID, signature, signer) {
+		t.Fatal("signature accepted for a tampered payload")
+	}
+	tampered = testEnvelope()
+	tampered.ParentBeaconBlockRoot = common.Hash{0xbf}
+	if VerifySignature(tampered, chainID, signature, signer) {
+		t.Fatal("signature accepted for a tampered beacon root")
+	}
+	tampered = testEnvelope()
+	tampered.ExecutionPayload.Transactions[1][0] = 0xff
+	if VerifySignature(tampered, chainID, signature, signer) {
+		t.Fatal("signature accepted for a tampered transaction")
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package withdrawals
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PortalABI is the subset of the OptimismPortal ABI used to finalize withdrawals.
+const PortalABI = `[
+	{"type":"function","name":"finalizeWithdrawalTransaction","stateMutability":"nonpayable","inputs":[
+		{"name":"_tx","type":"tuple","components":[
+			{"name":"nonce","type":"uint256"},
+			{"name":"sender","type":"address"},
+			{"name":"target","type":"address"},
+			{"name":"value","type":"uint256"},
+			{"name":"gasLimit","type":"uint256"},
+			{"name":"data","type":"bytes"}
+		]}
+	],"outputs":[]},
+	{"type":"function","name":"finalizedWithdrawals","stateMutability":"view","inputs":[
+		{"name":"","type":"bytes32"}
+	],"outputs":[{"name":"","type":"bool"}]}
+]`
+
+var (
+	errMissingPortal        = errors.New("missing portal address")
+	errOutputRootMismatch   = errors.New("output root proof mismatch")
+	errMissingStorageProof  = errors.New("missing withdrawal storage proof")
+	errInvalidWithdrawal    = errors.New("invalid withdrawal transaction")
+	errUnexpectedCallResult = errors.New("unexpected call result")
+)
+
+// parsedPortalABI is the parsed PortalABI.
+var parsedPortalABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(PortalABI))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// WithdrawalTransaction is a withdrawal initiated on L2 through the message
+// passer, as relayed by the portal on L1.
+type WithdrawalTransaction struct {
+	Nonce    *big.Int
+	Sender   common.Address
+	Target   common.Address
+	Value    *big.Int
+	GasLimit *big.Int
+	Data     []byte
+}
+
+// Hash returns the withdrawal hash, the keccak256 hash of the ABI encoded
+// withdrawal under which the portal tracks it.
+func (w *WithdrawalTransaction) Hash() (common.Hash, error) {
+	args := parsedPortalABI.Methods["finalizeWithdrawalTransaction"].Inputs
+	enc, err := args.Pack(w)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("%w: %v", errInvalidWithdrawal, err)
+	}
+	// The method inputs encode the withdrawal as a single dynamic tuple, which
+	// is prefixed by its offset unlike the encoding hashed by the portal.
+	return crypto.Keccak256Hash(enc[32:]), nil
+}
+
+// OutputRootProof is the preimage of an L2 output root.
+type OutputRootProof struct {
+	Version                  common.Hash
+	StateRoot                common.Hash
+	MessagePasserStorageRoot common.Hash
+	LatestBlockhash          common.Hash
+}
+
+// OutputRoot returns the output root committed to by the proof.
+func (p *OutputRootProof) OutputRoot() common.Hash {
+	return crypto.Keccak256Hash(p.Version[:], p.StateRoot[:], p.MessagePasserStorageRoot[:], p.LatestBlockhash[:])
+}
+
+// WithdrawalProof is the proof of a withdrawal against the output root of an
+// L2 block, as proven on the portal ahead of its finalization.
+type WithdrawalProof struct {
+	Portal          common.Address  // OptimismPortal the withdrawal was proven on
+	OutputRoot      common.Hash     // L2 output root the withdrawal was proven against
+	OutputRootProof OutputRootProof // Preimage of the output root
+	StorageProof    [][]byte        // Proof of the withdrawal in the message passer storage
+}
+
+// BuildFinalizationTransaction creates the unsigned L1 transaction finalizing
+// a proven withdrawal on the portal. Only the withdrawal itself is part of the
+// calldata, the proof being checked beforehand to ensure the transaction does
+// not target a withdrawal that could never have been proven.
+func BuildFinalizationTransaction(withdrawal *WithdrawalTransaction, proof *WithdrawalProof, portalABI *abi.ABI) (*types.Transaction, error) {
+	if proof.Portal == (common.Address{}) {
+		return nil, errMissingPortal
+	}
+	if root := proof.OutputRootProof.OutputRoot(); root != proof.OutputRoot {
+		return nil, fmt.Errorf("%w: have %x, want %x", errOutputRootMismatch, root, proof.OutputRoot)
+	}
+	if len(proof.StorageProof) == 0 {
+		return nil, errMissingStorageProof
+	}
+	data, err := portalABI.Pack("finalizeWithdrawalTransaction", withdrawal)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidWithdrawal, err)
+	}
+	return types.NewTx(&types.LegacyTx{
+		To:    &proof.Portal,
+		Value: new(big.Int),
+		Data:  data,
+	}), nil
+}
+
+// IsWithdrawalFinalized reports whether the withdrawal with the given hash was
+// finalized on the portal, as of the latest L1 block.
+func IsWithdrawalFinalized(portalCaller bind.ContractCaller, portalAddr common.Address, withdrawalHash common.Hash) (bool, error) {
+	input, err := parsedPortalABI.Pack("finalizedWithdrawals", withdrawalHash)
+	if err != nil {
+		return false, err
+	}
+	output, err := portalCaller.CallContract(context.Background(), ethereum.CallMsg{To: &portalAddr, Data: input}, nil)
+	if err != nil {
+		return false, err
+	}
+	results, err := parsedPortalABI.Unpack("finalizedWithdrawals", output)
+	if err != nil {
+		return false, err
+	}
+	finalized, ok := results[0].(bool)
+	if !ok {
+		return false, fmt.Errorf("%w: %v", errUnexpectedCallResult, results[0])
+	}
+	return finalized, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package withdrawals
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// testPortal is a portal caller answering finalizedWithdrawals queries.
+type testPortal struct {
+	address   common.Address
+	finalized map[common.Hash]bool
+}
+
+func (p *testPortal) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{0x00}, nil
+}
+
+func (p *testPortal) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if *call.To != p.address || !bytes.Equal(call.Data[:4], crypto.Keccak256([]byte("finalizedWithdrawals(bytes32)"))[:4]) {
+		return nil, nil
+	}
+	result := make([]byte, 32)
+	if p.finalized[common.BytesToHash(call.Data[4:])] {
+		result[31] = 1
+	}
+	return result, nil
+}
+
+func testWithdrawal() *WithdrawalTransaction {
+	return &WithdrawalTransaction{
+		Nonce:    big.NewInt(7),
+		Sender:   common.Address{0x11},
+		Target:   common.Address{0x22},
+		Value:    big.NewInt(1_000_000),
+		GasLimit: big.NewInt(100_000),
+		Data:     []byte{0xde, 0xad},
+	}
+}
+
+func testProof() *WithdrawalProof {
+	proof := &WithdrawalProof{
+		Portal: common.Address{0x99},
+		OutputRootProof: OutputRootProof{
+			StateRoot:                common.Hash{0x01},
+			MessagePasserStorageRoot: common.Hash{0x02},
+			LatestBlockhash:          common.Hash{0x03},
+		},
+		StorageProof: [][]byte{{0xc0}},
+	}
+	proof.OutputRoot = proof.OutputRootProof.OutputRoot()
+	return proof
+}
+
+func word(v uint64) []byte {
+	return common.BigToHash(new(big.Int).SetUint64(v)).Bytes()
+}
+
+func TestBuildFinalizationTransaction(t *testing.T) {
+	withdrawal := testWithdrawal()
+	tx, err := BuildFinalizationTransaction(withdrawal, testProof(), &parsedPortalABI)
+	if err != nil {
+		t.Fatalf("failed to build finalization transaction: %v", err)
+	}
+	if tx.Type() != types.LegacyTxType || *tx.To() != (common.Address{0x99}) || tx.Value().Sign() != 0 {
+		t.Fatalf("transaction mismatch: type %d, to %v, value %v", tx.Type(), tx.To(), tx.Value())
+	}
+	// Assemble the calldata by hand: selector, tuple offset, static fields, data
+	// offset relative to the tuple, data length and padded data.
+	tuple := bytes.Join([][]byte{
+		word(7),
+		common.LeftPadBytes(withdrawal.Sender[:], 32),
+		common.LeftPadBytes(withdrawal.Target[:], 32),
+		word(1_000_000),
+		word(100_000),
+		word(6 * 32),
+		word(2),
+		common.RightPadBytes([]byte{0xde, 0xad}, 32),
+	}, nil)
+	want := append(hexutil.MustDecode("0x8c3152e9"), append(word(32), tuple...)...)
+	if !bytes.Equal(tx.Data(), want) {
+		t.Fatalf("calldata mismatch:\nhave %x\nwant %x", tx.Data(), want)
+	}
+	hash, err := withdrawal.Hash()
+	if err != nil {
+		t.Fatalf("failed to hash withdrawal: %v", err)
+	}
+	if hash != crypto.Keccak256Hash(tuple) {
+		t.Fatalf("withdrawal hash mismatch: have %x, want %x", hash, crypto.Keccak256Hash(tuple))
+	}
+}
+
+func TestBuildFinalizationTransactionInvalidProof(t *testing.T) {
+	tests := map[string]func(*WithdrawalProof){
+		"missing portal":    func(p *WithdrawalProof) { p.Portal = common.Address{} },
+		"wrong output root": func(p *WithdrawalProof) { p.OutputRoot[0] ^= 1 },
+		"no storage proof":  func(p *WithdrawalProof) { p.StorageProof = nil },
+	}
+	for name, tamper := range tests {
+		proof := testProof()
+		tamper(proof)
+		if _, err := BuildFinalizationTransaction(testWithdrawal(), proof, &parsedPortalABI); err == nil {
+			t.Errorf("%s: expected proof to be rejected", name)
+		}
+	}
+}
+
+func TestIsWithdrawalFinalized(t *testing.T) {
+	hash, _ := testWithdrawal().Hash()
+	portal := &testPortal{address: common.Address{0x99}, finalized: map[common.Hash]bool{hash: true}}
+
+	finalized, err := IsWithdrawalFinalized(portal, portal.address, hash)
+	if err != nil {
+		t.Fatalf("failed to query withdrawal: %v", err)
+	}
+	if !finalized {
+		t.Fatal("finalized withdrawal reported as pending")
+	}
+	finalized, err = IsWithdrawalFinalized(portal, portal.address, common.Hash{0x01})
+	if err != nil {
+		t.Fatalf("failed to query withdrawal: %v", err)
+	}
+	if finalized {
+		t.Fatal("pending withdrawal reported as finalized")
+	}
+	// An empty result, as returned by accounts without code, must not decode
+	if _, err := IsWithdrawalFinalized(portal, common.Address{0x01}, hash); err == nil {
+		t.Fatal("expected empty call result to fail")
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+// Package withdrawals implements the proving of OP-Stack withdrawals against the
+// output roots disputed on L1.
+package withdrawals
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rollup/dispute"
+)
+
+var errNotOptimism = errors.New("chain is not an OP-Stack rollup")
+
+// Prover proves withdrawals against the output roots of L2 blocks, each proof
+// being backed by a dispute game over the output root.
+type Prover struct {
+	config  *params.ChainConfig
+	factory dispute.GameFactory
+}
+
+// NewProver creates a withdrawal prover creating its games through the factory.
+func NewProver(config *params.ChainConfig, factory dispute.GameFactory) *Prover {
+	return &Prover{config: config, factory: factory}
+}
+
+// GameType returns the type of dispute game withdrawals are proven against.
+func (p *Prover) GameType() dispute.DisputeGameType {
+	if p.config.Optimism != nil && p.config.Optimism.UsePermissionlessGame {
+		return dispute.FaultGameType
+	}
+	return dispute.PermissionedGameType
+}
+
+// ProveOutput creates the dispute game over the output root of the given L2
+// block, which withdrawals initiated up to that block are then proven against.
+func (p *Prover) ProveOutput(outputRoot common.Hash, l2BlockNumber uint64) (*dispute.DisputeGame, error) {
+	if !p.config.IsOptimism() {
+		return nil, errNotOptimism
+	}
+	gameType := p.GameType()
+	extraData := common.BigToHash(new(big.Int).SetUint64(l2BlockNumber)).Bytes()
+
+	game, err := p.factory.Create(gameType, outputRoot, extraData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %v dispute game: %w", gameType, err)
+	}
+	if game.GameType != gameType || game.RootClaim != outputRoot {
+		return nil, fmt.Errorf("dispute game mismatch: have %v game over %x, want %v game over %x", game.GameType, game.RootClaim, gameType, outputRoot)
+	}
+	return game, nil
+}
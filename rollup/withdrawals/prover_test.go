@@ -0,0 +1,83 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package withdrawals
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rollup/dispute"
+)
+
+// testFactory is a game factory recording the games it was asked to create.
+type testFactory struct {
+	created map[dispute.DisputeGameType]int
+	last    []byte
+}
+
+func (f *testFactory) Create(gameType dispute.DisputeGameType, rootClaim common.Hash, extraData []byte) (*dispute.DisputeGame, error) {
+	f.created[gameType]++
+	f.last = extraData
+	return &dispute.DisputeGame{
+		GameType:  gameType,
+		RootClaim: rootClaim,
+		ExtraData: extraData,
+		Game:      dispute.NewFaultDisputeGame(rootClaim, common.Hash{}, 0),
+	}, nil
+}
+
+func TestProveOutputGameType(t *testing.T) {
+	for _, permissionless := range []bool{false, true} {
+		config := *params.TestChainConfig
+		config.Optimism = &params.OptimismConfig{UsePermissionlessGame: permissionless}
+
+		factory := &testFactory{created: make(map[dispute.DisputeGameType]int)}
+		prover := NewProver(&config, factory)
+
+		root := common.Hash{0xaa}
+		game, err := prover.ProveOutput(root, 1000)
+		if err != nil {
+			t.Fatalf("permissionless %v: failed to prove output: %v", permissionless, err)
+		}
+		want, other := dispute.PermissionedGameType, dispute.FaultGameType
+		if permissionless {
+			want, other = other, want
+		}
+		if game.GameType != want || factory.created[want] != 1 || factory.created[other] != 0 {
+			t.Fatalf("permissionless %v: game type mismatch: have %v, created %v", permissionless, game.GameType, factory.created)
+		}
+		if game.Game.RootClaim != root {
+			t.Fatalf("permissionless %v: root claim mismatch: have %x, want %x", permissionless, game.Game.RootClaim, root)
+		}
+		if !bytes.Equal(factory.last, common.BigToHash(big.NewInt(1000)).Bytes()) {
+			t.Fatalf("permissionless %v: extra data mismatch: %x", permissionless, factory.last)
+		}
+	}
+}
+
+func TestProveOutputNotOptimism(t *testing.T) {
+	factory := &testFactory{created: make(map[dispute.DisputeGameType]int)}
+	if _, err := NewProver(params.TestChainConfig, factory).ProveOutput(common.Hash{0xaa}, 1); err == nil {
+		t.Fatal("expected proving on a non OP-Stack chain to fail")
+	}
+	if len(factory.created) != 0 {
+		t.Fatalf("unexpected games created: %v", factory.created)
+	}
+}
@@ -25,6 +25,13 @@ import (
 
 // StartIPCEndpoint starts an IPC endpoint.
 func StartIPCEndpoint(ipcEndpoint string, apis []API) (net.Listener, *Server, error) {
+	return StartIPCEndpointWithUIDs(ipcEndpoint, apis, nil)
+}
+
+// StartIPCEndpointWithUIDs starts an IPC endpoint only serving the processes
+// running under the given user IDs, or all of them if none are given. Checking
+// the peer credentials is only supported on Linux.
+func StartIPCEndpointWithUIDs(ipcEndpoint string, apis []API, allowedUIDs []uint32) (net.Listener, *Server, error) {
 	// Register all the APIs exposed by the services.
 	var (
 		handler    = NewServer()
@@ -47,6 +54,9 @@ func StartIPCEndpoint(ipcEndpoint string, apis []API) (net.Listener, *Server, er
 	if err != nil {
 		return nil, nil, err
 	}
+	if len(allowedUIDs) > 0 {
+		listener = &uidFilterListener{Listener: listener, allowed: allowedUIDs}
+	}
 	go handler.ServeListener(listener)
 	return listener, handler, nil
 }
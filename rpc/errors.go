@@ -45,6 +45,13 @@ type DataError interface {
 	ErrorData() interface{} // returns the error data
 }
 
+// A FieldsError contains additional members of the error object, next to the
+// code, the message and the data.
+type FieldsError interface {
+	Error() string                       // returns the message
+	ErrorFields() map[string]interface{} // returns the additional members
+}
+
 // Error types defined below are the built-in JSON-RPC errors.
 
 var (
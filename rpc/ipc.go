@@ -19,6 +19,7 @@ package rpc
 import (
 	"context"
 	"net"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p/netutil"
@@ -39,6 +40,34 @@ func (s *Server) ServeListener(l net.Listener) error {
 	}
 }
 
+// uidFilterListener is an IPC listener only accepting the connections of the
+// processes running under the allowed user IDs.
+type uidFilterListener struct {
+	net.Listener
+	allowed []uint32
+}
+
+// Accept waits for the next connection from an allowed user, closing the
+// connections of the others.
+func (l *uidFilterListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		uid, err := ipcPeerUID(conn)
+		switch {
+		case err != nil:
+			log.Warn("Rejected IPC connection, failed to read peer credentials", "err", err)
+		case !slices.Contains(l.allowed, uid):
+			log.Warn("Rejected IPC connection from unauthorized user", "uid", uid)
+		default:
+			return conn, nil
+		}
+		conn.Close()
+	}
+}
+
 // DialIPC create a new IPC client that connects to the given endpoint. On Unix it assumes
 // the endpoint is the full path to a unix socket, and Windows the endpoint is an
 // identifier for a named pipe.
@@ -0,0 +1,66 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Tests that the IPC endpoint only serves the users it's restricted to.
+func TestIPCAllowedUIDs(t *testing.T) {
+	uid := uint32(os.Getuid())
+
+	tests := []struct {
+		name    string
+		allowed []uint32
+		served  bool
+	}{
+		{"unrestricted", nil, true},
+		{"authorized", []uint32{uid + 1, uid}, true},
+		{"unauthorized", []uint32{uid + 1}, false},
+	}
+	for i, tt := range tests {
+		endpoint := filepath.Join(t.TempDir(), fmt.Sprintf("geth-%d.ipc", i))
+		listener, srv, err := StartIPCEndpointWithUIDs(endpoint, []API{{Namespace: "test", Service: new(testService)}}, tt.allowed)
+		if err != nil {
+			t.Fatalf("%s: failed to start IPC endpoint: %v", tt.name, err)
+		}
+		client, err := DialIPC(context.Background(), endpoint)
+		if err != nil {
+			t.Fatalf("%s: failed to dial IPC endpoint: %v", tt.name, err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		var modules map[string]string
+		err = client.CallContext(ctx, &modules, "rpc_modules")
+		cancel()
+
+		if tt.served && (err != nil || modules["test"] == "") {
+			t.Errorf("%s: request not served: have %v, %v", tt.name, modules, err)
+		}
+		if !tt.served && err == nil {
+			t.Errorf("%s: request served from unauthorized user", tt.name)
+		}
+		client.Close()
+		listener.Close()
+		srv.Stop()
+	}
+}
@@ -134,6 +134,10 @@ func errorMessage(err error) *jsonrpcMessage {
 	if ok {
 		msg.Error.Data = de.ErrorData()
 	}
+	fe, ok := err.(FieldsError)
+	if ok {
+		msg.Error.Fields = fe.ErrorFields()
+	}
 	return msg
 }
 
@@ -141,6 +145,26 @@ type jsonError struct {
 	Code    int         `json:"code"`
 	Message string      `json:"message"`
 	Data    interface{} `json:"data,omitempty"`
+
+	Fields map[string]interface{} `json:"-"` // additional members, only sent by the server
+}
+
+// MarshalJSON encodes the error object, along with its additional members.
+func (err *jsonError) MarshalJSON() ([]byte, error) {
+	type plainError jsonError
+	if len(err.Fields) == 0 {
+		return json.Marshal((*plainError)(err))
+	}
+	enc := make(map[string]interface{}, len(err.Fields)+3)
+	for key, value := range err.Fields {
+		enc[key] = value
+	}
+	enc["code"] = err.Code
+	enc["message"] = err.Message
+	if err.Data != nil {
+		enc["data"] = err.Data
+	}
+	return json.Marshal(enc)
 }
 
 func (err *jsonError) Error() string {
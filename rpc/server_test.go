@@ -19,6 +19,7 @@ package rpc
 import (
 	"bufio"
 	"bytes"
+	"encoding/json"
 	"io"
 	"net"
 	"os"
@@ -202,3 +203,25 @@ func TestServerBatchResponseSizeLimit(t *testing.T) {
 		}
 	}
 }
+
+func TestServerErrorFields(t *testing.T) {
+	t.Parallel()
+
+	enc, err := json.Marshal(errorMessage(testFieldsError{}).Error)
+	if err != nil {
+		t.Fatal("failed to encode error:", err)
+	}
+	want := `{"code":444,"data":"testError data","message":"testError","reason":"testError reason"}`
+	if string(enc) != want {
+		t.Errorf("wrong error object, have %s want %s", enc, want)
+	}
+	// Errors without additional members keep the order of the members
+	enc, err = json.Marshal(errorMessage(testError{}).Error)
+	if err != nil {
+		t.Fatal("failed to encode error:", err)
+	}
+	want = `{"code":444,"message":"testError","data":"testError data"}`
+	if string(enc) != want {
+		t.Errorf("wrong error object, have %s want %s", enc, want)
+	}
+}
@@ -70,6 +70,12 @@ func (testError) Error() string          { return "testError" }
 func (testError) ErrorCode() int         { return 444 }
 func (testError) ErrorData() interface{} { return "testError data" }
 
+type testFieldsError struct{ testError }
+
+func (testFieldsError) ErrorFields() map[string]interface{} {
+	return map[string]interface{}{"reason": "testError reason"}
+}
+
 type MarshalErrObj struct{}
 
 func (o *MarshalErrObj) MarshalText() ([]byte, error) {
@@ -28,12 +28,16 @@ import (
 //go:embed 4byte.json
 var embeddedJSON []byte
 
+//go:embed events.json
+var embeddedEventsJSON []byte
+
 // Database is a 4byte database with the possibility of maintaining an immutable
 // set (embedded) into the process and a mutable set (loaded and written to file).
 type Database struct {
 	embedded   map[string]string
 	custom     map[string]string
 	customPath string
+	events     map[string]string // event signatures keyed by topic, embedded only
 }
 
 // newEmpty exists for testing purposes.
@@ -41,6 +45,7 @@ func newEmpty() *Database {
 	return &Database{
 		embedded: make(map[string]string),
 		custom:   make(map[string]string),
+		events:   make(map[string]string),
 	}
 }
 
@@ -73,12 +78,15 @@ func NewFromFile(path string) (*Database, error) {
 // file) as well as a custom database. The latter will be used to write new
 // values into if they are submitted via the API.
 func NewWithFile(path string) (*Database, error) {
-	db := &Database{make(map[string]string), make(map[string]string), path}
+	db := &Database{make(map[string]string), make(map[string]string), path, make(map[string]string)}
 	db.customPath = path
 
 	if err := json.Unmarshal(embeddedJSON, &db.embedded); err != nil {
 		return nil, err
 	}
+	if err := json.Unmarshal(embeddedEventsJSON, &db.events); err != nil {
+		return nil, err
+	}
 	// Custom file may not exist. Will be created during save, if needed.
 	if _, err := os.Stat(path); err == nil {
 		var blob []byte
@@ -114,6 +122,21 @@ func (db *Database) Selector(id []byte) (string, error) {
 	return "", fmt.Errorf("signature %v not found", sig)
 }
 
+// Event checks the given log topic against the known event signatures. The
+// indexed parameters of the known events come first in their signatures.
+//
+// This method does not validate the match, it's assumed the caller will do.
+func (db *Database) Event(topic []byte) (string, error) {
+	if len(topic) != 32 {
+		return "", fmt.Errorf("expected 32-byte topic, got %d", len(topic))
+	}
+	sig := hex.EncodeToString(topic)
+	if event, exists := db.events[sig]; exists {
+		return event, nil
+	}
+	return "", fmt.Errorf("event %v not found", sig)
+}
+
 // AddSelector inserts a new 4byte entry into the database. If custom database
 // saving is enabled, the new dataset is also persisted to disk.
 //
@@ -23,6 +23,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 // Tests that all the selectors contained in the 4byte database are valid.
@@ -54,6 +55,30 @@ func TestEmbeddedDatabase(t *testing.T) {
 	}
 }
 
+// Tests that all the event signatures contained in the database match their topic.
+func TestEmbeddedEvents(t *testing.T) {
+	t.Parallel()
+	db, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(db.events) == 0 {
+		t.Fatal("no embedded events")
+	}
+	for topic, event := range db.events {
+		if have := common.Bytes2Hex(crypto.Keccak256([]byte(event))); have != topic {
+			t.Errorf("topic mismatch for %s: have %v, want %v", event, have, topic)
+		}
+		if _, err := parseSelector(event); err != nil {
+			t.Errorf("failed to parse event %s: %v", event, err)
+		}
+	}
+	transfer := crypto.Keccak256([]byte("Transfer(address,address,uint256)"))
+	if event, err := db.Event(transfer); err != nil || event != "Transfer(address,address,uint256)" {
+		t.Errorf("transfer event mismatch: have %q, %v", event, err)
+	}
+}
+
 // Tests that custom 4byte datasets can be handled too.
 func TestCustomDatabase(t *testing.T) {
 	t.Parallel()
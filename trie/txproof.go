@@ -0,0 +1,76 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+)
+
+// GenerateTxInclusionProof returns the Merkle proof of the transaction at the
+// given index in the transaction trie of the block. The proof nodes are ordered
+// from the root down to the leaf.
+func GenerateTxInclusionProof(block *types.Block, txIndex int) ([]rlp.RawValue, error) {
+	txs := block.Transactions()
+	if txIndex < 0 || txIndex >= len(txs) {
+		return nil, fmt.Errorf("transaction index %d out of range [0, %d)", txIndex, len(txs))
+	}
+	// Rebuild the transaction trie the same way as types.DeriveSha does
+	tr := NewEmpty(nil)
+	for i, tx := range txs {
+		blob, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		if err := tr.Update(rlp.AppendUint64(nil, uint64(i)), blob); err != nil {
+			return nil, err
+		}
+	}
+	if root := tr.Hash(); root != block.TxHash() {
+		return nil, fmt.Errorf("transaction root mismatch: have %x, want %x", root, block.TxHash())
+	}
+	var proof trienode.ProofList
+	if err := tr.Prove(rlp.AppendUint64(nil, uint64(txIndex)), &proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// VerifyTxInclusionProof checks the Merkle proof of the transaction at the given
+// index in the transaction trie with the given root. It returns false if the
+// proof is valid but proves another transaction or none at all, and an error if
+// the proof itself is invalid.
+func VerifyTxInclusionProof(txRoot common.Hash, txIndex int, tx *types.Transaction, proof []rlp.RawValue) (bool, error) {
+	if txIndex < 0 {
+		return false, errors.New("negative transaction index")
+	}
+	value, err := VerifyProof(txRoot, rlp.AppendUint64(nil, uint64(txIndex)), trienode.ProofList(proof).Set())
+	if err != nil {
+		return false, err
+	}
+	blob, err := tx.MarshalBinary()
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(value, blob), nil
+}
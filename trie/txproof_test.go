@@ -0,0 +1,75 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package trie
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Tests that the inclusion proofs of transactions are verified against the
+// transaction root of their block.
+func TestTxInclusionProof(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+
+	var txs types.Transactions
+	for i := 0; i < 10; i++ {
+		txs = append(txs, types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   big.NewInt(1),
+			Nonce:     uint64(i),
+			Gas:       21000,
+			GasFeeCap: big.NewInt(1),
+			To:        &common.Address{},
+		}))
+	}
+	block := types.NewBlock(&types.Header{Number: big.NewInt(1)}, &types.Body{Transactions: txs}, nil, NewStackTrie(nil))
+
+	for _, index := range []int{0, 5, 9} {
+		proof, err := GenerateTxInclusionProof(block, index)
+		if err != nil {
+			t.Fatalf("tx %d: failed to generate proof: %v", index, err)
+		}
+		if ok, err := VerifyTxInclusionProof(block.TxHash(), index, txs[index], proof); !ok || err != nil {
+			t.Errorf("tx %d: proof rejected: %v", index, err)
+		}
+		// The proof doesn't prove another transaction at the same index, nor
+		// the same transaction at another index
+		other := txs[(index+1)%len(txs)]
+		if ok, _ := VerifyTxInclusionProof(block.TxHash(), index, other, proof); ok {
+			t.Errorf("tx %d: proof accepted for another transaction", index)
+		}
+		if ok, _ := VerifyTxInclusionProof(block.TxHash(), (index+1)%len(txs), txs[index], proof); ok {
+			t.Errorf("tx %d: proof accepted for another index", index)
+		}
+		// Proofs don't verify against another root, nor with missing nodes
+		if _, err := VerifyTxInclusionProof(common.Hash{0x01}, index, txs[index], proof); err == nil {
+			t.Errorf("tx %d: proof accepted for another root", index)
+		}
+		if _, err := VerifyTxInclusionProof(block.TxHash(), index, txs[index], []rlp.RawValue{proof[0]}); err == nil {
+			t.Errorf("tx %d: truncated proof accepted", index)
+		}
+	}
+	if _, err := GenerateTxInclusionProof(block, len(txs)); err == nil {
+		t.Error("proof generated for missing transaction")
+	}
+}
@@ -46,6 +46,7 @@ var (
 	memcacheDirtyMissMeter  = metrics.NewRegisteredMeter("hashdb/memcache/dirty/miss", nil)
 	memcacheDirtyReadMeter  = metrics.NewRegisteredMeter("hashdb/memcache/dirty/read", nil)
 	memcacheDirtyWriteMeter = metrics.NewRegisteredMeter("hashdb/memcache/dirty/write", nil)
+	memcacheDirtySizeHist   = metrics.NewRegisteredHistogram("hashdb/memcache/dirty/size", nil, metrics.NewExpDecaySample(1028, 0.015))
 
 	memcacheFlushTimeTimer  = metrics.NewRegisteredResettingTimer("hashdb/memcache/flush/time", nil)
 	memcacheFlushNodesMeter = metrics.NewRegisteredMeter("hashdb/memcache/flush/nodes", nil)
@@ -148,6 +149,7 @@ func (db *Database) insert(hash common.Hash, node []byte) {
 		return
 	}
 	memcacheDirtyWriteMeter.Mark(int64(len(node)))
+	memcacheDirtySizeHist.Update(int64(len(node)))
 
 	// Create the cached entry for this node
 	entry := &cachedNode{
@@ -0,0 +1,86 @@
+// Copyright 2025 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hashdb
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// Tests that the clean cache hits and misses of the node reads are metered.
+func TestCleanCacheMetrics(t *testing.T) {
+	diskdb := rawdb.NewMemoryDatabase()
+	hashes := make([]common.Hash, 1000)
+	for i := range hashes {
+		blob := binary.BigEndian.AppendUint64(make([]byte, 24), uint64(i))
+		hashes[i] = crypto.Keccak256Hash(blob)
+		rawdb.WriteLegacyTrieNode(diskdb, hashes[i], blob)
+	}
+	db := New(diskdb, &Config{CleanCacheSize: 1024 * 1024})
+	defer db.Close()
+
+	// Warm up the clean cache with half of the nodes
+	for _, hash := range hashes[:500] {
+		if _, err := db.node(hash); err != nil {
+			t.Fatalf("failed to read node %x: %v", hash, err)
+		}
+	}
+	hits, misses := memcacheCleanHitMeter.Snapshot().Count(), memcacheCleanMissMeter.Snapshot().Count()
+	for _, hash := range hashes {
+		if _, err := db.node(hash); err != nil {
+			t.Fatalf("failed to read node %x: %v", hash, err)
+		}
+	}
+	if have := memcacheCleanHitMeter.Snapshot().Count() - hits; have != 500 {
+		t.Errorf("clean cache hits mismatch: have %d, want %d", have, 500)
+	}
+	if have := memcacheCleanMissMeter.Snapshot().Count() - misses; have != 500 {
+		t.Errorf("clean cache misses mismatch: have %d, want %d", have, 500)
+	}
+}
+
+// Tests that the sizes of the nodes inserted into the dirty cache are sampled.
+func TestDirtyNodeSizeHistogram(t *testing.T) {
+	metrics.Enable()
+
+	db := New(rawdb.NewMemoryDatabase(), nil)
+	count := memcacheDirtySizeHist.Snapshot().Count()
+
+	// Leaf nodes with an empty key and values of increasing size
+	var blobs [][]byte
+	for i := 0; i < 10; i++ {
+		blob, _ := rlp.EncodeToBytes([][]byte{{0x20}, make([]byte, 32+i)})
+		blobs = append(blobs, blob)
+		db.insert(crypto.Keccak256Hash(blob), blob)
+	}
+	// Inserting known nodes again is not sampled
+	db.insert(crypto.Keccak256Hash(blobs[0]), blobs[0])
+
+	snap := memcacheDirtySizeHist.Snapshot()
+	if have := snap.Count() - count; have != 10 {
+		t.Errorf("sampled node count mismatch: have %d, want %d", have, 10)
+	}
+	if want := int64(len(blobs[9])); snap.Max() < want {
+		t.Errorf("max node size mismatch: have %d, want >= %d", snap.Max(), want)
+	}
+}
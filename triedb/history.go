@@ -70,3 +70,15 @@ func (db *Database) HistoryRange() (uint64, uint64, error) {
 	}
 	return pdb.HistoryRange()
 }
+
+// PruneHistory removes the state histories of the blocks below the given number
+// and returns the number of histories removed.
+//
+// This function is only supported by path mode database.
+func (db *Database) PruneHistory(number uint64) (int, error) {
+	pdb, ok := db.backend.(*pathdb.Database)
+	if !ok {
+		return 0, errors.New("not supported")
+	}
+	return pdb.PruneHistory(number)
+}
@@ -700,6 +700,22 @@ func (db *Database) HistoryRange() (uint64, uint64, error) {
 	return historyRange(db.freezer)
 }
 
+// PruneHistory removes the state histories of the blocks below the given number,
+// the states of these blocks are no longer recoverable afterwards. It returns
+// the number of histories removed.
+func (db *Database) PruneHistory(number uint64) (int, error) {
+	db.lock.Lock()
+	defer db.lock.Unlock()
+
+	if err := db.modifyAllowed(); err != nil {
+		return 0, err
+	}
+	if db.freezer == nil {
+		return 0, errors.New("state history is not available")
+	}
+	return truncateBeforeBlock(db.diskdb, db.freezer, number)
+}
+
 // AccountIterator creates a new account iterator for the specified root hash and
 // seeks to a starting account hash.
 func (db *Database) AccountIterator(root common.Hash, seek common.Hash) (AccountIterator, error) {
@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"maps"
 	"slices"
+	"sort"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -678,3 +679,31 @@ func truncateFromTail(db ethdb.Batcher, store ethdb.AncientStore, ntail uint64)
 	}
 	return int(ntail - otail), nil
 }
+
+// truncateBeforeBlock removes the state histories of the blocks below the given
+// number from the tail. It returns the number of items removed from the tail.
+func truncateBeforeBlock(db ethdb.Batcher, store ethdb.AncientStore, number uint64) (int, error) {
+	ohead, err := store.Ancients()
+	if err != nil {
+		return 0, err
+	}
+	otail, err := store.Tail()
+	if err != nil {
+		return 0, err
+	}
+	// Histories are stored in ascending block order, binary search for the
+	// first one that must be retained.
+	var searchErr error
+	n := sort.Search(int(ohead-otail), func(i int) bool {
+		var m meta
+		if err := m.decode(rawdb.ReadStateHistoryMeta(store, otail+uint64(i)+1)); err != nil {
+			searchErr = err
+			return true
+		}
+		return m.block >= number
+	})
+	if searchErr != nil {
+		return 0, searchErr
+	}
+	return truncateFromTail(db, store, otail+uint64(n))
+}
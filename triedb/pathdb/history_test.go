@@ -230,6 +230,43 @@ func TestTruncateTailHistories(t *testing.T) {
 	}
 }
 
+func TestTruncateBeforeBlock(t *testing.T) {
+	var (
+		roots      []common.Hash
+		hs         = makeHistories(100)
+		db         = rawdb.NewMemoryDatabase()
+		freezer, _ = rawdb.NewStateFreezer(t.TempDir(), false, false)
+	)
+	defer freezer.Close()
+
+	for i := 0; i < len(hs); i++ {
+		accountData, storageData, accountIndex, storageIndex := hs[i].encode()
+		rawdb.WriteStateHistory(freezer, uint64(i+1), hs[i].meta.encode(), accountIndex, storageIndex, accountData, storageData)
+		rawdb.WriteStateID(db, hs[i].meta.root, uint64(i+1))
+		roots = append(roots, hs[i].meta.root)
+	}
+	// The histories of blocks [0, 49] are pruned
+	pruned, err := truncateBeforeBlock(db, freezer, 50)
+	if err != nil {
+		t.Fatalf("Failed to truncate histories: %v", err)
+	}
+	if pruned != 50 {
+		t.Fatalf("Unexpected pruned items, want: %d, got: %d", 50, pruned)
+	}
+	checkHistoriesInRange(t, db, freezer, uint64(1), uint64(50), roots[:50], false)
+	checkHistoriesInRange(t, db, freezer, uint64(51), uint64(100), roots[50:], true)
+
+	// Pruning again below the retained histories is a noop
+	pruned, err = truncateBeforeBlock(db, freezer, 30)
+	if err != nil {
+		t.Fatalf("Failed to truncate histories: %v", err)
+	}
+	if pruned != 0 {
+		t.Fatalf("Unexpected pruned items, want: %d, got: %d", 0, pruned)
+	}
+	checkHistoriesInRange(t, db, freezer, uint64(51), uint64(100), roots[50:], true)
+}
+
 func TestTruncateOutOfRange(t *testing.T) {
 	var (
 		hs         = makeHistories(10)